@@ -0,0 +1,137 @@
+package application_test
+
+import (
+	"errors"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/event"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/message/exception"
+
+	"github.com/google/uuid"
+)
+
+// errOutbox is returned by the outbox of unitOfWork when failOutbox is set.
+var errOutbox = errors.New("outbox is not available")
+
+// unitOfWork is an in-memory repository.UnitOfWork, changes made within a transaction are only kept when it commits.
+type unitOfWork struct {
+	users      map[string]entity.User
+	roles      map[string]entity.Role
+	events     []*event.Event
+	failOutbox bool
+}
+
+func newUnitOfWork() *unitOfWork {
+	return &unitOfWork{users: map[string]entity.User{}, roles: map[string]entity.Role{}}
+}
+
+func (u *unitOfWork) Transaction(fn func(tx *repository.TxRepositories) error) error {
+	tx := &transaction{uw: u, users: map[string]entity.User{}, roles: map[string]entity.Role{}}
+	err := fn(&repository.TxRepositories{
+		Outbox: &txOutboxRepo{tx: tx},
+		Role:   &txRoleRepo{tx: tx},
+		User:   &txUserRepo{tx: tx},
+	})
+	if err != nil {
+		return err
+	}
+
+	for UUID, user := range tx.users {
+		u.users[UUID] = user
+	}
+	for UUID, role := range tx.roles {
+		u.roles[UUID] = role
+	}
+	u.events = append(u.events, tx.events...)
+
+	return nil
+}
+
+// transaction keeps changes until the unitOfWork commits them.
+type transaction struct {
+	uw     *unitOfWork
+	users  map[string]entity.User
+	roles  map[string]entity.Role
+	events []*event.Event
+}
+
+type txOutboxRepo struct {
+	repository.OutboxRepository
+	tx *transaction
+}
+
+func (r *txOutboxRepo) SaveEvent(e *event.Event) error {
+	if r.tx.uw.failOutbox {
+		return errOutbox
+	}
+	r.tx.events = append(r.tx.events, e)
+	return nil
+}
+
+type txUserRepo struct {
+	repository.UserRepository
+	tx *transaction
+}
+
+func (r *txUserRepo) SaveUser(user *entity.User) (*entity.User, map[string]string, error) {
+	savedUser := *user
+	savedUser.UUID = uuid.New().String()
+	savedUser.Version = 1
+	r.tx.users[savedUser.UUID] = savedUser
+	return &savedUser, nil, nil
+}
+
+func (r *txUserRepo) UpdateUser(UUID string, user *entity.User) (*entity.User, map[string]string, error) {
+	currentUser, ok := r.tx.uw.users[UUID]
+	if !ok {
+		return nil, nil, exception.ErrorTextUserNotFound
+	}
+	updatedUser := *user
+	updatedUser.UUID = UUID
+	updatedUser.Version = currentUser.Version + 1
+	r.tx.users[UUID] = updatedUser
+	return &updatedUser, nil, nil
+}
+
+type txRoleRepo struct {
+	repository.RoleRepository
+	tx *transaction
+}
+
+func (r *txRoleRepo) UpdateRole(UUID string, role *entity.Role) (*entity.Role, map[string]string, error) {
+	currentRole, ok := r.tx.uw.roles[UUID]
+	if !ok {
+		return nil, nil, exception.ErrorTextRoleNotFound
+	}
+	updatedRole := *role
+	updatedRole.UUID = UUID
+	updatedRole.Version = currentRole.Version + 1
+	r.tx.roles[UUID] = updatedRole
+	return &updatedRole, nil, nil
+}
+
+type applicationRepo struct {
+	repository.ApplicationRepository
+	applications map[string]entity.Application
+}
+
+func (r *applicationRepo) GetApplication(UUID string) (*entity.Application, error) {
+	application, ok := r.applications[UUID]
+	if !ok {
+		return nil, exception.ErrorTextApplicationNotFound
+	}
+	return &application, nil
+}
+
+type webhookSubscriptionRepo struct {
+	repository.WebhookSubscriptionRepository
+	subscriptions []entity.WebhookSubscription
+}
+
+func (r *webhookSubscriptionRepo) SaveWebhookSubscription(
+	subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, map[string]string, error) {
+	savedSubscription := *subscription
+	savedSubscription.UUID = uuid.New().String()
+	r.subscriptions = append(r.subscriptions, savedSubscription)
+	return &savedSubscription, nil, nil
+}
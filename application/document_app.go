@@ -0,0 +1,96 @@
+package application
+
+import (
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/event"
+	"go-rest-skeleton/domain/repository"
+)
+
+type documentApp struct {
+	ds repository.DocumentRepository
+	uw repository.UnitOfWork
+}
+
+// documentApp implement the DocumentAppInterface.
+var _ DocumentAppInterface = &documentApp{}
+
+// DocumentAppInterface is an interface.
+type DocumentAppInterface interface {
+	SaveDocument(*entity.Document) (*entity.Document, map[string]string, error)
+	UpdateDocument(string, *entity.Document) (*entity.Document, map[string]string, error)
+	ApproveDocument(UUID string) (*entity.Document, map[string]string, error)
+	DeleteDocument(UUID string) error
+	GetDocuments(p *repository.Parameters) ([]entity.Document, interface{}, error)
+	GetDocument(UUID string) (*entity.Document, error)
+}
+
+// NewDocumentApp will initialize document application, changes are recorded to the outbox through uw.
+func NewDocumentApp(ds repository.DocumentRepository, uw repository.UnitOfWork) DocumentAppInterface {
+	return &documentApp{ds: ds, uw: uw}
+}
+
+// SaveDocument is an implementation of method SaveDocument.
+func (d *documentApp) SaveDocument(document *entity.Document) (*entity.Document, map[string]string, error) {
+	return d.ds.SaveDocument(document)
+}
+
+// UpdateDocument is an implementation of method UpdateDocument.
+func (d *documentApp) UpdateDocument(UUID string, document *entity.Document) (*entity.Document, map[string]string, error) {
+	return d.ds.UpdateDocument(UUID, document)
+}
+
+// ApproveDocument is an implementation of method ApproveDocument.
+// It emits event.DocumentApproved within the same transaction, approving an approved document is a no-op.
+func (d *documentApp) ApproveDocument(UUID string) (*entity.Document, map[string]string, error) {
+	var approvedDocument *entity.Document
+	var errDesc map[string]string
+	err := d.uw.Transaction(func(tx *repository.TxRepositories) error {
+		document, errGet := tx.Document.GetDocument(UUID)
+		if errGet != nil {
+			return errGet
+		}
+		if document.Workflow == entity.DocumentWorkflowApproved {
+			approvedDocument = document
+			return nil
+		}
+
+		var errUpdate error
+		approvedDocument, errDesc, errUpdate = tx.Document.UpdateDocumentWorkflow(UUID, entity.DocumentWorkflowApproved)
+		if errUpdate != nil {
+			return errUpdate
+		}
+
+		e, errEvent := event.New(event.DocumentApproved, event.AggregateDocument, approvedDocument.UUID, event.DocumentPayload{
+			UUID:     approvedDocument.UUID,
+			UserUUID: approvedDocument.UserUUID,
+			Title:    approvedDocument.Title,
+			Type:     approvedDocument.Type,
+			Workflow: approvedDocument.Workflow,
+		})
+		if errEvent != nil {
+			return errEvent
+		}
+
+		return tx.Outbox.SaveEvent(e.WithIdempotencyKey(event.DocumentApproved + ":" + approvedDocument.UUID))
+	})
+	if err != nil {
+		return nil, errDesc, err
+	}
+
+	return approvedDocument, nil, nil
+}
+
+// DeleteDocument is an implementation of method DeleteDocument.
+func (d *documentApp) DeleteDocument(UUID string) error {
+	return d.ds.DeleteDocument(UUID)
+}
+
+// GetDocument is an implementation of method GetDocument.
+func (d *documentApp) GetDocument(UUID string) (*entity.Document, error) {
+	return d.ds.GetDocument(UUID)
+}
+
+// GetDocuments is an implementation of method GetDocuments.
+func (d *documentApp) GetDocuments(p *repository.Parameters) ([]entity.Document, interface{}, error) {
+	return d.ds.GetDocuments(p)
+}
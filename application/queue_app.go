@@ -0,0 +1,27 @@
+package application
+
+import (
+	"go-rest-skeleton/infrastructure/queue"
+)
+
+type queueApp struct {
+	qu queue.QueueInterface
+}
+
+// queueApp implement the QueueAppInterface.
+var _ QueueAppInterface = &queueApp{}
+
+// QueueAppInterface is an interface.
+type QueueAppInterface interface {
+	Dispatch(job *queue.Job) error
+}
+
+// NewQueueApp will initialize queue application, jobs are dispatched to qu.
+func NewQueueApp(qu queue.QueueInterface) QueueAppInterface {
+	return &queueApp{qu: qu}
+}
+
+// Dispatch is an implementation of method Dispatch.
+func (q queueApp) Dispatch(job *queue.Job) error {
+	return q.qu.Dispatch(job)
+}
@@ -2,11 +2,14 @@ package application
 
 import (
 	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/event"
 	"go-rest-skeleton/domain/repository"
+	"time"
 )
 
 type roleApp struct {
 	us repository.RoleRepository
+	uw repository.UnitOfWork
 }
 
 // roleApp implement the RoleAppInterface.
@@ -17,6 +20,9 @@ type RoleAppInterface interface {
 	SaveRole(*entity.Role) (*entity.Role, map[string]string, error)
 	UpdateRole(string, *entity.Role) (*entity.Role, map[string]string, error)
 	DeleteRole(UUID string) error
+	RestoreRole(UUID string) (*entity.Role, error)
+	ForceDeleteRole(UUID string) error
+	PurgeRoles(before time.Time) (int64, error)
 	GetRoles(p *repository.Parameters) ([]entity.Role, interface{}, error)
 	GetRole(UUID string) (*entity.Role, error)
 	GetRolePermissions(UUID string) ([]entity.RolePermission, error)
@@ -28,9 +34,38 @@ func (u *roleApp) SaveRole(Role *entity.Role) (*entity.Role, map[string]string,
 	return u.us.SaveRole(Role)
 }
 
+// NewRoleApp will initialize role application, changes are recorded to the outbox through uw.
+func NewRoleApp(us repository.RoleRepository, uw repository.UnitOfWork) RoleAppInterface {
+	return &roleApp{us: us, uw: uw}
+}
+
 // UpdateRole is an implementation of method UpdateRole.
+// It emits event.RoleUpdated within the same transaction.
 func (u *roleApp) UpdateRole(UUID string, Role *entity.Role) (*entity.Role, map[string]string, error) {
-	return u.us.UpdateRole(UUID, Role)
+	var updatedRole *entity.Role
+	var errDesc map[string]string
+	err := u.uw.Transaction(func(tx *repository.TxRepositories) error {
+		var errUpdate error
+		updatedRole, errDesc, errUpdate = tx.Role.UpdateRole(UUID, Role)
+		if errUpdate != nil {
+			return errUpdate
+		}
+
+		e, errEvent := event.New(event.RoleUpdated, event.AggregateRole, updatedRole.UUID, event.RolePayload{
+			UUID: updatedRole.UUID,
+			Name: updatedRole.Name,
+		})
+		if errEvent != nil {
+			return errEvent
+		}
+
+		return tx.Outbox.SaveEvent(e)
+	})
+	if err != nil {
+		return nil, errDesc, err
+	}
+
+	return updatedRole, nil, nil
 }
 
 // DeleteRole is an implementation of method SaveRole.
@@ -38,6 +73,21 @@ func (u *roleApp) DeleteRole(UUID string) error {
 	return u.us.DeleteRole(UUID)
 }
 
+// RestoreRole is an implementation of method RestoreRole.
+func (u *roleApp) RestoreRole(UUID string) (*entity.Role, error) {
+	return u.us.RestoreRole(UUID)
+}
+
+// ForceDeleteRole is an implementation of method ForceDeleteRole.
+func (u *roleApp) ForceDeleteRole(UUID string) error {
+	return u.us.ForceDeleteRole(UUID)
+}
+
+// PurgeRoles is an implementation of method PurgeRoles.
+func (u *roleApp) PurgeRoles(before time.Time) (int64, error) {
+	return u.us.PurgeRoles(before)
+}
+
 // GetRole is an implementation of method GetRole.
 func (u *roleApp) GetRole(UUID string) (*entity.Role, error) {
 	return u.us.GetRole(UUID)
@@ -0,0 +1,47 @@
+package application_test
+
+import (
+	"go-rest-skeleton/application"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/event"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleApp_UpdateRole(t *testing.T) {
+	uw := newUnitOfWork()
+	UUID := uuid.New().String()
+	uw.roles[UUID] = entity.Role{UUID: UUID, Name: "Example", Version: 1}
+	roleApp := application.NewRoleApp(nil, uw)
+
+	role, _, err := roleApp.UpdateRole(UUID, &entity.Role{Name: "Updated"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, role.Version)
+	assert.Equal(t, "Updated", uw.roles[UUID].Name)
+	assert.Len(t, uw.events, 1)
+	assert.Equal(t, event.RoleUpdated, uw.events[0].Type)
+	assert.Equal(t, UUID, uw.events[0].AggregateUUID)
+}
+
+func TestRoleApp_UpdateRole_RollsBack(t *testing.T) {
+	uw := newUnitOfWork()
+	UUID := uuid.New().String()
+	uw.roles[UUID] = entity.Role{UUID: UUID, Name: "Example", Version: 1}
+	uw.failOutbox = true
+	roleApp := application.NewRoleApp(nil, uw)
+
+	role, _, err := roleApp.UpdateRole(UUID, &entity.Role{Name: "Updated"})
+	assert.Equal(t, errOutbox, err)
+	assert.Nil(t, role)
+	assert.Equal(t, "Example", uw.roles[UUID].Name)
+	assert.Empty(t, uw.events)
+
+	// No event is recorded for a change which fails.
+	uw.failOutbox = false
+	_, _, err = roleApp.UpdateRole(uuid.New().String(), &entity.Role{Name: "Updated"})
+	assert.Equal(t, exception.ErrorTextRoleNotFound, err)
+	assert.Empty(t, uw.events)
+}
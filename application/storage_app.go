@@ -1,7 +1,9 @@
 package application
 
 import (
+	"go-rest-skeleton/domain/entity"
 	"go-rest-skeleton/infrastructure/storage"
+	"io"
 	"mime/multipart"
 )
 
@@ -14,16 +16,83 @@ var _ StorageAppInterface = &storageApp{}
 
 // StorageAppInterface is an interface.
 type StorageAppInterface interface {
-	UploadFile(file *multipart.FileHeader, category string) (string, map[string]string, error, interface{})
-	GetFile(UUID string) (interface{}, error)
+	UploadFile(file *multipart.FileHeader, category string, userUUID string) (string, map[string]string, error, interface{})
+	GetFile(UUID string, variant string) (interface{}, error)
+	GetFileVariants(UUID string) (map[string]interface{}, error)
+	DeleteFile(UUID string) error
 }
 
 // UploadFile is an implementation of method UploadFile.
-func (s storageApp) UploadFile(file *multipart.FileHeader, category string) (string, map[string]string, error, interface{}) {
-	return s.ss.UploadFile(file, category)
+func (s storageApp) UploadFile(
+	file *multipart.FileHeader,
+	category string,
+	userUUID string) (string, map[string]string, error, interface{}) {
+	return s.ss.UploadFile(file, category, userUUID)
 }
 
 // GetFile is an implementation of method GetFile.
-func (s storageApp) GetFile(UUID string) (interface{}, error) {
-	return s.ss.GetFile(UUID)
+func (s storageApp) GetFile(UUID string, variant string) (interface{}, error) {
+	return s.ss.GetFile(UUID, variant)
+}
+
+// GetFileVariants is an implementation of method GetFileVariants.
+func (s storageApp) GetFileVariants(UUID string) (map[string]interface{}, error) {
+	return s.ss.GetFileVariants(UUID)
+}
+
+// DeleteFile is an implementation of method DeleteFile.
+func (s storageApp) DeleteFile(UUID string) error {
+	return s.ss.DeleteFile(UUID)
+}
+
+// StorageUploadAppInterface is an interface of uploads sent directly to the bucket or resumed in parts.
+type StorageUploadAppInterface interface {
+	CreateDirectUpload(upload *storage.DirectUploadRequest) (*storage.DirectUpload, error, interface{})
+	ConfirmDirectUpload(UUID string, userUUID string) (string, error, interface{})
+	CreateResumableUpload(upload *storage.ResumableUploadRequest) (*entity.StorageUpload, error, interface{})
+	GetResumableUpload(UUID string, userUUID string) (*entity.StorageUpload, error)
+	WriteResumableUpload(
+		UUID string,
+		userUUID string,
+		offset int64,
+		reader io.Reader) (*entity.StorageUpload, error, interface{})
+	DeleteResumableUpload(UUID string, userUUID string) error
+}
+
+// storageApp implement the StorageUploadAppInterface.
+var _ StorageUploadAppInterface = &storageApp{}
+
+// CreateDirectUpload is an implementation of method CreateDirectUpload.
+func (s storageApp) CreateDirectUpload(upload *storage.DirectUploadRequest) (*storage.DirectUpload, error, interface{}) {
+	return s.ss.CreateDirectUpload(upload)
+}
+
+// ConfirmDirectUpload is an implementation of method ConfirmDirectUpload.
+func (s storageApp) ConfirmDirectUpload(UUID string, userUUID string) (string, error, interface{}) {
+	return s.ss.ConfirmDirectUpload(UUID, userUUID)
+}
+
+// CreateResumableUpload is an implementation of method CreateResumableUpload.
+func (s storageApp) CreateResumableUpload(
+	upload *storage.ResumableUploadRequest) (*entity.StorageUpload, error, interface{}) {
+	return s.ss.CreateResumableUpload(upload)
+}
+
+// GetResumableUpload is an implementation of method GetResumableUpload.
+func (s storageApp) GetResumableUpload(UUID string, userUUID string) (*entity.StorageUpload, error) {
+	return s.ss.GetResumableUpload(UUID, userUUID)
+}
+
+// WriteResumableUpload is an implementation of method WriteResumableUpload.
+func (s storageApp) WriteResumableUpload(
+	UUID string,
+	userUUID string,
+	offset int64,
+	reader io.Reader) (*entity.StorageUpload, error, interface{}) {
+	return s.ss.WriteResumableUpload(UUID, userUUID, offset, reader)
+}
+
+// DeleteResumableUpload is an implementation of method DeleteResumableUpload.
+func (s storageApp) DeleteResumableUpload(UUID string, userUUID string) error {
+	return s.ss.DeleteResumableUpload(UUID, userUUID)
 }
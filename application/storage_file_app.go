@@ -0,0 +1,37 @@
+package application
+
+import (
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+)
+
+type storageFileApp struct {
+	fr repository.StorageFileRepository
+}
+
+// storageFileApp implement the StorageFileAppInterface.
+var _ StorageFileAppInterface = &storageFileApp{}
+
+// StorageFileAppInterface is an interface.
+type StorageFileAppInterface interface {
+	GetUserFiles(userUUID string, category string, p *repository.Parameters) ([]entity.StorageFile, interface{}, error)
+	GetUserFile(userUUID string, UUID string) (*entity.StorageFile, error)
+}
+
+// NewStorageFileApp will initialize storage file application.
+func NewStorageFileApp(fr repository.StorageFileRepository) StorageFileAppInterface {
+	return &storageFileApp{fr: fr}
+}
+
+// GetUserFiles is an implementation of method GetUserFiles.
+func (f *storageFileApp) GetUserFiles(
+	userUUID string,
+	category string,
+	p *repository.Parameters) ([]entity.StorageFile, interface{}, error) {
+	return f.fr.GetUserFiles(userUUID, category, p)
+}
+
+// GetUserFile is an implementation of method GetUserFile.
+func (f *storageFileApp) GetUserFile(userUUID string, UUID string) (*entity.StorageFile, error) {
+	return f.fr.GetUserFile(userUUID, UUID)
+}
@@ -3,6 +3,7 @@ package application
 import (
 	"go-rest-skeleton/domain/entity"
 	"go-rest-skeleton/domain/repository"
+	"time"
 )
 
 type tourApp struct {
@@ -17,6 +18,9 @@ type TourAppInterface interface {
 	SaveTour(*entity.Tour) (*entity.Tour, map[string]string, error)
 	UpdateTour(UUID string, tour *entity.Tour) (*entity.Tour, map[string]string, error)
 	DeleteTour(UUID string) error
+	RestoreTour(UUID string) (*entity.Tour, error)
+	ForceDeleteTour(UUID string) error
+	PurgeTours(before time.Time) (int64, error)
 	GetTours(p *repository.Parameters) ([]entity.Tour, interface{}, error)
 	GetTour(UUID string) (*entity.Tour, error)
 	GetTourBySlug(tour *entity.Tour) (*entity.Tour, map[string]string, error)
@@ -34,6 +38,18 @@ func (t tourApp) DeleteTour(UUID string) error {
 	return t.tr.DeleteTour(UUID)
 }
 
+func (t tourApp) RestoreTour(UUID string) (*entity.Tour, error) {
+	return t.tr.RestoreTour(UUID)
+}
+
+func (t tourApp) ForceDeleteTour(UUID string) error {
+	return t.tr.ForceDeleteTour(UUID)
+}
+
+func (t tourApp) PurgeTours(before time.Time) (int64, error) {
+	return t.tr.PurgeTours(before)
+}
+
 func (t tourApp) GetTours(p *repository.Parameters) ([]entity.Tour, interface{}, error) {
 	return t.tr.GetTours(p)
 }
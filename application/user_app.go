@@ -2,11 +2,14 @@ package application
 
 import (
 	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/event"
 	"go-rest-skeleton/domain/repository"
+	"time"
 )
 
 type userApp struct {
 	us repository.UserRepository
+	uw repository.UnitOfWork
 }
 
 type userAuthApp struct {
@@ -24,6 +27,9 @@ type UserAppInterface interface {
 	SaveUser(*entity.User) (*entity.User, map[string]string, error)
 	UpdateUser(string, *entity.User) (*entity.User, map[string]string, error)
 	DeleteUser(UUID string) error
+	RestoreUser(UUID string) (*entity.User, error)
+	ForceDeleteUser(UUID string) error
+	PurgeUsers(before time.Time) (int64, error)
 	GetUsers(parameters *repository.Parameters) ([]*entity.User, *repository.Meta, error)
 	GetUser(UUID string) (*entity.User, error)
 	GetUserRoles(UUID string) ([]entity.UserRole, error)
@@ -42,14 +48,71 @@ type UserAuthAppInterface interface {
 	GetUserByEmailAndPassword(*entity.User) (*entity.User, map[string]string, error)
 }
 
+// NewUserApp will initialize user application, changes are recorded to the outbox through uw.
+func NewUserApp(us repository.UserRepository, uw repository.UnitOfWork) UserAppInterface {
+	return &userApp{us: us, uw: uw}
+}
+
 // SaveUser is an implementation of method SaveUser.
+// It emits event.UserCreated within the same transaction.
 func (u *userApp) SaveUser(user *entity.User) (*entity.User, map[string]string, error) {
-	return u.us.SaveUser(user)
+	var savedUser *entity.User
+	var errDesc map[string]string
+	err := u.uw.Transaction(func(tx *repository.TxRepositories) error {
+		var errSave error
+		savedUser, errDesc, errSave = tx.User.SaveUser(user)
+		if errSave != nil {
+			return errSave
+		}
+
+		e, errEvent := event.New(event.UserCreated, event.AggregateUser, savedUser.UUID, event.UserPayload{
+			UUID:  savedUser.UUID,
+			Name:  savedUser.Name,
+			Email: savedUser.Email,
+			Phone: savedUser.Phone,
+		})
+		if errEvent != nil {
+			return errEvent
+		}
+
+		return tx.Outbox.SaveEvent(e.WithIdempotencyKey(event.UserCreated + ":" + savedUser.UUID))
+	})
+	if err != nil {
+		return nil, errDesc, err
+	}
+
+	return savedUser, nil, nil
 }
 
-// UpdateUser is an implementation of method SaveUser.
+// UpdateUser is an implementation of method UpdateUser.
+// It emits event.UserUpdated within the same transaction.
 func (u *userApp) UpdateUser(UUID string, user *entity.User) (*entity.User, map[string]string, error) {
-	return u.us.UpdateUser(UUID, user)
+	var updatedUser *entity.User
+	var errDesc map[string]string
+	err := u.uw.Transaction(func(tx *repository.TxRepositories) error {
+		var errUpdate error
+		updatedUser, errDesc, errUpdate = tx.User.UpdateUser(UUID, user)
+		if errUpdate != nil {
+			return errUpdate
+		}
+
+		e, errEvent := event.New(event.UserUpdated, event.AggregateUser, updatedUser.UUID, event.UserPayload{
+			UUID:  updatedUser.UUID,
+			Name:  updatedUser.Name,
+			Email: updatedUser.Email,
+			Phone: updatedUser.Phone,
+		})
+		if errEvent != nil {
+			return errEvent
+		}
+
+		return tx.Outbox.SaveEvent(e)
+	})
+	if err != nil {
+		return nil, errDesc, err
+	}
+
+	return updatedUser, nil, nil
 }
 
 // DeleteUser is an implementation of method DeleteUser.
@@ -57,6 +120,21 @@ func (u *userApp) DeleteUser(UUID string) error {
 	return u.us.DeleteUser(UUID)
 }
 
+// RestoreUser is an implementation of method RestoreUser.
+func (u *userApp) RestoreUser(UUID string) (*entity.User, error) {
+	return u.us.RestoreUser(UUID)
+}
+
+// ForceDeleteUser is an implementation of method ForceDeleteUser.
+func (u *userApp) ForceDeleteUser(UUID string) error {
+	return u.us.ForceDeleteUser(UUID)
+}
+
+// PurgeUsers is an implementation of method PurgeUsers.
+func (u *userApp) PurgeUsers(before time.Time) (int64, error) {
+	return u.us.PurgeUsers(before)
+}
+
 // GetUser is an implementation of method GetUser.
 func (u *userApp) GetUser(UUID string) (*entity.User, error) {
 	return u.us.GetUser(UUID)
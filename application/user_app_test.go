@@ -0,0 +1,71 @@
+package application_test
+
+import (
+	"go-rest-skeleton/application"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/event"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserApp_SaveUser(t *testing.T) {
+	uw := newUnitOfWork()
+	userApp := application.NewUserApp(nil, uw)
+
+	user, _, err := userApp.SaveUser(&entity.User{Name: "Example", Email: "example@test.com"})
+	assert.NoError(t, err)
+	assert.Contains(t, uw.users, user.UUID)
+	assert.Len(t, uw.events, 1)
+	assert.Equal(t, event.UserCreated, uw.events[0].Type)
+	assert.Equal(t, user.UUID, uw.events[0].AggregateUUID)
+	assert.Equal(t, event.UserCreated+":"+user.UUID, uw.events[0].IdempotencyKey)
+}
+
+func TestUserApp_SaveUser_RollsBack(t *testing.T) {
+	uw := newUnitOfWork()
+	uw.failOutbox = true
+	userApp := application.NewUserApp(nil, uw)
+
+	user, _, err := userApp.SaveUser(&entity.User{Name: "Example", Email: "example@test.com"})
+	assert.Equal(t, errOutbox, err)
+	assert.Nil(t, user)
+	assert.Empty(t, uw.users)
+	assert.Empty(t, uw.events)
+}
+
+func TestUserApp_UpdateUser(t *testing.T) {
+	uw := newUnitOfWork()
+	UUID := uuid.New().String()
+	uw.users[UUID] = entity.User{UUID: UUID, Name: "Example", Version: 1}
+	userApp := application.NewUserApp(nil, uw)
+
+	user, _, err := userApp.UpdateUser(UUID, &entity.User{Name: "Updated"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, user.Version)
+	assert.Equal(t, "Updated", uw.users[UUID].Name)
+	assert.Len(t, uw.events, 1)
+	assert.Equal(t, event.UserUpdated, uw.events[0].Type)
+	assert.Equal(t, UUID, uw.events[0].AggregateUUID)
+}
+
+func TestUserApp_UpdateUser_RollsBack(t *testing.T) {
+	uw := newUnitOfWork()
+	UUID := uuid.New().String()
+	uw.users[UUID] = entity.User{UUID: UUID, Name: "Example", Version: 1}
+	uw.failOutbox = true
+	userApp := application.NewUserApp(nil, uw)
+
+	_, _, err := userApp.UpdateUser(UUID, &entity.User{Name: "Updated"})
+	assert.Equal(t, errOutbox, err)
+	assert.Equal(t, "Example", uw.users[UUID].Name)
+	assert.Empty(t, uw.events)
+
+	// No event is recorded for a change which fails.
+	uw.failOutbox = false
+	_, _, err = userApp.UpdateUser(uuid.New().String(), &entity.User{Name: "Updated"})
+	assert.Equal(t, exception.ErrorTextUserNotFound, err)
+	assert.Empty(t, uw.events)
+}
@@ -0,0 +1,49 @@
+package application
+
+import (
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+)
+
+type userNotificationApp struct {
+	un repository.UserNotificationRepository
+}
+
+// userNotificationApp implement the UserNotificationAppInterface.
+var _ UserNotificationAppInterface = &userNotificationApp{}
+
+// UserNotificationAppInterface is an interface.
+type UserNotificationAppInterface interface {
+	GetUserNotifications(userUUID string, status string, p *repository.Parameters) ([]entity.UserNotification, interface{}, error)
+	MarkUserNotificationRead(userUUID string, UUID string) (*entity.UserNotification, error)
+	MarkUserNotificationUnread(userUUID string, UUID string) (*entity.UserNotification, error)
+	MarkAllUserNotificationsRead(userUUID string) (int64, error)
+}
+
+// NewUserNotificationApp will initialize user notification application.
+func NewUserNotificationApp(un repository.UserNotificationRepository) UserNotificationAppInterface {
+	return &userNotificationApp{un: un}
+}
+
+// GetUserNotifications is an implementation of method GetUserNotifications.
+func (n *userNotificationApp) GetUserNotifications(
+	userUUID string,
+	status string,
+	p *repository.Parameters) ([]entity.UserNotification, interface{}, error) {
+	return n.un.GetUserNotifications(userUUID, status, p)
+}
+
+// MarkUserNotificationRead is an implementation of method MarkUserNotificationRead.
+func (n *userNotificationApp) MarkUserNotificationRead(userUUID string, UUID string) (*entity.UserNotification, error) {
+	return n.un.MarkUserNotificationRead(userUUID, UUID)
+}
+
+// MarkUserNotificationUnread is an implementation of method MarkUserNotificationUnread.
+func (n *userNotificationApp) MarkUserNotificationUnread(userUUID string, UUID string) (*entity.UserNotification, error) {
+	return n.un.MarkUserNotificationUnread(userUUID, UUID)
+}
+
+// MarkAllUserNotificationsRead is an implementation of method MarkAllUserNotificationsRead.
+func (n *userNotificationApp) MarkAllUserNotificationsRead(userUUID string) (int64, error) {
+	return n.un.MarkAllUserNotificationsRead(userUUID)
+}
@@ -17,6 +17,7 @@ type UserPreferenceAppInterface interface {
 	GetUserPreference(UUID string) (*entity.UserPreference, error)
 	UpdateUserPreference(UUID string, p *entity.DetailUserPreference) (*entity.UserPreference, map[string]string, error)
 	ResetUserPreference(UUID string) (*entity.UserPreference, error)
+	GetUserLanguage(UUID string) (string, error)
 }
 
 // GetUserPreference is an implementation of method GetUserPreference.
@@ -36,3 +37,8 @@ func (up userPreferenceApp) UpdateUserPreference(userUUID string, p *entity.Deta
 func (up userPreferenceApp) ResetUserPreference(userUUID string) (*entity.UserPreference, error) {
 	return up.up.ResetUserPreference(userUUID)
 }
+
+// GetUserLanguage is an implementation of method GetUserLanguage.
+func (up userPreferenceApp) GetUserLanguage(userUUID string) (string, error) {
+	return up.up.GetUserLanguage(userUUID)
+}
@@ -0,0 +1,130 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/event"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/infrastructure/webhook"
+)
+
+type webhookApp struct {
+	ap repository.ApplicationRepository
+	ws repository.WebhookSubscriptionRepository
+	wd repository.WebhookDeliveryRepository
+	dl *webhook.Deliverer
+}
+
+// webhookApp implement the WebhookAppInterface.
+var _ WebhookAppInterface = &webhookApp{}
+
+// WebhookAppInterface is an interface.
+type WebhookAppInterface interface {
+	SaveWebhookSubscription(*entity.WebhookSubscription) (*entity.WebhookSubscription, map[string]string, error)
+	UpdateWebhookSubscription(string, *entity.WebhookSubscription) (*entity.WebhookSubscription, map[string]string, error)
+	EnableWebhookSubscription(UUID string) error
+	DeleteWebhookSubscription(UUID string) error
+	GetWebhookSubscription(UUID string) (*entity.WebhookSubscription, error)
+	GetWebhookSubscriptions(p *repository.Parameters) ([]entity.WebhookSubscription, interface{}, error)
+	GetWebhookDelivery(subscriptionUUID string, UUID string) (*entity.WebhookDelivery, error)
+	GetWebhookDeliveries(subscriptionUUID string, p *repository.Parameters) ([]entity.WebhookDelivery, interface{}, error)
+	ReplayWebhookDelivery(ctx context.Context, subscriptionUUID string, UUID string) (*entity.WebhookDelivery, error)
+}
+
+// NewWebhookApp will initialize webhook application.
+func NewWebhookApp(
+	ap repository.ApplicationRepository,
+	ws repository.WebhookSubscriptionRepository,
+	wd repository.WebhookDeliveryRepository,
+	dl *webhook.Deliverer) WebhookAppInterface {
+	return &webhookApp{ap: ap, ws: ws, wd: wd, dl: dl}
+}
+
+// SaveWebhookSubscription is an implementation of method SaveWebhookSubscription.
+// The subscription is only created for an existing application.
+func (w *webhookApp) SaveWebhookSubscription(
+	subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, map[string]string, error) {
+	if _, err := w.ap.GetApplication(subscription.ApplicationUUID); err != nil {
+		errDesc := map[string]string{}
+		if errors.Is(err, exception.ErrorTextApplicationNotFound) {
+			errDesc["application_uuid"] = exception.ErrorTextApplicationInvalidUUID.Error()
+			return nil, errDesc, exception.ErrorTextUnprocessableEntity
+		}
+		return nil, errDesc, err
+	}
+
+	return w.ws.SaveWebhookSubscription(subscription)
+}
+
+// UpdateWebhookSubscription is an implementation of method UpdateWebhookSubscription.
+func (w *webhookApp) UpdateWebhookSubscription(
+	UUID string,
+	subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, map[string]string, error) {
+	return w.ws.UpdateWebhookSubscription(UUID, subscription)
+}
+
+// EnableWebhookSubscription is an implementation of method EnableWebhookSubscription.
+func (w *webhookApp) EnableWebhookSubscription(UUID string) error {
+	return w.ws.EnableWebhookSubscription(UUID)
+}
+
+// DeleteWebhookSubscription is an implementation of method DeleteWebhookSubscription.
+func (w *webhookApp) DeleteWebhookSubscription(UUID string) error {
+	return w.ws.DeleteWebhookSubscription(UUID)
+}
+
+// GetWebhookSubscription is an implementation of method GetWebhookSubscription.
+func (w *webhookApp) GetWebhookSubscription(UUID string) (*entity.WebhookSubscription, error) {
+	return w.ws.GetWebhookSubscription(UUID)
+}
+
+// GetWebhookSubscriptions is an implementation of method GetWebhookSubscriptions.
+func (w *webhookApp) GetWebhookSubscriptions(
+	p *repository.Parameters) ([]entity.WebhookSubscription, interface{}, error) {
+	return w.ws.GetWebhookSubscriptions(p)
+}
+
+// GetWebhookDelivery is an implementation of method GetWebhookDelivery.
+func (w *webhookApp) GetWebhookDelivery(subscriptionUUID string, UUID string) (*entity.WebhookDelivery, error) {
+	return w.wd.GetWebhookDelivery(subscriptionUUID, UUID)
+}
+
+// GetWebhookDeliveries is an implementation of method GetWebhookDeliveries.
+func (w *webhookApp) GetWebhookDeliveries(
+	subscriptionUUID string,
+	p *repository.Parameters) ([]entity.WebhookDelivery, interface{}, error) {
+	return w.wd.GetWebhookDeliveries(subscriptionUUID, p)
+}
+
+// ReplayWebhookDelivery is an implementation of method ReplayWebhookDelivery.
+// It sends the event of a recorded delivery again to the subscription and returns the new delivery,
+// a failed replay is returned as a delivery with failed status.
+func (w *webhookApp) ReplayWebhookDelivery(
+	ctx context.Context,
+	subscriptionUUID string,
+	UUID string) (*entity.WebhookDelivery, error) {
+	subscription, err := w.ws.GetWebhookSubscription(subscriptionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := w.wd.GetWebhookDelivery(subscriptionUUID, UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var e event.Event
+	if err := json.Unmarshal([]byte(delivery.RequestBody), &e); err != nil {
+		return nil, err
+	}
+
+	replayed, errDeliver := w.dl.Deliver(ctx, subscription, &e, delivery.Attempt+1)
+	if replayed == nil {
+		return nil, errDeliver
+	}
+
+	return replayed, nil
+}
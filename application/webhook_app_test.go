@@ -0,0 +1,41 @@
+package application_test
+
+import (
+	"go-rest-skeleton/application"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookApp_SaveWebhookSubscription(t *testing.T) {
+	UUID := uuid.New().String()
+	ap := &applicationRepo{applications: map[string]entity.Application{UUID: {UUID: UUID, Name: "Example"}}}
+	ws := &webhookSubscriptionRepo{}
+	webhookApp := application.NewWebhookApp(ap, ws, nil, nil)
+
+	subscription, _, err := webhookApp.SaveWebhookSubscription(&entity.WebhookSubscription{
+		ApplicationUUID: UUID,
+		TargetURL:       "https://example.com/webhook",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, UUID, subscription.ApplicationUUID)
+	assert.Len(t, ws.subscriptions, 1)
+}
+
+func TestWebhookApp_SaveWebhookSubscription_UnknownApplication(t *testing.T) {
+	ap := &applicationRepo{applications: map[string]entity.Application{}}
+	ws := &webhookSubscriptionRepo{}
+	webhookApp := application.NewWebhookApp(ap, ws, nil, nil)
+
+	subscription, errDesc, err := webhookApp.SaveWebhookSubscription(&entity.WebhookSubscription{
+		ApplicationUUID: uuid.New().String(),
+		TargetURL:       "https://example.com/webhook",
+	})
+	assert.Equal(t, exception.ErrorTextUnprocessableEntity, err)
+	assert.Nil(t, subscription)
+	assert.Equal(t, exception.ErrorTextApplicationInvalidUUID.Error(), errDesc["application_uuid"])
+	assert.Empty(t, ws.subscriptions)
+}
@@ -2,9 +2,6 @@ package config
 
 import (
 	"fmt"
-	"os"
-	"strconv"
-	"strings"
 )
 
 // DBConfig represent db config keys.
@@ -17,6 +14,8 @@ type DBConfig struct {
 	DBPassword string
 	DBTimeZone string
 	DBLog      bool
+	// DBPurgeRetentionDays is how long soft deleted records are kept before db:purge removes them.
+	DBPurgeRetentionDays int
 }
 
 // DBTestConfig represent db test config keys.
@@ -55,6 +54,105 @@ type MinioConfig struct {
 	Bucket    string
 }
 
+// StorageConfig represent storage config keys.
+type StorageConfig struct {
+	StorageDriver          string
+	S3Region               string
+	S3Endpoint             string
+	S3AccessKey            string
+	S3SecretKey            string
+	S3Bucket               string
+	GCSBucket              string
+	GCSCredentialsFile     string
+	LocalStoragePath       string
+	LocalStorageURL        string
+	LocalStorageSigningKey string
+	StorageUploadPath      string
+	StorageResumableUpload bool
+	StorageImageVariants   []string
+	StorageImageWebP       bool
+	StorageImageQuality    int
+	StorageScanner         string
+	ClamAVAddress          string
+	ClamAVTimeout          int
+}
+
+// QueueConfig represent queue config keys.
+type QueueConfig struct {
+	QueueName              string
+	QueueConcurrency       int
+	QueueVisibilityTimeout int
+}
+
+// OutboxConfig represent outbox config keys.
+type OutboxConfig struct {
+	OutboxBatchSize  int
+	OutboxStream     string
+	OutboxWebhookURL string
+}
+
+// WebhookConfig represent webhook config keys.
+type WebhookConfig struct {
+	WebhookTimeout     int
+	WebhookMaxFailures int
+}
+
+// CacheConfig represent repository cache config keys, each repository is cached only when enabled.
+// TTL are in seconds.
+type CacheConfig struct {
+	CacheUserEnabled            bool
+	CacheUserTTL                int
+	CacheRoleEnabled            bool
+	CacheRoleTTL                int
+	CachePermissionEnabled      bool
+	CachePermissionTTL          int
+	CacheStorageCategoryEnabled bool
+	CacheStorageCategoryTTL     int
+}
+
+// MetricsConfig represent metrics config keys.
+// Metrics are served on MetricsListen when set, otherwise on the app port behind basic auth.
+type MetricsConfig struct {
+	MetricsEnabled  bool
+	MetricsListen   string
+	MetricsUsername string
+	MetricsPassword string
+}
+
+// TracingConfig represent tracing config keys.
+// Tracing is disabled while TracingExporter is empty, otherwise it is either otlp or stdout.
+type TracingConfig struct {
+	TracingExporter     string
+	TracingServiceName  string
+	TracingOTLPEndpoint string
+	TracingOTLPInsecure bool
+	TracingSampleRatio  float64
+}
+
+// HealthConfig represent health check config keys, timeout and cache TTL are in seconds.
+// SMTP server is only checked by readiness when HealthCheckSMTP is enabled.
+type HealthConfig struct {
+	HealthCheckTimeout  int
+	HealthCheckCacheTTL int
+	HealthCheckSMTP     bool
+}
+
+// ServerConfig represent config keys of components started by serve command.
+// ShutdownTimeout is the time in seconds given to components to drain work in flight on SIGTERM.
+type ServerConfig struct {
+	ServeHTTP       bool
+	ServeGRPC       bool
+	ServeWorkers    bool
+	GRPCPort        int
+	ShutdownTimeout int
+}
+
+// IdempotencyConfig represent idempotency config keys.
+type IdempotencyConfig struct {
+	IdempotencyTTL     int
+	IdempotencyLockTTL int
+}
+
 // SMTPConfig represent SMTP config keys.
 type SMTPConfig struct {
 	SMTPHost     string
@@ -63,6 +161,19 @@ type SMTPConfig struct {
 	SMTPPassword string
 }
 
+// NotificationConfig represent notification config keys.
+type NotificationConfig struct {
+	NotificationBroadcastChannel string
+	NotificationLogFile          string
+	SMSProvider                  string
+	TwilioAccountSID             string
+	TwilioAuthToken              string
+	TwilioFrom                   string
+	PushProvider                 string
+	FCMProjectID                 string
+	FCMCredentialsFile           string
+}
+
 // RollbarConfig represent rollbar config keys.
 type RollbarConfig struct {
 	Token       string
@@ -93,10 +204,22 @@ type Config struct {
 	RedisConfig
 	RedisTestConfig
 	MinioConfig
+	StorageConfig
+	QueueConfig
+	OutboxConfig
+	WebhookConfig
+	CacheConfig
+	MetricsConfig
+	TracingConfig
+	HealthConfig
+	ServerConfig
+	IdempotencyConfig
 	SMTPConfig
+	NotificationConfig
 	RollbarConfig
 	Oauth2Config
 	KeyConfig
+	AppPort         string
 	AppEnvironment  string
 	AppLanguage     string
 	AppTimezone     string
@@ -104,125 +227,200 @@ type Config struct {
 	EnableLogger    bool
 	EnableRequestID bool
 	DebugMode       bool
+	ErrorFormat     string
+	ProblemTypeURL  string
+
+	// entries keeps every key read with its effective value and source.
+	entries map[string]Entry
 }
 
-// New returns a new Config struct.
+// New returns a new Config struct read from defaults, CONFIG_FILE and environment variables.
+// Invalid values fall back to their default, use Load to refuse them.
 func New() *Config {
+	conf, _ := Load(Options{})
+	return conf
+}
+
+// Load returns a new Config struct read from defaults, then the config file, then environment variables,
+// then flags, each source overriding the previous. Config is always returned, along with Errors
+// when a source can not be read, a value is invalid, or a secret is left to its insecure default in production.
+func Load(opts Options) (*Config, error) {
+	l := newLoader(opts)
+	conf := l.config()
+	conf.entries = l.entries
+
+	errs := append(l.errs, conf.validate()...)
+	if len(errs) > 0 {
+		return conf, errs
+	}
+
+	return conf, nil
+}
+
+// config reads every key through the loader.
+func (l *loader) config() *Config {
+	appPort := l.getEnv("APP_PORT", "8888")
+
 	return &Config{
 		DBConfig: DBConfig{
-			DBDriver:   getEnv("DB_DRIVER", "mysql"),
-			DBHost:     getEnv("DB_HOST", "localhost"),
-			DBPort:     getEnv("DB_PORT", "3306"),
-			DBUser:     getEnv("DB_USER", "root"),
-			DBName:     getEnv("DB_NAME", "go_rest_skeleton"),
-			DBPassword: getEnv("DB_PASSWORD", ""),
-			DBTimeZone: getEnv("APP_TIMEZONE", "Asia/Jakarta"),
-			DBLog:      getEnvAsBool("ENABLE_LOGGER", true),
+			DBDriver:   l.getEnv("DB_DRIVER", "mysql"),
+			DBHost:     l.getEnv("DB_HOST", "localhost"),
+			DBPort:     l.getEnv("DB_PORT", "3306"),
+			DBUser:     l.getEnv("DB_USER", "root"),
+			DBName:     l.getEnv("DB_NAME", "go_rest_skeleton"),
+			DBPassword: l.getEnv("DB_PASSWORD", ""),
+			DBTimeZone: l.getEnv("APP_TIMEZONE", "Asia/Jakarta"),
+			DBLog:      l.getEnvAsBool("ENABLE_LOGGER", true),
+
+			DBPurgeRetentionDays: l.getEnvAsInt("DB_PURGE_RETENTION_DAYS", 30),
 		},
 		DBTestConfig: DBTestConfig{
-			DBDriver:   getEnv("TEST_DB_DRIVER", "mysql"),
-			DBHost:     getEnv("TEST_DB_HOST", "localhost"),
-			DBPort:     getEnv("TEST_DB_PORT", "3306"),
-			DBUser:     getEnv("TEST_DB_USER", "root"),
-			DBName:     getEnv("TEST_DB_NAME", "go_rest_skeleton_test"),
-			DBPassword: getEnv("TEST_DB_PASSWORD", ""),
-			DBTimeZone: getEnv("APP_TIMEZONE", "Asia/Jakarta"),
-			DBLog:      getEnvAsBool("ENABLE_LOGGER", true),
+			DBDriver:   l.getEnv("TEST_DB_DRIVER", "mysql"),
+			DBHost:     l.getEnv("TEST_DB_HOST", "localhost"),
+			DBPort:     l.getEnv("TEST_DB_PORT", "3306"),
+			DBUser:     l.getEnv("TEST_DB_USER", "root"),
+			DBName:     l.getEnv("TEST_DB_NAME", "go_rest_skeleton_test"),
+			DBPassword: l.getEnv("TEST_DB_PASSWORD", ""),
+			DBTimeZone: l.getEnv("APP_TIMEZONE", "Asia/Jakarta"),
+			DBLog:      l.getEnvAsBool("ENABLE_LOGGER", true),
 		},
 		RedisConfig: RedisConfig{
-			RedisHost:     getEnv("REDIS_HOST", "127.0.0.1"),
-			RedisPort:     getEnv("REDIS_PORT", "6379"),
-			RedisPassword: getEnv("REDIS_PASSWORD", ""),
-			RedisDB:       getEnvAsInt("REDIS_DB", 0),
+			RedisHost:     l.getEnv("REDIS_HOST", "127.0.0.1"),
+			RedisPort:     l.getEnv("REDIS_PORT", "6379"),
+			RedisPassword: l.getEnv("REDIS_PASSWORD", ""),
+			RedisDB:       l.getEnvAsInt("REDIS_DB", 0),
 		},
 		RedisTestConfig: RedisTestConfig{
-			RedisHost:     getEnv("TEST_REDIS_HOST", "127.0.0.1"),
-			RedisPort:     getEnv("TEST_REDIS_PORT", "6379"),
-			RedisPassword: getEnv("TEST_REDIS_PASSWORD", ""),
-			RedisDB:       getEnvAsInt("TEST_REDIS_DB", 10),
+			RedisHost:     l.getEnv("TEST_REDIS_HOST", "127.0.0.1"),
+			RedisPort:     l.getEnv("TEST_REDIS_PORT", "6379"),
+			RedisPassword: l.getEnv("TEST_REDIS_PASSWORD", ""),
+			RedisDB:       l.getEnvAsInt("TEST_REDIS_DB", 10),
 		},
 		MinioConfig: MinioConfig{
-			Endpoint:  getEnv("MINIO_HOST", "127.0.0.1:9000"),
-			AccessKey: getEnv("MINIO_ACCESS_KEY", "minio"),
-			SecretKey: getEnv("MINIO_SECRET_KEY", "miniostorage"),
-			Bucket:    getEnv("MINIO_BUCKET", "go-rest-skeleton"),
+			Endpoint:  l.getEnv("MINIO_HOST", "127.0.0.1:9000"),
+			AccessKey: l.getEnv("MINIO_ACCESS_KEY", "minio"),
+			SecretKey: l.getEnv("MINIO_SECRET_KEY", defaultMinioSecretKey),
+			Bucket:    l.getEnv("MINIO_BUCKET", "go-rest-skeleton"),
+		},
+		StorageConfig: StorageConfig{
+			StorageDriver:          l.getEnv("STORAGE_DRIVER", "minio"),
+			S3Region:               l.getEnv("S3_REGION", "us-east-1"),
+			S3Endpoint:             l.getEnv("S3_ENDPOINT", ""),
+			S3AccessKey:            l.getEnv("S3_ACCESS_KEY", ""),
+			S3SecretKey:            l.getEnv("S3_SECRET_KEY", ""),
+			S3Bucket:               l.getEnv("S3_BUCKET", "go-rest-skeleton"),
+			GCSBucket:              l.getEnv("GCS_BUCKET", "go-rest-skeleton"),
+			GCSCredentialsFile:     l.getEnv("GCS_CREDENTIALS_FILE", ""),
+			LocalStoragePath:       l.getEnv("LOCAL_STORAGE_PATH", "storage"),
+			LocalStorageURL:        l.getEnv("LOCAL_STORAGE_URL", "http://localhost:8888"),
+			LocalStorageSigningKey: l.getEnv("LOCAL_STORAGE_SIGNING_KEY", ""),
+			StorageUploadPath:      l.getEnv("STORAGE_UPLOAD_PATH", ""),
+			StorageResumableUpload: l.getEnvAsBool("STORAGE_RESUMABLE_UPLOAD", true),
+			StorageImageVariants: l.getEnvAsSlice(
+				"STORAGE_IMAGE_VARIANTS", []string{"small:64", "medium:256", "large:1024"}, ","),
+			StorageImageWebP:    l.getEnvAsBool("STORAGE_IMAGE_WEBP", true),
+			StorageImageQuality: l.getEnvAsInt("STORAGE_IMAGE_QUALITY", 90),
+			StorageScanner:      l.getEnv("STORAGE_SCANNER", ""),
+			ClamAVAddress:       l.getEnv("CLAMAV_ADDRESS", "127.0.0.1:3310"),
+			ClamAVTimeout:       l.getEnvAsInt("CLAMAV_TIMEOUT", 60),
+		},
+		QueueConfig: QueueConfig{
+			QueueName:              l.getEnv("QUEUE_NAME", "default"),
+			QueueConcurrency:       l.getEnvAsInt("QUEUE_CONCURRENCY", 4),
+			QueueVisibilityTimeout: l.getEnvAsInt("QUEUE_VISIBILITY_TIMEOUT", 300),
+		},
+		OutboxConfig: OutboxConfig{
+			OutboxBatchSize:  l.getEnvAsInt("OUTBOX_BATCH_SIZE", 100),
+			OutboxStream:     l.getEnv("OUTBOX_STREAM", "events"),
+			OutboxWebhookURL: l.getEnv("OUTBOX_WEBHOOK_URL", ""),
+		},
+		WebhookConfig: WebhookConfig{
+			WebhookTimeout:     l.getEnvAsInt("WEBHOOK_TIMEOUT", 10),
+			WebhookMaxFailures: l.getEnvAsInt("WEBHOOK_MAX_FAILURES", 10),
+		},
+		CacheConfig: CacheConfig{
+			CacheUserEnabled:            l.getEnvAsBool("CACHE_USER_ENABLED", false),
+			CacheUserTTL:                l.getEnvAsInt("CACHE_USER_TTL", 300),
+			CacheRoleEnabled:            l.getEnvAsBool("CACHE_ROLE_ENABLED", false),
+			CacheRoleTTL:                l.getEnvAsInt("CACHE_ROLE_TTL", 300),
+			CachePermissionEnabled:      l.getEnvAsBool("CACHE_PERMISSION_ENABLED", false),
+			CachePermissionTTL:          l.getEnvAsInt("CACHE_PERMISSION_TTL", 3600),
+			CacheStorageCategoryEnabled: l.getEnvAsBool("CACHE_STORAGE_CATEGORY_ENABLED", false),
+			CacheStorageCategoryTTL:     l.getEnvAsInt("CACHE_STORAGE_CATEGORY_TTL", 3600),
+		},
+		MetricsConfig: MetricsConfig{
+			MetricsEnabled:  l.getEnvAsBool("METRICS_ENABLED", false),
+			MetricsListen:   l.getEnv("METRICS_LISTEN", ""),
+			MetricsUsername: l.getEnv("METRICS_USERNAME", ""),
+			MetricsPassword: l.getEnv("METRICS_PASSWORD", ""),
+		},
+		TracingConfig: TracingConfig{
+			TracingExporter:     l.getEnv("TRACING_EXPORTER", ""),
+			TracingServiceName:  l.getEnv("TRACING_SERVICE_NAME", "go-rest-skeleton"),
+			TracingOTLPEndpoint: l.getEnv("TRACING_OTLP_ENDPOINT", "localhost:4317"),
+			TracingOTLPInsecure: l.getEnvAsBool("TRACING_OTLP_INSECURE", true),
+			TracingSampleRatio:  l.getEnvAsFloat("TRACING_SAMPLE_RATIO", 1),
+		},
+		HealthConfig: HealthConfig{
+			HealthCheckTimeout:  l.getEnvAsInt("HEALTH_CHECK_TIMEOUT", 2),
+			HealthCheckCacheTTL: l.getEnvAsInt("HEALTH_CHECK_CACHE_TTL", 5),
+			HealthCheckSMTP:     l.getEnvAsBool("HEALTH_CHECK_SMTP", false),
+		},
+		ServerConfig: ServerConfig{
+			ServeHTTP:       l.getEnvAsBool("SERVE_HTTP", true),
+			ServeGRPC:       l.getEnvAsBool("SERVE_GRPC", false),
+			ServeWorkers:    l.getEnvAsBool("SERVE_WORKERS", false),
+			GRPCPort:        l.getEnvAsInt("GRPC_PORT", 4040),
+			ShutdownTimeout: l.getEnvAsInt("SHUTDOWN_TIMEOUT", 30),
+		},
+		IdempotencyConfig: IdempotencyConfig{
+			IdempotencyTTL:     l.getEnvAsInt("IDEMPOTENCY_TTL", 86400),
+			IdempotencyLockTTL: l.getEnvAsInt("IDEMPOTENCY_LOCK_TTL", 60),
 		},
 		SMTPConfig: SMTPConfig{
-			SMTPHost:     getEnv("SMTP_HOST", ""),
-			SMTPPort:     getEnvAsInt("SMTP_PORT", 587),
-			SMTPUsername: getEnv("SMTP_USERNAME", ""),
-			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			SMTPHost:     l.getEnv("SMTP_HOST", ""),
+			SMTPPort:     l.getEnvAsInt("SMTP_PORT", 587),
+			SMTPUsername: l.getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: l.getEnv("SMTP_PASSWORD", ""),
+		},
+		NotificationConfig: NotificationConfig{
+			NotificationBroadcastChannel: l.getEnv("NOTIFICATION_BROADCAST_CHANNEL", "notifications"),
+			NotificationLogFile:          l.getEnv("NOTIFICATION_LOG_FILE", ""),
+			SMSProvider:                  l.getEnv("SMS_PROVIDER", "log"),
+			TwilioAccountSID:             l.getEnv("TWILIO_ACCOUNT_SID", ""),
+			TwilioAuthToken:              l.getEnv("TWILIO_AUTH_TOKEN", ""),
+			TwilioFrom:                   l.getEnv("TWILIO_FROM", ""),
+			PushProvider:                 l.getEnv("PUSH_PROVIDER", "log"),
+			FCMProjectID:                 l.getEnv("FCM_PROJECT_ID", ""),
+			FCMCredentialsFile:           l.getEnv("FCM_CREDENTIALS_FILE", ""),
 		},
 		RollbarConfig: RollbarConfig{
-			Token:       getEnv("ROLLBAR_TOKEN", ""),
-			Environment: getEnv("APP_ENV", "local"),
-			CodeVersion: getEnv("APP_VERSION", "1.0.0"),
-			ServerHost:  getEnv("APP_SERVER_HOST", ""),
-			ServerRoot:  getEnv("APP_SERVER_ROOT", ""),
+			Token:       l.getEnv("ROLLBAR_TOKEN", ""),
+			Environment: l.getEnv("APP_ENV", "local"),
+			CodeVersion: l.getEnv("APP_VERSION", "1.0.0"),
+			ServerHost:  l.getEnv("APP_SERVER_HOST", ""),
+			ServerRoot:  l.getEnv("APP_SERVER_ROOT", ""),
 		},
 		Oauth2Config: Oauth2Config{
-			OauthID:     getEnv("OAUTH_ID", "go-rest-skeleton"),
-			OauthSecret: getEnv("OAUTH_SECRET", "go-rest-skeleton"),
-			OauthDomain: getEnv("OAUTH_DOMAIN",
-				fmt.Sprintf("http://localhost:%s", os.Getenv("APP_PORT"))),
-			OauthToken: getEnv("OAUTH_TOKEN", "Bearer"),
+			OauthID:     l.getEnv("OAUTH_ID", "go-rest-skeleton"),
+			OauthSecret: l.getEnv("OAUTH_SECRET", defaultOauthSecret),
+			OauthDomain: l.getEnv("OAUTH_DOMAIN",
+				fmt.Sprintf("http://localhost:%s", appPort)),
+			OauthToken: l.getEnv("OAUTH_TOKEN", "Bearer"),
 		},
 		KeyConfig: KeyConfig{
-			AppPrivateKey: getEnv("APP_PRIVATE_KEY", "default-private-key"),
-			AppPublicKey:  getEnv("APP_PUBLIC_KEY", "default-public-key"),
-		},
-		AppEnvironment:  getEnv("APP_ENV", "local"),
-		AppLanguage:     getEnv("APP_LANG", "en"),
-		AppTimezone:     getEnv("APP_TIMEZONE", "Asia/Jakarta"),
-		EnableCors:      getEnvAsBool("ENABLE_CORS", true),
-		EnableLogger:    getEnvAsBool("ENABLE_LOGGER", true),
-		EnableRequestID: getEnvAsBool("ENABLE_REQUEST_ID", true),
-		DebugMode:       getEnv("APP_ENV", "local") != "production",
-	}
-}
-
-// Simple helper function to read an environment or return a default value.
-func getEnv(key string, defaultVal string) string {
-	if value, exists := os.LookupEnv(key); exists {
-		return value
-	}
-
-	if nextValue := os.Getenv(key); nextValue != "" {
-		return nextValue
-	}
-
-	return defaultVal
-}
-
-// Simple helper function to read an environment variable into integer or return a default value.
-func getEnvAsInt(name string, defaultVal int) int {
-	valueStr := getEnv(name, "")
-	if value, err := strconv.Atoi(valueStr); err == nil {
-		return value
-	}
-
-	return defaultVal
-}
-
-// Helper to read an environment variable into a bool or return default value.
-func getEnvAsBool(name string, defaultVal bool) bool {
-	valStr := getEnv(name, "")
-	if val, err := strconv.ParseBool(valStr); err == nil {
-		return val
-	}
-
-	return defaultVal
-}
-
-// Helper to read an environment variable into a string slice or return default value.
-func getEnvAsSlice(name string, defaultVal []string, sep string) []string {
-	valStr := getEnv(name, "")
-
-	if valStr == "" {
-		return defaultVal
+			AppPrivateKey: l.getEnv("APP_PRIVATE_KEY", defaultPrivateKey),
+			AppPublicKey:  l.getEnv("APP_PUBLIC_KEY", defaultPublicKey),
+		},
+		AppPort:         appPort,
+		AppEnvironment:  l.getEnv("APP_ENV", "local"),
+		AppLanguage:     l.getEnv("APP_LANG", "en"),
+		AppTimezone:     l.getEnv("APP_TIMEZONE", "Asia/Jakarta"),
+		EnableCors:      l.getEnvAsBool("ENABLE_CORS", true),
+		EnableLogger:    l.getEnvAsBool("ENABLE_LOGGER", true),
+		EnableRequestID: l.getEnvAsBool("ENABLE_REQUEST_ID", true),
+		DebugMode:       l.getEnv("APP_ENV", "local") != "production",
+		ErrorFormat:     l.getEnv("ERROR_FORMAT", "default"),
+		ProblemTypeURL:  l.getEnv("PROBLEM_TYPE_URL", "/problems"),
 	}
-
-	val := strings.Split(valStr, sep)
-
-	return val
 }
@@ -0,0 +1,132 @@
+package config_test
+
+import (
+	"go-rest-skeleton/config"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setEnv(t *testing.T, key string, value string) {
+	previous, exists := os.LookupEnv(key)
+	_ = os.Setenv(key, value)
+	t.Cleanup(func() {
+		if exists {
+			_ = os.Setenv(key, previous)
+			return
+		}
+		_ = os.Unsetenv(key)
+	})
+}
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("want non error, got %#v", err)
+	}
+	return path
+}
+
+func entry(conf *config.Config, key string) config.Entry {
+	for _, e := range conf.Entries() {
+		if e.Key == key {
+			return e
+		}
+	}
+	return config.Entry{}
+}
+
+func TestLoad_Layers(t *testing.T) {
+	file := writeFile(t, "config.yaml", `
+db:
+  host: db.internal
+  name: from_file
+redis_db: 3
+storage_image_variants: [small:32, large:512]
+`)
+	setEnv(t, "DB_NAME", "from_env")
+	setEnv(t, "REDIS_PORT", "6380")
+
+	conf, err := config.Load(config.Options{File: file, Flags: map[string]string{"REDIS_PORT": "6381"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "db.internal", conf.DBConfig.DBHost)
+	assert.Equal(t, "from_env", conf.DBConfig.DBName)
+	assert.Equal(t, 3, conf.RedisConfig.RedisDB)
+	assert.Equal(t, "6381", conf.RedisConfig.RedisPort)
+	assert.Equal(t, []string{"small:32", "large:512"}, conf.StorageImageVariants)
+	assert.Equal(t, "3306", conf.DBConfig.DBPort)
+
+	assert.Equal(t, config.SourceFile, entry(conf, "DB_HOST").Source)
+	assert.Equal(t, config.SourceEnv, entry(conf, "DB_NAME").Source)
+	assert.Equal(t, config.SourceFlag, entry(conf, "REDIS_PORT").Source)
+	assert.Equal(t, config.SourceDefault, entry(conf, "DB_PORT").Source)
+}
+
+func TestLoad_TOML(t *testing.T) {
+	file := writeFile(t, "config.toml", `
+[redis]
+host = "redis.internal"
+db = 2
+`)
+
+	conf, err := config.Load(config.Options{File: file})
+	assert.NoError(t, err)
+	assert.Equal(t, "redis.internal", conf.RedisConfig.RedisHost)
+	assert.Equal(t, 2, conf.RedisConfig.RedisDB)
+}
+
+func TestLoad_SecretFile(t *testing.T) {
+	secret := writeFile(t, "db_password", "s3cret\n")
+	setEnv(t, "DB_PASSWORD_FILE", secret)
+
+	conf, err := config.Load(config.Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, "s3cret", conf.DBConfig.DBPassword)
+	assert.Equal(t, "env DB_PASSWORD_FILE", entry(conf, "DB_PASSWORD").Source)
+	assert.Equal(t, "******", entry(conf, "DB_PASSWORD").Redacted())
+	assert.Equal(t, "3306", entry(conf, "DB_PORT").Redacted())
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	conf, err := config.Load(config.Options{Flags: map[string]string{
+		"REDIS_DB":       "two",
+		"ENABLE_CORS":    "sometimes",
+		"STORAGE_DRIVER": "ftp",
+	}})
+	assert.Error(t, err)
+	assert.Len(t, err.(config.Errors), 3)
+	assert.Equal(t, 0, conf.RedisConfig.RedisDB)
+	assert.True(t, conf.EnableCors)
+
+	assert.Equal(t, 0, config.New().RedisConfig.RedisDB)
+}
+
+func TestLoad_Production(t *testing.T) {
+	_, err := config.Load(config.Options{Flags: map[string]string{"APP_ENV": "production"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "APP_PRIVATE_KEY")
+	assert.Contains(t, err.Error(), "OAUTH_SECRET")
+
+	_, err = config.Load(config.Options{Flags: map[string]string{
+		"APP_ENV":          "production",
+		"APP_PRIVATE_KEY":  "private",
+		"APP_PUBLIC_KEY":   "public",
+		"OAUTH_SECRET":     "secret",
+		"MINIO_SECRET_KEY": "secret",
+	}})
+	assert.NoError(t, err)
+}
+
+func TestParseFlags(t *testing.T) {
+	opts, err := config.ParseFlags([]string{
+		"--config", "app.yaml", "--set", "app_env=production", "--set=DB_HOST=db", "serve", "--set", "X=1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "app.yaml", opts.File)
+	assert.Equal(t, map[string]string{"APP_ENV": "production", "DB_HOST": "db"}, opts.Flags)
+
+	_, err = config.ParseFlags([]string{"--set", "DB_HOST"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,309 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Collections of config sources, each one overrides the previous.
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+
+	// secretFileSuffix is the suffix of a key pointing to a file containing its value, e.g. DB_PASSWORD_FILE.
+	secretFileSuffix = "_FILE"
+
+	// redacted replaces value of secrets.
+	redacted = "******"
+)
+
+// secretSuffixes are suffixes of keys whose value is redacted.
+var secretSuffixes = []string{"_PASSWORD", "_SECRET", "_SECRET_KEY", "_PRIVATE_KEY", "_SIGNING_KEY", "_TOKEN"}
+
+// Options selects sources read by Load on top of defaults and environment variables.
+type Options struct {
+	// File is a YAML or TOML file, CONFIG_FILE environment variable is used when empty.
+	File string
+
+	// Flags are values set on command line by key, e.g. APP_ENV=production.
+	Flags map[string]string
+}
+
+// Entry is a config key with its effective value and the source it was read from.
+type Entry struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// Secret reports whether the value of the entry is a secret.
+func (e Entry) Secret() bool {
+	for _, suffix := range secretSuffixes {
+		if strings.HasSuffix(e.Key, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Redacted returns value of the entry, secrets which are set are replaced.
+func (e Entry) Redacted() string {
+	if e.Secret() && e.Value != "" {
+		return redacted
+	}
+
+	return e.Value
+}
+
+// Errors collects every invalid value of config.
+type Errors []error
+
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return "invalid config: " + strings.Join(messages, "; ")
+}
+
+// source is a layer of config values.
+type source struct {
+	name   string
+	lookup func(key string) (string, bool)
+}
+
+// loader reads keys through sources, from the one taking precedence.
+type loader struct {
+	sources []source
+	entries map[string]Entry
+	errs    Errors
+}
+
+// newLoader creates loader reading flags, then environment variables, then the config file.
+func newLoader(opts Options) *loader {
+	l := &loader{entries: make(map[string]Entry)}
+
+	l.sources = append(l.sources, source{name: SourceFlag, lookup: func(key string) (string, bool) {
+		value, ok := opts.Flags[key]
+		return value, ok
+	}})
+	l.sources = append(l.sources, source{name: SourceEnv, lookup: os.LookupEnv})
+
+	file := opts.File
+	if file == "" {
+		file = os.Getenv("CONFIG_FILE")
+	}
+	if file != "" {
+		values, err := readFile(file)
+		if err != nil {
+			l.errs = append(l.errs, err)
+		}
+		l.sources = append(l.sources, source{name: SourceFile, lookup: func(key string) (string, bool) {
+			value, ok := values[key]
+			return value, ok
+		}})
+	}
+
+	return l
+}
+
+// value returns value of key from the first source having either the key or the key suffixed by _FILE,
+// in which case the value is read from the file. defaultVal is returned when no source has the key.
+func (l *loader) value(key string, defaultVal string) string {
+	entry := Entry{Key: key, Value: defaultVal, Source: SourceDefault}
+	for _, s := range l.sources {
+		if value, ok := s.lookup(key); ok {
+			entry = Entry{Key: key, Value: value, Source: s.name}
+			break
+		}
+		if path, ok := s.lookup(key + secretFileSuffix); ok && path != "" {
+			secret, err := ioutil.ReadFile(path)
+			if err != nil {
+				l.errs = append(l.errs, fmt.Errorf("%s%s: %w", key, secretFileSuffix, err))
+				continue
+			}
+			source := s.name + " " + key + secretFileSuffix
+			entry = Entry{Key: key, Value: strings.TrimSpace(string(secret)), Source: source}
+			break
+		}
+	}
+	l.entries[key] = entry
+
+	return entry.Value
+}
+
+// Simple helper function to read a key or return a default value.
+func (l *loader) getEnv(key string, defaultVal string) string {
+	return l.value(key, defaultVal)
+}
+
+// Simple helper function to read a key into integer or return a default value.
+func (l *loader) getEnvAsInt(name string, defaultVal int) int {
+	valueStr := l.value(name, strconv.Itoa(defaultVal))
+	if valueStr == "" {
+		return defaultVal
+	}
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: %q is not an integer", name, valueStr))
+		return defaultVal
+	}
+
+	return value
+}
+
+// Helper to read a key into a float or return default value.
+func (l *loader) getEnvAsFloat(name string, defaultVal float64) float64 {
+	valStr := l.value(name, strconv.FormatFloat(defaultVal, 'f', -1, 64))
+	if valStr == "" {
+		return defaultVal
+	}
+	val, err := strconv.ParseFloat(valStr, 64)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: %q is not a number", name, valStr))
+		return defaultVal
+	}
+
+	return val
+}
+
+// Helper to read a key into a bool or return default value.
+func (l *loader) getEnvAsBool(name string, defaultVal bool) bool {
+	valStr := l.value(name, strconv.FormatBool(defaultVal))
+	if valStr == "" {
+		return defaultVal
+	}
+	val, err := strconv.ParseBool(valStr)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: %q is not a boolean", name, valStr))
+		return defaultVal
+	}
+
+	return val
+}
+
+// Helper to read a key into a string slice or return default value.
+func (l *loader) getEnvAsSlice(name string, defaultVal []string, sep string) []string {
+	valStr := l.value(name, strings.Join(defaultVal, sep))
+	if valStr == "" {
+		return defaultVal
+	}
+
+	return strings.Split(valStr, sep)
+}
+
+// readFile reads a YAML or TOML config file into values by key.
+// Keys of nested tables are joined by underscore, so `db: {host: x}` is DB_HOST, and lists are joined by comma.
+func readFile(path string) (map[string]string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var tree map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &tree)
+	case ".toml":
+		err = toml.Unmarshal(content, &tree)
+	default:
+		return nil, fmt.Errorf("config file %s: unknown format, use yaml or toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	flatten("", tree, values)
+
+	return values, nil
+}
+
+func flatten(prefix string, tree map[string]interface{}, values map[string]string) {
+	for name, value := range tree {
+		key := strings.ToUpper(name)
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flatten(key, v, values)
+		case []interface{}:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				items = append(items, fmt.Sprint(item))
+			}
+			values[key] = strings.Join(items, ",")
+		case nil:
+			values[key] = ""
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+}
+
+// ParseFlags reads config flags leading args, --config <file> and --set KEY=VALUE, which may be repeated.
+// Reading stops at the first argument which is not a config flag, such as the command.
+func ParseFlags(args []string) (Options, error) {
+	opts := Options{Flags: make(map[string]string)}
+	for i := 0; i < len(args); i++ {
+		name := strings.TrimLeft(args[i], "-")
+		if name == args[i] {
+			break
+		}
+
+		value := ""
+		hasValue := false
+		if index := strings.Index(name, "="); index >= 0 {
+			name, value, hasValue = name[:index], name[index+1:], true
+		}
+		if name != "config" && name != "set" {
+			break
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("flag --%s requires a value", name)
+			}
+			i++
+			value = args[i]
+		}
+
+		if name == "config" {
+			opts.File = value
+			continue
+		}
+		pair := strings.SplitN(value, "=", 2)
+		if len(pair) != 2 || pair[0] == "" {
+			return opts, fmt.Errorf("flag --set requires KEY=VALUE, got %q", value)
+		}
+		opts.Flags[strings.ToUpper(pair[0])] = pair[1]
+	}
+
+	return opts, nil
+}
+
+// Entries returns every key of config with its effective value and source, sorted by key.
+func (c *Config) Entries() []Entry {
+	entries := make([]Entry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+
+	return entries
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"fmt"
+)
+
+// Insecure defaults, only accepted outside production.
+const (
+	defaultPrivateKey     = "default-private-key"
+	defaultPublicKey      = "default-public-key"
+	defaultOauthSecret    = "go-rest-skeleton"
+	defaultMinioSecretKey = "miniostorage"
+
+	// EnvironmentProduction is the APP_ENV of production.
+	EnvironmentProduction = "production"
+)
+
+// validate checks values read by Load, secrets left to their insecure defaults are refused in production.
+func (c *Config) validate() Errors {
+	var errs Errors
+	invalid := func(key string, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+
+	if !oneOf(c.StorageDriver, "minio", "s3", "gcs", "local") {
+		invalid("STORAGE_DRIVER", "%q is not one of minio, s3, gcs or local", c.StorageDriver)
+	}
+	if !oneOf(c.ErrorFormat, "default", "problem") {
+		invalid("ERROR_FORMAT", "%q is not one of default or problem", c.ErrorFormat)
+	}
+	if !oneOf(c.TracingExporter, "", "otlp", "stdout") {
+		invalid("TRACING_EXPORTER", "%q is not one of otlp or stdout", c.TracingExporter)
+	}
+	if c.TracingSampleRatio < 0 || c.TracingSampleRatio > 1 {
+		invalid("TRACING_SAMPLE_RATIO", "%v is not between 0 and 1", c.TracingSampleRatio)
+	}
+	if c.GRPCPort < 1 || c.GRPCPort > 65535 {
+		invalid("GRPC_PORT", "%d is not a port", c.GRPCPort)
+	}
+	positives := []struct {
+		key   string
+		value int
+	}{
+		{"QUEUE_CONCURRENCY", c.QueueConcurrency},
+		{"QUEUE_VISIBILITY_TIMEOUT", c.QueueVisibilityTimeout},
+		{"OUTBOX_BATCH_SIZE", c.OutboxBatchSize},
+		{"WEBHOOK_TIMEOUT", c.WebhookTimeout},
+		{"HEALTH_CHECK_TIMEOUT", c.HealthCheckTimeout},
+		{"SHUTDOWN_TIMEOUT", c.ShutdownTimeout},
+	}
+	for _, p := range positives {
+		if p.value <= 0 {
+			invalid(p.key, "%d is not greater than 0", p.value)
+		}
+	}
+
+	if c.AppEnvironment != EnvironmentProduction {
+		return errs
+	}
+	insecure := []struct {
+		key      string
+		insecure bool
+	}{
+		{"APP_PRIVATE_KEY", c.AppPrivateKey == defaultPrivateKey || c.AppPrivateKey == ""},
+		{"APP_PUBLIC_KEY", c.AppPublicKey == defaultPublicKey || c.AppPublicKey == ""},
+		{"OAUTH_SECRET", c.OauthSecret == defaultOauthSecret || c.OauthSecret == ""},
+		{"MINIO_SECRET_KEY", c.StorageDriver == "minio" && c.SecretKey == defaultMinioSecretKey},
+	}
+	for _, i := range insecure {
+		if i.insecure {
+			invalid(i.key, "must be set to a secret value in production")
+		}
+	}
+
+	return errs
+}
+
+func oneOf(value string, allowed ...string) bool {
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+
+	return false
+}
@@ -17,6 +17,7 @@ type Application struct {
 	ApplicationApiKeys      []ApplicationApiKey      `gorm:"foreignKey:ApplicationUUID"`
 	ApplicationOauth        ApplicationOauth         `gorm:"foreignKey:ApplicationUUID"`
 	ApplicationOauthClients []ApplicationOauthClient `gorm:"foreignKey:ApplicationUUID"`
+	WebhookSubscriptions    []WebhookSubscription    `gorm:"foreignKey:ApplicationUUID"`
 }
 
 // Applications represent multiple Application.
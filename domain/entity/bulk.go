@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"go-rest-skeleton/pkg/response"
+	"go-rest-skeleton/pkg/validator"
+)
+
+// BulkMaxItems is the maximum number of items of a bulk request.
+const BulkMaxItems = 100
+
+// BulkDelete represent payload for bulk delete request.
+type BulkDelete struct {
+	UUIDs []string `json:"uuids" form:"uuids"`
+}
+
+// UserBulkSave represent payload for bulk create or update users request, an item with uuid updates the user.
+type UserBulkSave struct {
+	Items []User `json:"items" form:"items"`
+}
+
+// RoleBulkSave represent payload for bulk create or update roles request, an item with uuid updates the role.
+type RoleBulkSave struct {
+	Items []Role `json:"items" form:"items"`
+}
+
+// TourBulkSave represent payload for bulk create or update tours request, an item with uuid updates the tour.
+type TourBulkSave struct {
+	Items []Tour `json:"items" form:"items"`
+}
+
+// ValidateBulkDelete will validate bulk delete request.
+func (b *BulkDelete) ValidateBulkDelete() []response.ErrorForm {
+	return validateBulk("uuids", b.UUIDs)
+}
+
+// ValidateBulkSaveUser will validate bulk save user request, each item is validated when it is processed.
+func (b *UserBulkSave) ValidateBulkSaveUser() []response.ErrorForm {
+	return validateBulk("items", b.Items)
+}
+
+// ValidateBulkSaveRole will validate bulk save role request, each item is validated when it is processed.
+func (b *RoleBulkSave) ValidateBulkSaveRole() []response.ErrorForm {
+	return validateBulk("items", b.Items)
+}
+
+// ValidateBulkSaveTour will validate bulk save tour request, each item is validated when it is processed.
+func (b *TourBulkSave) ValidateBulkSaveTour() []response.ErrorForm {
+	return validateBulk("items", b.Items)
+}
+
+// validateBulk will validate the items of bulk request are given and no more than BulkMaxItems.
+func validateBulk(field string, items interface{}) []response.ErrorForm {
+	validation := validator.New()
+	validation.
+		Set(field, items, validation.AddRule().Required().Length(1, BulkMaxItems).Apply())
+
+	return validation.Validate()
+}
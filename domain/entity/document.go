@@ -7,6 +7,14 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	// DocumentWorkflowDraft is the workflow of a document waiting for approval.
+	DocumentWorkflowDraft = "draft"
+
+	// DocumentWorkflowApproved is the workflow of an approved document.
+	DocumentWorkflowApproved = "approved"
+)
+
 // Document represent schema of table modules.
 type Document struct {
 	UUID      string    `gorm:"size:36;not null;uniqueIndex;primary_key" json:"uuid"`
@@ -35,3 +43,25 @@ func (d *Document) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+// DetailDocument represent format of detail Document.
+type DetailDocument struct {
+	UUID      string    `json:"uuid"`
+	UserUUID  string    `json:"user_uuid"`
+	Title     string    `json:"title"`
+	Workflow  string    `json:"workflow"`
+	Type      string    `json:"type"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DetailDocument will return formatted document detail of document.
+func (d *Document) DetailDocument() interface{} {
+	return &DetailDocument{
+		UUID:      d.UUID,
+		UserUUID:  d.UserUUID,
+		Title:     d.Title,
+		Workflow:  d.Workflow,
+		Type:      d.Type,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"go-rest-skeleton/domain/event"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OutboxEvent represent schema of table outbox_events.
+type OutboxEvent struct {
+	UUID           string     `gorm:"size:36;not null;uniqueIndex;primary_key;" json:"uuid"`
+	EventType      string     `gorm:"size:64;not null;index;" json:"event_type"`
+	AggregateType  string     `gorm:"size:64;not null;index;" json:"aggregate_type"`
+	AggregateUUID  string     `gorm:"size:36;not null;index;" json:"aggregate_uuid"`
+	IdempotencyKey string     `gorm:"size:191;not null;uniqueIndex;" json:"idempotency_key"`
+	Payload        string     `gorm:"type:text;" json:"payload"`
+	Attempts       int        `gorm:"not null;default:0;" json:"attempts"`
+	LastError      string     `gorm:"type:text;" json:"last_error"`
+	OccurredAt     time.Time  `gorm:"not null;" json:"occurred_at"`
+	AvailableAt    time.Time  `gorm:"not null;index;" json:"available_at"`
+	PublishedAt    *time.Time `gorm:"index;default:null" json:"published_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// OutboxEvents represent multiple OutboxEvent.
+type OutboxEvents []OutboxEvent
+
+// TableName return name of table.
+func (o *OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+// BeforeCreate handle uuid generation.
+func (o *OutboxEvent) BeforeCreate(tx *gorm.DB) error {
+	generateUUID := uuid.New()
+	if o.UUID == "" {
+		o.UUID = generateUUID.String()
+	}
+	return nil
+}
+
+// NewOutboxEvent will convert a domain event to its outbox record.
+func NewOutboxEvent(e *event.Event) *OutboxEvent {
+	return &OutboxEvent{
+		UUID:           e.UUID,
+		EventType:      e.Type,
+		AggregateType:  e.AggregateType,
+		AggregateUUID:  e.AggregateUUID,
+		IdempotencyKey: e.IdempotencyKey,
+		Payload:        string(e.Payload),
+		OccurredAt:     e.OccurredAt,
+		AvailableAt:    e.OccurredAt,
+	}
+}
+
+// Event will convert the outbox record back to the domain event.
+func (o *OutboxEvent) Event() *event.Event {
+	return &event.Event{
+		UUID:           o.UUID,
+		Type:           o.EventType,
+		AggregateType:  o.AggregateType,
+		AggregateUUID:  o.AggregateUUID,
+		IdempotencyKey: o.IdempotencyKey,
+		Payload:        []byte(o.Payload),
+		OccurredAt:     o.OccurredAt,
+	}
+}
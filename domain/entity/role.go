@@ -10,13 +10,15 @@ import (
 	"github.com/google/uuid"
 )
 
-// Role represent schema of table roles.
+// Role represent schema of table roles, Live is null once the role is soft deleted.
 type Role struct {
 	UUID            string    `gorm:"size:36;not null;unique_index;primary_key" json:"uuid"`
-	Name            string    `gorm:"size:100;not null;uniqueIndex;" json:"name" form:"name"`
+	Name            string    `gorm:"size:100;not null;uniqueIndex:idx_roles_name_live;" json:"name" form:"name"`
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
+	Version         int64     `gorm:"not null;default:1" json:"version"`
 	DeletedAt       gorm.DeletedAt
+	Live            *bool            `gorm:"default:true;uniqueIndex:idx_roles_name_live;" json:"-"`
 	RolePermissions []RolePermission `gorm:"foreignKey:RoleUUID"`
 }
 
@@ -43,6 +45,9 @@ func (r *Role) BeforeCreate(tx *gorm.DB) error {
 	if r.UUID == "" {
 		r.UUID = generateUUID.String()
 	}
+	if r.Version == 0 {
+		r.Version = 1
+	}
 	return nil
 }
 
@@ -54,7 +59,8 @@ type FieldsForRoleDetail struct {
 
 // FieldsForRoleList represent fields for role list.
 type FieldsForRoleList struct {
-	CreatedAt time.Time `json:"created_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 // DetailRole represent format of detail role.
@@ -89,6 +95,7 @@ func (r *Role) DetailRoleList() interface{} {
 		},
 		FieldsForRoleList: FieldsForRoleList{
 			CreatedAt: r.CreatedAt,
+			DeletedAt: deletedAt(r.DeletedAt),
 		},
 	}
 }
@@ -15,6 +15,7 @@ type StorageCategory struct {
 	Path      string    `gorm:"size:100;not null;" json:"path" form:"path"`
 	Name      string    `gorm:"size:100;not null;" json:"name" form:"name"`
 	MimeTypes string    `gorm:"size:255;not null;" json:"mime_types" form:"mime_types"`
+	MaxSize   int64     `gorm:"not null;default:0;" json:"max_size" form:"max_size"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	DeletedAt gorm.DeletedAt
@@ -33,3 +34,12 @@ func (sc *StorageCategory) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+// AllowedSize returns maximum size in bytes of file stored in the category.
+// Category without MaxSize falls back to defaultSize.
+func (sc *StorageCategory) AllowedSize(defaultSize int64) int64 {
+	if sc.MaxSize > 0 {
+		return sc.MaxSize
+	}
+	return defaultSize
+}
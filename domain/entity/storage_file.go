@@ -8,25 +8,71 @@ import (
 	"github.com/google/uuid"
 )
 
+// Collections of storage file status.
+const (
+	// StorageFileStatusPending is status of file waiting for the client to upload it directly to the bucket.
+	StorageFileStatusPending = "pending"
+
+	// StorageFileStatusUploaded is status of file stored in the bucket.
+	StorageFileStatusUploaded = "uploaded"
+)
+
+// Collections of storage file scan status.
+const (
+	// StorageFileScanPending is scan status of file waiting to be scanned, it can not be downloaded yet.
+	StorageFileScanPending = "pending"
+
+	// StorageFileScanClean is scan status of file scanned without any finding, or stored while scanning is disabled.
+	StorageFileScanClean = "clean"
+
+	// StorageFileScanQuarantined is scan status of file the scanner found malware in, it is never served.
+	StorageFileScanQuarantined = "quarantined"
+)
+
 // StorageFile represent schema of table storage_files.
 type StorageFile struct {
 	UUID         string    `gorm:"size:36;not null;uniqueIndex;primary_key;" json:"uuid"`
 	CategoryUUID string    `gorm:"size:36;not null;index;" json:"category_uui"`
+	UserUUID     string    `gorm:"size:36;index;" json:"user_uuid"`
 	OriginalName string    `gorm:"size:255;not null;" json:"original_name"`
 	Name         string    `gorm:"size:255;not null;" json:"name"`
 	Path         string    `gorm:"size:255;not null;" json:"path"`
 	Type         string    `gorm:"size:36;not null;" json:"type"`
 	Size         int64     `gorm:"not null;" json:"size"`
+	Status       string    `gorm:"size:20;not null;default:uploaded;" json:"status"`
+	ScanStatus   string    `gorm:"size:20;not null;default:clean;index;" json:"scan_status"`
+	ScanResult   string    `gorm:"size:255;" json:"scan_result"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt
 }
 
+// StorageFiles represent multiple StorageFile.
+type StorageFiles []StorageFile
+
+// DetailStorageFile represent format of detail StorageFile.
+type DetailStorageFile struct {
+	UUID         string      `json:"uuid"`
+	CategoryUUID string      `json:"category_uuid"`
+	OriginalName string      `json:"original_name"`
+	Type         string      `json:"type"`
+	Size         int64       `json:"size"`
+	Status       string      `json:"status"`
+	ScanStatus   string      `json:"scan_status"`
+	URL          interface{} `json:"url,omitempty"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
 // TableName return name of table.
 func (sf *StorageFile) TableName() string {
 	return "storage_files"
 }
 
+// FilterableFields return fields.
+func (sf *StorageFile) FilterableFields() []interface{} {
+	return []interface{}{"type", "status", "scan_status"}
+}
+
 // BeforeCreate handle uuid generation.
 func (sf *StorageFile) BeforeCreate(tx *gorm.DB) error {
 	generateUUID := uuid.New()
@@ -35,3 +81,37 @@ func (sf *StorageFile) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
+
+// IsUploaded returns true when the file has been stored in the bucket.
+func (sf *StorageFile) IsUploaded() bool {
+	return sf.Status == "" || sf.Status == StorageFileStatusUploaded
+}
+
+// IsClean returns true when the file has been scanned without any finding, or was stored while scanning is disabled.
+func (sf *StorageFile) IsClean() bool {
+	return sf.ScanStatus == "" || sf.ScanStatus == StorageFileScanClean
+}
+
+// DetailStorageFile will return formatted detail of storage file, url is omitted when it is nil.
+func (sf *StorageFile) DetailStorageFile(url interface{}) interface{} {
+	return &DetailStorageFile{
+		UUID:         sf.UUID,
+		CategoryUUID: sf.CategoryUUID,
+		OriginalName: sf.OriginalName,
+		Type:         sf.Type,
+		Size:         sf.Size,
+		Status:       sf.Status,
+		ScanStatus:   sf.ScanStatus,
+		URL:          url,
+		CreatedAt:    sf.CreatedAt,
+	}
+}
+
+// DetailStorageFiles will return formatted detail of multiple storage file.
+func (files StorageFiles) DetailStorageFiles() []interface{} {
+	result := make([]interface{}, len(files))
+	for index, file := range files {
+		result[index] = file.DetailStorageFile(nil)
+	}
+	return result
+}
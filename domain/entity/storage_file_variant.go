@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StorageFileVariant represent schema of table storage_file_variants.
+// It keeps an image generated from a storage file, such as a resized copy of an avatar.
+type StorageFileVariant struct {
+	UUID      string    `gorm:"size:36;not null;uniqueIndex;primary_key;" json:"uuid"`
+	FileUUID  string    `gorm:"size:36;not null;uniqueIndex:idx_storage_file_variant;" json:"file_uuid"`
+	Name      string    `gorm:"size:50;not null;uniqueIndex:idx_storage_file_variant;" json:"name"`
+	Path      string    `gorm:"size:255;not null;" json:"path"`
+	Type      string    `gorm:"size:36;not null;" json:"type"`
+	Size      int64     `gorm:"not null;" json:"size"`
+	Width     int       `gorm:"not null;" json:"width"`
+	Height    int       `gorm:"not null;" json:"height"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName return name of table.
+func (v *StorageFileVariant) TableName() string {
+	return "storage_file_variants"
+}
+
+// BeforeCreate handle uuid generation.
+func (v *StorageFileVariant) BeforeCreate(tx *gorm.DB) error {
+	generateUUID := uuid.New()
+	if v.UUID == "" {
+		v.UUID = generateUUID.String()
+	}
+	return nil
+}
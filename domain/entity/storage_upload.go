@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StorageUpload represent schema of table storage_uploads.
+// It keeps the progress of a resumable upload until every byte has been received.
+type StorageUpload struct {
+	UUID         string    `gorm:"size:36;not null;uniqueIndex;primary_key;" json:"uuid"`
+	CategoryUUID string    `gorm:"size:36;not null;index;" json:"category_uuid"`
+	UserUUID     string    `gorm:"size:36;index;" json:"user_uuid"`
+	FileName     string    `gorm:"size:255;not null;" json:"file_name"`
+	Type         string    `gorm:"size:100;not null;default:'';" json:"type"`
+	Length       int64     `gorm:"column:upload_length;not null;" json:"length"`
+	Offset       int64     `gorm:"column:upload_offset;not null;default:0;" json:"offset"`
+	ExpiresAt    time.Time `gorm:"index;" json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// DetailStorageUpload represent format of detail StorageUpload.
+type DetailStorageUpload struct {
+	UUID      string    `json:"uuid"`
+	FileName  string    `json:"file_name"`
+	Length    int64     `json:"length"`
+	Offset    int64     `json:"offset"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TableName return name of table.
+func (su *StorageUpload) TableName() string {
+	return "storage_uploads"
+}
+
+// BeforeCreate handle uuid generation.
+func (su *StorageUpload) BeforeCreate(tx *gorm.DB) error {
+	generateUUID := uuid.New()
+	if su.UUID == "" {
+		su.UUID = generateUUID.String()
+	}
+	return nil
+}
+
+// IsComplete returns true when every byte of the upload has been received.
+func (su *StorageUpload) IsComplete() bool {
+	return su.Offset >= su.Length
+}
+
+// IsExpired returns true when the upload can no longer be resumed.
+func (su *StorageUpload) IsExpired(now time.Time) bool {
+	return !su.ExpiresAt.IsZero() && now.After(su.ExpiresAt)
+}
+
+// DetailStorageUpload will return formatted detail of storage upload.
+func (su *StorageUpload) DetailStorageUpload() interface{} {
+	return &DetailStorageUpload{
+		UUID:      su.UUID,
+		FileName:  su.FileName,
+		Length:    su.Length,
+		Offset:    su.Offset,
+		ExpiresAt: su.ExpiresAt,
+	}
+}
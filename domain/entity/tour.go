@@ -11,15 +11,17 @@ import (
 	"gorm.io/gorm"
 )
 
-// Tour represent schema of table tours.
+// Tour represent schema of table tours, Live is null once the tour is soft deleted.
 type Tour struct {
 	UUID      string     `gorm:"size:36;not null;unique_index;primary_key" json:"uuid"`
 	Name      string     `gorm:"size:100;not null;index;" json:"name" form:"name"`
-	Slug      string     `gorm:"size:100;not null;uniqueIndex;" json:"slug" form:"slug"`
+	Slug      string     `gorm:"size:100;not null;uniqueIndex:idx_tours_slug_live;" json:"slug" form:"slug"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
+	Version   int64      `gorm:"not null;default:1" json:"version"`
 	ExpiredAt *time.Time `gorm:"default:null" json:"expired_at"`
 	DeletedAt gorm.DeletedAt
+	Live      *bool `gorm:"default:true;uniqueIndex:idx_tours_slug_live;" json:"-"`
 }
 
 // Tours represent multiple Tour.
@@ -41,6 +43,9 @@ func (t *Tour) BeforeCreate(tx *gorm.DB) error {
 	if t.UUID == "" {
 		t.UUID = generateUUID.String()
 	}
+	if t.Version == 0 {
+		t.Version = 1
+	}
 	return nil
 }
 
@@ -55,6 +60,7 @@ type FieldsForTourDetail struct {
 type FieldsForTourList struct {
 	CreatedAt time.Time  `json:"created_at"`
 	ExpiredAt *time.Time `json:"expired_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 // DetailTour represent format of detail Tour.
@@ -90,6 +96,7 @@ func (t *Tour) DetailTourList() interface{} {
 		FieldsForTourList: FieldsForTourList{
 			CreatedAt: t.CreatedAt,
 			ExpiredAt: t.ExpiredAt,
+			DeletedAt: deletedAt(t.DeletedAt),
 		},
 	}
 }
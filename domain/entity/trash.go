@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// deletedAt return time of soft deletion, nil when the record is not deleted.
+func deletedAt(d gorm.DeletedAt) *time.Time {
+	if !d.Valid {
+		return nil
+	}
+	return &d.Time
+}
@@ -14,17 +14,20 @@ import (
 )
 
 // User represent schema of table users.
+// Live is null once the user is soft deleted, so unique indexes only apply to users not deleted.
 type User struct {
 	UUID               string    `gorm:"size:36;not null;uniqueIndex;primary_key;" json:"uuid"`
 	Name               string    `gorm:"size:100;not null;" json:"name" form:"name"`
-	Email              string    `gorm:"size:100;not null;uniqueIndex;" json:"email" form:"email"`
-	EmailOrigin        string    `gorm:"size:100;not null;uniqueIndex;" json:"email_origin" form:"email_origin"`
+	Email              string    `gorm:"size:100;not null;uniqueIndex:idx_users_email_live;" json:"email" form:"email"`
+	EmailOrigin        string    `gorm:"size:100;not null;uniqueIndex:idx_users_email_origin_live;" json:"email_origin" form:"email_origin"`
 	Phone              string    `gorm:"size:100;index;" json:"phone,omitempty" form:"phone"`
 	Password           string    `gorm:"size:100;not null;index;" json:"password" form:"password"`
 	AvatarUUID         string    `gorm:"size:36;" json:"avatar_uuid"`
 	CreatedAt          time.Time `json:"created_at"`
 	UpdatedAt          time.Time `json:"updated_at"`
+	Version            int64     `gorm:"not null;default:1" json:"version"`
 	DeletedAt          gorm.DeletedAt
+	Live               *bool                `gorm:"default:true;uniqueIndex:idx_users_email_live;uniqueIndex:idx_users_email_origin_live;" json:"-"`
 	UserRoles          []UserRole           `gorm:"foreignKey:UserUUID"`
 	UserLogins         []UserLogin          `gorm:"foreignKey:UserUUID"`
 	UserForgotPassword []UserForgotPassword `gorm:"foreignKey:UserUUID"`
@@ -71,7 +74,8 @@ type UserFieldsForDetail struct {
 
 // UserFieldsForList represent fields of detail User for User list.
 type UserFieldsForList struct {
-	CreatedAt time.Time `json:"created_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 // TableName return name of table.
@@ -103,6 +107,9 @@ func (u *User) BeforeCreate(tx *gorm.DB) error {
 	if u.UUID == "" {
 		u.UUID = generateUUID.String()
 	}
+	if u.Version == 0 {
+		u.Version = 1
+	}
 	u.EmailOrigin = emailOrigin
 	u.Password = string(hashPassword)
 	return nil
@@ -131,15 +138,20 @@ func (u *User) DetailUser() interface{} {
 	}
 }
 
-// DetailUserAvatar will return formatted user detail of user.
-func (u *User) DetailUserAvatar(url interface{}) interface{} {
+// DetailUserAvatar will return formatted user detail of user, avatar is signed URL of each avatar variant by name.
+func (u *User) DetailUserAvatar(urls map[string]interface{}) interface{} {
+	var avatar interface{}
+	if len(urls) > 0 {
+		avatar = urls
+	}
+
 	return &DetailUser{
 		UserFieldsForDetail: UserFieldsForDetail{
 			UUID:   u.UUID,
 			Name:   u.Name,
 			Email:  u.Email,
 			Phone:  u.Phone,
-			Avatar: url,
+			Avatar: avatar,
 		},
 		Role: UserRoles.GetUserRole(u.UserRoles),
 	}
@@ -156,6 +168,7 @@ func (u *User) DetailUserList() interface{} {
 		},
 		UserFieldsForList: UserFieldsForList{
 			CreatedAt: u.CreatedAt,
+			DeletedAt: deletedAt(u.DeletedAt),
 		},
 	}
 }
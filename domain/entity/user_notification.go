@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"encoding/json"
+	"go-rest-skeleton/pkg/response"
+	"go-rest-skeleton/pkg/validator"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// UserNotificationStatusRead is the inbox filter of notifications already read by the user.
+	UserNotificationStatusRead = "read"
+
+	// UserNotificationStatusUnread is the inbox filter of notifications not read yet by the user.
+	UserNotificationStatusUnread = "unread"
+)
+
+// UserNotification represent schema of table user_notifications.
+type UserNotification struct {
+	UUID      string     `gorm:"size:36;not null;uniqueIndex;primary_key" json:"uuid"`
+	UserUUID  string     `gorm:"size:36;not null;index;" json:"user_uuid"`
+	Type      string     `gorm:"size:64;not null;index;" json:"type"`
+	Title     string     `gorm:"size:255;not null;" json:"title"`
+	Body      string     `gorm:"type:text;" json:"body"`
+	Data      string     `gorm:"type:text;" json:"data"`
+	ReadAt    *time.Time `gorm:"default:null;index;" json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// UserNotifications represent multiple UserNotification.
+type UserNotifications []UserNotification
+
+// DetailUserNotification represent format of detail UserNotification.
+type DetailUserNotification struct {
+	UUID      string          `json:"uuid"`
+	Type      string          `json:"type"`
+	Title     string          `json:"title"`
+	Body      string          `json:"body"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	IsRead    bool            `json:"is_read"`
+	ReadAt    *time.Time      `json:"read_at"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// TableName return name of table.
+func (n *UserNotification) TableName() string {
+	return "user_notifications"
+}
+
+// FilterableFields return fields.
+func (n *UserNotification) FilterableFields() []interface{} {
+	return []interface{}{"type"}
+}
+
+// BeforeCreate handle uuid generation.
+func (n *UserNotification) BeforeCreate(tx *gorm.DB) error {
+	generateUUID := uuid.New()
+	if n.UUID == "" {
+		n.UUID = generateUUID.String()
+	}
+	return nil
+}
+
+// IsRead return true when the notification has been read by the user.
+func (n *UserNotification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+// DetailUserNotification will return formatted detail of user notification.
+func (n *UserNotification) DetailUserNotification() interface{} {
+	detail := &DetailUserNotification{
+		UUID:      n.UUID,
+		Type:      n.Type,
+		Title:     n.Title,
+		Body:      n.Body,
+		IsRead:    n.IsRead(),
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+	if n.Data != "" && json.Valid([]byte(n.Data)) {
+		detail.Data = json.RawMessage(n.Data)
+	}
+	return detail
+}
+
+// DetailUserNotifications will return formatted detail of multiple user notification.
+func (notifications UserNotifications) DetailUserNotifications() []interface{} {
+	result := make([]interface{}, len(notifications))
+	for index, notification := range notifications {
+		result[index] = notification.DetailUserNotification()
+	}
+	return result
+}
+
+// ValidateUserNotificationStatus will validate status filter of notification inbox.
+func (n *UserNotification) ValidateUserNotificationStatus(status string) []response.ErrorForm {
+	validation := validator.New()
+	validation.
+		Set("status", status, validation.AddRule().
+			In(UserNotificationStatusRead, UserNotificationStatusUnread).Apply())
+
+	return validation.Validate()
+}
@@ -3,6 +3,7 @@ package entity
 import (
 	"encoding/json"
 	"go-rest-skeleton/pkg/response"
+	"go-rest-skeleton/pkg/translation"
 	"go-rest-skeleton/pkg/validator"
 	"time"
 
@@ -92,9 +93,14 @@ func (up *UserPreference) BuildPatchUserPreference(preference *json.RawMessage)
 
 // ValidateUpdatePreference will validate update preference request.
 func (dup *DetailUserPreference) ValidateUpdatePreference() []response.ErrorForm {
+	languages := make([]interface{}, 0)
+	for _, lang := range translation.Languages() {
+		languages = append(languages, lang)
+	}
+
 	validation := validator.New()
 	validation.
-		Set("language", dup.Language, validation.AddRule().Required().In("en", "id").Apply()).
+		Set("language", dup.Language, validation.AddRule().Required().In(languages...).Apply()).
 		Set("dark_mode", dup.DarkMode, validation.AddRule().Required().Apply())
 
 	return validation.Validate()
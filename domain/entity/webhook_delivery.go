@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// WebhookDeliveryStatusSuccess is the status of delivery acknowledged by the receiver with 2xx response.
+	WebhookDeliveryStatusSuccess = "success"
+
+	// WebhookDeliveryStatusFailed is the status of delivery which failed or got non 2xx response.
+	WebhookDeliveryStatusFailed = "failed"
+)
+
+// WebhookDelivery represent schema of table webhook_deliveries.
+type WebhookDelivery struct {
+	UUID             string    `gorm:"size:36;not null;uniqueIndex;primary_key" json:"uuid"`
+	SubscriptionUUID string    `gorm:"size:36;not null;index;" json:"subscription_uuid"`
+	EventUUID        string    `gorm:"size:36;not null;index;" json:"event_uuid"`
+	EventType        string    `gorm:"size:64;not null;index;" json:"event_type"`
+	Attempt          int       `gorm:"not null;default:1;" json:"attempt"`
+	Status           string    `gorm:"size:16;not null;index;" json:"status"`
+	RequestURL       string    `gorm:"size:255;not null;" json:"request_url"`
+	RequestHeaders   string    `gorm:"type:text;" json:"request_headers"`
+	RequestBody      string    `gorm:"type:text;" json:"request_body"`
+	ResponseStatus   int       `json:"response_status"`
+	ResponseHeaders  string    `gorm:"type:text;" json:"response_headers"`
+	ResponseBody     string    `gorm:"type:text;" json:"response_body"`
+	Error            string    `gorm:"type:text;" json:"error"`
+	DurationMs       int64     `json:"duration_ms"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// WebhookDeliveries represent multiple WebhookDelivery.
+type WebhookDeliveries []WebhookDelivery
+
+// TableName return name of table.
+func (w *WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// FilterableFields return fields.
+func (w *WebhookDelivery) FilterableFields() []interface{} {
+	return []interface{}{"event_type", "status"}
+}
+
+// BeforeCreate handle uuid generation.
+func (w *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	generateUUID := uuid.New()
+	if w.UUID == "" {
+		w.UUID = generateUUID.String()
+	}
+	return nil
+}
+
+// FieldsForWebhookDeliveryDetail represent fields for WebhookDelivery detail.
+type FieldsForWebhookDeliveryDetail struct {
+	UUID             string    `json:"uuid"`
+	SubscriptionUUID string    `json:"subscription_uuid"`
+	EventUUID        string    `json:"event_uuid"`
+	EventType        string    `json:"event_type"`
+	Attempt          int       `json:"attempt"`
+	Status           string    `json:"status"`
+	ResponseStatus   int       `json:"response_status"`
+	DurationMs       int64     `json:"duration_ms"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// FieldsForWebhookDeliveryRequest represent request and response fields of WebhookDelivery detail.
+type FieldsForWebhookDeliveryRequest struct {
+	RequestURL      string `json:"request_url"`
+	RequestHeaders  string `json:"request_headers"`
+	RequestBody     string `json:"request_body"`
+	ResponseHeaders string `json:"response_headers"`
+	ResponseBody    string `json:"response_body"`
+	Error           string `json:"error"`
+}
+
+// DetailWebhookDelivery represent format of detail WebhookDelivery.
+type DetailWebhookDelivery struct {
+	FieldsForWebhookDeliveryDetail
+	FieldsForWebhookDeliveryRequest
+}
+
+// DetailWebhookDelivery will return formatted detail of webhook delivery, including request and response.
+func (w *WebhookDelivery) DetailWebhookDelivery() interface{} {
+	return &DetailWebhookDelivery{
+		FieldsForWebhookDeliveryDetail: w.fieldsForDetail(),
+		FieldsForWebhookDeliveryRequest: FieldsForWebhookDeliveryRequest{
+			RequestURL:      w.RequestURL,
+			RequestHeaders:  w.RequestHeaders,
+			RequestBody:     w.RequestBody,
+			ResponseHeaders: w.ResponseHeaders,
+			ResponseBody:    w.ResponseBody,
+			Error:           w.Error,
+		},
+	}
+}
+
+// DetailWebhookDeliveryList will return formatted detail of webhook delivery list.
+func (w *WebhookDelivery) DetailWebhookDeliveryList() interface{} {
+	return w.fieldsForDetail()
+}
+
+// DetailWebhookDeliveries will return formatted detail of multiple webhook delivery.
+func (deliveries WebhookDeliveries) DetailWebhookDeliveries() []interface{} {
+	result := make([]interface{}, len(deliveries))
+	for index, delivery := range deliveries {
+		result[index] = delivery.DetailWebhookDeliveryList()
+	}
+	return result
+}
+
+func (w *WebhookDelivery) fieldsForDetail() FieldsForWebhookDeliveryDetail {
+	return FieldsForWebhookDeliveryDetail{
+		UUID:             w.UUID,
+		SubscriptionUUID: w.SubscriptionUUID,
+		EventUUID:        w.EventUUID,
+		EventType:        w.EventType,
+		Attempt:          w.Attempt,
+		Status:           w.Status,
+		ResponseStatus:   w.ResponseStatus,
+		DurationMs:       w.DurationMs,
+		CreatedAt:        w.CreatedAt,
+	}
+}
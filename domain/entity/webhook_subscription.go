@@ -0,0 +1,180 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"go-rest-skeleton/pkg/response"
+	"go-rest-skeleton/pkg/validator"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WebhookAllEvents is the event type used to subscribe to every event.
+const WebhookAllEvents = "*"
+
+// WebhookSubscription represent schema of table webhook_subscriptions.
+type WebhookSubscription struct {
+	UUID            string     `gorm:"size:36;not null;uniqueIndex;primary_key" json:"uuid"`
+	ApplicationUUID string     `gorm:"size:36;not null;index;" json:"application_uuid" form:"application_uuid"`
+	TargetURL       string     `gorm:"size:255;not null;" json:"target_url" form:"target_url"`
+	EventTypes      string     `gorm:"size:255;not null;" json:"event_types" form:"event_types"`
+	Secret          string     `gorm:"size:64;not null;" json:"secret" form:"secret"`
+	IsActive        bool       `gorm:"not null;default:true;index;" json:"is_active"`
+	FailureCount    int        `gorm:"not null;default:0;" json:"failure_count"`
+	DisabledAt      *time.Time `gorm:"default:null" json:"disabled_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt
+}
+
+// WebhookSubscriptions represent multiple WebhookSubscription.
+type WebhookSubscriptions []WebhookSubscription
+
+// TableName return name of table.
+func (w *WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// FilterableFields return fields.
+func (w *WebhookSubscription) FilterableFields() []interface{} {
+	return []interface{}{"application_uuid", "target_url"}
+}
+
+// BeforeCreate handle uuid and secret generation.
+func (w *WebhookSubscription) BeforeCreate(tx *gorm.DB) error {
+	generateUUID := uuid.New()
+	if w.UUID == "" {
+		w.UUID = generateUUID.String()
+	}
+	if w.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return err
+		}
+		w.Secret = secret
+	}
+	w.IsActive = true
+	return nil
+}
+
+// EventTypeList return subscribed event types.
+func (w *WebhookSubscription) EventTypeList() []string {
+	var eventTypes []string
+	for _, eventType := range strings.Split(w.EventTypes, ",") {
+		if eventType = strings.TrimSpace(eventType); eventType != "" {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	return eventTypes
+}
+
+// Subscribes return whether the subscription receives events of the given type.
+func (w *WebhookSubscription) Subscribes(eventType string) bool {
+	for _, subscribed := range w.EventTypeList() {
+		if subscribed == WebhookAllEvents || subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldsForWebhookSubscriptionDetail represent fields for WebhookSubscription detail.
+type FieldsForWebhookSubscriptionDetail struct {
+	UUID            string     `json:"uuid"`
+	ApplicationUUID string     `json:"application_uuid"`
+	TargetURL       string     `json:"target_url"`
+	EventTypes      []string   `json:"event_types"`
+	IsActive        bool       `json:"is_active"`
+	FailureCount    int        `json:"failure_count"`
+	DisabledAt      *time.Time `json:"disabled_at"`
+}
+
+// FieldsForWebhookSubscriptionList represent fields for WebhookSubscription list.
+type FieldsForWebhookSubscriptionList struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DetailWebhookSubscription represent format of detail WebhookSubscription.
+type DetailWebhookSubscription struct {
+	FieldsForWebhookSubscriptionDetail
+	Secret string `json:"secret"`
+}
+
+// DetailWebhookSubscriptionList represent format of detail WebhookSubscription list.
+type DetailWebhookSubscriptionList struct {
+	FieldsForWebhookSubscriptionDetail
+	FieldsForWebhookSubscriptionList
+}
+
+// DetailWebhookSubscription will return formatted detail of webhook subscription, including its secret.
+func (w *WebhookSubscription) DetailWebhookSubscription() interface{} {
+	return &DetailWebhookSubscription{
+		FieldsForWebhookSubscriptionDetail: w.fieldsForDetail(),
+		Secret:                             w.Secret,
+	}
+}
+
+// DetailWebhookSubscriptionList will return formatted detail of webhook subscription list.
+func (w *WebhookSubscription) DetailWebhookSubscriptionList() interface{} {
+	return &DetailWebhookSubscriptionList{
+		FieldsForWebhookSubscriptionDetail: w.fieldsForDetail(),
+		FieldsForWebhookSubscriptionList: FieldsForWebhookSubscriptionList{
+			CreatedAt: w.CreatedAt,
+		},
+	}
+}
+
+// DetailWebhookSubscriptions will return formatted detail of multiple webhook subscription.
+func (subscriptions WebhookSubscriptions) DetailWebhookSubscriptions() []interface{} {
+	result := make([]interface{}, len(subscriptions))
+	for index, subscription := range subscriptions {
+		result[index] = subscription.DetailWebhookSubscriptionList()
+	}
+	return result
+}
+
+// ValidateSaveWebhookSubscription will validate create a new webhook subscription request.
+func (w *WebhookSubscription) ValidateSaveWebhookSubscription() []response.ErrorForm {
+	validation := validator.New()
+	validation.
+		Set("application_uuid", w.ApplicationUUID, validation.AddRule().Required().IsUUID().Apply()).
+		Set("target_url", w.TargetURL, validation.AddRule().Required().IsURL().MaxLength(255).Apply()).
+		Set("event_types", w.EventTypes, validation.AddRule().Required().MaxLength(255).Apply()).
+		Set("secret", w.Secret, validation.AddRule().Length(16, 64).Apply())
+
+	return validation.Validate()
+}
+
+// ValidateUpdateWebhookSubscription will validate update webhook subscription request.
+func (w *WebhookSubscription) ValidateUpdateWebhookSubscription() []response.ErrorForm {
+	validation := validator.New()
+	validation.
+		Set("target_url", w.TargetURL, validation.AddRule().Required().IsURL().MaxLength(255).Apply()).
+		Set("event_types", w.EventTypes, validation.AddRule().Required().MaxLength(255).Apply()).
+		Set("secret", w.Secret, validation.AddRule().Length(16, 64).Apply())
+
+	return validation.Validate()
+}
+
+func (w *WebhookSubscription) fieldsForDetail() FieldsForWebhookSubscriptionDetail {
+	return FieldsForWebhookSubscriptionDetail{
+		UUID:            w.UUID,
+		ApplicationUUID: w.ApplicationUUID,
+		TargetURL:       w.TargetURL,
+		EventTypes:      w.EventTypeList(),
+		IsActive:        w.IsActive,
+		FailureCount:    w.FailureCount,
+		DisabledAt:      w.DisabledAt,
+	}
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
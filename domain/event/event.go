@@ -0,0 +1,103 @@
+// Package event defines domain events emitted by the application layer when an entity changes.
+// Events are recorded in the outbox table within the same database transaction as the change,
+// then relayed to the subscribed sinks by the outbox dispatcher.
+package event
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// UserCreated is emitted after a new user has been created.
+	UserCreated = "user.created"
+
+	// UserUpdated is emitted after a user has been updated.
+	UserUpdated = "user.updated"
+
+	// RoleUpdated is emitted after a role has been updated.
+	RoleUpdated = "role.updated"
+
+	// DocumentApproved is emitted after a document has been approved.
+	DocumentApproved = "document.approved"
+)
+
+const (
+	// AggregateUser is the aggregate type of user events.
+	AggregateUser = "user"
+
+	// AggregateRole is the aggregate type of role events.
+	AggregateRole = "role"
+
+	// AggregateDocument is the aggregate type of document events.
+	AggregateDocument = "document"
+)
+
+// Event represent it self.
+type Event struct {
+	UUID           string          `json:"uuid"`
+	Type           string          `json:"type"`
+	AggregateType  string          `json:"aggregate_type"`
+	AggregateUUID  string          `json:"aggregate_uuid"`
+	IdempotencyKey string          `json:"idempotency_key"`
+	Payload        json.RawMessage `json:"payload"`
+	OccurredAt     time.Time       `json:"occurred_at"`
+}
+
+// New creates an Event with the payload encoded as json.
+// The idempotency key defaults to the event UUID, use WithIdempotencyKey to derive it from the change instead.
+func New(eventType string, aggregateType string, aggregateUUID string, payload interface{}) (*Event, error) {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	eventUUID := uuid.New().String()
+	return &Event{
+		UUID:           eventUUID,
+		Type:           eventType,
+		AggregateType:  aggregateType,
+		AggregateUUID:  aggregateUUID,
+		IdempotencyKey: eventUUID,
+		Payload:        payloadBytes,
+		OccurredAt:     time.Now(),
+	}, nil
+}
+
+// WithIdempotencyKey overrides the idempotency key of the event.
+// Subscribers use the key to discard events delivered more than once.
+func (e *Event) WithIdempotencyKey(key string) *Event {
+	e.IdempotencyKey = key
+
+	return e
+}
+
+// Bind decodes the event payload into v.
+func (e *Event) Bind(v interface{}) error {
+	return json.Unmarshal(e.Payload, v)
+}
+
+// UserPayload represents payload of user events.
+type UserPayload struct {
+	UUID  string `json:"uuid"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Phone string `json:"phone"`
+}
+
+// RolePayload represents payload of role events.
+type RolePayload struct {
+	UUID string `json:"uuid"`
+	Name string `json:"name"`
+}
+
+// DocumentPayload represents payload of document events.
+type DocumentPayload struct {
+	UUID     string `json:"uuid"`
+	UserUUID string `json:"user_uuid"`
+	Title    string `json:"title"`
+	Type     string `json:"type"`
+	Workflow string `json:"workflow"`
+}
@@ -18,17 +18,23 @@ func CollectEntities() []entities {
 		{Entity: entity.ApplicationOauthClient{}},
 		{Entity: entity.Document{}},
 		{Entity: entity.Module{}},
+		{Entity: entity.OutboxEvent{}},
 		{Entity: entity.Permission{}},
 		{Entity: entity.Role{}},
 		{Entity: entity.RolePermission{}},
 		{Entity: entity.StorageCategory{}},
 		{Entity: entity.StorageFile{}},
+		{Entity: entity.StorageFileVariant{}},
+		{Entity: entity.StorageUpload{}},
 		{Entity: entity.Tour{}},
 		{Entity: entity.User{}},
 		{Entity: entity.UserForgotPassword{}},
 		{Entity: entity.UserLogin{}},
+		{Entity: entity.UserNotification{}},
 		{Entity: entity.UserPreference{}},
 		{Entity: entity.UserRole{}},
+		{Entity: entity.WebhookDelivery{}},
+		{Entity: entity.WebhookSubscription{}},
 	}
 }
 
@@ -39,17 +45,23 @@ func CollectTableNames() []table {
 	var applicationOauthClient entity.ApplicationOauthClient
 	var document entity.Document
 	var module entity.Module
+	var outboxEvent entity.OutboxEvent
 	var permission entity.Permission
 	var role entity.Role
 	var rolePermission entity.RolePermission
 	var storageCategory entity.StorageCategory
 	var storageFile entity.StorageFile
+	var storageFileVariant entity.StorageFileVariant
+	var storageUpload entity.StorageUpload
 	var tour entity.Tour
 	var user entity.User
 	var userForgotPassword entity.UserForgotPassword
 	var userLogin entity.UserLogin
+	var userNotification entity.UserNotification
 	var userPreference entity.UserPreference
 	var userRole entity.UserRole
+	var webhookDelivery entity.WebhookDelivery
+	var webhookSubscription entity.WebhookSubscription
 
 	return []table{
 		{Name: application.TableName()},
@@ -58,16 +70,22 @@ func CollectTableNames() []table {
 		{Name: applicationOauthClient.TableName()},
 		{Name: document.TableName()},
 		{Name: module.TableName()},
+		{Name: outboxEvent.TableName()},
 		{Name: permission.TableName()},
 		{Name: role.TableName()},
 		{Name: rolePermission.TableName()},
 		{Name: storageCategory.TableName()},
 		{Name: storageFile.TableName()},
+		{Name: storageFileVariant.TableName()},
+		{Name: storageUpload.TableName()},
 		{Name: tour.TableName()},
 		{Name: user.TableName()},
 		{Name: userForgotPassword.TableName()},
 		{Name: userLogin.TableName()},
+		{Name: userNotification.TableName()},
 		{Name: userPreference.TableName()},
 		{Name: userRole.TableName()},
+		{Name: webhookDelivery.TableName()},
+		{Name: webhookSubscription.TableName()},
 	}
 }
@@ -5,7 +5,6 @@ import (
 	"go-rest-skeleton/infrastructure/message/exception"
 	"go-rest-skeleton/pkg/response"
 	"go-rest-skeleton/pkg/validator"
-	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
@@ -24,6 +23,11 @@ const (
 	or      = "OR"
 	latest  = "LATEST"
 	oldest  = "OLDEST"
+
+	// TrashedWith include soft deleted records in list.
+	TrashedWith = "with"
+	// TrashedOnly list only soft deleted records.
+	TrashedOnly = "only"
 )
 
 type QueryParameters struct {
@@ -35,6 +39,7 @@ type QueryParameters struct {
 	Equals          map[int]map[string]interface{}
 	Likes           map[int]map[string]interface{}
 	NotEquals       map[int]map[string]interface{}
+	Trashed         string
 }
 
 // Parameters represent it self.
@@ -48,6 +53,7 @@ type Parameters struct {
 	QueryCondition  string
 	QueryKey        string
 	QueryValue      []interface{}
+	Trashed         string
 	QueryParameters *QueryParameters
 }
 
@@ -77,7 +83,8 @@ func (p *Parameters) ValidateParameter(fields ...interface{}) []response.ErrorFo
 		Set("page", qp.Page, validation.AddRule().MinValue(1).Apply()).
 		Set("order_by", qp.OrderBy, validation.AddRule().Required().Apply()).
 		Set("order_method", qp.OrderMethod, validation.AddRule().Required().In("asc", "desc").Apply()).
-		Set("search_condition", qp.SearchCondition, validation.AddRule().Required().In("and", "or").Apply())
+		Set("search_condition", qp.SearchCondition, validation.AddRule().Required().In("and", "or").Apply()).
+		Set("trashed", qp.Trashed, validation.AddRule().In(TrashedWith, TrashedOnly).Apply())
 
 	for _, querySlice := range qp.Equals {
 		for key, value := range querySlice {
@@ -113,6 +120,7 @@ func NewGinParameters(c *gin.Context) *Parameters {
 	perPage, _ := strconv.Atoi(c.DefaultQuery("per_page", strconv.Itoa(defaultPerPage)))
 	orderBy := c.DefaultQuery("order_by", "created_at")
 	orderMethod := c.DefaultQuery("order_method", "desc")
+	trashed := strings.ToLower(c.Query("trashed"))
 
 	var queryCondition string
 	var queryKey []string
@@ -197,7 +205,7 @@ func NewGinParameters(c *gin.Context) *Parameters {
 
 	if perPage > maxPerPage {
 		c.Set("args", fmt.Sprintf("Max:%d", maxPerPage))
-		_ = c.AbortWithError(http.StatusBadRequest, exception.ErrorTextPerPage)
+		response.Abort(c, exception.ErrorTextPerPage)
 	}
 
 	var offset int
@@ -220,6 +228,7 @@ func NewGinParameters(c *gin.Context) *Parameters {
 		Equals:          queryEqual,
 		Likes:           queryLike,
 		NotEquals:       queryNotEqual,
+		Trashed:         trashed,
 	}
 
 	return &Parameters{
@@ -230,6 +239,7 @@ func NewGinParameters(c *gin.Context) *Parameters {
 		Order:           orderBy + " " + orderMethod,
 		QueryKey:        strings.Join(queryKey, queryCondition),
 		QueryValue:      queryValue,
+		Trashed:         trashed,
 		QueryParameters: queryParameters,
 	}
 }
@@ -275,7 +285,7 @@ func NewGqlParameters(c *gin.Context, gqlParameters *GqlParameters) *Parameters
 
 	if perPage > maxPerPage {
 		c.Set("args", fmt.Sprintf("Max:%d", maxPerPage))
-		_ = c.AbortWithError(http.StatusBadRequest, exception.ErrorTextPerPage)
+		response.Abort(c, exception.ErrorTextPerPage)
 	}
 
 	if len(search) > 0 {
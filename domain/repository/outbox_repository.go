@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/event"
+	"time"
+)
+
+// OutboxRepository is an interface.
+type OutboxRepository interface {
+	SaveEvent(*event.Event) error
+	GetPendingEvents(limit int) ([]entity.OutboxEvent, error)
+	MarkEventPublished(UUID string) error
+	MarkEventFailed(UUID string, errPublish error, retryAt time.Time) error
+}
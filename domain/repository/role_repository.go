@@ -2,6 +2,7 @@ package repository
 
 import (
 	"go-rest-skeleton/domain/entity"
+	"time"
 )
 
 // RoleRepository is an interface.
@@ -9,6 +10,9 @@ type RoleRepository interface {
 	SaveRole(*entity.Role) (*entity.Role, map[string]string, error)
 	UpdateRole(string, *entity.Role) (*entity.Role, map[string]string, error)
 	DeleteRole(string) error
+	RestoreRole(string) (*entity.Role, error)
+	ForceDeleteRole(string) error
+	PurgeRoles(time.Time) (int64, error)
 	GetRole(string) (*entity.Role, error)
 	GetRolePermissions(string) ([]entity.RolePermission, error)
 	GetRoleWithPermissions(string) (*entity.Role, error)
@@ -1,4 +1,9 @@
 package repository
 
+import "go-rest-skeleton/domain/entity"
+
+// StorageCategoryRepository is an interface.
 type StorageCategoryRepository interface {
+	GetCategory(UUID string) (*entity.StorageCategory, error)
+	GetCategoryBySlug(slug string) (*entity.StorageCategory, error)
 }
@@ -5,4 +5,6 @@ import "go-rest-skeleton/domain/entity"
 type StorageFileRepository interface {
 	SaveFile(file *entity.StorageFile) (*entity.StorageFile, map[string]string, error)
 	GetFile(string) (*entity.StorageFile, error)
+	GetUserFiles(userUUID string, category string, parameters *Parameters) ([]entity.StorageFile, interface{}, error)
+	GetUserFile(userUUID string, UUID string) (*entity.StorageFile, error)
 }
@@ -1,12 +1,18 @@
 package repository
 
-import "go-rest-skeleton/domain/entity"
+import (
+	"go-rest-skeleton/domain/entity"
+	"time"
+)
 
 // TourRepository is an interface.
 type TourRepository interface {
 	SaveTour(tour *entity.Tour) (*entity.Tour, map[string]string, error)
 	UpdateTour(UUID string, tour *entity.Tour) (*entity.Tour, map[string]string, error)
 	DeleteTour(UUID string) error
+	RestoreTour(UUID string) (*entity.Tour, error)
+	ForceDeleteTour(UUID string) error
+	PurgeTours(before time.Time) (int64, error)
 	GetTour(UUID string) (*entity.Tour, error)
 	GetTourBySlug(tour *entity.Tour) (*entity.Tour, map[string]string, error)
 	GetTours(parameters *Parameters) ([]entity.Tour, interface{}, error)
@@ -0,0 +1,15 @@
+package repository
+
+// TxRepositories represent repositories sharing a single database transaction.
+type TxRepositories struct {
+	Document DocumentRepository
+	Outbox   OutboxRepository
+	Role     RoleRepository
+	User     UserRepository
+}
+
+// UnitOfWork is an interface.
+// Transaction commits every change made through the given repositories when fn returns nil, otherwise rolls back.
+type UnitOfWork interface {
+	Transaction(fn func(tx *TxRepositories) error) error
+}
@@ -0,0 +1,12 @@
+package repository
+
+import "go-rest-skeleton/domain/entity"
+
+// UserNotificationRepository is an interface.
+type UserNotificationRepository interface {
+	SaveUserNotification(*entity.UserNotification) (*entity.UserNotification, error)
+	GetUserNotifications(userUUID string, status string, parameters *Parameters) ([]entity.UserNotification, interface{}, error)
+	MarkUserNotificationRead(userUUID string, UUID string) (*entity.UserNotification, error)
+	MarkUserNotificationUnread(userUUID string, UUID string) (*entity.UserNotification, error)
+	MarkAllUserNotificationsRead(userUUID string) (int64, error)
+}
@@ -7,4 +7,5 @@ type UserPreferenceRepository interface {
 	GetUserPreference(string) (*entity.UserPreference, error)
 	UpdateUserPreference(string, *entity.DetailUserPreference) (*entity.UserPreference, map[string]string, error)
 	ResetUserPreference(string) (*entity.UserPreference, error)
+	GetUserLanguage(string) (string, error)
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"go-rest-skeleton/domain/entity"
+	"time"
 )
 
 // UserRepository is an interface.
@@ -9,6 +10,9 @@ type UserRepository interface {
 	SaveUser(*entity.User) (*entity.User, map[string]string, error)
 	UpdateUser(string, *entity.User) (*entity.User, map[string]string, error)
 	DeleteUser(string) error
+	RestoreUser(string) (*entity.User, error)
+	ForceDeleteUser(string) error
+	PurgeUsers(time.Time) (int64, error)
 	GetUser(string) (*entity.User, error)
 	GetUserRoles(string) ([]entity.UserRole, error)
 	GetUserWithRoles(string) (*entity.User, error)
@@ -0,0 +1,10 @@
+package repository
+
+import "go-rest-skeleton/domain/entity"
+
+// WebhookDeliveryRepository is an interface.
+type WebhookDeliveryRepository interface {
+	SaveWebhookDelivery(*entity.WebhookDelivery) (*entity.WebhookDelivery, error)
+	GetWebhookDelivery(subscriptionUUID string, UUID string) (*entity.WebhookDelivery, error)
+	GetWebhookDeliveries(subscriptionUUID string, parameters *Parameters) ([]entity.WebhookDelivery, interface{}, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import "go-rest-skeleton/domain/entity"
+
+// WebhookSubscriptionRepository is an interface.
+type WebhookSubscriptionRepository interface {
+	SaveWebhookSubscription(*entity.WebhookSubscription) (*entity.WebhookSubscription, map[string]string, error)
+	UpdateWebhookSubscription(string, *entity.WebhookSubscription) (*entity.WebhookSubscription, map[string]string, error)
+	EnableWebhookSubscription(string) error
+	DeleteWebhookSubscription(string) error
+	GetWebhookSubscription(string) (*entity.WebhookSubscription, error)
+	GetWebhookSubscriptions(parameters *Parameters) ([]entity.WebhookSubscription, interface{}, error)
+	GetActiveWebhookSubscriptionsByEventType(eventType string) ([]entity.WebhookSubscription, error)
+	RecordWebhookSubscriptionSuccess(string) error
+	RecordWebhookSubscriptionFailure(UUID string, maxFailures int) (bool, error)
+}
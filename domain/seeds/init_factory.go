@@ -29,20 +29,37 @@ var (
 		{UUID: uuid.New().String(), ModuleKey: "user", PermissionKey: "create"},
 		{UUID: uuid.New().String(), ModuleKey: "user", PermissionKey: "update"},
 		{UUID: uuid.New().String(), ModuleKey: "user", PermissionKey: "delete"},
+		{UUID: uuid.New().String(), ModuleKey: "user", PermissionKey: "bulk_save"},
 		{UUID: uuid.New().String(), ModuleKey: "user", PermissionKey: "bulk_delete"},
+		{UUID: uuid.New().String(), ModuleKey: "user", PermissionKey: "restore"},
+		{UUID: uuid.New().String(), ModuleKey: "user", PermissionKey: "force_delete"},
 		{UUID: uuid.New().String(), ModuleKey: "user", PermissionKey: "detail"},
 		{UUID: uuid.New().String(), ModuleKey: "role", PermissionKey: "read"},
 		{UUID: uuid.New().String(), ModuleKey: "role", PermissionKey: "create"},
 		{UUID: uuid.New().String(), ModuleKey: "role", PermissionKey: "update"},
 		{UUID: uuid.New().String(), ModuleKey: "role", PermissionKey: "delete"},
+		{UUID: uuid.New().String(), ModuleKey: "role", PermissionKey: "bulk_save"},
 		{UUID: uuid.New().String(), ModuleKey: "role", PermissionKey: "bulk_delete"},
+		{UUID: uuid.New().String(), ModuleKey: "role", PermissionKey: "restore"},
+		{UUID: uuid.New().String(), ModuleKey: "role", PermissionKey: "force_delete"},
 		{UUID: uuid.New().String(), ModuleKey: "role", PermissionKey: "detail"},
 		{UUID: uuid.New().String(), ModuleKey: "tour", PermissionKey: "read"},
 		{UUID: uuid.New().String(), ModuleKey: "tour", PermissionKey: "create"},
 		{UUID: uuid.New().String(), ModuleKey: "tour", PermissionKey: "update"},
 		{UUID: uuid.New().String(), ModuleKey: "tour", PermissionKey: "delete"},
+		{UUID: uuid.New().String(), ModuleKey: "tour", PermissionKey: "bulk_save"},
 		{UUID: uuid.New().String(), ModuleKey: "tour", PermissionKey: "bulk_delete"},
+		{UUID: uuid.New().String(), ModuleKey: "tour", PermissionKey: "restore"},
+		{UUID: uuid.New().String(), ModuleKey: "tour", PermissionKey: "force_delete"},
 		{UUID: uuid.New().String(), ModuleKey: "tour", PermissionKey: "detail"},
+		{UUID: uuid.New().String(), ModuleKey: "webhook", PermissionKey: "read"},
+		{UUID: uuid.New().String(), ModuleKey: "webhook", PermissionKey: "create"},
+		{UUID: uuid.New().String(), ModuleKey: "webhook", PermissionKey: "update"},
+		{UUID: uuid.New().String(), ModuleKey: "webhook", PermissionKey: "delete"},
+		{UUID: uuid.New().String(), ModuleKey: "webhook", PermissionKey: "detail"},
+		{UUID: uuid.New().String(), ModuleKey: "webhook", PermissionKey: "replay"},
+		{UUID: uuid.New().String(), ModuleKey: "document", PermissionKey: "approve"},
+		{UUID: uuid.New().String(), ModuleKey: "health", PermissionKey: "detail"},
 	}
 	userRole = &entity.UserRole{
 		UUID:     uuid.New().String(),
@@ -56,6 +73,7 @@ var (
 			Path:      "avatar",
 			Name:      "Avatar",
 			MimeTypes: "image/jpg,image/jpeg,image/png,image/bmp,image/gif",
+			MaxSize:   2048000,
 		},
 		{
 			UUID:      uuid.New().String(),
@@ -63,6 +81,7 @@ var (
 			Path:      "document",
 			Name:      "Document",
 			MimeTypes: "application/pdf",
+			MaxSize:   104857600,
 		},
 		{
 			UUID:      uuid.New().String(),
@@ -70,6 +89,7 @@ var (
 			Path:      "file",
 			Name:      "File",
 			MimeTypes: "application/pdf",
+			MaxSize:   20480000,
 		},
 		{
 			UUID:      uuid.New().String(),
@@ -77,6 +97,7 @@ var (
 			Path:      "thumbnail",
 			Name:      "Thumbnail",
 			MimeTypes: "image/png",
+			MaxSize:   2048000,
 		},
 	}
 	application = &entity.Application{
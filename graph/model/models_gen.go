@@ -65,10 +65,11 @@ type UpdateRoleInput struct {
 }
 
 type UpdateUserInput struct {
-	UUID  string `json:"uuid"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
-	Phone string `json:"phone"`
+	UUID    string `json:"uuid"`
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Phone   string `json:"phone"`
+	Version int    `json:"version"`
 }
 
 type UserConnection struct {
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"go-rest-skeleton/application"
 	"go-rest-skeleton/domain/entity"
 	"go-rest-skeleton/domain/repository"
 	"go-rest-skeleton/graph/model"
@@ -23,7 +24,33 @@ func (r *mutationResolver) CreateUser(ctx context.Context, input model.CreateUse
 }
 
 func (r *mutationResolver) UpdateUser(ctx context.Context, input model.UpdateUserInput) (*entity.User, error) {
-	panic(fmt.Errorf("not implemented"))
+	c, err := ginContextFromContext(ctx)
+	if err != nil {
+		return nil, newError(c, err)
+	}
+
+	// The version is required, so the user is never overwritten without knowing its latest changes.
+	if input.Version <= 0 {
+		return nil, newError(c, exception.ErrorTextPreconditionFailed)
+	}
+
+	user := entity.User{
+		Name:    input.Name,
+		Email:   input.Email,
+		Phone:   input.Phone,
+		Version: int64(input.Version),
+	}
+	if validateErr := user.ValidateUpdateUser(); len(validateErr) > 0 {
+		return nil, newError(c, exception.ErrorTextUnprocessableEntity)
+	}
+
+	db := r.DBServices.WithContext(ctx)
+	updatedUser, _, err := application.NewUserApp(db.User, db).UpdateUser(input.UUID, &user)
+	if err != nil {
+		return nil, newError(c, err)
+	}
+
+	return updatedUser, nil
 }
 
 func (r *mutationResolver) DeleteUser(ctx context.Context, uuid string) (bool, error) {
@@ -32,7 +59,7 @@ func (r *mutationResolver) DeleteUser(ctx context.Context, uuid string) (bool, e
 		return false, newError(c, err)
 	}
 
-	err = r.DBServices.User.DeleteUser(uuid)
+	err = r.DBServices.WithContext(ctx).User.DeleteUser(uuid)
 	if err != nil {
 		if errors.Is(err, exception.ErrorTextUserNotFound) {
 			return false, newError(c, err)
@@ -54,7 +81,7 @@ func (r *queryResolver) Users(
 	}
 
 	parameters := repository.NewGqlParameters(c, newGqlParameters(search, orderBy, pagination))
-	users, meta, err := r.DBServices.User.GetUsers(parameters)
+	users, meta, err := r.DBServices.WithContext(ctx).User.GetUsers(parameters)
 	if err != nil {
 		return nil, newError(c, err)
 	}
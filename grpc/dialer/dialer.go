@@ -5,6 +5,7 @@ import (
 
 	"github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
 	"github.com/opentracing/opentracing-go"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 )
 
@@ -18,6 +19,13 @@ func WithTracer(t opentracing.Tracer) DialOption {
 	}
 }
 
+// WithTracing propagates the trace context of rpc calls to the server, each call is recorded as a span.
+func WithTracing() DialOption {
+	return func(name string) (grpc.DialOption, error) {
+		return grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()), nil
+	}
+}
+
 func Dial(name string, opts ...DialOption) (*grpc.ClientConn, error) {
 	dialOpts := []grpc.DialOption{
 		grpc.WithInsecure(),
@@ -6,7 +6,9 @@ import (
 	"go-rest-skeleton/config"
 	"go-rest-skeleton/grpc/dialer"
 	"go-rest-skeleton/grpc/services"
+	"go-rest-skeleton/infrastructure/metrics"
 	"go-rest-skeleton/infrastructure/persistence"
+	"go-rest-skeleton/infrastructure/tracing"
 	"log"
 	"os"
 	"time"
@@ -26,11 +28,21 @@ func main() {
 		log.Println("no .env file provided")
 	}
 
-	// Init Config
-	conf := config.New()
+	// Init Config, refusing invalid values and insecure defaults in production
+	conf, errConfig := config.Load(config.Options{})
+	if errConfig != nil {
+		log.Fatal(errConfig)
+	}
 	timeLoc, _ := time.LoadLocation(conf.AppTimezone)
 	time.Local = timeLoc
 
+	// Init tracing
+	shutdownTracing, errTracing := tracing.Init(conf.TracingConfig)
+	if errTracing != nil {
+		panic(errTracing)
+	}
+	defer shutdownTracing()
+
 	// Connect to DB
 	dbService, errDBService := persistence.NewDBService(conf.DBConfig)
 	if errDBService != nil {
@@ -43,6 +55,23 @@ func main() {
 		panic(errAutoMigrate)
 	}
 
+	// Trace queries run within traced calls
+	if conf.TracingExporter != "" {
+		if err := dbService.DB.Use(tracing.NewGormPlugin()); err != nil {
+			panic(err)
+		}
+	}
+
+	// Serve metrics on a separate listener, the gRPC server has no http router to expose them
+	if conf.MetricsEnabled && conf.MetricsListen != "" {
+		if err := dbService.DB.Use(metrics.NewGormPlugin()); err != nil {
+			panic(err)
+		}
+		go func() {
+			log.Println(metrics.ListenAndServe(conf.MetricsListen, conf.MetricsUsername, conf.MetricsPassword))
+		}()
+	}
+
 	// Init Vars
 	var (
 		port     = flag.Int("port", 8080, "The services port")
@@ -70,7 +99,7 @@ func main() {
 }
 
 func initGRPCConn(addr string) *grpc.ClientConn {
-	conn, err := dialer.Dial(addr)
+	conn, err := dialer.Dial(addr, dialer.WithTracing())
 	if err != nil {
 		panic(fmt.Sprintf("ERROR: dial error: %v", err))
 	}
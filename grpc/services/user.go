@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"go-rest-skeleton/grpc/proto/v1/user"
+	"go-rest-skeleton/infrastructure/metrics"
 	"go-rest-skeleton/infrastructure/persistence"
 	"log"
 	"net"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc/reflection"
 
 	"google.golang.org/grpc"
@@ -24,12 +26,22 @@ func NewUser(repo *persistence.Repositories) *User {
 	}
 }
 
-// Run starts the server
-func (u *User) Run(port int) error {
-	srv := grpc.NewServer()
+// NewServer creates gRPC server serving the user service.
+func (u *User) NewServer() *grpc.Server {
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		otelgrpc.UnaryServerInterceptor(),
+		metrics.UnaryServerInterceptor(),
+	))
 	user.RegisterUserServiceServer(srv, u)
 	reflection.Register(srv)
 
+	return srv
+}
+
+// Run starts the server
+func (u *User) Run(port int) error {
+	srv := u.NewServer()
+
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", 4040))
 	if err != nil {
 		return fmt.Errorf("failed to listen: %v", err)
@@ -43,7 +55,7 @@ func (u *User) Run(port int) error {
 }
 
 func (u *User) GetUser(ctx context.Context, requestUser *user.RequestUser) (*user.User, error) {
-	userData, err := u.DBServices.User.GetUser(requestUser.Uuid)
+	userData, err := u.DBServices.WithContext(ctx).User.GetUser(requestUser.Uuid)
 	if err != nil {
 		return nil, err
 	}
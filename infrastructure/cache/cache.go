@@ -0,0 +1,141 @@
+// Package cache keeps results of repository reads in a store, so repeated reads of data rarely changed skip the
+// database. Each entry is tagged, a write invalidates every entry having one of the tags it touches.
+// Entries also expire after their TTL, which bounds how long an entry missed by an invalidation is served.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Store is an interface. Needs to be implemented by each storage of cache entries.
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error
+	Invalidate(ctx context.Context, tags ...string) error
+}
+
+// Loader loads the value of a missing entry from the database, along with the tags of the entry.
+type Loader func() (value interface{}, tags []string, err error)
+
+// Cache reads entries through its store, a missing entry is loaded and stored.
+// Concurrent reads of the same missing entry share a single load.
+type Cache struct {
+	store Store
+	group *singleflight.Group
+
+	// pending keeps tags invalidated within a transaction, nil when the cache is not used in a transaction.
+	pending *[]string
+	mu      *sync.Mutex
+}
+
+// New creates new Cache.
+func New(store Store) *Cache {
+	return &Cache{
+		store: store,
+		group: &singleflight.Group{},
+		mu:    &sync.Mutex{},
+	}
+}
+
+// Remember decodes the entry of key into dest, a missing entry is loaded by load and stored for ttl.
+// Failing to reach the store is not an error, the entry is loaded from the database instead.
+// Within a transaction the entry is always loaded, so uncommitted changes are neither missed nor stored.
+// The entry is also always loaded without ttl, so writes of a repository not cached still invalidate other entries.
+func (c *Cache) Remember(ctx context.Context, key string, ttl time.Duration, dest interface{}, load Loader) error {
+	if c.pending != nil || ttl <= 0 {
+		value, _, err := load()
+		if err != nil {
+			return err
+		}
+		return assign(value, dest)
+	}
+
+	encoded, err := c.store.Get(ctx, key)
+	if err == nil && encoded != nil && json.Unmarshal(encoded, dest) == nil {
+		return nil
+	}
+
+	loaded, err, _ := c.group.Do(key, func() (interface{}, error) {
+		value, tags, err := load()
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		_ = c.store.Set(ctx, key, encoded, ttl, tags...)
+		return encoded, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(loaded.([]byte), dest)
+}
+
+// Invalidate removes every entry having one of the tags.
+// Within a transaction the tags are kept until Commit, so entries are not stored again before the changes are visible.
+func (c *Cache) Invalidate(ctx context.Context, tags ...string) error {
+	if c.pending != nil {
+		c.mu.Lock()
+		*c.pending = append(*c.pending, tags...)
+		c.mu.Unlock()
+		return nil
+	}
+
+	return c.store.Invalidate(ctx, tags...)
+}
+
+// Transaction returns the cache to be used by repositories sharing a database transaction.
+// Commit must be called once the transaction is committed.
+func (c *Cache) Transaction() *Cache {
+	return &Cache{
+		store:   c.store,
+		group:   c.group,
+		pending: &[]string{},
+		mu:      &sync.Mutex{},
+	}
+}
+
+// Commit invalidates the tags kept within the transaction.
+func (c *Cache) Commit(ctx context.Context) error {
+	if c.pending == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	tags := *c.pending
+	*c.pending = nil
+	c.mu.Unlock()
+	if len(tags) == 0 {
+		return nil
+	}
+
+	return c.store.Invalidate(ctx, tags...)
+}
+
+// assign copies the loaded value into dest, value is either of the type dest points to or a pointer to it.
+func assign(value interface{}, dest interface{}) error {
+	target := reflect.ValueOf(dest).Elem()
+	source := reflect.ValueOf(value)
+	if !source.IsValid() || (source.Kind() == reflect.Ptr && source.IsNil()) {
+		return nil
+	}
+	if source.Kind() == reflect.Ptr && source.Type().Elem() == target.Type() {
+		source = source.Elem()
+	}
+	if source.Type() != target.Type() {
+		return fmt.Errorf("cache: can not assign %s to %s", source.Type(), target.Type())
+	}
+	target.Set(source)
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"go-rest-skeleton/infrastructure/cache"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStore is a Store keeping entries in memory.
+type memoryStore struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+	tags    map[string][]string
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{entries: map[string][]byte{}, tags: map[string][]string{}}
+}
+
+func (s *memoryStore) Get(ctx context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.entries[key], nil
+}
+
+func (s *memoryStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = value
+	for _, tag := range tags {
+		s.tags[tag] = append(s.tags[tag], key)
+	}
+	return nil
+}
+
+func (s *memoryStore) Invalidate(ctx context.Context, tags ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, tag := range tags {
+		for _, key := range s.tags[tag] {
+			delete(s.entries, key)
+		}
+		delete(s.tags, tag)
+	}
+	return nil
+}
+
+type item struct {
+	Name string `json:"name"`
+}
+
+func TestCache_Remember(t *testing.T) {
+	c := cache.New(newMemoryStore())
+	ctx := context.Background()
+	var loads int32
+	load := func() (interface{}, []string, error) {
+		atomic.AddInt32(&loads, 1)
+		return &item{Name: "a"}, []string{"item:a"}, nil
+	}
+
+	var first, second item
+	assert.NoError(t, c.Remember(ctx, "item:a", time.Minute, &first, load))
+	assert.NoError(t, c.Remember(ctx, "item:a", time.Minute, &second, load))
+	assert.Equal(t, "a", second.Name)
+	assert.EqualValues(t, 1, loads)
+
+	assert.NoError(t, c.Invalidate(ctx, "item:a"))
+	assert.NoError(t, c.Remember(ctx, "item:a", time.Minute, &second, load))
+	assert.EqualValues(t, 2, loads)
+}
+
+func TestCache_Remember_Error(t *testing.T) {
+	c := cache.New(newMemoryStore())
+	ctx := context.Background()
+	errLoad := errors.New("not found")
+	var loads int32
+	load := func() (interface{}, []string, error) {
+		atomic.AddInt32(&loads, 1)
+		return nil, nil, errLoad
+	}
+
+	var dest item
+	assert.Equal(t, errLoad, c.Remember(ctx, "item:a", time.Minute, &dest, load))
+	assert.Equal(t, errLoad, c.Remember(ctx, "item:a", time.Minute, &dest, load))
+	assert.EqualValues(t, 2, loads)
+}
+
+func TestCache_Remember_Singleflight(t *testing.T) {
+	c := cache.New(newMemoryStore())
+	ctx := context.Background()
+	release := make(chan struct{})
+	var loads int32
+	load := func() (interface{}, []string, error) {
+		atomic.AddInt32(&loads, 1)
+		<-release
+		return []item{{Name: "a"}}, nil, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var dest []item
+			assert.NoError(t, c.Remember(ctx, "items", time.Minute, &dest, load))
+			assert.Len(t, dest, 1)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, loads)
+}
+
+func TestCache_Remember_WithoutTTL(t *testing.T) {
+	store := newMemoryStore()
+	c := cache.New(store)
+	ctx := context.Background()
+
+	var dest item
+	err := c.Remember(ctx, "item:a", 0, &dest, func() (interface{}, []string, error) {
+		return &item{Name: "a"}, []string{"item:a"}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "a", dest.Name)
+	assert.Empty(t, store.entries)
+}
+
+func TestCache_Transaction(t *testing.T) {
+	store := newMemoryStore()
+	c := cache.New(store)
+	ctx := context.Background()
+	load := func() (interface{}, []string, error) {
+		return &item{Name: "a"}, []string{"item:a"}, nil
+	}
+
+	var dest item
+	assert.NoError(t, c.Remember(ctx, "item:a", time.Minute, &dest, load))
+
+	tx := c.Transaction()
+	assert.NoError(t, tx.Invalidate(ctx, "item:a"))
+	assert.Contains(t, store.entries, "item:a")
+
+	assert.NoError(t, tx.Commit(ctx))
+	assert.NotContains(t, store.entries, "item:a")
+}
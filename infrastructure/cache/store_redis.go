@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	// redisKeyPrefix is the prefix of every entry stored in redis.
+	redisKeyPrefix = "cache:"
+
+	// redisTagPrefix is the prefix of the sets keeping keys of the entries of a tag.
+	redisTagPrefix = "cache:tag:"
+)
+
+// setScript stores the entry and adds its key to the set of each tag.
+// A set expires no sooner than its latest entry, so no entry outlives the set used to invalidate it.
+var setScript = redis.NewScript(`
+local ttl = tonumber(ARGV[2])
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ttl)
+for i = 2, #KEYS do
+	redis.call('SADD', KEYS[i], KEYS[1])
+	if redis.call('PTTL', KEYS[i]) < ttl then
+		redis.call('PEXPIRE', KEYS[i], ttl)
+	end
+end
+return 1
+`)
+
+// invalidateScript removes the entries of each tag, then the set of the tag.
+var invalidateScript = redis.NewScript(`
+for i = 1, #KEYS do
+	local keys = redis.call('SMEMBERS', KEYS[i])
+	for _, key in ipairs(keys) do
+		redis.call('DEL', key)
+	end
+	redis.call('DEL', KEYS[i])
+end
+return 1
+`)
+
+// RedisStore is a Store keeping each entry under its own redis key, and keys of the entries of a tag in a set.
+type RedisStore struct {
+	client *redis.Client
+}
+
+// RedisStore implements the Store interface.
+var _ Store = &RedisStore{}
+
+// NewRedisStore creates new RedisStore.
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// Get returns the entry of the key, nil when the key is not stored.
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, error) {
+	encoded, err := s.client.Get(ctx, redisKeyPrefix+key).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+
+	return encoded, err
+}
+
+// Set stores the entry of the key for ttl, tagged by tags.
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	keys := []string{redisKeyPrefix + key}
+	for _, tag := range tags {
+		keys = append(keys, redisTagPrefix+tag)
+	}
+
+	return setScript.Run(ctx, s.client, keys, value, ttl.Milliseconds()).Err()
+}
+
+// Invalidate removes every entry tagged by one of the tags.
+func (s *RedisStore) Invalidate(ctx context.Context, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(tags))
+	for i, tag := range tags {
+		keys[i] = redisTagPrefix + tag
+	}
+
+	return invalidateScript.Run(ctx, s.client, keys).Err()
+}
@@ -0,0 +1,61 @@
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"go-rest-skeleton/config"
+	"go-rest-skeleton/infrastructure/cache"
+	"go-rest-skeleton/pkg/util"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+)
+
+// SkipThis is a function.
+func SkipThis(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skip this test")
+	}
+}
+
+// RedisStoreSetup will initialize store connected to the redis server of tests.
+func RedisStoreSetup() *cache.RedisStore {
+	if err := godotenv.Load(fmt.Sprintf("%s/.env", util.RootDir())); err != nil {
+		log.Println("no .env file provided")
+	}
+	conf := config.New().RedisTestConfig
+
+	return cache.NewRedisStore(redis.NewClient(&redis.Options{
+		Addr:     conf.RedisHost + ":" + conf.RedisPort,
+		Password: conf.RedisPassword,
+		DB:       conf.RedisDB,
+	}))
+}
+
+func TestRedisStore(t *testing.T) {
+	SkipThis(t)
+
+	store := RedisStoreSetup()
+	ctx := context.Background()
+	key := "test:" + uuid.New().String()
+	tag := "test:" + uuid.New().String()
+
+	missing, err := store.Get(ctx, key)
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+
+	assert.NoError(t, store.Set(ctx, key, []byte(`{"name":"a"}`), time.Minute, tag))
+	stored, err := store.Get(ctx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(`{"name":"a"}`), stored)
+
+	assert.NoError(t, store.Invalidate(ctx, tag))
+	invalidated, err := store.Get(ctx, key)
+	assert.NoError(t, err)
+	assert.Nil(t, invalidated)
+}
@@ -0,0 +1,170 @@
+// Package health checks whether dependencies of the app are reachable, uses by readiness probe.
+// Checks are registered by name to Registry, each one is bounded by its own timeout and its result is cached,
+// so frequent probes do not hammer the database, redis or storage.
+// Checkers reuse connection pools of the app services, they never open a connection of their own.
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// StatusUp is the status of a passing check or report.
+	StatusUp = "up"
+
+	// StatusDown is the status of a failing check or report.
+	StatusDown = "down"
+)
+
+// ErrTimeout is the error of a check which has not completed within its timeout.
+var ErrTimeout = errors.New("check timed out")
+
+// Checker is an interface. Needs to be implemented by each dependency checked by Registry.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc is an adapter to use an ordinary function as Checker.
+type CheckerFunc func(ctx context.Context) error
+
+// Check calls f(ctx).
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Result is the result of a single check.
+type Result struct {
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Latency   string    `json:"latency"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Report is the result of every check, it is down when any check is down.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Up reports whether every check is up.
+func (r *Report) Up() bool {
+	return r.Status == StatusUp
+}
+
+type check struct {
+	name    string
+	checker Checker
+	timeout time.Duration
+
+	mu     sync.Mutex
+	result *Result
+}
+
+// Registry keeps named checks and runs them.
+type Registry struct {
+	cacheTTL time.Duration
+	checks   []*check
+	now      func() time.Time
+}
+
+// NewRegistry creates new Registry, results are cached for cacheTTL, zero disables the cache.
+func NewRegistry(cacheTTL time.Duration) *Registry {
+	return &Registry{cacheTTL: cacheTTL, now: time.Now}
+}
+
+// Register adds checker by name, the check fails when it has not completed within timeout.
+func (r *Registry) Register(name string, checker Checker, timeout time.Duration) *Registry {
+	r.checks = append(r.checks, &check{name: name, checker: checker, timeout: timeout})
+	return r
+}
+
+// Names returns name of every registered check.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.checks))
+	for _, c := range r.checks {
+		names = append(names, c.name)
+	}
+
+	return names
+}
+
+// Run runs every check concurrently and returns their results.
+// A cached result is returned as long as it is fresh, concurrent runs of an expired check share a single run.
+func (r *Registry) Run(ctx context.Context) *Report {
+	results := make([]Result, len(r.checks))
+
+	var wg sync.WaitGroup
+	for i, c := range r.checks {
+		wg.Add(1)
+		go func(i int, c *check) {
+			defer wg.Done()
+			results[i] = r.run(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	report := &Report{Status: StatusUp, Checks: make(map[string]Result, len(r.checks))}
+	for i, c := range r.checks {
+		report.Checks[c.name] = results[i]
+		if results[i].Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+
+	return report
+}
+
+// run returns the cached result of the check, the check is run when its result has expired.
+func (r *Registry) run(ctx context.Context, c *check) Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.result != nil && r.now().Sub(c.result.CheckedAt) < r.cacheTTL {
+		return *c.result
+	}
+
+	start := r.now()
+	err := runWithTimeout(ctx, c.checker, c.timeout)
+	result := &Result{
+		Status:    StatusUp,
+		Latency:   r.now().Sub(start).String(),
+		CheckedAt: start,
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	c.result = result
+
+	return *result
+}
+
+// runWithTimeout runs the checker, giving up after timeout even if the checker ignores its context.
+func runWithTimeout(ctx context.Context, checker Checker, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- checker.Check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return ErrTimeout
+		}
+		return err
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return ErrTimeout
+		}
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,45 @@
+package health
+
+import (
+	"context"
+	"go-rest-skeleton/infrastructure/storage"
+	"net"
+
+	"github.com/go-redis/redis/v8"
+	"gorm.io/gorm"
+)
+
+// DB checks the database by pinging a connection of the pool of db.
+func DB(db *gorm.DB) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	})
+}
+
+// Redis checks the redis server by pinging a connection of the pool of client.
+func Redis(client *redis.Client) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	})
+}
+
+// Bucket checks the bucket of the storage driver exists.
+func Bucket(bucket storage.BucketStorage) Checker {
+	return CheckerFunc(bucket.CheckBucket)
+}
+
+// SMTP checks the SMTP server accepts tcp connection on addr.
+func SMTP(addr string) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	})
+}
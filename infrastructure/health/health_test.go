@@ -0,0 +1,85 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"go-rest-skeleton/infrastructure/health"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Run(t *testing.T) {
+	registry := health.NewRegistry(0).
+		Register("db", health.CheckerFunc(func(ctx context.Context) error { return nil }), time.Second).
+		Register("redis", health.CheckerFunc(func(ctx context.Context) error { return errors.New("refused") }), time.Second)
+
+	report := registry.Run(context.Background())
+	assert.False(t, report.Up())
+	assert.Equal(t, health.StatusDown, report.Status)
+	assert.Equal(t, health.StatusUp, report.Checks["db"].Status)
+	assert.Equal(t, health.StatusDown, report.Checks["redis"].Status)
+	assert.Equal(t, "refused", report.Checks["redis"].Error)
+	assert.Equal(t, []string{"db", "redis"}, registry.Names())
+}
+
+func TestRegistry_Run_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	registry := health.NewRegistry(0).
+		Register("storage", health.CheckerFunc(func(ctx context.Context) error {
+			<-release
+			return nil
+		}), 20*time.Millisecond)
+
+	start := time.Now()
+	report := registry.Run(context.Background())
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+	assert.Equal(t, health.StatusDown, report.Checks["storage"].Status)
+	assert.Equal(t, health.ErrTimeout.Error(), report.Checks["storage"].Error)
+}
+
+func TestRegistry_Run_Cache(t *testing.T) {
+	var runs int32
+	registry := health.NewRegistry(time.Minute).
+		Register("db", health.CheckerFunc(func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		}), time.Second)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.True(t, registry.Run(context.Background()).Up())
+		}()
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, 1, runs)
+}
+
+func TestSMTP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("want non error, got %#v", err)
+	}
+	addr := listener.Addr().String()
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			_ = conn.Close()
+		}
+	}()
+
+	assert.NoError(t, health.SMTP(addr).Check(context.Background()))
+
+	_ = listener.Close()
+	assert.Error(t, health.SMTP(addr).Check(context.Background()))
+}
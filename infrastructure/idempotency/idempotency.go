@@ -0,0 +1,55 @@
+// Package idempotency stores responses of requests sent with an Idempotency-Key header, so a client retrying
+// the same request gets the first response back instead of performing the request twice.
+// A key is held by the first request while it is in flight, then it is replaced by the stored response.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultTTL is how long a stored response is replayed when no TTL is configured.
+	DefaultTTL = 24 * time.Hour
+
+	// DefaultLockTTL is how long a request in flight holds its key when no TTL is configured.
+	// A key held longer than this is considered abandoned (e.g. the server crashed) and released.
+	DefaultLockTTL = time.Minute
+)
+
+// Record represents the state of an idempotency key.
+// Fingerprint identifies the request, a record not completed yet belongs to a request in flight.
+type Record struct {
+	Fingerprint string      `json:"fingerprint"`
+	Completed   bool        `json:"completed"`
+	Status      int         `json:"status,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+}
+
+// Store is an interface. Needs to be implemented by each storage of idempotency records.
+type Store interface {
+	Acquire(ctx context.Context, key string, record *Record, ttl time.Duration) (bool, error)
+	Get(ctx context.Context, key string) (*Record, error)
+	Save(ctx context.Context, key string, record *Record, ttl time.Duration) error
+	Release(ctx context.Context, key string) error
+}
+
+// Fingerprint returns the hash identifying a request by its method, path and body.
+func Fingerprint(method string, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + path + "\n"))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Key returns the storage key of the idempotency key sent by the principal, such as the UUID of the user.
+// The idempotency key is hashed, so its length and characters do not matter.
+func Key(principal string, idempotencyKey string) string {
+	hash := sha256.Sum256([]byte(idempotencyKey))
+	return principal + ":" + hex.EncodeToString(hash[:])
+}
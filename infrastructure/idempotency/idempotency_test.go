@@ -0,0 +1,23 @@
+package idempotency_test
+
+import (
+	"go-rest-skeleton/infrastructure/idempotency"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFingerprint(t *testing.T) {
+	fingerprint := idempotency.Fingerprint(http.MethodPost, "/users", []byte(`{"email":"me@example.com"}`))
+
+	assert.Len(t, fingerprint, 64)
+	assert.Equal(t, fingerprint, idempotency.Fingerprint(http.MethodPost, "/users", []byte(`{"email":"me@example.com"}`)))
+	assert.NotEqual(t, fingerprint, idempotency.Fingerprint(http.MethodPost, "/users", []byte(`{"email":"you@example.com"}`)))
+	assert.NotEqual(t, fingerprint, idempotency.Fingerprint(http.MethodPost, "/roles", []byte(`{"email":"me@example.com"}`)))
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, idempotency.Key("user:1", "retry-1"), idempotency.Key("user:1", "retry-1"))
+	assert.NotEqual(t, idempotency.Key("user:1", "retry-1"), idempotency.Key("user:2", "retry-1"))
+}
@@ -0,0 +1,68 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// redisKeyPrefix is the prefix of every key stored in redis.
+const redisKeyPrefix = "idempotency:"
+
+// RedisStore is a Store keeping each record as JSON under its own redis key.
+type RedisStore struct {
+	client *redis.Client
+}
+
+// RedisStore implements the Store interface.
+var _ Store = &RedisStore{}
+
+// NewRedisStore creates new RedisStore.
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// Acquire stores the record only when the key is not stored yet, it returns false when the key is already taken.
+func (s *RedisStore) Acquire(ctx context.Context, key string, record *Record, ttl time.Duration) (bool, error) {
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return false, err
+	}
+
+	return s.client.SetNX(ctx, redisKeyPrefix+key, encoded, ttl).Result()
+}
+
+// Get returns the record of the key, nil when the key is not stored.
+func (s *RedisStore) Get(ctx context.Context, key string) (*Record, error) {
+	encoded, err := s.client.Get(ctx, redisKeyPrefix+key).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var record Record
+	if err := json.Unmarshal(encoded, &record); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// Save replaces the record of the key.
+func (s *RedisStore) Save(ctx context.Context, key string, record *Record, ttl time.Duration) error {
+	encoded, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Set(ctx, redisKeyPrefix+key, encoded, ttl).Err()
+}
+
+// Release removes the key, so the request can be sent again with it.
+func (s *RedisStore) Release(ctx context.Context, key string) error {
+	return s.client.Del(ctx, redisKeyPrefix+key).Err()
+}
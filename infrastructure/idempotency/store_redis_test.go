@@ -0,0 +1,73 @@
+package idempotency_test
+
+import (
+	"context"
+	"fmt"
+	"go-rest-skeleton/config"
+	"go-rest-skeleton/infrastructure/idempotency"
+	"go-rest-skeleton/pkg/util"
+	"log"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
+)
+
+// SkipThis is a function.
+func SkipThis(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skip this test")
+	}
+}
+
+// RedisStoreSetup will initialize store connected to the redis server of tests.
+func RedisStoreSetup() *idempotency.RedisStore {
+	if err := godotenv.Load(fmt.Sprintf("%s/.env", util.RootDir())); err != nil {
+		log.Println("no .env file provided")
+	}
+	conf := config.New().RedisTestConfig
+
+	return idempotency.NewRedisStore(redis.NewClient(&redis.Options{
+		Addr:     conf.RedisHost + ":" + conf.RedisPort,
+		Password: conf.RedisPassword,
+		DB:       conf.RedisDB,
+	}))
+}
+
+func TestRedisStore(t *testing.T) {
+	SkipThis(t)
+
+	store := RedisStoreSetup()
+	ctx := context.Background()
+	key := idempotency.Key("user:test", uuid.New().String())
+	inFlight := &idempotency.Record{Fingerprint: "fingerprint"}
+
+	acquired, err := store.Acquire(ctx, key, inFlight, time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	acquired, err = store.Acquire(ctx, key, inFlight, time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+
+	completed := &idempotency.Record{
+		Fingerprint: "fingerprint",
+		Completed:   true,
+		Status:      http.StatusCreated,
+		Header:      http.Header{"Content-Type": []string{"application/json"}},
+		Body:        []byte(`{"code":201}`),
+	}
+	assert.NoError(t, store.Save(ctx, key, completed, time.Minute))
+	record, err := store.Get(ctx, key)
+	assert.NoError(t, err)
+	assert.Equal(t, completed, record)
+
+	assert.NoError(t, store.Release(ctx, key))
+	record, err = store.Get(ctx, key)
+	assert.NoError(t, err)
+	assert.Nil(t, record)
+}
@@ -0,0 +1,131 @@
+package exception
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// catalog stores every registered error by its code.
+var catalog = make(map[string]*Error)
+
+// Error is an error registered in the catalog.
+// Key is the translation key of the message, it is also returned by Error, so the error is translated as before.
+type Error struct {
+	Code        string `json:"code"`
+	Status      int    `json:"status"`
+	Key         string `json:"key"`
+	Description string `json:"description"`
+}
+
+// statusError is an error responded with another HTTP status than the one registered in the catalog.
+type statusError struct {
+	err    error
+	status int
+}
+
+// newError registers an error in the catalog, registering the same code twice is a programming error.
+func newError(code string, status int, key string, description string) *Error {
+	if _, exists := catalog[code]; exists {
+		panic(fmt.Sprintf("exception: error code %s is registered twice", code))
+	}
+
+	e := &Error{
+		Code:        code,
+		Status:      status,
+		Key:         key,
+		Description: description,
+	}
+	catalog[code] = e
+
+	return e
+}
+
+// Error returns the translation key of the error.
+func (e *Error) Error() string {
+	return e.Key
+}
+
+// HTTPStatus returns the HTTP status the error is responded with.
+func (e *Error) HTTPStatus() int {
+	return e.Status
+}
+
+// ErrorCode returns the code of the error in the catalog.
+func (e *Error) ErrorCode() string {
+	return e.Code
+}
+
+// Error returns the message of the wrapped error.
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the wrapped error, so errors.Is still matches the error of the catalog.
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+// HTTPStatus returns the HTTP status given to WithStatus.
+func (e *statusError) HTTPStatus() int {
+	return e.status
+}
+
+// ErrorCode returns the code of the wrapped error, empty when it is not registered in the catalog.
+func (e *statusError) ErrorCode() string {
+	return Code(e.err)
+}
+
+// WithStatus wraps the error, so it is responded with the given HTTP status instead of the registered one.
+// Such as the email not registered error is responded as unauthorized on login.
+func WithStatus(err error, status int) error {
+	return &statusError{err: err, status: status}
+}
+
+// Status returns the HTTP status of the error, internal server error when it is not registered in the catalog.
+func Status(err error) int {
+	var typed interface{ HTTPStatus() int }
+	if errors.As(err, &typed) {
+		return typed.HTTPStatus()
+	}
+
+	return http.StatusInternalServerError
+}
+
+// Code returns the code of the error in the catalog, empty when it is not registered.
+func Code(err error) string {
+	var typed *Error
+	if errors.As(err, &typed) {
+		return typed.Code
+	}
+
+	return ""
+}
+
+// Catalog returns every registered error sorted by its code.
+func Catalog() []*Error {
+	errs := make([]*Error, 0, len(catalog))
+	for _, e := range catalog {
+		errs = append(errs, e)
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Code < errs[j].Code
+	})
+
+	return errs
+}
+
+// CatalogMarkdown formats the given errors as Markdown table, such as for documentation of the support team.
+func CatalogMarkdown(errs []*Error) string {
+	var builder strings.Builder
+	builder.WriteString("| Code | HTTP status | Translation key | Description |\n")
+	builder.WriteString("| --- | --- | --- | --- |\n")
+	for _, e := range errs {
+		builder.WriteString(fmt.Sprintf("| %s | %d %s | `%s` | %s |\n",
+			e.Code, e.Status, http.StatusText(e.Status), e.Key, strings.ReplaceAll(e.Description, "|", "\\|")))
+	}
+
+	return builder.String()
+}
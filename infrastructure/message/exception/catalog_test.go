@@ -0,0 +1,57 @@
+package exception_test
+
+import (
+	"errors"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/pkg/translation"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalog(t *testing.T) {
+	errs := exception.Catalog()
+
+	assert.NotEmpty(t, errs)
+	for index, e := range errs {
+		if index > 0 {
+			assert.Less(t, errs[index-1].Code, e.Code)
+		}
+		assert.NotEmpty(t, http.StatusText(e.Status), e.Code)
+		assert.NotEmpty(t, e.Description, e.Code)
+		for _, lang := range []string{"en", "id"} {
+			assert.True(t, translation.Has(lang, e.Key), "%s is not translated in %s", e.Key, lang)
+		}
+	}
+}
+
+func TestError(t *testing.T) {
+	err := exception.ErrorTextUserNotFound
+
+	assert.Equal(t, "api.msg.error.user.not_found", err.Error())
+	assert.Equal(t, http.StatusNotFound, exception.Status(err))
+	assert.Equal(t, err.Code, exception.Code(err))
+
+	assert.Equal(t, http.StatusInternalServerError, exception.Status(errors.New("dial tcp: connection refused")))
+	assert.Empty(t, exception.Code(errors.New("dial tcp: connection refused")))
+}
+
+func TestWithStatus(t *testing.T) {
+	err := exception.WithStatus(exception.ErrorTextUserEmailNotRegistered, http.StatusUnauthorized)
+
+	assert.True(t, errors.Is(err, exception.ErrorTextUserEmailNotRegistered))
+	assert.Equal(t, exception.ErrorTextUserEmailNotRegistered.Key, err.Error())
+	assert.Equal(t, http.StatusUnauthorized, exception.Status(err))
+	assert.Equal(t, exception.ErrorTextUserEmailNotRegistered.Code, exception.Code(err))
+}
+
+func TestCatalogMarkdown(t *testing.T) {
+	markdown := exception.CatalogMarkdown([]*exception.Error{exception.ErrorTextFileTooLarge})
+	lines := strings.Split(strings.TrimSpace(markdown), "\n")
+
+	assert.Len(t, lines, 3)
+	assert.Equal(t, "| Code | HTTP status | Translation key | Description |", lines[0])
+	assert.Contains(t, lines[2], "| 413 Request Entity Too Large | `api.msg.error.common.file_too_large` |")
+}
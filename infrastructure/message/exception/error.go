@@ -1,122 +1,406 @@
 package exception
 
 import (
-	"errors"
+	"net/http"
 )
 
 // Common errors.
 var (
 	// ErrorTextNoRecordInsertedToRedis is an error representing there is no record inserted to redis.
-	ErrorTextNoRecordInsertedToRedis = errors.New("api.msg.error.common.no_record_inserted_to_redis")
+	ErrorTextNoRecordInsertedToRedis = newError(
+		"EXCOMM001", http.StatusInternalServerError,
+		"api.msg.error.common.no_record_inserted_to_redis",
+		"The record could not be stored in redis, such as the token metadata on login.",
+	)
 
 	// ErrorTextInternalServerError is an error representing internal server error.
-	ErrorTextInternalServerError = errors.New("api.msg.error.common.internal_server_error")
+	ErrorTextInternalServerError = newError(
+		"EXCOMM002", http.StatusInternalServerError,
+		"api.msg.error.common.internal_server_error",
+		"The server failed to process the request, details are written to the log.",
+	)
 
 	// ErrorTextAnErrorOccurred is an error representing an error occurred.
-	ErrorTextAnErrorOccurred = errors.New("api.msg.error.common.an_error_occurred")
+	ErrorTextAnErrorOccurred = newError(
+		"EXCOMM003", http.StatusInternalServerError,
+		"api.msg.error.common.an_error_occurred",
+		"An unexpected error occurred while processing the request.",
+	)
 
 	// ErrorTextUnauthorized is an error representing unauthorized request.
-	ErrorTextUnauthorized = errors.New("api.msg.error.common.unauthorized")
+	ErrorTextUnauthorized = newError(
+		"EXCOMM004", http.StatusUnauthorized,
+		"api.msg.error.common.unauthorized",
+		"The request does not carry a valid access token or credentials.",
+	)
 
 	// ErrorTextForbidden is an error representing forbidden request.
-	ErrorTextForbidden = errors.New("api.msg.error.common.forbidden")
+	ErrorTextForbidden = newError(
+		"EXCOMM005", http.StatusForbidden,
+		"api.msg.error.common.forbidden",
+		"The authenticated user is not allowed to perform the request.",
+	)
 
 	// ErrorTextBadRequest is an error representing bad request.
-	ErrorTextBadRequest = errors.New("api.msg.error.common.bad_request")
+	ErrorTextBadRequest = newError(
+		"EXCOMM006", http.StatusBadRequest,
+		"api.msg.error.common.bad_request",
+		"The request is malformed, such as a header or parameter with invalid value.",
+	)
 
 	// ErrorTextUnprocessableEntity is an error representing unprocessable entity.
-	ErrorTextUnprocessableEntity = errors.New("api.msg.error.common.unprocessable_entity")
+	ErrorTextUnprocessableEntity = newError(
+		"EXCOMM007", http.StatusUnprocessableEntity,
+		"api.msg.error.common.unprocessable_entity",
+		"The request body failed validation, failing fields are listed in data.",
+	)
 
 	// ErrorTextNotFound is an error representing request not found.
-	ErrorTextNotFound = errors.New("api.msg.error.common.not_found")
+	ErrorTextNotFound = newError(
+		"EXCOMM008", http.StatusNotFound,
+		"api.msg.error.common.not_found",
+		"The requested route or resource does not exist.",
+	)
 
 	// ErrorTextFileTooLarge is an error representing that received file size too large.
-	ErrorTextFileTooLarge = errors.New("api.msg.error.common.file_too_large")
+	ErrorTextFileTooLarge = newError(
+		"EXCOMM009", http.StatusRequestEntityTooLarge,
+		"api.msg.error.common.file_too_large",
+		"The uploaded file is larger than the server accepts.",
+	)
 
 	// ErrorTextInvalidPrivateKey is an error representing invalid private key.
-	ErrorTextInvalidPrivateKey = errors.New("api.msg.error.common.invalid_private_key")
+	ErrorTextInvalidPrivateKey = newError(
+		"EXCOMM010", http.StatusInternalServerError,
+		"api.msg.error.common.invalid_private_key",
+		"The private key configured to sign tokens can not be parsed.",
+	)
 
 	// ErrorTextInvalidPublicKey is an error representing invalid public key.
-	ErrorTextInvalidPublicKey = errors.New("api.msg.error.common.invalid_public_key")
+	ErrorTextInvalidPublicKey = newError(
+		"EXCOMM011", http.StatusInternalServerError,
+		"api.msg.error.common.invalid_public_key",
+		"The public key configured to verify tokens can not be parsed.",
+	)
 
 	// ErrorTextRefreshTokenIsExpired is an error representing refresh token is expired.
-	ErrorTextRefreshTokenIsExpired = errors.New("api.msg.error.common.refresh_token_expired")
+	ErrorTextRefreshTokenIsExpired = newError(
+		"EXCOMM012", http.StatusUnauthorized,
+		"api.msg.error.common.refresh_token_expired",
+		"The refresh token has expired, the user has to login again.",
+	)
 
 	// ErrorTextPerPage is an error representing request per page over the limit.
-	ErrorTextPerPage = errors.New("api.msg.error.common.per_page")
+	ErrorTextPerPage = newError(
+		"EXCOMM013", http.StatusBadRequest,
+		"api.msg.error.common.per_page",
+		"The requested per_page is over the allowed limit.",
+	)
+
+	// ErrorTextPreconditionFailed is an error representing resource modified since the version given by If-Match.
+	ErrorTextPreconditionFailed = newError(
+		"EXCOMM014", http.StatusPreconditionFailed,
+		"api.msg.error.common.precondition_failed",
+		"The resource has been modified since it was read, fetch it again and retry with its latest ETag.",
+	)
+
+	// ErrorTextRestoreConflict is an error representing deleted resource conflicts with an existing resource.
+	ErrorTextRestoreConflict = newError(
+		"EXCOMM015", http.StatusConflict,
+		"api.msg.error.common.restore_conflict",
+		"The deleted resource can not be restored, another resource with the same unique value has been created since.",
+	)
+)
+
+// Errors for application.
+var (
+	// ErrorTextApplicationNotFound is an error representing application not found in database.
+	ErrorTextApplicationNotFound = newError(
+		"EXAPPL001", http.StatusNotFound,
+		"api.msg.error.application.not_found",
+		"The application does not exist.",
+	)
+
+	// ErrorTextApplicationInvalidUUID is an error representing UUID not found in database.
+	ErrorTextApplicationInvalidUUID = newError(
+		"EXAPPL002", http.StatusUnprocessableEntity,
+		"api.msg.error.application.invalid_uuid",
+		"The given application UUID is not valid.",
+	)
 )
 
 // Errors for document
 var (
 	// ErrorTextDocumentNotFound is an error representing document not found in database.
-	ErrorTextDocumentNotFound = errors.New("api.msg.error.document.not_found")
+	ErrorTextDocumentNotFound = newError(
+		"EXDOCU001", http.StatusNotFound,
+		"api.msg.error.document.not_found",
+		"The document does not exist.",
+	)
 
 	// ErrorTextDocumentInvalidUUID is an error representing UUID not found in database.
-	ErrorTextDocumentInvalidUUID = errors.New("api.msg.error.document.invalid_uuid")
+	ErrorTextDocumentInvalidUUID = newError(
+		"EXDOCU002", http.StatusUnprocessableEntity,
+		"api.msg.error.document.invalid_uuid",
+		"The given document UUID is not valid.",
+	)
 )
 
 // Errors for role.
 var (
 	// ErrorTextRoleNotFound is an error representing role not found in database.
-	ErrorTextRoleNotFound = errors.New("api.msg.error.role.not_found")
+	ErrorTextRoleNotFound = newError(
+		"EXROLE001", http.StatusNotFound,
+		"api.msg.error.role.not_found",
+		"The role does not exist.",
+	)
 
 	// ErrorTextRoleInvalidUUID is an error representing UUID not found in database.
-	ErrorTextRoleInvalidUUID = errors.New("api.msg.error.role.invalid_uuid")
+	ErrorTextRoleInvalidUUID = newError(
+		"EXROLE002", http.StatusUnprocessableEntity,
+		"api.msg.error.role.invalid_uuid",
+		"The given role UUID is not valid.",
+	)
 )
 
 // Errors fot tour.
 var (
 	// ErrorTextTourSlugAlreadyExists is an error representing slug is already exists in database.
-	ErrorTextTourSlugAlreadyExists = errors.New("api.msg.error.tour.slug_already_exists")
+	ErrorTextTourSlugAlreadyExists = newError(
+		"EXTOUR001", http.StatusConflict,
+		"api.msg.error.tour.slug_already_exists",
+		"Another tour already uses the given slug.",
+	)
+
+	// ErrorTextTourNotFound is an error representing tour not found in database.
+	ErrorTextTourNotFound = newError(
+		"EXTOUR002", http.StatusNotFound,
+		"api.msg.error.tour.not_found",
+		"The tour does not exist.",
+	)
 )
 
 // Errors for user.
 var (
 	// ErrorTextUserNotFound is an error representing user not found in database.
-	ErrorTextUserNotFound = errors.New("api.msg.error.user.not_found")
+	ErrorTextUserNotFound = newError(
+		"EXUSER001", http.StatusNotFound,
+		"api.msg.error.user.not_found",
+		"The user does not exist.",
+	)
 
 	// ErrorTextUserInvalidUUID is an error representing UUID not found in database.
-	ErrorTextUserInvalidUUID = errors.New("api.msg.error.user.invalid_uuid")
+	ErrorTextUserInvalidUUID = newError(
+		"EXUSER002", http.StatusUnprocessableEntity,
+		"api.msg.error.user.invalid_uuid",
+		"The given user UUID is not valid.",
+	)
 
 	// ErrorTextUserInvalidPassword is an error representing hashed password not match with stored in database.
-	ErrorTextUserInvalidPassword = errors.New("api.msg.error.user.invalid_password")
+	ErrorTextUserInvalidPassword = newError(
+		"EXUSER003", http.StatusUnprocessableEntity,
+		"api.msg.error.user.invalid_password",
+		"The given password does not match the password of the user.",
+	)
 
 	// ErrorTextUserInvalidUsernameAndPassword is an error representing hashed password not match with stored in database.
-	ErrorTextUserInvalidUsernameAndPassword = errors.New("api.msg.error.user.invalid_email_and_password")
+	ErrorTextUserInvalidUsernameAndPassword = newError(
+		"EXUSER004", http.StatusUnauthorized,
+		"api.msg.error.user.invalid_email_and_password",
+		"The given email and password do not match any user.",
+	)
 
 	// ErrorTextUserEmailNotRegistered is an error representing email already is not exists in database.
-	ErrorTextUserEmailNotRegistered = errors.New("api.msg.error.user.email_not_registered")
+	ErrorTextUserEmailNotRegistered = newError(
+		"EXUSER005", http.StatusUnprocessableEntity,
+		"api.msg.error.user.email_not_registered",
+		"No user is registered with the given email.",
+	)
 
 	// ErrorTextUserPhoneNotRegistered is an error representing email already is not exists in database.
-	ErrorTextUserPhoneNotRegistered = errors.New("api.msg.error.user.phone_not_registered")
+	ErrorTextUserPhoneNotRegistered = newError(
+		"EXUSER006", http.StatusUnprocessableEntity,
+		"api.msg.error.user.phone_not_registered",
+		"No user is registered with the given phone.",
+	)
 
 	// ErrorTextUserEmailAlreadyTaken is an error representing email already exists in database.
-	ErrorTextUserEmailAlreadyTaken = errors.New("api.msg.error.user.email_already_taken")
+	ErrorTextUserEmailAlreadyTaken = newError(
+		"EXUSER007", http.StatusConflict,
+		"api.msg.error.user.email_already_taken",
+		"Another user already uses the given email.",
+	)
 
 	// ErrorTextUserPhoneAlreadyTaken is an error representing phone already exists in database.
-	ErrorTextUserPhoneAlreadyTaken = errors.New("api.msg.error.user.phone_already_taken")
+	ErrorTextUserPhoneAlreadyTaken = newError(
+		"EXUSER008", http.StatusConflict,
+		"api.msg.error.user.phone_already_taken",
+		"Another user already uses the given phone.",
+	)
 
 	// ErrorTextUserPreferenceInvalidUUID is an error representing UUID not found in database.
-	ErrorTextUserPreferenceInvalidUUID = errors.New("api.msg.error.user.preference.invalid_uuid")
+	ErrorTextUserPreferenceInvalidUUID = newError(
+		"EXUSER009", http.StatusUnprocessableEntity,
+		"api.msg.error.user.preference.invalid_uuid",
+		"The given user preference UUID is not valid.",
+	)
 
 	// ErrorTextUserForgotPasswordTokenNotFound is an error representing Token not found in database.
-	ErrorTextUserForgotPasswordTokenNotFound = errors.New("api.msg.error.user.forgot_password.token_not_found")
+	ErrorTextUserForgotPasswordTokenNotFound = newError(
+		"EXUSER010", http.StatusNotFound,
+		"api.msg.error.user.forgot_password.token_not_found",
+		"The forgot password token does not exist or has already been used.",
+	)
 )
 
 // Errors for storage.
 var (
 	// ErrorTextStorageCategoryNotFound is an error representing storage_category not found in database.
-	ErrorTextStorageCategoryNotFound = errors.New("api.msg.error.storage.category.not_found")
+	ErrorTextStorageCategoryNotFound = newError(
+		"EXSTOR001", http.StatusUnprocessableEntity,
+		"api.msg.error.storage.category.not_found",
+		"The storage category does not exist.",
+	)
 
 	// ErrorTextStorageFileNotFound is an error representing storage_file not found in database.
-	ErrorTextStorageFileNotFound = errors.New("api.msg.error.storage.file.not_found")
+	ErrorTextStorageFileNotFound = newError(
+		"EXSTOR002", http.StatusNotFound,
+		"api.msg.error.storage.file.not_found",
+		"The file does not exist or has been deleted.",
+	)
+
+	// ErrorTextStorageFileInUse is an error representing storage_file still referenced by another record.
+	ErrorTextStorageFileInUse = newError(
+		"EXSTOR003", http.StatusConflict,
+		"api.msg.error.storage.file.in_use",
+		"The file is still referenced by another record, such as the avatar of a user.",
+	)
+
+	// ErrorTextStorageFileScanPending is an error representing storage_file which has not been scanned yet.
+	ErrorTextStorageFileScanPending = newError(
+		"EXSTOR004", http.StatusConflict,
+		"api.msg.error.storage.file.scan_pending",
+		"The file has not been scanned for malware yet, retry later.",
+	)
+
+	// ErrorTextStorageFileQuarantined is an error representing storage_file the scanner found malware in.
+	ErrorTextStorageFileQuarantined = newError(
+		"EXSTOR005", http.StatusForbidden,
+		"api.msg.error.storage.file.quarantined",
+		"Malware was found in the file, it is kept for inspection but never served.",
+	)
 
 	// ErrorTextStorageUploadCannotOpenFile is an error representing uploaded file can not opened by system.
-	ErrorTextStorageUploadCannotOpenFile = errors.New("api.msg.error.storage.file.cannot_open_file")
+	ErrorTextStorageUploadCannotOpenFile = newError(
+		"EXSTOR006", http.StatusUnprocessableEntity,
+		"api.msg.error.storage.file.cannot_open_file",
+		"The uploaded file can not be opened.",
+	)
 
 	// ErrorTextStorageUploadInvalidSize is an error representing uploaded file size is greater than allowed maximum size.
-	ErrorTextStorageUploadInvalidSize = errors.New("api.msg.error.storage.file.invalid_file_size")
+	ErrorTextStorageUploadInvalidSize = newError(
+		"EXSTOR007", http.StatusUnprocessableEntity,
+		"api.msg.error.storage.file.invalid_file_size",
+		"The uploaded file size is greater than the maximum size of its category.",
+	)
 
 	// ErrorTextStorageUploadInvalidFileType is an error representing uploaded file has invalid file type.
-	ErrorTextStorageUploadInvalidFileType = errors.New("api.msg.error.storage.file.invalid_file_type")
+	ErrorTextStorageUploadInvalidFileType = newError(
+		"EXSTOR008", http.StatusUnprocessableEntity,
+		"api.msg.error.storage.file.invalid_file_type",
+		"The uploaded file type is not allowed by its category.",
+	)
+
+	// ErrorTextStorageInvalidSignature is an error representing signed URL of file is invalid.
+	ErrorTextStorageInvalidSignature = newError(
+		"EXSTOR009", http.StatusForbidden,
+		"api.msg.error.storage.file.invalid_signature",
+		"The signature of the signed file URL is invalid.",
+	)
+
+	// ErrorTextStorageExpiredSignature is an error representing signed URL of file has expired.
+	ErrorTextStorageExpiredSignature = newError(
+		"EXSTOR010", http.StatusForbidden,
+		"api.msg.error.storage.file.expired_signature",
+		"The signed file URL has expired.",
+	)
+
+	// ErrorTextStorageUploadNotFound is an error representing resumable upload not found or has expired.
+	ErrorTextStorageUploadNotFound = newError(
+		"EXSTOR011", http.StatusNotFound,
+		"api.msg.error.storage.upload.not_found",
+		"The resumable upload does not exist or has expired.",
+	)
+
+	// ErrorTextStorageUploadNotUploaded is an error representing direct upload confirmed before the file is uploaded.
+	ErrorTextStorageUploadNotUploaded = newError(
+		"EXSTOR012", http.StatusConflict,
+		"api.msg.error.storage.upload.not_uploaded",
+		"The direct upload is confirmed before the file is uploaded to the storage.",
+	)
+
+	// ErrorTextStorageUploadOffsetMismatch is an error representing resumable upload written at wrong offset.
+	ErrorTextStorageUploadOffsetMismatch = newError(
+		"EXSTOR013", http.StatusConflict,
+		"api.msg.error.storage.upload.offset_mismatch",
+		"The chunk of the resumable upload is written at the wrong offset.",
+	)
+
+	// ErrorTextStorageUploadMethodNotSupported is an error representing direct upload method not supported by driver.
+	ErrorTextStorageUploadMethodNotSupported = newError(
+		"EXSTOR014", http.StatusUnprocessableEntity,
+		"api.msg.error.storage.upload.method_not_supported",
+		"The storage driver does not support the requested upload method.",
+	)
+)
+
+// Errors for webhook.
+var (
+	// ErrorTextWebhookSubscriptionNotFound is an error representing webhook subscription not found in database.
+	ErrorTextWebhookSubscriptionNotFound = newError(
+		"EXWEBH001", http.StatusNotFound,
+		"api.msg.error.webhook.subscription.not_found",
+		"The webhook subscription does not exist.",
+	)
+
+	// ErrorTextWebhookSubscriptionInvalidUUID is an error representing UUID not found in database.
+	ErrorTextWebhookSubscriptionInvalidUUID = newError(
+		"EXWEBH002", http.StatusUnprocessableEntity,
+		"api.msg.error.webhook.subscription.invalid_uuid",
+		"The given webhook subscription UUID is not valid.",
+	)
+
+	// ErrorTextWebhookDeliveryNotFound is an error representing webhook delivery not found in database.
+	ErrorTextWebhookDeliveryNotFound = newError(
+		"EXWEBH003", http.StatusNotFound,
+		"api.msg.error.webhook.delivery.not_found",
+		"The webhook delivery does not exist.",
+	)
+)
+
+// Errors for notification.
+var (
+	// ErrorTextNotificationNotFound is an error representing notification not found in the inbox of the user.
+	ErrorTextNotificationNotFound = newError(
+		"EXNOTI001", http.StatusNotFound,
+		"api.msg.error.notification.not_found",
+		"The notification does not exist in the inbox of the user.",
+	)
+)
+
+// Errors for idempotency.
+var (
+	// ErrorTextIdempotencyKeyReused is an error representing idempotency key sent again with another request.
+	ErrorTextIdempotencyKeyReused = newError(
+		"EXIDEM001", http.StatusUnprocessableEntity,
+		"api.msg.error.idempotency.key_reused",
+		"The Idempotency-Key has already been used with a different request body or endpoint.",
+	)
+
+	// ErrorTextIdempotencyRequestInProgress is an error representing request with the same idempotency key in flight.
+	ErrorTextIdempotencyRequestInProgress = newError(
+		"EXIDEM002", http.StatusConflict,
+		"api.msg.error.idempotency.request_in_progress",
+		"A request with the same Idempotency-Key is still being processed, retry later.",
+	)
 )
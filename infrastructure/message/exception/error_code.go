@@ -1,5 +1,7 @@
 package exception
 
+import "net/http"
+
 const (
 	// ErrorCodeIFAUGA001 is an error represent represent given authorization via request headers
 	// is not valid.
@@ -30,3 +32,54 @@ const (
 	// User does not have permission to perform this request.
 	ErrorCodeITMIPO002 = "ITMIPO002"
 )
+
+// Tracing codes are registered in the catalog as well, they are responded with the message of the error they trace.
+var (
+	_ = newError(
+		ErrorCodeIFAUGA001, http.StatusUnauthorized,
+		ErrorTextUnauthorized.Key,
+		"The authorization header is missing or is not valid.",
+	)
+
+	_ = newError(
+		ErrorCodeIFAUGA002, http.StatusUnauthorized,
+		ErrorTextUnauthorized.Key,
+		"The authentication type of the authorization header is not supported.",
+	)
+
+	_ = newError(
+		ErrorCodeIFAUGA003, http.StatusUnauthorized,
+		ErrorTextUnauthorized.Key,
+		"The decoded basic auth does not contain a pair of username and password.",
+	)
+
+	_ = newError(
+		ErrorCodeIFAUGA004, http.StatusUnauthorized,
+		ErrorTextUnauthorized.Key,
+		"The username and password of the basic auth are not registered.",
+	)
+
+	_ = newError(
+		ErrorCodeIFAUGA005, http.StatusUnauthorized,
+		ErrorTextUnauthorized.Key,
+		"The JWT token is not valid or has expired.",
+	)
+
+	_ = newError(
+		ErrorCodeIFAUGA006, http.StatusUnauthorized,
+		ErrorTextUnauthorized.Key,
+		"The JWT token is valid but does not represent a user.",
+	)
+
+	_ = newError(
+		ErrorCodeITMIPO001, http.StatusUnauthorized,
+		ErrorTextUnauthorized.Key,
+		"The authenticated user UUID is missing from the request context.",
+	)
+
+	_ = newError(
+		ErrorCodeITMIPO002, http.StatusForbidden,
+		ErrorTextForbidden.Key,
+		"The authenticated user does not have permission to perform the request.",
+	)
+)
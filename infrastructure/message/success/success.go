@@ -15,22 +15,35 @@ const (
 	AuthSuccessfullyGetProfile     = "api.msg.success.auth.successfully_get_profile"
 )
 
+// Success message for document.
+const (
+	DocumentSuccessfullyApproveDocument = "api.msg.success.document.successfully_approve_document"
+)
+
 // Success message for role.
 const (
-	RoleSuccessfullyGetRoleList   = "api.msg.success.role.successfully_get_role_list"
-	RoleSuccessfullyGetRoleDetail = "api.msg.success.role.successfully_get_role_detail"
-	RoleSuccessfullyCreateRole    = "api.msg.success.role.successfully_create_role"
-	RoleSuccessfullyUpdateRole    = "api.msg.success.role.successfully_update_role"
-	RoleSuccessfullyDeleteRole    = "api.msg.success.role.successfully_delete_role"
+	RoleSuccessfullyGetRoleList     = "api.msg.success.role.successfully_get_role_list"
+	RoleSuccessfullyGetRoleDetail   = "api.msg.success.role.successfully_get_role_detail"
+	RoleSuccessfullyCreateRole      = "api.msg.success.role.successfully_create_role"
+	RoleSuccessfullyUpdateRole      = "api.msg.success.role.successfully_update_role"
+	RoleSuccessfullyDeleteRole      = "api.msg.success.role.successfully_delete_role"
+	RoleSuccessfullyRestoreRole     = "api.msg.success.role.successfully_restore_role"
+	RoleSuccessfullyForceDeleteRole = "api.msg.success.role.successfully_force_delete_role"
+	RoleSuccessfullyBulkSaveRole    = "api.msg.success.role.successfully_bulk_save_role"
+	RoleSuccessfullyBulkDeleteRole  = "api.msg.success.role.successfully_bulk_delete_role"
 )
 
 // Success message for tour.
 const (
-	TourSuccessfullyGetTourList   = "api.msg.success.tour.successfully_get_tour_list"
-	TourSuccessfullyGetTourDetail = "api.msg.success.tour.successfully_get_tour_detail"
-	TourSuccessfullyCreateTour    = "api.msg.success.tour.successfully_create_tour"
-	TourSuccessfullyUpdateTour    = "api.msg.success.tour.successfully_update_tour"
-	TourSuccessfullyDeleteTour    = "api.msg.success.tour.successfully_delete_tour"
+	TourSuccessfullyGetTourList     = "api.msg.success.tour.successfully_get_tour_list"
+	TourSuccessfullyGetTourDetail   = "api.msg.success.tour.successfully_get_tour_detail"
+	TourSuccessfullyCreateTour      = "api.msg.success.tour.successfully_create_tour"
+	TourSuccessfullyUpdateTour      = "api.msg.success.tour.successfully_update_tour"
+	TourSuccessfullyDeleteTour      = "api.msg.success.tour.successfully_delete_tour"
+	TourSuccessfullyRestoreTour     = "api.msg.success.tour.successfully_restore_tour"
+	TourSuccessfullyForceDeleteTour = "api.msg.success.tour.successfully_force_delete_tour"
+	TourSuccessfullyBulkSaveTour    = "api.msg.success.tour.successfully_bulk_save_tour"
+	TourSuccessfullyBulkDeleteTour  = "api.msg.success.tour.successfully_bulk_delete_tour"
 )
 
 // Success message for user.
@@ -41,7 +54,42 @@ const (
 	UserSuccessfullyUpdateUser           = "api.msg.success.user.successfully_update_user"
 	UserSuccessfullyUpdateUserAvatar     = "api.msg.success.user.successfully_update_user_avatar"
 	UserSuccessfullyDeleteUser           = "api.msg.success.user.successfully_delete_user"
+	UserSuccessfullyRestoreUser          = "api.msg.success.user.successfully_restore_user"
+	UserSuccessfullyForceDeleteUser      = "api.msg.success.user.successfully_force_delete_user"
+	UserSuccessfullyBulkSaveUser         = "api.msg.success.user.successfully_bulk_save_user"
+	UserSuccessfullyBulkDeleteUser       = "api.msg.success.user.successfully_bulk_delete_user"
 	UserSuccessfullyGetUserPreference    = "api.msg.success.user.successfully_get_user_preference"
 	UserSuccessfullyUpdateUserPreference = "api.msg.success.user.successfully_update_user_preference"
 	UserSuccessfullyResetUserPreference  = "api.msg.success.user.successfully_reset_user_preference"
 )
+
+// Success message for webhook.
+const (
+	WebhookSuccessfullyGetWebhookList           = "api.msg.success.webhook.successfully_get_webhook_list"
+	WebhookSuccessfullyGetWebhookDetail         = "api.msg.success.webhook.successfully_get_webhook_detail"
+	WebhookSuccessfullyCreateWebhook            = "api.msg.success.webhook.successfully_create_webhook"
+	WebhookSuccessfullyUpdateWebhook            = "api.msg.success.webhook.successfully_update_webhook"
+	WebhookSuccessfullyEnableWebhook            = "api.msg.success.webhook.successfully_enable_webhook"
+	WebhookSuccessfullyDeleteWebhook            = "api.msg.success.webhook.successfully_delete_webhook"
+	WebhookSuccessfullyGetWebhookDeliveryList   = "api.msg.success.webhook.successfully_get_webhook_delivery_list"
+	WebhookSuccessfullyGetWebhookDeliveryDetail = "api.msg.success.webhook.successfully_get_webhook_delivery_detail"
+	WebhookSuccessfullyReplayWebhookDelivery    = "api.msg.success.webhook.successfully_replay_webhook_delivery"
+)
+
+// Success message for notification.
+const (
+	NotificationSuccessfullyGetNotificationList = "api.msg.success.notification.successfully_get_notification_list"
+	NotificationSuccessfullyMarkAsRead          = "api.msg.success.notification.successfully_mark_as_read"
+	NotificationSuccessfullyMarkAsUnread        = "api.msg.success.notification.successfully_mark_as_unread"
+	NotificationSuccessfullyMarkAllAsRead       = "api.msg.success.notification.successfully_mark_all_as_read"
+)
+
+// Success message for storage.
+const (
+	StorageSuccessfullyCreateDirectUpload    = "api.msg.success.storage.successfully_create_direct_upload"
+	StorageSuccessfullyConfirmDirectUpload   = "api.msg.success.storage.successfully_confirm_direct_upload"
+	StorageSuccessfullyCreateResumableUpload = "api.msg.success.storage.successfully_create_resumable_upload"
+	StorageSuccessfullyGetFileList           = "api.msg.success.storage.successfully_get_file_list"
+	StorageSuccessfullyGetFileDetail         = "api.msg.success.storage.successfully_get_file_detail"
+	StorageSuccessfullyDeleteFile            = "api.msg.success.storage.successfully_delete_file"
+)
@@ -0,0 +1,109 @@
+// Package metrics collects prometheus metrics of the app: http and gRPC requests, database queries, redis pool,
+// notifications and queue depth. Metrics are registered to Registry, which is exposed by Handler either on the
+// app router or on a separate listener.
+// Counters are always recorded, they are only exposed when metrics are enabled in config.
+package metrics
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry is the registry of every metric of the app.
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Number of http requests handled, by route template, method and status.",
+	}, []string{"route", "method", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Latency of http requests, by route template, method and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	grpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Number of gRPC requests handled, by method and status code.",
+	}, []string{"method", "code"})
+
+	grpcRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Latency of gRPC requests, by method and status code.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Latency of database queries, by operation and table.",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+
+	dbQueryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_query_errors_total",
+		Help: "Number of database queries failed, by operation and table.",
+	}, []string{"operation", "table"})
+
+	notificationsSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "notifications_sent_total",
+		Help: "Number of notifications sent, by channel and status (success or failure).",
+	}, []string{"channel", "status"})
+)
+
+func init() {
+	Registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		httpRequests,
+		httpRequestDuration,
+		grpcRequests,
+		grpcRequestDuration,
+		dbQueryDuration,
+		dbQueryErrors,
+		notificationsSent,
+	)
+}
+
+// MustRegister registers collectors of app services (e.g. redis pool, queue) to Registry.
+func MustRegister(collectors ...prometheus.Collector) {
+	Registry.MustRegister(collectors...)
+}
+
+// Handler returns the http handler exposing Registry.
+// The handler requires basic auth when username is not empty.
+func Handler(username string, password string) http.Handler {
+	handler := promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+	if username == "" {
+		return handler
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, p, ok := r.BasicAuth()
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(u), []byte(username)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(p), []byte(password)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="metrics"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// NewServer creates http server exposing Registry at /metrics on a listener separated from the app.
+func NewServer(addr string, username string, password string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler(username, password))
+
+	return &http.Server{Addr: addr, Handler: mux}
+}
+
+// ListenAndServe exposes Registry at /metrics on a listener separated from the app.
+func ListenAndServe(addr string, username string, password string) error {
+	return NewServer(addr, username, password).ListenAndServe()
+}
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const gormStartKey = "metrics:start"
+
+// GormPlugin is a gorm plugin recording the latency of every database query.
+type GormPlugin struct{}
+
+// NewGormPlugin creates new GormPlugin.
+func NewGormPlugin() *GormPlugin {
+	return &GormPlugin{}
+}
+
+// Name returns the name of the plugin.
+func (p *GormPlugin) Name() string {
+	return "metrics"
+}
+
+// Initialize registers callbacks timing each operation of db.
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	errs := []error{
+		callbacks.Create().Before("gorm:create").Register("metrics:before_create", startQuery),
+		callbacks.Create().After("gorm:create").Register("metrics:after_create", observeQuery("create")),
+		callbacks.Query().Before("gorm:query").Register("metrics:before_query", startQuery),
+		callbacks.Query().After("gorm:query").Register("metrics:after_query", observeQuery("query")),
+		callbacks.Update().Before("gorm:update").Register("metrics:before_update", startQuery),
+		callbacks.Update().After("gorm:update").Register("metrics:after_update", observeQuery("update")),
+		callbacks.Delete().Before("gorm:delete").Register("metrics:before_delete", startQuery),
+		callbacks.Delete().After("gorm:delete").Register("metrics:after_delete", observeQuery("delete")),
+		callbacks.Row().Before("gorm:row").Register("metrics:before_row", startQuery),
+		callbacks.Row().After("gorm:row").Register("metrics:after_row", observeQuery("row")),
+		callbacks.Raw().Before("gorm:raw").Register("metrics:before_raw", startQuery),
+		callbacks.Raw().After("gorm:raw").Register("metrics:after_raw", observeQuery("raw")),
+	}
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func startQuery(db *gorm.DB) {
+	db.InstanceSet(gormStartKey, time.Now())
+}
+
+func observeQuery(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(gormStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		dbQueryDuration.WithLabelValues(operation, table).Observe(time.Since(start).Seconds())
+		if db.Error != nil && db.Error != gorm.ErrRecordNotFound {
+			dbQueryErrors.WithLabelValues(operation, table).Inc()
+		}
+	}
+}
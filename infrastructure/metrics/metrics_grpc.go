@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor returns the gRPC interceptor recording unary requests handled by the server.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		code := status.Code(err).String()
+		grpcRequests.WithLabelValues(info.FullMethod, code).Inc()
+		grpcRequestDuration.WithLabelValues(info.FullMethod, code).Observe(time.Since(start).Seconds())
+
+		return resp, err
+	}
+}
@@ -0,0 +1,19 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+)
+
+// UnmatchedRoute is the route label of requests not matching any route, so unknown paths do not add new series.
+const UnmatchedRoute = "unmatched"
+
+// ObserveHTTPRequest records a handled http request. route is the route template (e.g. /api/v1/users/:uuid).
+func ObserveHTTPRequest(route string, method string, status int, duration time.Duration) {
+	if route == "" {
+		route = UnmatchedRoute
+	}
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(route, method, code).Inc()
+	httpRequestDuration.WithLabelValues(route, method, code).Observe(duration.Seconds())
+}
@@ -0,0 +1,10 @@
+package metrics
+
+// ObserveNotification records a notification sent to channel, err is the error of sending it.
+func ObserveNotification(channel string, err error) {
+	status := "success"
+	if err != nil {
+		status = "failure"
+	}
+	notificationsSent.WithLabelValues(channel, status).Inc()
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"context"
+	"go-rest-skeleton/infrastructure/queue"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// queueStatsTimeout bounds how long a scrape waits for stats of the queue.
+const queueStatsTimeout = 2 * time.Second
+
+// QueueStats is an interface. Needs to be implemented by a queue to be collected.
+type QueueStats interface {
+	Name() string
+	Stats(ctx context.Context) (*queue.Stats, error)
+}
+
+// QueueCollector collects the number of jobs on each state of a queue.
+type QueueCollector struct {
+	queue QueueStats
+	jobs  *prometheus.Desc
+}
+
+// NewQueueCollector creates new QueueCollector.
+func NewQueueCollector(q QueueStats) *QueueCollector {
+	return &QueueCollector{
+		queue: q,
+		jobs: prometheus.NewDesc("queue_jobs",
+			"Number of jobs in the queue, by state (ready, delayed, reserved or dead).",
+			[]string{"queue", "state"}, nil),
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (qc *QueueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- qc.jobs
+}
+
+// Collect implements the prometheus.Collector interface.
+// Failing to read stats of the queue is reported as an invalid metric, so the rest of the scrape still succeeds.
+func (qc *QueueCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), queueStatsTimeout)
+	defer cancel()
+
+	stats, err := qc.queue.Stats(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(qc.jobs, err)
+		return
+	}
+
+	name := qc.queue.Name()
+	ch <- prometheus.MustNewConstMetric(qc.jobs, prometheus.GaugeValue, float64(stats.Ready), name, "ready")
+	ch <- prometheus.MustNewConstMetric(qc.jobs, prometheus.GaugeValue, float64(stats.Delayed), name, "delayed")
+	ch <- prometheus.MustNewConstMetric(qc.jobs, prometheus.GaugeValue, float64(stats.Reserved), name, "reserved")
+	ch <- prometheus.MustNewConstMetric(qc.jobs, prometheus.GaugeValue, float64(stats.Dead), name, "dead")
+}
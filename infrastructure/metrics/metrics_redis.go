@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RedisCollector collects stats of the connection pool of a redis client.
+type RedisCollector struct {
+	client *redis.Client
+
+	hits       *prometheus.Desc
+	misses     *prometheus.Desc
+	timeouts   *prometheus.Desc
+	totalConns *prometheus.Desc
+	idleConns  *prometheus.Desc
+	staleConns *prometheus.Desc
+}
+
+// NewRedisCollector creates new RedisCollector.
+func NewRedisCollector(client *redis.Client) *RedisCollector {
+	return &RedisCollector{
+		client: client,
+		hits: prometheus.NewDesc("redis_pool_hits_total",
+			"Number of times a free connection was found in the pool.", nil, nil),
+		misses: prometheus.NewDesc("redis_pool_misses_total",
+			"Number of times a free connection was not found in the pool.", nil, nil),
+		timeouts: prometheus.NewDesc("redis_pool_timeouts_total",
+			"Number of times a wait for a connection timed out.", nil, nil),
+		totalConns: prometheus.NewDesc("redis_pool_connections",
+			"Number of connections in the pool.", nil, nil),
+		idleConns: prometheus.NewDesc("redis_pool_idle_connections",
+			"Number of idle connections in the pool.", nil, nil),
+		staleConns: prometheus.NewDesc("redis_pool_stale_connections_total",
+			"Number of stale connections removed from the pool.", nil, nil),
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (rc *RedisCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- rc.hits
+	ch <- rc.misses
+	ch <- rc.timeouts
+	ch <- rc.totalConns
+	ch <- rc.idleConns
+	ch <- rc.staleConns
+}
+
+// Collect implements the prometheus.Collector interface.
+func (rc *RedisCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := rc.client.PoolStats()
+	ch <- prometheus.MustNewConstMetric(rc.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(rc.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(rc.timeouts, prometheus.CounterValue, float64(stats.Timeouts))
+	ch <- prometheus.MustNewConstMetric(rc.totalConns, prometheus.GaugeValue, float64(stats.TotalConns))
+	ch <- prometheus.MustNewConstMetric(rc.idleConns, prometheus.GaugeValue, float64(stats.IdleConns))
+	ch <- prometheus.MustNewConstMetric(rc.staleConns, prometheus.CounterValue, float64(stats.StaleConns))
+}
@@ -0,0 +1,111 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"go-rest-skeleton/infrastructure/metrics"
+	"go-rest-skeleton/infrastructure/queue"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scrape returns metrics exposed by the handler.
+func scrape(t *testing.T) string {
+	w := httptest.NewRecorder()
+	metrics.Handler("", "").ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	body, err := ioutil.ReadAll(w.Body)
+	assert.NoError(t, err)
+
+	return string(body)
+}
+
+func TestHandler_BasicAuth(t *testing.T) {
+	handler := metrics.Handler("prometheus", "secret")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	r := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	r.SetBasicAuth("prometheus", "wrong")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	r = httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	r.SetBasicAuth("prometheus", "secret")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestObserveHTTPRequest(t *testing.T) {
+	metrics.ObserveHTTPRequest("/api/v1/test/:uuid", http.MethodGet, http.StatusOK, 10*time.Millisecond)
+	metrics.ObserveHTTPRequest("", http.MethodGet, http.StatusNotFound, time.Millisecond)
+
+	body := scrape(t)
+	assert.Contains(t, body, `http_requests_total{method="GET",route="/api/v1/test/:uuid",status="200"} 1`)
+	assert.Contains(t, body, `http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, body, `http_request_duration_seconds_count{method="GET",route="/api/v1/test/:uuid",status="200"} 1`)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := metrics.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Get"}
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	})
+	assert.Error(t, err)
+
+	assert.Contains(t, scrape(t), `grpc_server_handled_total{code="NotFound",method="/test.Service/Get"} 1`)
+}
+
+func TestObserveNotification(t *testing.T) {
+	metrics.ObserveNotification("test", nil)
+	metrics.ObserveNotification("test", errors.New("failed"))
+
+	body := scrape(t)
+	assert.Contains(t, body, `notifications_sent_total{channel="test",status="success"} 1`)
+	assert.Contains(t, body, `notifications_sent_total{channel="test",status="failure"} 1`)
+}
+
+// fakeQueue is a queue returning fixed stats.
+type fakeQueue struct {
+	stats *queue.Stats
+	err   error
+}
+
+func (q *fakeQueue) Name() string {
+	return "test"
+}
+
+func (q *fakeQueue) Stats(ctx context.Context) (*queue.Stats, error) {
+	return q.stats, q.err
+}
+
+func TestQueueCollector(t *testing.T) {
+	collector := metrics.NewQueueCollector(&fakeQueue{stats: &queue.Stats{Ready: 3, Delayed: 2, Reserved: 1}})
+	expected := `
+# HELP queue_jobs Number of jobs in the queue, by state (ready, delayed, reserved or dead).
+# TYPE queue_jobs gauge
+queue_jobs{queue="test",state="dead"} 0
+queue_jobs{queue="test",state="delayed"} 2
+queue_jobs{queue="test",state="ready"} 3
+queue_jobs{queue="test",state="reserved"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+
+	failing := metrics.NewQueueCollector(&fakeQueue{err: errors.New("connection refused")})
+	assert.Error(t, testutil.CollectAndCompare(failing, strings.NewReader("")))
+}
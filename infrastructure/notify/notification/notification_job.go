@@ -0,0 +1,66 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"go-rest-skeleton/infrastructure/notify"
+	"go-rest-skeleton/infrastructure/queue"
+)
+
+// JobSendNotification is the job type of sending a notification.
+const JobSendNotification = "notification:send"
+
+// Collections of channel.
+const (
+	ChannelEmail    = "email"
+	ChannelSMS      = "sms"
+	ChannelFirebase = "firebase"
+	ChannelInApp    = "in_app"
+)
+
+// SendNotificationPayload represent payload of JobSendNotification.
+type SendNotificationPayload struct {
+	Receiver     []string    `json:"receiver"`
+	Template     string      `json:"template"`
+	TemplateData interface{} `json:"template_data"`
+	Language     string      `json:"language"`
+	Channels     []string    `json:"channels"`
+}
+
+// NewSendNotificationJob creates a job to send notification by queue worker.
+func NewSendNotificationJob(payload SendNotificationPayload) (*queue.Job, error) {
+	return queue.NewJob(JobSendNotification, payload)
+}
+
+// SendNotificationHandler returns queue handler of JobSendNotification.
+// Each job gets a new notification from newNotification, so channels are not shared between jobs.
+func SendNotificationHandler(newNotification func() *notify.Notification) queue.Handler {
+	return func(ctx context.Context, job *queue.Job) error {
+		var payload SendNotificationPayload
+		if err := job.Bind(&payload); err != nil {
+			return err
+		}
+
+		n := newNotification().Notify(payload.Receiver, payload.Template, payload.TemplateData, payload.Language)
+		for _, channel := range payload.Channels {
+			switch channel {
+			case ChannelEmail:
+				n.ToEmail()
+			case ChannelSMS:
+				n.ToSMS()
+			case ChannelFirebase:
+				n.ToFireBase()
+			case ChannelInApp:
+				n.ToInApp()
+			default:
+				return fmt.Errorf("unknown notification channel %s", channel)
+			}
+		}
+
+		for _, err := range n.Send() {
+			return err
+		}
+
+		return nil
+	}
+}
@@ -42,3 +42,19 @@ func NewForgotPassword(
 func (n *ForgotPassword) Send() map[int]error {
 	return n.Notification.Notify([]string{n.Receiver}, n.Template, n.TemplateData, n.Language).ToEmail().Send()
 }
+
+// Dispatch will push the notification to the queue, so it is sent by queue worker.
+func (n *ForgotPassword) Dispatch(q application.QueueAppInterface) error {
+	job, err := NewSendNotificationJob(SendNotificationPayload{
+		Receiver:     []string{n.Receiver},
+		Template:     n.Template,
+		TemplateData: n.TemplateData,
+		Language:     n.Language,
+		Channels:     []string{ChannelEmail},
+	})
+	if err != nil {
+		return err
+	}
+
+	return q.Dispatch(job)
+}
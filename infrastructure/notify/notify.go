@@ -1,11 +1,13 @@
 // Package notify perform notification handling. This package uses to generate notification message and send it
-// to notification channel (email, sms, firebase, FCM). It also possible to send notification to multiple channels
+// to notification channel (email, sms, firebase, FCM, in-app). It also possible to send notification to multiple channels
 // at the same time.
 // Possible to implement multiple channel.
 // Generate message by template and support multilingual usage.
 // Design pattern: Template Method - Behavioral Design Pattern.
 package notify
 
+import "go-rest-skeleton/infrastructure/metrics"
+
 // NotificationInterface is an interface.
 type NotificationInterface interface {
 	SetReceiver(receiver []string)
@@ -22,6 +24,7 @@ type Notification struct {
 	EmailNotification    NotificationInterface
 	SMSNotification      NotificationInterface
 	FirebaseNotification NotificationInterface
+	InAppNotification    NotificationInterface
 	Notifications        []NotificationInterface
 }
 
@@ -60,6 +63,13 @@ func (ni *Notification) ToFireBase() *Notification {
 	return ni
 }
 
+// ToInApp will add in-app channel to be sent of notification.
+func (ni *Notification) ToInApp() *Notification {
+	ni.Notifications = append(ni.Notifications, ni.InAppNotification)
+
+	return ni
+}
+
 // Notify will initialize notification.
 func (ni *Notification) Notify(
 	receiver []string,
@@ -87,10 +97,28 @@ func (ni *Notification) Send() map[int]error {
 		n.SetTemplateData(ni.NotificationData.templateData)
 		n.GenerateMessage()
 
-		if err := n.SendNotification(); err != nil {
+		err := n.SendNotification()
+		metrics.ObserveNotification(channelName(n), err)
+		if err != nil {
 			errors[i] = err
 		}
 	}
 
 	return errors
 }
+
+// channelName returns the name of channel n, used to label metrics of sent notifications.
+func channelName(n NotificationInterface) string {
+	switch n.(type) {
+	case *EmailChannel:
+		return "email"
+	case *SMSChannel:
+		return "sms"
+	case *FirebaseChannel:
+		return "firebase"
+	case *InAppChannel:
+		return "in_app"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,187 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"go-rest-skeleton/domain/entity"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// DefaultBroadcastChannel is the prefix of redis channels used to fan out in-app notifications.
+const DefaultBroadcastChannel = "notifications"
+
+// subscriptionBuffer is the number of notifications kept for a slow client before new ones are dropped.
+// Dropped notifications are still available in the inbox.
+const subscriptionBuffer = 16
+
+// InAppBroadcaster publishes in-app notifications and lets connected clients subscribe to them.
+type InAppBroadcaster interface {
+	InAppPublisher
+	Subscribe(userUUID string) *Subscription
+}
+
+// Subscription receives in-app notifications of a user connected to this instance.
+type Subscription struct {
+	UserUUID string
+	C        <-chan *entity.UserNotification
+	ch       chan *entity.UserNotification
+	close    func()
+}
+
+// Close stops the subscription and releases its channel.
+func (s *Subscription) Close() {
+	s.close()
+}
+
+// LocalBroadcaster fans out in-app notifications to clients connected to this instance.
+type LocalBroadcaster struct {
+	mu            sync.RWMutex
+	subscriptions map[string]map[*Subscription]struct{}
+	closed        bool
+}
+
+// LocalBroadcaster implements the InAppBroadcaster interface.
+var _ InAppBroadcaster = &LocalBroadcaster{}
+
+// NewLocalBroadcaster will initialize broadcaster of a single instance.
+func NewLocalBroadcaster() *LocalBroadcaster {
+	return &LocalBroadcaster{subscriptions: make(map[string]map[*Subscription]struct{})}
+}
+
+// Subscribe registers a client of the user.
+func (b *LocalBroadcaster) Subscribe(userUUID string) *Subscription {
+	ch := make(chan *entity.UserNotification, subscriptionBuffer)
+	s := &Subscription{UserUUID: userUUID, C: ch, ch: ch}
+	var once sync.Once
+	s.close = func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscriptions[userUUID], s)
+			if len(b.subscriptions[userUUID]) == 0 {
+				delete(b.subscriptions, userUUID)
+			}
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		s.close()
+		return s
+	}
+	if b.subscriptions[userUUID] == nil {
+		b.subscriptions[userUUID] = make(map[*Subscription]struct{})
+	}
+	b.subscriptions[userUUID][s] = struct{}{}
+	b.mu.Unlock()
+
+	return s
+}
+
+// Close closes every subscription, so clients streaming notifications return, e.g. when the http server shuts down.
+// Subscriptions made afterwards are closed right away.
+func (b *LocalBroadcaster) Close() {
+	b.mu.Lock()
+	b.closed = true
+	var subscriptions []*Subscription
+	for _, userSubscriptions := range b.subscriptions {
+		for s := range userSubscriptions {
+			subscriptions = append(subscriptions, s)
+		}
+	}
+	b.mu.Unlock()
+
+	for _, s := range subscriptions {
+		s.Close()
+	}
+}
+
+// Publish delivers the notification to every client of its receiver without blocking.
+func (b *LocalBroadcaster) Publish(ctx context.Context, notification *entity.UserNotification) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for s := range b.subscriptions[notification.UserUUID] {
+		select {
+		case s.ch <- notification:
+		default:
+			log.Printf("in-app notification %s dropped for slow client of user %s", notification.UUID, s.UserUUID)
+		}
+	}
+
+	return nil
+}
+
+// RedisBroadcaster fans out in-app notifications across instances through redis pub/sub.
+// Each instance listens to every user channel once and delivers to its own clients by LocalBroadcaster.
+type RedisBroadcaster struct {
+	*LocalBroadcaster
+	client  *redis.Client
+	channel string
+}
+
+// RedisBroadcaster implements the InAppBroadcaster interface.
+var _ InAppBroadcaster = &RedisBroadcaster{}
+
+// NewRedisBroadcaster will initialize broadcaster using channel as prefix of redis channels.
+func NewRedisBroadcaster(client *redis.Client, channel string) *RedisBroadcaster {
+	if channel == "" {
+		channel = DefaultBroadcastChannel
+	}
+
+	return &RedisBroadcaster{
+		LocalBroadcaster: NewLocalBroadcaster(),
+		client:           client,
+		channel:          channel,
+	}
+}
+
+// Publish sends the notification to redis channel of its receiver, so every instance gets it.
+func (b *RedisBroadcaster) Publish(ctx context.Context, notification *entity.UserNotification) error {
+	message, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	return b.client.Publish(ctx, b.userChannel(notification.UserUUID), message).Err()
+}
+
+// Run listens to redis channels and delivers notifications to local clients until ctx is canceled.
+func (b *RedisBroadcaster) Run(ctx context.Context) error {
+	pubSub := b.client.PSubscribe(ctx, b.userChannel("*"))
+	defer pubSub.Close()
+
+	if _, err := pubSub.Receive(ctx); err != nil {
+		return err
+	}
+
+	messages := pubSub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+
+			var notification entity.UserNotification
+			if err := json.Unmarshal([]byte(message.Payload), &notification); err != nil {
+				log.Println(err)
+				continue
+			}
+			notification.UserUUID = strings.TrimPrefix(message.Channel, b.userChannel(""))
+			_ = b.LocalBroadcaster.Publish(ctx, &notification)
+		}
+	}
+}
+
+func (b *RedisBroadcaster) userChannel(userUUID string) string {
+	return b.channel + ":" + userUUID
+}
@@ -2,17 +2,17 @@ package notify
 
 import (
 	"bytes"
-	"context"
+	"errors"
 	"fmt"
 	"go-rest-skeleton/pkg/util"
 	"html/template"
 	"log"
 
-	"golang.org/x/sync/errgroup"
-
 	"gopkg.in/gomail.v2"
 )
 
+var errEmailClientNotConfigured = errors.New("email client is not configured")
+
 type EmailChannel struct {
 	EmailClient  *gomail.Dialer
 	EmailMessage *gomail.Message
@@ -102,29 +102,11 @@ func (e *EmailChannel) GenerateMessage() {
 }
 
 // SendNotification will send email notification.
+// It blocks until the message is delivered to SMTP server, so it should be called from a queue worker.
 func (e *EmailChannel) SendNotification() error {
-	g, _ := errgroup.WithContext(context.Background())
-
-	// Using goroutine to send email
-	chanSendEmail := make(chan bool)
-	g.Go(func() error {
-		errSend := e.EmailClient.DialAndSend(e.EmailMessage)
-		if errSend != nil {
-			return errSend
-		}
-
-		chanSendEmail <- true
-		return nil
-	})
-
-	go func() {
-		err := g.Wait()
-		if err != nil {
-			log.Println(err)
-			return
-		}
-		close(chanSendEmail)
-	}()
-
-	return nil
+	if e.EmailClient == nil {
+		return errEmailClientNotConfigured
+	}
+
+	return e.EmailClient.DialAndSend(e.EmailMessage)
 }
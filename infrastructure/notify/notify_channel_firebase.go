@@ -1,30 +1,120 @@
 package notify
 
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-rest-skeleton/pkg/translation"
+	"log"
+)
+
+var errPushProviderNotConfigured = errors.New("push provider is not configured")
+
+// PushProvider sends a push notification to a device.
+type PushProvider interface {
+	SendPush(ctx context.Context, message PushMessage) error
+}
+
+// PushMessage represent a push notification of a device.
+type PushMessage struct {
+	Token string            `json:"token"`
+	Title string            `json:"title"`
+	Body  string            `json:"body"`
+	Data  map[string]string `json:"data,omitempty"`
+}
+
+// FirebaseChannel sends notification as push notification, receivers of this channel are device registration tokens.
 type FirebaseChannel struct {
+	Provider     PushProvider
+	PushMessages []PushMessage
+	firebaseData
+}
+
+type firebaseData struct {
+	receiver     []string
+	template     string
+	templateData interface{}
+	language     string
 }
 
-func (f FirebaseChannel) SetReceiver(receiver []string) {
-	panic("implement me")
+// SetReceiver sets a value to the receiver.
+func (f *FirebaseChannel) SetReceiver(receiver []string) {
+	f.firebaseData.receiver = receiver
 }
 
 // SetLanguage sets a value to the language.
 func (f *FirebaseChannel) SetLanguage(language string) {
-	panic("implement me")
+	f.firebaseData.language = language
 }
 
-func (f FirebaseChannel) SetTemplate(template string) {
-	panic("implement me")
+// SetTemplate sets a value to the template.
+func (f *FirebaseChannel) SetTemplate(template string) {
+	f.firebaseData.template = template
 }
 
 // SetTemplateData sets a value to the templateData.
-func (f FirebaseChannel) SetTemplateData(data interface{}) {
-	panic("implement me")
+func (f *FirebaseChannel) SetTemplateData(data interface{}) {
+	f.firebaseData.templateData = data
 }
 
-func (f FirebaseChannel) GenerateMessage() {
-	panic("implement me")
+// data converts the template data into string values, the only type accepted as push data.
+func (f *FirebaseChannel) data() map[string]string {
+	if f.firebaseData.templateData == nil {
+		return nil
+	}
+
+	encoded, err := json.Marshal(f.firebaseData.templateData)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return nil
+	}
+
+	data := make(map[string]string, len(fields))
+	for key, value := range fields {
+		if s, ok := value.(string); ok {
+			data[key] = s
+			continue
+		}
+		v, _ := json.Marshal(value)
+		data[key] = string(v)
+	}
+
+	return data
+}
+
+// GenerateMessage translates title and body of the template, one message for each receiver.
+// Title and body are taken from translation keys notification.{template}.title and notification.{template}.body.
+func (f *FirebaseChannel) GenerateMessage() {
+	title := translation.Translate(
+		f.firebaseData.language, fmt.Sprintf("notification.%s.title", f.firebaseData.template), f.firebaseData.templateData)
+	body := translation.Translate(
+		f.firebaseData.language, fmt.Sprintf("notification.%s.body", f.firebaseData.template), f.firebaseData.templateData)
+	data := f.data()
+
+	f.PushMessages = make([]PushMessage, len(f.firebaseData.receiver))
+	for i, receiver := range f.firebaseData.receiver {
+		f.PushMessages[i] = PushMessage{Token: receiver, Title: title, Body: body, Data: data}
+	}
 }
 
-func (f FirebaseChannel) SendNotification() error {
-	panic("implement me")
+// SendNotification will send the messages through the provider.
+// It blocks until the provider accepts every message, so it should be called from a queue worker.
+func (f *FirebaseChannel) SendNotification() error {
+	if f.Provider == nil {
+		return errPushProviderNotConfigured
+	}
+
+	for _, message := range f.PushMessages {
+		if err := f.Provider.SendPush(context.Background(), message); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,108 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/pkg/translation"
+	"log"
+)
+
+var errInAppRepositoryNotConfigured = errors.New("in-app notification repository is not configured")
+
+// InAppPublisher publishes a stored in-app notification to the connected clients of its receiver.
+type InAppPublisher interface {
+	Publish(ctx context.Context, notification *entity.UserNotification) error
+}
+
+// InAppChannel stores notification into the inbox of each receiver and publishes it to connected clients.
+// Receivers of this channel are user UUIDs.
+type InAppChannel struct {
+	Repository    repository.UserNotificationRepository
+	Publisher     InAppPublisher
+	InAppMessages []*entity.UserNotification
+	inAppData
+}
+
+type inAppData struct {
+	receiver     []string
+	template     string
+	templateData interface{}
+	language     string
+}
+
+// SetReceiver sets a value to the receiver.
+func (i *InAppChannel) SetReceiver(receiver []string) {
+	i.inAppData.receiver = receiver
+}
+
+// SetLanguage sets a value to the language.
+func (i *InAppChannel) SetLanguage(language string) {
+	i.inAppData.language = language
+}
+
+// SetTemplate sets a value to the template.
+func (i *InAppChannel) SetTemplate(template string) {
+	i.inAppData.template = template
+}
+
+// SetTemplateData sets a value to the templateData.
+func (i *InAppChannel) SetTemplateData(data interface{}) {
+	i.inAppData.templateData = data
+}
+
+// GenerateMessage translates title and body of the template, one message for each receiver.
+// Title and body are taken from translation keys notification.{template}.title and notification.{template}.body.
+func (i *InAppChannel) GenerateMessage() {
+	title := translation.Translate(
+		i.inAppData.language, fmt.Sprintf("notification.%s.title", i.inAppData.template), i.inAppData.templateData)
+	body := translation.Translate(
+		i.inAppData.language, fmt.Sprintf("notification.%s.body", i.inAppData.template), i.inAppData.templateData)
+
+	var data string
+	if i.inAppData.templateData != nil {
+		encoded, err := json.Marshal(i.inAppData.templateData)
+		if err != nil {
+			log.Println(err)
+		}
+		data = string(encoded)
+	}
+
+	i.InAppMessages = make([]*entity.UserNotification, len(i.inAppData.receiver))
+	for index, receiver := range i.inAppData.receiver {
+		i.InAppMessages[index] = &entity.UserNotification{
+			UserUUID: receiver,
+			Type:     i.inAppData.template,
+			Title:    title,
+			Body:     body,
+			Data:     data,
+		}
+	}
+}
+
+// SendNotification will store the messages and publish them to connected clients.
+// Failing to publish does not fail the notification, the message is still available in the inbox.
+func (i *InAppChannel) SendNotification() error {
+	if i.Repository == nil {
+		return errInAppRepositoryNotConfigured
+	}
+
+	for _, message := range i.InAppMessages {
+		notification, err := i.Repository.SaveUserNotification(message)
+		if err != nil {
+			return err
+		}
+
+		if i.Publisher == nil {
+			continue
+		}
+		if err := i.Publisher.Publish(context.Background(), notification); err != nil {
+			log.Println(err)
+		}
+	}
+
+	return nil
+}
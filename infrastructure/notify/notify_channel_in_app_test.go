@@ -0,0 +1,85 @@
+package notify_test
+
+import (
+	"context"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/infrastructure/notify"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInAppChannel_SendNotification(t *testing.T) {
+	repo := &userNotificationRepo{}
+	broadcaster := notify.NewLocalBroadcaster()
+	receiver := uuid.New().String()
+	subscription := broadcaster.Subscribe(receiver)
+	defer subscription.Close()
+
+	n := &notify.Notification{InAppNotification: &notify.InAppChannel{Repository: repo, Publisher: broadcaster}}
+	data := struct{ Name string }{Name: "Jane"}
+	errs := n.Notify([]string{receiver}, "forgot_password", data, "en").ToInApp().Send()
+	assert.Empty(t, errs)
+
+	assert.Len(t, repo.notifications, 1)
+	assert.Equal(t, receiver, repo.notifications[0].UserUUID)
+	assert.Equal(t, "forgot_password", repo.notifications[0].Type)
+	assert.Equal(t, "Reset Your Password", repo.notifications[0].Title)
+	assert.Contains(t, repo.notifications[0].Body, "Jane")
+	assert.JSONEq(t, `{"Name":"Jane"}`, repo.notifications[0].Data)
+
+	select {
+	case received := <-subscription.C:
+		assert.Equal(t, repo.notifications[0].UUID, received.UUID)
+	case <-time.After(time.Second):
+		t.Fatal("notification was not published")
+	}
+}
+
+func TestInAppChannel_SendNotification_WithoutRepository(t *testing.T) {
+	n := &notify.Notification{InAppNotification: &notify.InAppChannel{}}
+	errs := n.Notify([]string{uuid.New().String()}, "forgot_password", nil, "en").ToInApp().Send()
+	assert.Len(t, errs, 1)
+}
+
+func TestLocalBroadcaster_Publish(t *testing.T) {
+	broadcaster := notify.NewLocalBroadcaster()
+	receiver := uuid.New().String()
+	first := broadcaster.Subscribe(receiver)
+	second := broadcaster.Subscribe(receiver)
+	other := broadcaster.Subscribe(uuid.New().String())
+	defer other.Close()
+
+	repo := &userNotificationRepo{}
+	n, _ := repo.SaveUserNotification(&entity.UserNotification{UserUUID: receiver})
+	assert.NoError(t, broadcaster.Publish(context.Background(), n))
+
+	assert.Equal(t, n, <-first.C)
+	assert.Equal(t, n, <-second.C)
+	assert.Len(t, other.C, 0)
+
+	first.Close()
+	first.Close()
+	_, open := <-first.C
+	assert.False(t, open)
+
+	assert.NoError(t, broadcaster.Publish(context.Background(), n))
+	assert.Equal(t, n, <-second.C)
+	second.Close()
+}
+
+func TestLocalBroadcaster_Close(t *testing.T) {
+	broadcaster := notify.NewLocalBroadcaster()
+	subscription := broadcaster.Subscribe(uuid.New().String())
+
+	broadcaster.Close()
+	_, open := <-subscription.C
+	assert.False(t, open)
+	subscription.Close()
+
+	late := broadcaster.Subscribe(uuid.New().String())
+	_, open = <-late.C
+	assert.False(t, open)
+}
@@ -1,32 +1,110 @@
 package notify
 
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go-rest-skeleton/pkg/util"
+	"strings"
+	"text/template"
+)
+
+var errSMSProviderNotConfigured = errors.New("sms provider is not configured")
+
+// SMSProvider sends a text message to a phone number.
+type SMSProvider interface {
+	SendSMS(ctx context.Context, message SMSMessage) error
+}
+
+// SMSMessage represent a text message of a receiver.
+type SMSMessage struct {
+	To   string `json:"to"`
+	Body string `json:"body"`
+}
+
+// SMSChannel sends notification as text message, receivers of this channel are phone numbers.
 type SMSChannel struct {
+	Provider    SMSProvider
+	SMSMessages []SMSMessage
+	smsData
+	err error
+}
+
+type smsData struct {
+	receiver     []string
+	template     string
+	templateData interface{}
+	language     string
 }
 
 // SetReceiver sets a value to the receiver.
-func (s SMSChannel) SetReceiver(receiver []string) {
-	panic("implement me")
+func (s *SMSChannel) SetReceiver(receiver []string) {
+	s.smsData.receiver = receiver
 }
 
 // SetLanguage sets a value to the language.
 func (s *SMSChannel) SetLanguage(language string) {
-	panic("implement me")
+	s.smsData.language = language
 }
 
 // SetTemplate sets a value to the template.
-func (s SMSChannel) SetTemplate(template string) {
-	panic("implement me")
+func (s *SMSChannel) SetTemplate(template string) {
+	s.smsData.template = template
 }
 
 // SetTemplateData sets a value to the templateData.
-func (s SMSChannel) SetTemplateData(data interface{}) {
-	panic("implement me")
+func (s *SMSChannel) SetTemplateData(data interface{}) {
+	s.smsData.templateData = data
 }
 
-func (s SMSChannel) GenerateMessage() {
-	panic("implement me")
+func (s *SMSChannel) body() (string, error) {
+	templateName := fmt.Sprintf("%s_%s", s.smsData.language, s.smsData.template)
+	templatePath := fmt.Sprintf("%s/infrastructure/notify/template/%s.txt", util.RootDir(), templateName)
+	t, errParsing := template.ParseFiles(templatePath)
+	if errParsing != nil {
+		return "", errParsing
+	}
+
+	buf := new(bytes.Buffer)
+	if errBind := t.Execute(buf, s.smsData.templateData); errBind != nil {
+		return "", errBind
+	}
+
+	return strings.TrimSpace(buf.String()), nil
 }
 
-func (s SMSChannel) SendNotification() error {
-	panic("implement me")
+// GenerateMessage renders template {language}_{template}.txt, one message for each receiver.
+// When rendering fails no message is generated, SendNotification returns the error instead.
+func (s *SMSChannel) GenerateMessage() {
+	var body string
+	body, s.err = s.body()
+	if s.err != nil {
+		s.SMSMessages = nil
+		return
+	}
+
+	s.SMSMessages = make([]SMSMessage, len(s.smsData.receiver))
+	for i, receiver := range s.smsData.receiver {
+		s.SMSMessages[i] = SMSMessage{To: receiver, Body: body}
+	}
+}
+
+// SendNotification will send the messages through the provider.
+// It blocks until the provider accepts every message, so it should be called from a queue worker.
+func (s *SMSChannel) SendNotification() error {
+	if s.Provider == nil {
+		return errSMSProviderNotConfigured
+	}
+	if s.err != nil {
+		return s.err
+	}
+
+	for _, message := range s.SMSMessages {
+		if err := s.Provider.SendSMS(context.Background(), message); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
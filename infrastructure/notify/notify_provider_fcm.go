@@ -0,0 +1,129 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+)
+
+const (
+	// DefaultFCMEndpoint is the base URL of FCM HTTP v1 API.
+	DefaultFCMEndpoint = "https://fcm.googleapis.com"
+
+	fcmScope    = "https://www.googleapis.com/auth/firebase.messaging"
+	fcmTokenURL = "https://oauth2.googleapis.com/token"
+)
+
+// FCMPushProvider sends push notification through FCM HTTP v1 API.
+type FCMPushProvider struct {
+	Endpoint    string
+	ProjectID   string
+	TokenSource oauth2.TokenSource
+	Client      *http.Client
+}
+
+// FCMPushProvider implements the PushProvider interface.
+var _ PushProvider = &FCMPushProvider{}
+
+type fcmServiceAccount struct {
+	ProjectID    string `json:"project_id"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+}
+
+type fcmRequest struct {
+	Message fcmMessage `json:"message"`
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// NewFCMPushProvider will initialize FCM provider authorized by the service account credentials json.
+// Project id of the credentials is used when projectID is empty.
+func NewFCMPushProvider(projectID string, credentials []byte) (*FCMPushProvider, error) {
+	var account fcmServiceAccount
+	if err := json.Unmarshal(credentials, &account); err != nil {
+		return nil, err
+	}
+	if account.ClientEmail == "" || account.PrivateKey == "" {
+		return nil, errors.New("fcm credentials must contain client_email and private_key")
+	}
+	if projectID == "" {
+		projectID = account.ProjectID
+	}
+	if account.TokenURI == "" {
+		account.TokenURI = fcmTokenURL
+	}
+
+	conf := &jwt.Config{
+		Email:        account.ClientEmail,
+		PrivateKey:   []byte(account.PrivateKey),
+		PrivateKeyID: account.PrivateKeyID,
+		Scopes:       []string{fcmScope},
+		TokenURL:     account.TokenURI,
+	}
+
+	return &FCMPushProvider{
+		Endpoint:    DefaultFCMEndpoint,
+		ProjectID:   projectID,
+		TokenSource: conf.TokenSource(context.Background()),
+		Client:      &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// SendPush sends the push notification, any non 2xx response is returned as error.
+func (p *FCMPushProvider) SendPush(ctx context.Context, message PushMessage) error {
+	token, err := p.TokenSource.Token()
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(fcmRequest{Message: fcmMessage{
+		Token:        message.Token,
+		Notification: fcmNotification{Title: message.Title, Body: message.Body},
+		Data:         message.Data,
+	}})
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/projects/%s/messages:send", p.Endpoint, p.ProjectID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	token.SetAuthHeader(req)
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("fcm responded with status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// LogProvider writes messages as json lines instead of sending them, uses by development and tests.
+type LogProvider struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+// LogProvider implements the SMSProvider and PushProvider interfaces.
+var (
+	_ SMSProvider  = &LogProvider{}
+	_ PushProvider = &LogProvider{}
+)
+
+type logEntry struct {
+	Channel string      `json:"channel"`
+	Message interface{} `json:"message"`
+	SentAt  time.Time   `json:"sent_at"`
+}
+
+// NewLogProvider will initialize provider writing messages to w.
+func NewLogProvider(w io.Writer) *LogProvider {
+	return &LogProvider{writer: w}
+}
+
+// NewLogFileProvider will initialize provider appending messages to the file of path.
+// Messages are written to stdout when path is empty.
+func NewLogFileProvider(path string) (*LogProvider, error) {
+	if path == "" {
+		return NewLogProvider(os.Stdout), nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLogProvider(file), nil
+}
+
+// SendSMS writes the text message.
+func (p *LogProvider) SendSMS(ctx context.Context, message SMSMessage) error {
+	return p.write("sms", message)
+}
+
+// SendPush writes the push notification.
+func (p *LogProvider) SendPush(ctx context.Context, message PushMessage) error {
+	return p.write("firebase", message)
+}
+
+func (p *LogProvider) write(channel string, message interface{}) error {
+	line, err := json.Marshal(logEntry{Channel: channel, Message: message, SentAt: time.Now()})
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, err = p.writer.Write(append(line, '\n'))
+	return err
+}
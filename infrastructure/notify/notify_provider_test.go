@@ -0,0 +1,134 @@
+package notify_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"go-rest-skeleton/infrastructure/notify"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+func TestSMSChannel_SendNotification(t *testing.T) {
+	buf := new(bytes.Buffer)
+	n := &notify.Notification{SMSNotification: &notify.SMSChannel{Provider: notify.NewLogProvider(buf)}}
+	data := struct {
+		Name string
+		URL  string
+	}{Name: "Jane", URL: "http://example.com/reset"}
+	errs := n.Notify([]string{"+6281234567890"}, "forgot_password", data, "id").ToSMS().Send()
+	assert.Empty(t, errs)
+
+	var entry struct {
+		Channel string            `json:"channel"`
+		Message notify.SMSMessage `json:"message"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "sms", entry.Channel)
+	assert.Equal(t, "+6281234567890", entry.Message.To)
+	assert.Equal(t, "Hai Jane, atur ulang kata sandi Anda di http://example.com/reset", entry.Message.Body)
+}
+
+func TestSMSChannel_SendNotification_MissingTemplate(t *testing.T) {
+	buf := new(bytes.Buffer)
+	n := &notify.Notification{SMSNotification: &notify.SMSChannel{Provider: notify.NewLogProvider(buf)}}
+	errs := n.Notify([]string{"+6281234567890"}, "missing_template", nil, "en").ToSMS().Send()
+	assert.Len(t, errs, 1)
+	assert.Empty(t, buf.String())
+}
+
+func TestFirebaseChannel_SendNotification(t *testing.T) {
+	buf := new(bytes.Buffer)
+	n := &notify.Notification{FirebaseNotification: &notify.FirebaseChannel{Provider: notify.NewLogProvider(buf)}}
+	data := struct {
+		Name string
+		URL  string
+	}{Name: "Jane", URL: "http://example.com/reset"}
+	errs := n.Notify([]string{"device-token"}, "forgot_password", data, "en").ToFireBase().Send()
+	assert.Empty(t, errs)
+
+	var entry struct {
+		Channel string             `json:"channel"`
+		Message notify.PushMessage `json:"message"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "firebase", entry.Channel)
+	assert.Equal(t, "device-token", entry.Message.Token)
+	assert.Equal(t, "Reset Your Password", entry.Message.Title)
+	assert.Contains(t, entry.Message.Body, "Jane")
+	assert.Equal(t, map[string]string{"Name": "Jane", "URL": "http://example.com/reset"}, entry.Message.Data)
+}
+
+func TestChannel_SendNotification_WithoutProvider(t *testing.T) {
+	n := &notify.Notification{
+		SMSNotification:      &notify.SMSChannel{},
+		FirebaseNotification: &notify.FirebaseChannel{},
+	}
+	errs := n.Notify([]string{"receiver"}, "forgot_password", nil, "en").ToSMS().ToFireBase().Send()
+	assert.Len(t, errs, 2)
+}
+
+func TestTwilioSMSProvider_SendSMS(t *testing.T) {
+	var form map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		assert.Equal(t, "AC123", user)
+		assert.Equal(t, "secret", pass)
+		assert.Equal(t, "/2010-04-01/Accounts/AC123/Messages.json", r.URL.Path)
+		_ = r.ParseForm()
+		form = map[string]string{"To": r.PostForm.Get("To"), "From": r.PostForm.Get("From"), "Body": r.PostForm.Get("Body")}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	provider := notify.NewTwilioSMSProvider("AC123", "secret", "+15005550006")
+	provider.BaseURL = server.URL
+	err := provider.SendSMS(context.Background(), notify.SMSMessage{To: "+6281234567890", Body: "Hello"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"To": "+6281234567890", "From": "+15005550006", "Body": "Hello"}, form)
+}
+
+func TestTwilioSMSProvider_SendSMS_Failed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"invalid number"}`))
+	}))
+	defer server.Close()
+
+	provider := notify.NewTwilioSMSProvider("AC123", "secret", "+15005550006")
+	provider.BaseURL = server.URL
+	err := provider.SendSMS(context.Background(), notify.SMSMessage{To: "invalid", Body: "Hello"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid number")
+}
+
+func TestFCMPushProvider_SendPush(t *testing.T) {
+	var request map[string]map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer access-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "/v1/projects/example/messages:send", r.URL.Path)
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		_, _ = w.Write([]byte(`{"name":"projects/example/messages/1"}`))
+	}))
+	defer server.Close()
+
+	provider := &notify.FCMPushProvider{
+		Endpoint:    server.URL,
+		ProjectID:   "example",
+		TokenSource: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "access-token", TokenType: "Bearer"}),
+		Client:      server.Client(),
+	}
+	err := provider.SendPush(context.Background(), notify.PushMessage{Token: "device-token", Title: "Title", Body: "Body"})
+	assert.NoError(t, err)
+	assert.Equal(t, "device-token", request["message"]["token"])
+	assert.Equal(t, map[string]interface{}{"title": "Title", "body": "Body"}, request["message"]["notification"])
+}
+
+func TestNewFCMPushProvider_InvalidCredentials(t *testing.T) {
+	_, err := notify.NewFCMPushProvider("example", []byte(`{"project_id":"example"}`))
+	assert.Error(t, err)
+}
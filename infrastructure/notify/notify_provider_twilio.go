@@ -0,0 +1,67 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultTwilioBaseURL is the base URL of Twilio REST API.
+const DefaultTwilioBaseURL = "https://api.twilio.com"
+
+// TwilioSMSProvider sends text message through Twilio compatible HTTP API.
+type TwilioSMSProvider struct {
+	BaseURL    string
+	AccountSID string
+	AuthToken  string
+	From       string
+	Client     *http.Client
+}
+
+// TwilioSMSProvider implements the SMSProvider interface.
+var _ SMSProvider = &TwilioSMSProvider{}
+
+// NewTwilioSMSProvider will initialize Twilio provider sending message from the given phone number.
+func NewTwilioSMSProvider(accountSID string, authToken string, from string) *TwilioSMSProvider {
+	return &TwilioSMSProvider{
+		BaseURL:    DefaultTwilioBaseURL,
+		AccountSID: accountSID,
+		AuthToken:  authToken,
+		From:       from,
+		Client:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SendSMS sends the text message, any non 2xx response is returned as error.
+func (p *TwilioSMSProvider) SendSMS(ctx context.Context, message SMSMessage) error {
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", p.BaseURL, url.PathEscape(p.AccountSID))
+	form := url.Values{}
+	form.Set("To", message.To)
+	form.Set("From", p.From)
+	form.Set("Body", message.Body)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(p.AccountSID, p.AuthToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("twilio responded with status %d: %s", resp.StatusCode, body)
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package notify_test
+
+import (
+	"errors"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// userNotificationRepo is an in-memory repository.UserNotificationRepository.
+type userNotificationRepo struct {
+	mu            sync.Mutex
+	notifications []*entity.UserNotification
+}
+
+func (r *userNotificationRepo) SaveUserNotification(n *entity.UserNotification) (*entity.UserNotification, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n.UUID = uuid.New().String()
+	r.notifications = append(r.notifications, n)
+	return n, nil
+}
+
+func (r *userNotificationRepo) GetUserNotifications(
+	userUUID string,
+	status string,
+	p *repository.Parameters) ([]entity.UserNotification, interface{}, error) {
+	return nil, nil, errors.New("not implemented")
+}
+
+func (r *userNotificationRepo) MarkUserNotificationRead(userUUID string, UUID string) (*entity.UserNotification, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *userNotificationRepo) MarkUserNotificationUnread(userUUID string, UUID string) (*entity.UserNotification, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *userNotificationRepo) MarkAllUserNotificationsRead(userUUID string) (int64, error) {
+	return 0, errors.New("not implemented")
+}
@@ -0,0 +1,130 @@
+// Package outbox relays domain events recorded in the outbox table to the configured sinks.
+// The application layer records events in the same database transaction as the change, so an event
+// exists if and only if the change was committed. The dispatcher publishes pending events to every
+// sink and marks them as published afterwards. Delivery is at-least-once: an event is published again
+// when any sink fails or the dispatcher stops before marking it, subscribers must discard duplicates
+// using the event idempotency key.
+// Design pattern: Transactional Outbox.
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"go-rest-skeleton/domain/event"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/queue"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultBatchSize is the number of events relayed on each poll.
+	DefaultBatchSize = 100
+
+	// DefaultPollInterval is how long the dispatcher waits before polling again when the outbox is empty.
+	DefaultPollInterval = time.Second
+)
+
+// Sink is an interface. Needs to be implemented by each destination of relayed events.
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, e *event.Event) error
+}
+
+// Dispatcher represent it self.
+type Dispatcher struct {
+	repo         repository.OutboxRepository
+	sinks        []Sink
+	batchSize    int
+	pollInterval time.Duration
+}
+
+// NewDispatcher creates new Dispatcher.
+func NewDispatcher(repo repository.OutboxRepository, batchSize int, sinks ...Sink) *Dispatcher {
+	if batchSize < 1 {
+		batchSize = DefaultBatchSize
+	}
+
+	return &Dispatcher{
+		repo:         repo,
+		sinks:        sinks,
+		batchSize:    batchSize,
+		pollInterval: DefaultPollInterval,
+	}
+}
+
+// AddSink registers another sink to the dispatcher.
+func (d *Dispatcher) AddSink(sink Sink) *Dispatcher {
+	d.sinks = append(d.sinks, sink)
+
+	return d
+}
+
+// Relay publishes a batch of pending events and returns the number of published events.
+// Failed events are postponed using exponential backoff.
+func (d *Dispatcher) Relay(ctx context.Context) (int, error) {
+	events, err := d.repo.GetPendingEvents(d.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for i := range events {
+		if ctx.Err() != nil {
+			break
+		}
+
+		outboxEvent := events[i]
+		errPublish := d.publish(ctx, outboxEvent.Event())
+		if errPublish != nil {
+			retryAt := time.Now().Add(queue.Backoff(outboxEvent.Attempts + 1))
+			if errFail := d.repo.MarkEventFailed(outboxEvent.UUID, errPublish, retryAt); errFail != nil {
+				return published, errFail
+			}
+			log.Warn().Err(errPublish).
+				Str("event", outboxEvent.UUID).
+				Str("type", outboxEvent.EventType).
+				Msg("failed to publish event")
+			continue
+		}
+
+		if errMark := d.repo.MarkEventPublished(outboxEvent.UUID); errMark != nil {
+			return published, errMark
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+// Run relays pending events until the given context is canceled.
+func (d *Dispatcher) Run(ctx context.Context) error {
+	for {
+		published, err := d.Relay(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Msg("failed to relay events")
+		}
+
+		// Keep relaying without waiting while the outbox has a backlog.
+		if published == d.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(d.pollInterval):
+		}
+	}
+}
+
+func (d *Dispatcher) publish(ctx context.Context, e *event.Event) error {
+	for _, sink := range d.sinks {
+		if err := sink.Publish(ctx, e); err != nil {
+			return fmt.Errorf("sink %s: %w", sink.Name(), err)
+		}
+	}
+
+	return nil
+}
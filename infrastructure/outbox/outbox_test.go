@@ -0,0 +1,122 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/event"
+	"go-rest-skeleton/infrastructure/outbox"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// outboxRepo is an in-memory implementation of repository.OutboxRepository.
+type outboxRepo struct {
+	events    []entity.OutboxEvent
+	published map[string]bool
+	failed    map[string]error
+}
+
+func newOutboxRepo() *outboxRepo {
+	return &outboxRepo{published: map[string]bool{}, failed: map[string]error{}}
+}
+
+func (r *outboxRepo) SaveEvent(e *event.Event) error {
+	r.events = append(r.events, *entity.NewOutboxEvent(e))
+	return nil
+}
+
+func (r *outboxRepo) GetPendingEvents(limit int) ([]entity.OutboxEvent, error) {
+	var events []entity.OutboxEvent
+	for _, e := range r.events {
+		if !r.published[e.UUID] && r.failed[e.UUID] == nil && len(events) < limit {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func (r *outboxRepo) MarkEventPublished(UUID string) error {
+	r.published[UUID] = true
+	return nil
+}
+
+func (r *outboxRepo) MarkEventFailed(UUID string, errPublish error, retryAt time.Time) error {
+	r.failed[UUID] = errPublish
+	return nil
+}
+
+func newEvent(t *testing.T) *event.Event {
+	e, err := event.New(event.UserCreated, event.AggregateUser, "user-uuid", event.UserPayload{Name: "Example"})
+	assert.NoError(t, err)
+	return e.WithIdempotencyKey(event.UserCreated + ":user-uuid")
+}
+
+func TestDispatcher_Relay(t *testing.T) {
+	repo := newOutboxRepo()
+	e := newEvent(t)
+	assert.NoError(t, repo.SaveEvent(e))
+
+	var received []*event.Event
+	bus := outbox.NewBus().Subscribe(event.UserCreated, func(ctx context.Context, e *event.Event) error {
+		received = append(received, e)
+		return nil
+	})
+
+	total, err := outbox.NewDispatcher(repo, 10, bus).Relay(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.True(t, repo.published[e.UUID])
+	if assert.Len(t, received, 1) {
+		var payload event.UserPayload
+		assert.NoError(t, received[0].Bind(&payload))
+		assert.Equal(t, "Example", payload.Name)
+		assert.Equal(t, e.IdempotencyKey, received[0].IdempotencyKey)
+	}
+}
+
+func TestDispatcher_Relay_FailedSinkPostponesEvent(t *testing.T) {
+	repo := newOutboxRepo()
+	e := newEvent(t)
+	assert.NoError(t, repo.SaveEvent(e))
+
+	bus := outbox.NewBus().Subscribe(outbox.AllEvents, func(ctx context.Context, e *event.Event) error {
+		return errors.New("failed")
+	})
+
+	total, err := outbox.NewDispatcher(repo, 10, bus).Relay(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.False(t, repo.published[e.UUID])
+	assert.Error(t, repo.failed[e.UUID])
+}
+
+func TestWebhookSink_Publish(t *testing.T) {
+	e := newEvent(t)
+	var idempotencyKey, eventType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idempotencyKey = r.Header.Get("Idempotency-Key")
+		eventType = r.Header.Get("X-Event-Type")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := outbox.NewWebhookSink(server.URL).Publish(context.Background(), e)
+	assert.NoError(t, err)
+	assert.Equal(t, e.IdempotencyKey, idempotencyKey)
+	assert.Equal(t, event.UserCreated, eventType)
+}
+
+func TestWebhookSink_Publish_Failed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := outbox.NewWebhookSink(server.URL).Publish(context.Background(), newEvent(t))
+	assert.Error(t, err)
+}
@@ -0,0 +1,57 @@
+package outbox
+
+import (
+	"context"
+	"go-rest-skeleton/domain/event"
+	"sync"
+)
+
+// AllEvents is the event type used to subscribe to every event.
+const AllEvents = "*"
+
+// Subscriber is a function that handles a relayed event in process.
+// Returning an error will relay the event again later.
+type Subscriber func(ctx context.Context, e *event.Event) error
+
+// Bus represent in-process sink, it calls the subscribers of the event type.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[string][]Subscriber
+}
+
+// Bus implements the Sink interface.
+var _ Sink = &Bus{}
+
+// NewBus creates new Bus.
+func NewBus() *Bus {
+	return &Bus{subscribers: make(map[string][]Subscriber)}
+}
+
+// Subscribe registers the subscriber of the given event type, use AllEvents to receive every event.
+func (b *Bus) Subscribe(eventType string, subscriber Subscriber) *Bus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[eventType] = append(b.subscribers[eventType], subscriber)
+
+	return b
+}
+
+// Name returns name of the sink.
+func (b *Bus) Name() string {
+	return "bus"
+}
+
+// Publish calls every subscriber of the event type and stops on the first error.
+func (b *Bus) Publish(ctx context.Context, e *event.Event) error {
+	b.mu.RLock()
+	subscribers := append(append([]Subscriber{}, b.subscribers[e.Type]...), b.subscribers[AllEvents]...)
+	b.mu.RUnlock()
+
+	for _, subscriber := range subscribers {
+		if err := subscriber(ctx, e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
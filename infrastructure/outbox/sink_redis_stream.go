@@ -0,0 +1,51 @@
+package outbox
+
+import (
+	"context"
+	"go-rest-skeleton/domain/event"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// DefaultStreamMaxLen is the approximate number of entries kept in the stream.
+const DefaultStreamMaxLen = 10000
+
+// RedisStreamSink represent sink appending events to a redis stream.
+type RedisStreamSink struct {
+	client *redis.Client
+	stream string
+}
+
+// RedisStreamSink implements the Sink interface.
+var _ Sink = &RedisStreamSink{}
+
+// NewRedisStreamSink creates new RedisStreamSink.
+func NewRedisStreamSink(client *redis.Client, stream string) *RedisStreamSink {
+	return &RedisStreamSink{
+		client: client,
+		stream: stream,
+	}
+}
+
+// Name returns name of the sink.
+func (s *RedisStreamSink) Name() string {
+	return "redis_stream"
+}
+
+// Publish appends the event to the stream.
+func (s *RedisStreamSink) Publish(ctx context.Context, e *event.Event) error {
+	return s.client.XAdd(ctx, &redis.XAddArgs{
+		Stream:       s.stream,
+		MaxLenApprox: DefaultStreamMaxLen,
+		Values: map[string]interface{}{
+			"uuid":            e.UUID,
+			"type":            e.Type,
+			"aggregate_type":  e.AggregateType,
+			"aggregate_uuid":  e.AggregateUUID,
+			"idempotency_key": e.IdempotencyKey,
+			"payload":         string(e.Payload),
+			"occurred_at":     e.OccurredAt.Format(time.RFC3339Nano),
+		},
+	}).Err()
+}
@@ -0,0 +1,64 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-rest-skeleton/domain/event"
+	"net/http"
+	"time"
+)
+
+// DefaultWebhookTimeout is the maximum duration of a webhook request.
+const DefaultWebhookTimeout = 10 * time.Second
+
+// WebhookSink represent sink posting events as json to a URL.
+type WebhookSink struct {
+	client *http.Client
+	url    string
+}
+
+// WebhookSink implements the Sink interface.
+var _ Sink = &WebhookSink{}
+
+// NewWebhookSink creates new WebhookSink.
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		client: &http.Client{Timeout: DefaultWebhookTimeout},
+		url:    url,
+	}
+}
+
+// Name returns name of the sink.
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+// Publish posts the event to the URL, any non 2xx response is considered as failure.
+func (s *WebhookSink) Publish(ctx context.Context, e *event.Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", e.IdempotencyKey)
+	req.Header.Set("X-Event-Type", e.Type)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
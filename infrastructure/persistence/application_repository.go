@@ -0,0 +1,113 @@
+package persistence
+
+import (
+	"errors"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/message/exception"
+
+	"gorm.io/gorm"
+)
+
+// ApplicationRepo is a struct to store db connection.
+type ApplicationRepo struct {
+	db *gorm.DB
+}
+
+// NewApplicationRepository will initialize application repository.
+func NewApplicationRepository(db *gorm.DB) *ApplicationRepo {
+	return &ApplicationRepo{db}
+}
+
+// ApplicationRepo implements the repository.ApplicationRepository interface.
+var _ repository.ApplicationRepository = &ApplicationRepo{}
+
+// SaveApplication will create a new application.
+func (r *ApplicationRepo) SaveApplication(
+	application *entity.Application) (*entity.Application, map[string]string, error) {
+	errDesc := map[string]string{}
+	err := r.db.Create(&application).Error
+	if err != nil {
+		return nil, errDesc, exception.ErrorTextAnErrorOccurred
+	}
+	return application, nil, nil
+}
+
+// UpdateApplication will update name of specified application.
+func (r *ApplicationRepo) UpdateApplication(
+	uuid string,
+	application *entity.Application) (*entity.Application, map[string]string, error) {
+	errDesc := map[string]string{}
+	applicationData := &entity.Application{Name: application.Name}
+
+	err := r.db.First(&application, "uuid = ?", uuid).Updates(applicationData).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errDesc["uuid"] = exception.ErrorTextApplicationInvalidUUID.Error()
+			return nil, errDesc, exception.ErrorTextApplicationNotFound
+		}
+		return nil, errDesc, exception.ErrorTextAnErrorOccurred
+	}
+	return application, nil, nil
+}
+
+// ActivateApplication will restore a deactivated application, an application is active while it is not deleted.
+func (r *ApplicationRepo) ActivateApplication(uuid string) error {
+	result := r.db.Unscoped().Model(&entity.Application{}).
+		Where("uuid = ?", uuid).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return exception.ErrorTextApplicationNotFound
+	}
+	return nil
+}
+
+// DeactivateApplication will soft delete an application, so it can be activated again.
+func (r *ApplicationRepo) DeactivateApplication(uuid string) error {
+	return r.DeleteApplication(uuid)
+}
+
+// DeleteApplication will delete application.
+func (r *ApplicationRepo) DeleteApplication(uuid string) error {
+	var application entity.Application
+	err := r.db.Where("uuid = ?", uuid).Take(&application).Delete(&application).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return exception.ErrorTextApplicationNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// GetApplication will return an application.
+func (r *ApplicationRepo) GetApplication(uuid string) (*entity.Application, error) {
+	var application entity.Application
+	err := r.db.Where("uuid = ?", uuid).Take(&application).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.ErrorTextApplicationNotFound
+		}
+		return nil, err
+	}
+	return &application, nil
+}
+
+// GetApplications will return application list.
+func (r *ApplicationRepo) GetApplications(p *repository.Parameters) ([]entity.Application, interface{}, error) {
+	var total int64
+	var applications []entity.Application
+	errTotal := r.db.Where(p.QueryKey, p.QueryValue...).Find(&applications).Count(&total).Error
+	errList := r.db.Where(p.QueryKey, p.QueryValue...).Limit(p.Limit).Offset(p.Offset).Find(&applications).Error
+	if errTotal != nil {
+		return nil, nil, errTotal
+	}
+	if errList != nil {
+		return nil, nil, errList
+	}
+	meta := repository.NewMeta(p, total)
+	return applications, meta, nil
+}
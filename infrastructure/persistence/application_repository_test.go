@@ -0,0 +1,52 @@
+package persistence_test
+
+import (
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/infrastructure/persistence"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetApplication_Success(t *testing.T) {
+	SkipThis(t)
+
+	conn, errConn := DBConn()
+	if errConn != nil {
+		t.Fatalf("want non error, got %#v", errConn)
+	}
+
+	repo := persistence.NewApplicationRepository(conn)
+	a, _, errSave := repo.SaveApplication(&entity.Application{Name: "Example"})
+	assert.NoError(t, errSave)
+
+	application, errGet := repo.GetApplication(a.UUID)
+	assert.NoError(t, errGet)
+	assert.Equal(t, "Example", application.Name)
+
+	_, errGet = repo.GetApplication(uuid.New().String())
+	assert.Equal(t, exception.ErrorTextApplicationNotFound, errGet)
+}
+
+func TestDeactivateApplication_Success(t *testing.T) {
+	SkipThis(t)
+
+	conn, errConn := DBConn()
+	if errConn != nil {
+		t.Fatalf("want non error, got %#v", errConn)
+	}
+
+	repo := persistence.NewApplicationRepository(conn)
+	a, _, errSave := repo.SaveApplication(&entity.Application{Name: "Example"})
+	assert.NoError(t, errSave)
+
+	assert.NoError(t, repo.DeactivateApplication(a.UUID))
+	_, errGet := repo.GetApplication(a.UUID)
+	assert.Equal(t, exception.ErrorTextApplicationNotFound, errGet)
+
+	assert.NoError(t, repo.ActivateApplication(a.UUID))
+	_, errGet = repo.GetApplication(a.UUID)
+	assert.NoError(t, errGet)
+}
@@ -51,6 +51,22 @@ func (r DocumentRepo) UpdateDocument(uuid string, document *entity.Document) (*e
 	return document, nil, nil
 }
 
+// UpdateDocumentWorkflow will update workflow of specified document.
+func (r DocumentRepo) UpdateDocumentWorkflow(uuid string, workflow string) (*entity.Document, map[string]string, error) {
+	errDesc := map[string]string{}
+	var document entity.Document
+	err := r.db.First(&document, "uuid = ?", uuid).Update("workflow", workflow).Error
+	if err != nil {
+		//If record not found
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errDesc["uuid"] = exception.ErrorTextDocumentInvalidUUID.Error()
+			return nil, errDesc, exception.ErrorTextDocumentNotFound
+		}
+		return nil, errDesc, exception.ErrorTextAnErrorOccurred
+	}
+	return &document, nil, nil
+}
+
 // DeleteDocument will delete document.
 func (r DocumentRepo) DeleteDocument(uuid string) error {
 	var document entity.Document
@@ -69,13 +85,12 @@ func (r DocumentRepo) GetDocument(uuid string) (*entity.Document, error) {
 	var document entity.Document
 	err := r.db.Where("uuid = ?", uuid).Take(&document).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.ErrorTextDocumentNotFound
+		}
 		return nil, err
 	}
 
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, exception.ErrorTextDocumentNotFound
-	}
-
 	return &document, nil
 }
 
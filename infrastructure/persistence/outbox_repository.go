@@ -0,0 +1,60 @@
+package persistence
+
+import (
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/event"
+	"go-rest-skeleton/domain/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OutboxRepo is a struct to store db connection.
+type OutboxRepo struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository will initialize outbox repository.
+func NewOutboxRepository(db *gorm.DB) *OutboxRepo {
+	return &OutboxRepo{db}
+}
+
+// OutboxRepo implements the repository.OutboxRepository interface.
+var _ repository.OutboxRepository = &OutboxRepo{}
+
+// SaveEvent will record a domain event to the outbox.
+func (r *OutboxRepo) SaveEvent(e *event.Event) error {
+	return r.db.Create(entity.NewOutboxEvent(e)).Error
+}
+
+// GetPendingEvents will return unpublished events which are due, oldest first.
+func (r *OutboxRepo) GetPendingEvents(limit int) ([]entity.OutboxEvent, error) {
+	var events []entity.OutboxEvent
+	err := r.db.
+		Where("published_at IS NULL AND available_at <= ?", time.Now()).
+		Order("occurred_at asc").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// MarkEventPublished will flag the event as published to every sink.
+func (r *OutboxRepo) MarkEventPublished(uuid string) error {
+	return r.db.Model(&entity.OutboxEvent{}).
+		Where("uuid = ?", uuid).
+		Update("published_at", time.Now()).Error
+}
+
+// MarkEventFailed will record the publishing error and postpone the event until retryAt.
+func (r *OutboxRepo) MarkEventFailed(uuid string, errPublish error, retryAt time.Time) error {
+	return r.db.Model(&entity.OutboxEvent{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]interface{}{
+			"attempts":     gorm.Expr("attempts + 1"),
+			"last_error":   errPublish.Error(),
+			"available_at": retryAt,
+		}).Error
+}
@@ -0,0 +1,96 @@
+package persistence_test
+
+import (
+	"errors"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/event"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/persistence"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveEvent_Success(t *testing.T) {
+	SkipThis(t)
+
+	conn, errConn := DBConn()
+	if errConn != nil {
+		t.Fatalf("want non error, got %#v", errConn)
+	}
+
+	aggregateUUID := uuid.New().String()
+	e, _ := event.New(event.UserCreated, event.AggregateUser, aggregateUUID, event.UserPayload{UUID: aggregateUUID})
+	repo := persistence.NewOutboxRepository(conn)
+	assert.NoError(t, repo.SaveEvent(e))
+
+	var outboxEvent entity.OutboxEvent
+	assert.NoError(t, conn.Where("uuid = ?", e.UUID).Take(&outboxEvent).Error)
+	assert.Equal(t, event.UserCreated, outboxEvent.EventType)
+	assert.Nil(t, outboxEvent.PublishedAt)
+}
+
+func TestMarkEventPublished_Success(t *testing.T) {
+	SkipThis(t)
+
+	conn, errConn := DBConn()
+	if errConn != nil {
+		t.Fatalf("want non error, got %#v", errConn)
+	}
+
+	aggregateUUID := uuid.New().String()
+	e, _ := event.New(event.RoleUpdated, event.AggregateRole, aggregateUUID, event.RolePayload{UUID: aggregateUUID})
+	repo := persistence.NewOutboxRepository(conn)
+	assert.NoError(t, repo.SaveEvent(e))
+	assert.NoError(t, repo.MarkEventPublished(e.UUID))
+
+	var outboxEvent entity.OutboxEvent
+	assert.NoError(t, conn.Where("uuid = ?", e.UUID).Take(&outboxEvent).Error)
+	assert.NotNil(t, outboxEvent.PublishedAt)
+}
+
+func TestMarkEventFailed_Success(t *testing.T) {
+	SkipThis(t)
+
+	conn, errConn := DBConn()
+	if errConn != nil {
+		t.Fatalf("want non error, got %#v", errConn)
+	}
+
+	aggregateUUID := uuid.New().String()
+	e, _ := event.New(event.RoleUpdated, event.AggregateRole, aggregateUUID, event.RolePayload{UUID: aggregateUUID})
+	repo := persistence.NewOutboxRepository(conn)
+	assert.NoError(t, repo.SaveEvent(e))
+	assert.NoError(t, repo.MarkEventFailed(e.UUID, errors.New("failed"), time.Now().Add(time.Hour)))
+
+	var outboxEvent entity.OutboxEvent
+	assert.NoError(t, conn.Where("uuid = ?", e.UUID).Take(&outboxEvent).Error)
+	assert.Equal(t, 1, outboxEvent.Attempts)
+	assert.Equal(t, "failed", outboxEvent.LastError)
+}
+
+func TestTransaction_RollbackDiscardsEvent(t *testing.T) {
+	SkipThis(t)
+
+	dbService, errConn := DBService()
+	if errConn != nil {
+		t.Fatalf("want non error, got %#v", errConn)
+	}
+
+	aggregateUUID := uuid.New().String()
+	e, _ := event.New(event.UserCreated, event.AggregateUser, aggregateUUID, event.UserPayload{UUID: aggregateUUID})
+	errRollback := errors.New("rollback")
+	err := dbService.Transaction(func(tx *repository.TxRepositories) error {
+		if errSave := tx.Outbox.SaveEvent(e); errSave != nil {
+			return errSave
+		}
+		return errRollback
+	})
+	assert.Equal(t, errRollback, err)
+
+	var total int64
+	dbService.DB.Model(&entity.OutboxEvent{}).Where("uuid = ?", e.UUID).Count(&total)
+	assert.EqualValues(t, 0, total)
+}
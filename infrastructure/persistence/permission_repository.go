@@ -1,8 +1,10 @@
 package persistence
 
 import (
+	"errors"
 	"go-rest-skeleton/domain/entity"
 	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/message/exception"
 
 	"gorm.io/gorm"
 )
@@ -36,8 +38,16 @@ func (p PermissionRepo) DeletePermission(permission *entity.Permission) error {
 }
 
 // GetPermission will return a permission.
-func (p PermissionRepo) GetPermission(s string) (*entity.Permission, error) {
-	panic("implement me")
+func (p PermissionRepo) GetPermission(uuid string) (*entity.Permission, error) {
+	var permission entity.Permission
+	err := p.db.Where("uuid = ?", uuid).Take(&permission).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.ErrorTextNotFound
+		}
+		return nil, err
+	}
+	return &permission, nil
 }
 
 // GetPermissions will return a permission list.
@@ -0,0 +1,67 @@
+package persistence
+
+import (
+	"context"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/cache"
+	"time"
+)
+
+// permissionsTag is the tag of every entry holding permissions, including permissions of roles.
+const permissionsTag = "permissions"
+
+// PermissionCacheRepo is a struct to read permissions through the cache, other methods are passed to the wrapped
+// repository.
+type PermissionCacheRepo struct {
+	repository.PermissionRepository
+	cache *cache.Cache
+	ttl   time.Duration
+}
+
+// NewPermissionCacheRepository will initialize permission repository reading permissions through the cache.
+func NewPermissionCacheRepository(
+	repo repository.PermissionRepository,
+	c *cache.Cache,
+	ttl time.Duration) *PermissionCacheRepo {
+	return &PermissionCacheRepo{PermissionRepository: repo, cache: c, ttl: ttl}
+}
+
+// PermissionCacheRepo implements the repository.PermissionRepository interface.
+var _ repository.PermissionRepository = &PermissionCacheRepo{}
+
+// SavePermission will create a new permission and invalidate entries holding permissions.
+func (p *PermissionCacheRepo) SavePermission(permission *entity.Permission) (*entity.Permission, map[string]string) {
+	savedPermission, errDesc := p.PermissionRepository.SavePermission(permission)
+	_ = p.cache.Invalidate(context.Background(), permissionsTag)
+	return savedPermission, errDesc
+}
+
+// UpdatePermission will update specified permission and invalidate entries holding permissions.
+func (p *PermissionCacheRepo) UpdatePermission(permission *entity.Permission) (*entity.Permission, map[string]string) {
+	updatedPermission, errDesc := p.PermissionRepository.UpdatePermission(permission)
+	_ = p.cache.Invalidate(context.Background(), permissionsTag)
+	return updatedPermission, errDesc
+}
+
+// DeletePermission will delete specified permission and invalidate entries holding permissions.
+func (p *PermissionCacheRepo) DeletePermission(permission *entity.Permission) error {
+	err := p.PermissionRepository.DeletePermission(permission)
+	if err == nil {
+		_ = p.cache.Invalidate(context.Background(), permissionsTag)
+	}
+	return err
+}
+
+// GetPermission will return a permission through the cache.
+func (p *PermissionCacheRepo) GetPermission(uuid string) (*entity.Permission, error) {
+	var permission entity.Permission
+	err := p.cache.Remember(context.Background(), "permission:"+uuid, p.ttl, &permission, func() (interface{}, []string, error) {
+		permission, err := p.PermissionRepository.GetPermission(uuid)
+		return permission, []string{permissionsTag}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &permission, nil
+}
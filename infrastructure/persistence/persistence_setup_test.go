@@ -124,13 +124,17 @@ func DBServiceSetup(config config.DBTestConfig) (*persistence.Repositories, erro
 	}
 
 	return &persistence.Repositories{
-		Permission:      persistence.NewPermissionRepository(db),
-		Role:            persistence.NewRoleRepository(db),
-		StorageCategory: persistence.NewStorageCategoryRepository(db),
-		StorageFile:     persistence.NewStorageFileRepository(db),
-		User:            persistence.NewUserRepository(db),
-		UserPreference:  persistence.NewUserPreferenceRepository(db),
-		DB:              db,
+		Outbox:              persistence.NewOutboxRepository(db),
+		Permission:          persistence.NewPermissionRepository(db),
+		Role:                persistence.NewRoleRepository(db),
+		StorageCategory:     persistence.NewStorageCategoryRepository(db),
+		StorageFile:         persistence.NewStorageFileRepository(db),
+		User:                persistence.NewUserRepository(db),
+		UserNotification:    persistence.NewUserNotificationRepository(db),
+		UserPreference:      persistence.NewUserPreferenceRepository(db),
+		WebhookDelivery:     persistence.NewWebhookDeliveryRepository(db),
+		WebhookSubscription: persistence.NewWebhookSubscriptionRepository(db),
+		DB:                  db,
 	}, nil
 }
 
@@ -206,6 +210,20 @@ func seedRoles(db *gorm.DB) ([]entity.Role, error) {
 	return roles, nil
 }
 
+func seedTour(db *gorm.DB) (*entity.Tour, error) {
+	tour := entity.Tour{
+		UUID: uuid.New().String(),
+		Name: "Example Tour",
+		Slug: "example-tour-" + uuid.New().String(),
+	}
+	err := db.Create(&tour).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &tour, nil
+}
+
 func seedUserPreference(db *gorm.DB) (*entity.UserPreference, error) {
 	var userPreference entity.UserPreference
 	userPreference.UUID = uuid.New().String()
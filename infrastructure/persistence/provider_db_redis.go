@@ -42,3 +42,8 @@ func NewRedisService(config config.RedisConfig) (*RedisService, error) {
 		Client: redisClient,
 	}, nil
 }
+
+// Close closes connections of the redis pool.
+func (s *RedisService) Close() error {
+	return s.Client.Close()
+}
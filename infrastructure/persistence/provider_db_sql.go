@@ -1,15 +1,19 @@
 package persistence
 
 import (
+	"context"
 	"fmt"
 	"go-rest-skeleton/config"
+	"go-rest-skeleton/domain/entity"
 	"go-rest-skeleton/domain/registry"
 	"go-rest-skeleton/domain/repository"
 	"go-rest-skeleton/domain/seeds"
+	"go-rest-skeleton/infrastructure/cache"
 	"log"
 	"os"
 	"time"
 
+	"github.com/go-redis/redis/v8"
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 
@@ -24,16 +28,24 @@ const (
 
 // Repositories represent it self.
 type Repositories struct {
-	Document           repository.DocumentRepository
-	Permission         repository.PermissionRepository
-	Role               repository.RoleRepository
-	StorageFile        repository.StorageFileRepository
-	StorageCategory    repository.StorageCategoryRepository
-	Tour               repository.TourRepository
-	User               repository.UserRepository
-	UserForgotPassword repository.UserForgotPasswordRepository
-	UserPreference     repository.UserPreferenceRepository
-	DB                 *gorm.DB
+	Application         repository.ApplicationRepository
+	Document            repository.DocumentRepository
+	Outbox              repository.OutboxRepository
+	Permission          repository.PermissionRepository
+	Role                repository.RoleRepository
+	StorageFile         repository.StorageFileRepository
+	StorageCategory     repository.StorageCategoryRepository
+	Tour                repository.TourRepository
+	User                repository.UserRepository
+	UserForgotPassword  repository.UserForgotPasswordRepository
+	UserNotification    repository.UserNotificationRepository
+	UserPreference      repository.UserPreferenceRepository
+	WebhookDelivery     repository.WebhookDeliveryRepository
+	WebhookSubscription repository.WebhookSubscriptionRepository
+	DB                  *gorm.DB
+
+	cache       *cache.Cache
+	cacheConfig config.CacheConfig
 }
 
 // NewDBConnection will initialize db connection.
@@ -103,18 +115,123 @@ func NewDBService(config config.DBConfig) (*Repositories, error) {
 		return nil, err
 	}
 
+	return newRepositories(db), nil
+}
+
+// newRepositories will return repositories running queries on db.
+func newRepositories(db *gorm.DB) *Repositories {
 	return &Repositories{
-		Document:           NewDocumentRepository(db),
-		Permission:         NewPermissionRepository(db),
-		Role:               NewRoleRepository(db),
-		StorageFile:        NewStorageFileRepository(db),
-		StorageCategory:    NewStorageCategoryRepository(db),
-		Tour:               NewTourRepository(db),
-		User:               NewUserRepository(db),
-		UserForgotPassword: NewUserForgotPasswordRepository(db),
-		UserPreference:     NewUserPreferenceRepository(db),
-		DB:                 db,
-	}, nil
+		Application:         NewApplicationRepository(db),
+		Document:            NewDocumentRepository(db),
+		Outbox:              NewOutboxRepository(db),
+		Permission:          NewPermissionRepository(db),
+		Role:                NewRoleRepository(db),
+		StorageFile:         NewStorageFileRepository(db),
+		StorageCategory:     NewStorageCategoryRepository(db),
+		Tour:                NewTourRepository(db),
+		User:                NewUserRepository(db),
+		UserForgotPassword:  NewUserForgotPasswordRepository(db),
+		UserNotification:    NewUserNotificationRepository(db),
+		UserPreference:      NewUserPreferenceRepository(db),
+		WebhookDelivery:     NewWebhookDeliveryRepository(db),
+		WebhookSubscription: NewWebhookSubscriptionRepository(db),
+		DB:                  db,
+	}
+}
+
+// Repositories implements the repository.UnitOfWork interface.
+var _ repository.UnitOfWork = &Repositories{}
+
+// Transaction will run fn within a database transaction, the given repositories share the transaction.
+// Entries of the cache are invalidated once the transaction is committed.
+func (s *Repositories) Transaction(fn func(tx *repository.TxRepositories) error) error {
+	var txCache *cache.Cache
+	if s.cache != nil {
+		txCache = s.cache.Transaction()
+	}
+
+	err := s.DB.Transaction(func(tx *gorm.DB) error {
+		return fn(&repository.TxRepositories{
+			Document: NewDocumentRepository(tx),
+			Outbox:   NewOutboxRepository(tx),
+			Role:     s.cachedRole(NewRoleRepository(tx), txCache),
+			User:     s.cachedUser(NewUserRepository(tx), txCache),
+		})
+	})
+	if err == nil && txCache != nil {
+		_ = txCache.Commit(context.Background())
+	}
+
+	return err
+}
+
+// WithContext returns repositories running queries with ctx, so queries are recorded within the span of ctx.
+func (s *Repositories) WithContext(ctx context.Context) *Repositories {
+	repositories := newRepositories(s.DB.WithContext(ctx))
+	if s.cache != nil {
+		repositories.enableCache(s.cache, s.cacheConfig)
+	}
+
+	return repositories
+}
+
+// EnableCache will read users, roles, permissions and storage categories through the cache stored in redis,
+// each of them only when it is enabled in config.
+// Repositories not enabled still invalidate entries on writes, such as a role change invalidating roles of users.
+func (s *Repositories) EnableCache(conf config.CacheConfig, client *redis.Client) {
+	if !conf.CacheUserEnabled && !conf.CacheRoleEnabled &&
+		!conf.CachePermissionEnabled && !conf.CacheStorageCategoryEnabled {
+		return
+	}
+
+	s.enableCache(cache.New(cache.NewRedisStore(client)), conf)
+}
+
+// enableCache will wrap repositories with c.
+func (s *Repositories) enableCache(c *cache.Cache, conf config.CacheConfig) {
+	s.cache = c
+	s.cacheConfig = conf
+
+	s.User = s.cachedUser(s.User, s.cache)
+	s.Role = s.cachedRole(s.Role, s.cache)
+	s.Permission = NewPermissionCacheRepository(
+		s.Permission, s.cache, cacheTTL(conf.CachePermissionEnabled, conf.CachePermissionTTL))
+	s.StorageCategory = NewStorageCategoryCacheRepository(
+		s.StorageCategory, s.cache, cacheTTL(conf.CacheStorageCategoryEnabled, conf.CacheStorageCategoryTTL))
+}
+
+// cachedUser will wrap the user repository with the cache, it is returned as is while the cache is not enabled.
+func (s *Repositories) cachedUser(repo repository.UserRepository, c *cache.Cache) repository.UserRepository {
+	if c == nil {
+		return repo
+	}
+	return NewUserCacheRepository(repo, c, cacheTTL(s.cacheConfig.CacheUserEnabled, s.cacheConfig.CacheUserTTL))
+}
+
+// cachedRole will wrap the role repository with the cache, it is returned as is while the cache is not enabled.
+func (s *Repositories) cachedRole(repo repository.RoleRepository, c *cache.Cache) repository.RoleRepository {
+	if c == nil {
+		return repo
+	}
+	return NewRoleCacheRepository(repo, c, cacheTTL(s.cacheConfig.CacheRoleEnabled, s.cacheConfig.CacheRoleTTL))
+}
+
+// cacheTTL returns TTL of entries of a repository, zero when the repository is not cached.
+func cacheTTL(enabled bool, seconds int) time.Duration {
+	if !enabled {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// Close closes connections of the database pool.
+func (s *Repositories) Close() error {
+	sqlDB, err := s.DB.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.Close()
 }
 
 // AutoMigrate will migrate all tables.
@@ -128,7 +245,40 @@ func (s *Repositories) AutoMigrate() error {
 		}
 	}
 
-	return err
+	return s.migrateSoftDelete()
+}
+
+// migrateSoftDelete will replace unique indexes created before soft deleted rows were excluded,
+// and mark rows deleted before then as not live.
+func (s *Repositories) migrateSoftDelete() error {
+	legacyIndexes := []struct {
+		model interface{}
+		name  string
+	}{
+		{&entity.User{}, "idx_users_email"},
+		{&entity.User{}, "idx_users_email_origin"},
+		{&entity.Role{}, "idx_roles_name"},
+		{&entity.Tour{}, "idx_tours_slug"},
+	}
+	for _, index := range legacyIndexes {
+		if s.DB.Migrator().HasIndex(index.model, index.name) {
+			err := s.DB.Migrator().DropIndex(index.model, index.name)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, model := range []interface{}{&entity.User{}, &entity.Role{}, &entity.Tour{}} {
+		err := s.DB.Unscoped().Model(model).
+			Where("deleted_at IS NOT NULL AND live IS NOT NULL").
+			UpdateColumn("live", nil).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // Seeds will run all seeders.
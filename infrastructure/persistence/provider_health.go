@@ -0,0 +1,35 @@
+package persistence
+
+import (
+	"fmt"
+	"go-rest-skeleton/config"
+	"go-rest-skeleton/infrastructure/health"
+	"time"
+)
+
+// HealthService represent it self.
+type HealthService struct {
+	Registry *health.Registry
+}
+
+// NewHealthService will construct health service checking database, redis, bucket of storage and SMTP server
+// when HealthCheckSMTP is enabled. Checks reuse connection pools of the given services.
+func NewHealthService(
+	conf *config.Config,
+	dbService *Repositories,
+	redisService *RedisService,
+	storageService *StorageService) *HealthService {
+	timeout := time.Duration(conf.HealthCheckTimeout) * time.Second
+	registry := health.NewRegistry(time.Duration(conf.HealthCheckCacheTTL) * time.Second)
+
+	registry.Register("db", health.DB(dbService.DB), timeout)
+	registry.Register("redis", health.Redis(redisService.Client), timeout)
+	if storageService.Bucket != nil {
+		registry.Register("storage", health.Bucket(storageService.Bucket), timeout)
+	}
+	if conf.HealthCheckSMTP && conf.SMTPHost != "" {
+		registry.Register("smtp", health.SMTP(fmt.Sprintf("%s:%d", conf.SMTPHost, conf.SMTPPort)), timeout)
+	}
+
+	return &HealthService{Registry: registry}
+}
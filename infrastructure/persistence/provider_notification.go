@@ -1,9 +1,14 @@
 package persistence
 
 import (
+	"fmt"
 	"go-rest-skeleton/config"
+	"go-rest-skeleton/domain/repository"
 	"go-rest-skeleton/infrastructure/notify"
 
+	"io/ioutil"
+
+	"github.com/go-redis/redis/v8"
 	"gopkg.in/gomail.v2"
 )
 
@@ -13,7 +18,11 @@ type SMTPClient struct {
 
 type NotificationService struct {
 	Notification *notify.Notification
+	Broadcaster  *notify.RedisBroadcaster
+	inApp        repository.UserNotificationRepository
 	smtpClient   *SMTPClient
+	smsProvider  notify.SMSProvider
+	pushProvider notify.PushProvider
 }
 
 func NewSMTPClient(config config.SMTPConfig) (*SMTPClient, error) {
@@ -26,23 +35,103 @@ func NewSMTPClient(config config.SMTPConfig) (*SMTPClient, error) {
 	return &SMTPClient{Client: dialer}, nil
 }
 
-func NewNotificationService(config *config.Config) (*NotificationService, error) {
+// NewSMSProvider will initialize provider of sms channel selected by SMSProvider config.
+func NewSMSProvider(config config.NotificationConfig) (notify.SMSProvider, error) {
+	switch config.SMSProvider {
+	case "log":
+		provider, err := notify.NewLogFileProvider(config.NotificationLogFile)
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	case "twilio":
+		if config.TwilioAccountSID == "" || config.TwilioAuthToken == "" || config.TwilioFrom == "" {
+			return nil, fmt.Errorf("twilio sms provider requires account sid, auth token and sender")
+		}
+		return notify.NewTwilioSMSProvider(config.TwilioAccountSID, config.TwilioAuthToken, config.TwilioFrom), nil
+	default:
+		return nil, fmt.Errorf("unknown sms provider %s", config.SMSProvider)
+	}
+}
+
+// NewPushProvider will initialize provider of firebase channel selected by PushProvider config.
+func NewPushProvider(config config.NotificationConfig) (notify.PushProvider, error) {
+	switch config.PushProvider {
+	case "log":
+		provider, err := notify.NewLogFileProvider(config.NotificationLogFile)
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	case "fcm":
+		credentials, err := ioutil.ReadFile(config.FCMCredentialsFile)
+		if err != nil {
+			return nil, err
+		}
+		provider, err := notify.NewFCMPushProvider(config.FCMProjectID, credentials)
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	default:
+		return nil, fmt.Errorf("unknown push provider %s", config.PushProvider)
+	}
+}
+
+// NewNotificationService will initialize notification channels.
+// A channel which failed to initialize returns error when sending, so other channels are still available.
+// The first error is returned along with the service.
+func NewNotificationService(
+	config *config.Config,
+	dbService *Repositories,
+	client *redis.Client) (*NotificationService, error) {
+	notificationService := &NotificationService{
+		Broadcaster: notify.NewRedisBroadcaster(client, config.NotificationBroadcastChannel),
+		inApp:       dbService.UserNotification,
+	}
+
+	var errs []error
 	smtpClient, errSMTP := NewSMTPClient(config.SMTPConfig)
-	if errSMTP != nil {
-		return &NotificationService{}, errSMTP
+	notificationService.smtpClient = smtpClient
+	errs = append(errs, errSMTP)
+
+	smsProvider, errSMS := NewSMSProvider(config.NotificationConfig)
+	notificationService.smsProvider = smsProvider
+	errs = append(errs, errSMS)
+
+	pushProvider, errPush := NewPushProvider(config.NotificationConfig)
+	notificationService.pushProvider = pushProvider
+	errs = append(errs, errPush)
+
+	notificationService.Notification = notificationService.NewNotification()
+
+	for _, err := range errs {
+		if err != nil {
+			return notificationService, err
+		}
 	}
 
-	emailChannel := &notify.EmailChannel{EmailClient: smtpClient.Client}
-	smsChannel := &notify.SMSChannel{}
-	firebaseChannel := &notify.FirebaseChannel{}
-	notification := notify.Notification{
+	return notificationService, nil
+}
+
+// NewNotification will construct a notification with its own channels.
+// Uses by queue workers, so concurrent jobs do not share the state of channels.
+func (s *NotificationService) NewNotification() *notify.Notification {
+	emailChannel := &notify.EmailChannel{}
+	if s.smtpClient != nil {
+		emailChannel.EmailClient = s.smtpClient.Client
+	}
+	smsChannel := &notify.SMSChannel{Provider: s.smsProvider}
+	firebaseChannel := &notify.FirebaseChannel{Provider: s.pushProvider}
+	inAppChannel := &notify.InAppChannel{Repository: s.inApp}
+	if s.Broadcaster != nil {
+		inAppChannel.Publisher = s.Broadcaster
+	}
+
+	return &notify.Notification{
 		EmailNotification:    emailChannel,
 		SMSNotification:      smsChannel,
 		FirebaseNotification: firebaseChannel,
+		InAppNotification:    inAppChannel,
 	}
-
-	return &NotificationService{
-		Notification: &notification,
-		smtpClient:   smtpClient,
-	}, nil
 }
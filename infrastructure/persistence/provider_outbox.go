@@ -0,0 +1,37 @@
+package persistence
+
+import (
+	"go-rest-skeleton/config"
+	"go-rest-skeleton/infrastructure/outbox"
+	"go-rest-skeleton/infrastructure/queue"
+	"go-rest-skeleton/infrastructure/webhook"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// OutboxService represent it self.
+type OutboxService struct {
+	Bus        *outbox.Bus
+	Dispatcher *outbox.Dispatcher
+}
+
+// NewOutboxService will construct outbox dispatcher relaying events to the in-process bus, the redis stream,
+// the webhook subscriptions of applications and the webhook when OUTBOX_WEBHOOK_URL is configured.
+func NewOutboxService(
+	config config.OutboxConfig,
+	dbService *Repositories,
+	client *redis.Client,
+	q queue.QueueInterface) *OutboxService {
+	bus := outbox.NewBus()
+	dispatcher := outbox.NewDispatcher(dbService.Outbox, config.OutboxBatchSize, bus).
+		AddSink(outbox.NewRedisStreamSink(client, config.OutboxStream)).
+		AddSink(webhook.NewSink(dbService.WebhookSubscription, q))
+	if config.OutboxWebhookURL != "" {
+		dispatcher.AddSink(outbox.NewWebhookSink(config.OutboxWebhookURL))
+	}
+
+	return &OutboxService{
+		Bus:        bus,
+		Dispatcher: dispatcher,
+	}
+}
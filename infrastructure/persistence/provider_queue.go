@@ -0,0 +1,22 @@
+package persistence
+
+import (
+	"go-rest-skeleton/config"
+	"go-rest-skeleton/infrastructure/queue"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// QueueService represent it self.
+type QueueService struct {
+	Queue *queue.RedisQueue
+}
+
+// NewQueueService will construct queue service on top of the given redis client.
+func NewQueueService(config config.QueueConfig, client *redis.Client) *QueueService {
+	return &QueueService{
+		Queue: queue.NewRedisQueue(client, config.QueueName).
+			WithVisibilityTimeout(time.Duration(config.QueueVisibilityTimeout) * time.Second),
+	}
+}
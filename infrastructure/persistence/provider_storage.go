@@ -1,9 +1,19 @@
 package persistence
 
 import (
+	"fmt"
 	"go-rest-skeleton/config"
+	"go-rest-skeleton/domain/entity"
 	"go-rest-skeleton/infrastructure/storage"
+	"go-rest-skeleton/pkg/webp"
+	"io/ioutil"
+	"log"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	awsCredentials "github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"gorm.io/gorm"
 
 	"github.com/minio/minio-go/v7"
@@ -13,6 +23,24 @@ import (
 // StorageService represent it self.
 type StorageService struct {
 	Storage storage.FileStorageInterface
+
+	// Bucket checks the bucket of the selected driver is reachable.
+	Bucket storage.BucketStorage
+
+	// Local is the local disk driver, only set when it is the selected driver.
+	Local *storage.LocalDriver
+
+	// Processors post-process uploaded files on queue worker.
+	Processors []storage.FileProcessor
+}
+
+// Close releases idle connections of the storage driver, drivers keeping no connection have nothing to close.
+func (s *StorageService) Close() error {
+	if closer, ok := s.Storage.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+
+	return nil
 }
 
 // NewMinioConnection will initialize connection to minio server.
@@ -28,16 +56,105 @@ func NewMinioConnection(config config.MinioConfig) (*minio.Client, error) {
 	return minioClient, nil
 }
 
-// NewStorageService will initialize connection to minio server and construct storage service.
-func NewStorageService(config config.MinioConfig, db *gorm.DB) (*StorageService, error) {
-	minioClient, err := NewMinioConnection(config)
+// NewS3Connection will initialize client of s3, endpoint is optional for s3 compatible services.
+func NewS3Connection(config config.StorageConfig) (*s3.S3, error) {
+	awsConfig := &aws.Config{
+		Region:      aws.String(config.S3Region),
+		Credentials: awsCredentials.NewStaticCredentials(config.S3AccessKey, config.S3SecretKey, ""),
+	}
+	if config.S3Endpoint != "" {
+		awsConfig.Endpoint = aws.String(config.S3Endpoint)
+		awsConfig.S3ForcePathStyle = aws.Bool(true)
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return s3.New(sess), nil
+}
+
+// NewStorageService will initialize storage driver selected by StorageDriver config and construct storage service.
+func NewStorageService(conf *config.Config, db *gorm.DB) (*StorageService, error) {
+	var driver storage.Driver
+	storageService := &StorageService{}
+
+	switch conf.StorageDriver {
+	case storage.DriverMinio:
+		minioClient, err := NewMinioConnection(conf.MinioConfig)
+		if err != nil {
+			return nil, err
+		}
+		driver = storage.NewMinioDriver(minioClient, conf.MinioConfig.Bucket, db)
+	case storage.DriverS3:
+		s3Client, err := NewS3Connection(conf.StorageConfig)
+		if err != nil {
+			return nil, err
+		}
+		driver = storage.NewS3Driver(s3Client, conf.S3Bucket, db)
+	case storage.DriverGCS:
+		gcsCredentials, err := ioutil.ReadFile(conf.GCSCredentialsFile)
+		if err != nil {
+			return nil, err
+		}
+		gcsDriver, err := storage.NewGCSDriver(gcsCredentials, conf.GCSBucket, db)
+		if err != nil {
+			return nil, err
+		}
+		driver = gcsDriver
+	case storage.DriverLocal:
+		signingKey := conf.LocalStorageSigningKey
+		if signingKey == "" {
+			signingKey = conf.AppPrivateKey
+		}
+		localDriver, err := storage.NewLocalDriver(conf.LocalStoragePath, conf.LocalStorageURL, signingKey, db)
+		if err != nil {
+			return nil, err
+		}
+		storageService.Local = localDriver
+		driver = localDriver
+	default:
+		return nil, fmt.Errorf("unknown storage driver %s", conf.StorageDriver)
+	}
+
+	driver.SetUploadPath(conf.StorageUploadPath)
+	driver.SetReferences(fileReferences()...)
+
+	withWebP := conf.StorageImageWebP
+	if withWebP && !webp.Available {
+		log.Println("STORAGE_IMAGE_WEBP is ignored, WebP variants need a build with cgo")
+		withWebP = false
+	}
+	imageVariants, err := storage.ParseImageVariants(conf.StorageImageVariants, withWebP)
 	if err != nil {
 		return nil, err
 	}
-	minioDriver := storage.NewMinioDriver(minioClient, config.Bucket, db)
-	fileStorageClient := storage.FileStorageClient{FileStorage: minioDriver}
+	scanner, err := storage.NewScanner(
+		conf.StorageScanner, conf.ClamAVAddress, time.Duration(conf.ClamAVTimeout)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	driver.SetScanner(scanner)
+
+	// Scanning goes first, the other processors skip files which are not clean.
+	if scanner != nil {
+		storageService.Processors = append(storageService.Processors, storage.NewScanProcessor(driver, db, scanner))
+	}
+	storageService.Processors = append(storageService.Processors,
+		storage.NewImageProcessor(driver, db, imageVariants, conf.StorageImageQuality))
+
+	fileStorageClient := storage.FileStorageClient{FileStorage: driver}
+	storageService.Storage = fileStorageClient.FileStorage
+	storageService.Bucket = driver
 
-	return &StorageService{
-		Storage: fileStorageClient.FileStorage,
-	}, nil
+	return storageService, nil
+}
+
+// fileReferences returns every column keeping UUID of storage file, add new column here to keep its files
+// from deletion and garbage collection.
+func fileReferences() []storage.FileReference {
+	return []storage.FileReference{
+		{Category: storage.CategoryAvatar, Table: (&entity.User{}).TableName(), Column: "avatar_uuid"},
+	}
 }
@@ -2,6 +2,7 @@ package persistence_test
 
 import (
 	"go-rest-skeleton/infrastructure/persistence"
+	"go-rest-skeleton/infrastructure/storage"
 	"testing"
 
 	"github.com/minio/minio-go/v7"
@@ -27,11 +28,12 @@ func TestNewMinioConnection_Failed(t *testing.T) {
 
 func TestNewStorageService_Success(t *testing.T) {
 	conf := InitConfig()
+	conf.StorageDriver = storage.DriverMinio
 	dbConn, errDBConn := DBConnSetup(conf.DBTestConfig)
 	if errDBConn != nil {
 		t.Fatalf("want non error, got %#v", errDBConn)
 	}
-	storageService, errStorageService := persistence.NewStorageService(conf.MinioConfig, dbConn)
+	storageService, errStorageService := persistence.NewStorageService(conf, dbConn)
 
 	var typeStorageService *persistence.StorageService
 	assert.NoError(t, errStorageService)
@@ -40,9 +42,29 @@ func TestNewStorageService_Success(t *testing.T) {
 
 func TestNewStorageService_Failed(t *testing.T) {
 	conf := InitConfig()
+	conf.StorageDriver = storage.DriverMinio
 	conf.MinioConfig.Endpoint = "invalid host"
 	dbConn, _ := DBConnSetup(conf.DBTestConfig)
-	_, errStorageService := persistence.NewStorageService(conf.MinioConfig, dbConn)
+	_, errStorageService := persistence.NewStorageService(conf, dbConn)
+
+	assert.Error(t, errStorageService)
+}
+
+func TestNewStorageService_Local(t *testing.T) {
+	conf := InitConfig()
+	conf.StorageDriver = storage.DriverLocal
+	conf.LocalStoragePath = t.TempDir()
+	storageService, errStorageService := persistence.NewStorageService(conf, nil)
+
+	assert.NoError(t, errStorageService)
+	assert.NotNil(t, storageService.Local)
+	assert.Equal(t, storageService.Local, storageService.Storage)
+}
+
+func TestNewStorageService_UnknownDriver(t *testing.T) {
+	conf := InitConfig()
+	conf.StorageDriver = "unknown"
+	_, errStorageService := persistence.NewStorageService(conf, nil)
 
 	assert.Error(t, errStorageService)
 }
@@ -0,0 +1,24 @@
+package persistence
+
+import (
+	"go-rest-skeleton/config"
+	"go-rest-skeleton/infrastructure/webhook"
+	"time"
+)
+
+// WebhookService represent it self.
+type WebhookService struct {
+	Deliverer *webhook.Deliverer
+}
+
+// NewWebhookService will construct webhook service delivering events to the webhook subscriptions.
+func NewWebhookService(config config.WebhookConfig, dbService *Repositories) *WebhookService {
+	return &WebhookService{
+		Deliverer: webhook.NewDeliverer(
+			dbService.WebhookSubscription,
+			dbService.WebhookDelivery,
+			time.Duration(config.WebhookTimeout)*time.Second,
+			config.WebhookMaxFailures,
+		),
+	}
+}
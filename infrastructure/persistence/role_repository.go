@@ -5,6 +5,8 @@ import (
 	"go-rest-skeleton/domain/entity"
 	"go-rest-skeleton/domain/repository"
 	"go-rest-skeleton/infrastructure/message/exception"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -33,12 +35,15 @@ func (r RoleRepo) SaveRole(role *entity.Role) (*entity.Role, map[string]string,
 }
 
 // UpdateRole will update specified role.
+// When the version of role is set, the role is only updated while it is still at that version.
 func (r RoleRepo) UpdateRole(uuid string, role *entity.Role) (*entity.Role, map[string]string, error) {
 	errDesc := map[string]string{}
-	roleData := &entity.Role{
-		Name: role.Name,
+	version := role.Version
+	roleData := map[string]interface{}{
+		"name":    role.Name,
+		"version": gorm.Expr("version + 1"),
 	}
-	err := r.db.First(&role, "uuid = ?", uuid).Updates(roleData).Error
+	err := r.db.First(&role, "uuid = ?", uuid).Error
 	if err != nil {
 		//If record not found
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -47,20 +52,99 @@ func (r RoleRepo) UpdateRole(uuid string, role *entity.Role) (*entity.Role, map[
 		}
 		return nil, errDesc, exception.ErrorTextAnErrorOccurred
 	}
+
+	db := r.db.Model(role)
+	if version > 0 {
+		db = db.Where("version = ?", version)
+	}
+	result := db.Updates(roleData)
+	if result.Error != nil {
+		return nil, errDesc, exception.ErrorTextAnErrorOccurred
+	}
+	//If the role has been modified by another request
+	if result.RowsAffected == 0 {
+		return nil, errDesc, exception.ErrorTextPreconditionFailed
+	}
+
+	err = r.db.First(&role, "uuid = ?", uuid).Error
+	if err != nil {
+		return nil, errDesc, exception.ErrorTextAnErrorOccurred
+	}
 	return role, nil, nil
 }
 
-// DeleteRole will delete role.
+// DeleteRole will soft delete role.
 func (r RoleRepo) DeleteRole(uuid string) error {
 	var role entity.Role
-	err := r.db.Where("uuid = ?", uuid).Take(&role).Delete(&role).Error
+	err := r.db.Where("uuid = ?", uuid).Take(&role).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return exception.ErrorTextRoleNotFound
+		}
+		return err
+	}
+	return softDelete(r.db, &role)
+}
+
+// RestoreRole will restore soft deleted role.
+func (r RoleRepo) RestoreRole(uuid string) (*entity.Role, error) {
+	var role entity.Role
+	err := r.db.Unscoped().Where("uuid = ? AND deleted_at IS NOT NULL", uuid).Take(&role).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.ErrorTextRoleNotFound
+		}
+		return nil, err
+	}
+
+	err = restore(r.db, &role, uuid)
+	if err != nil {
+		//If the name is taken by another role since it was deleted
+		if strings.Contains(err.Error(), "duplicate") || strings.Contains(err.Error(), "Duplicate") {
+			return nil, exception.ErrorTextRestoreConflict
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+// ForceDeleteRole will permanently delete role, deleted or not, along with its permissions and assignments to users.
+func (r RoleRepo) ForceDeleteRole(uuid string) error {
+	var role entity.Role
+	err := r.db.Unscoped().Where("uuid = ?", uuid).Take(&role).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return exception.ErrorTextUserNotFound
+			return exception.ErrorTextRoleNotFound
 		}
 		return err
 	}
-	return nil
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, dependent := range []interface{}{&entity.RolePermission{}, &entity.UserRole{}} {
+			err := tx.Unscoped().Where("role_uuid = ?", uuid).Delete(dependent).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Delete(&role).Error
+	})
+}
+
+// PurgeRoles will permanently delete roles soft deleted before the time, returning number of roles purged.
+func (r RoleRepo) PurgeRoles(before time.Time) (int64, error) {
+	var purged int64
+	uuids, err := trashedBefore(r.db, &entity.Role{}, before)
+	if err != nil {
+		return purged, err
+	}
+	for _, uuid := range uuids {
+		err = r.ForceDeleteRole(uuid)
+		if err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
 }
 
 // GetRole will return a role.
@@ -106,8 +190,8 @@ func (r *RoleRepo) GetRoleWithPermissions(uuid string) (*entity.Role, error) {
 func (r RoleRepo) GetRoles(p *repository.Parameters) ([]entity.Role, interface{}, error) {
 	var total int64
 	var roles []entity.Role
-	errTotal := r.db.Where(p.QueryKey, p.QueryValue...).Find(&roles).Count(&total).Error
-	errList := r.db.Where(p.QueryKey, p.QueryValue...).Limit(p.Limit).Offset(p.Offset).Find(&roles).Error
+	errTotal := r.db.Scopes(trashed(p)).Where(p.QueryKey, p.QueryValue...).Find(&roles).Count(&total).Error
+	errList := r.db.Scopes(trashed(p)).Where(p.QueryKey, p.QueryValue...).Limit(p.Limit).Offset(p.Offset).Find(&roles).Error
 	if errTotal != nil {
 		return nil, nil, errTotal
 	}
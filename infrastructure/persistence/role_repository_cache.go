@@ -0,0 +1,104 @@
+package persistence
+
+import (
+	"context"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/cache"
+	"time"
+)
+
+// RoleCacheRepo is a struct to read roles through the cache, other methods are passed to the wrapped repository.
+type RoleCacheRepo struct {
+	repository.RoleRepository
+	cache *cache.Cache
+	ttl   time.Duration
+}
+
+// NewRoleCacheRepository will initialize role repository reading roles through the cache.
+func NewRoleCacheRepository(repo repository.RoleRepository, c *cache.Cache, ttl time.Duration) *RoleCacheRepo {
+	return &RoleCacheRepo{RoleRepository: repo, cache: c, ttl: ttl}
+}
+
+// RoleCacheRepo implements the repository.RoleRepository interface.
+var _ repository.RoleRepository = &RoleCacheRepo{}
+
+// roleTag is the tag of every entry of the role, including roles of users.
+func roleTag(uuid string) string {
+	return "role:" + uuid
+}
+
+// UpdateRole will update specified role and invalidate its entries.
+func (r *RoleCacheRepo) UpdateRole(uuid string, role *entity.Role) (*entity.Role, map[string]string, error) {
+	updatedRole, errDesc, err := r.RoleRepository.UpdateRole(uuid, role)
+	if err == nil {
+		_ = r.cache.Invalidate(context.Background(), roleTag(uuid))
+	}
+	return updatedRole, errDesc, err
+}
+
+// DeleteRole will soft delete role and invalidate its entries.
+func (r *RoleCacheRepo) DeleteRole(uuid string) error {
+	err := r.RoleRepository.DeleteRole(uuid)
+	if err == nil {
+		_ = r.cache.Invalidate(context.Background(), roleTag(uuid))
+	}
+	return err
+}
+
+// RestoreRole will restore soft deleted role and invalidate its entries.
+func (r *RoleCacheRepo) RestoreRole(uuid string) (*entity.Role, error) {
+	role, err := r.RoleRepository.RestoreRole(uuid)
+	if err == nil {
+		_ = r.cache.Invalidate(context.Background(), roleTag(uuid))
+	}
+	return role, err
+}
+
+// ForceDeleteRole will permanently delete role and invalidate its entries.
+func (r *RoleCacheRepo) ForceDeleteRole(uuid string) error {
+	err := r.RoleRepository.ForceDeleteRole(uuid)
+	if err == nil {
+		_ = r.cache.Invalidate(context.Background(), roleTag(uuid))
+	}
+	return err
+}
+
+// GetRole will return a role through the cache.
+func (r *RoleCacheRepo) GetRole(uuid string) (*entity.Role, error) {
+	var role entity.Role
+	err := r.cache.Remember(context.Background(), "role:"+uuid, r.ttl, &role, func() (interface{}, []string, error) {
+		role, err := r.RoleRepository.GetRole(uuid)
+		return role, []string{roleTag(uuid)}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// GetRolePermissions will return role permissions through the cache.
+func (r *RoleCacheRepo) GetRolePermissions(uuid string) ([]entity.RolePermission, error) {
+	var rolePermissions []entity.RolePermission
+	err := r.cache.Remember(context.Background(), "role:"+uuid+":permissions", r.ttl, &rolePermissions, func() (interface{}, []string, error) {
+		rolePermissions, err := r.RoleRepository.GetRolePermissions(uuid)
+		return rolePermissions, []string{roleTag(uuid), permissionsTag}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rolePermissions, nil
+}
+
+// GetRoleWithPermissions will return role detail with permissions through the cache.
+func (r *RoleCacheRepo) GetRoleWithPermissions(uuid string) (*entity.Role, error) {
+	var role entity.Role
+	err := r.cache.Remember(context.Background(), "role:"+uuid+":with_permissions", r.ttl, &role, func() (interface{}, []string, error) {
+		role, err := r.RoleRepository.GetRoleWithPermissions(uuid)
+		return role, []string{roleTag(uuid), permissionsTag}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
@@ -3,6 +3,7 @@ package persistence_test
 import (
 	"go-rest-skeleton/domain/entity"
 	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/message/exception"
 	"go-rest-skeleton/infrastructure/persistence"
 	"testing"
 
@@ -29,6 +30,28 @@ func TestUpdateRole_Success(t *testing.T) {
 	assert.EqualValues(t, r.Name, "Updated "+role.Name)
 }
 
+func TestUpdateRole_StaleVersion(t *testing.T) {
+	SkipThis(t)
+
+	conn, errConn := DBConn()
+	if errConn != nil {
+		t.Fatalf("want non error, got %#v", errConn)
+	}
+
+	role, errSeed := seedRole(conn)
+	if errSeed != nil {
+		t.Fatalf("want non error, got %#v", errSeed)
+	}
+	repo := persistence.NewRoleRepository(conn)
+
+	r, _, errUpdate := repo.UpdateRole(role.UUID, &entity.Role{Name: "Updated " + role.Name, Version: role.Version})
+	assert.NoError(t, errUpdate)
+	assert.EqualValues(t, role.Version+1, r.Version)
+
+	_, _, errStale := repo.UpdateRole(role.UUID, &entity.Role{Name: "Stale " + role.Name, Version: role.Version})
+	assert.Equal(t, exception.ErrorTextPreconditionFailed, errStale)
+}
+
 func TestSaveRole_Success(t *testing.T) {
 	SkipThis(t)
 
@@ -67,6 +90,46 @@ func TestDeleteRole_Success(t *testing.T) {
 	assert.Nil(t, errGet)
 }
 
+func TestRestoreRole_Success(t *testing.T) {
+	SkipThis(t)
+
+	conn, errConn := DBConn()
+	if errConn != nil {
+		t.Fatalf("want non error, got %#v", errConn)
+	}
+	role, errSeed := seedRole(conn)
+	if errSeed != nil {
+		t.Fatalf("want non error, got %#v", errSeed)
+	}
+	repo := persistence.NewRoleRepository(conn)
+	errDelete := repo.DeleteRole(role.UUID)
+	if errDelete != nil {
+		t.Fatalf("want non error, got %#v", errDelete)
+	}
+
+	r, errRestore := repo.RestoreRole(role.UUID)
+	assert.NoError(t, errRestore)
+	assert.EqualValues(t, role.Name, r.Name)
+	assert.False(t, r.DeletedAt.Valid)
+	assert.True(t, *r.Live)
+}
+
+func TestForceDeleteRole_Success(t *testing.T) {
+	SkipThis(t)
+
+	conn, errConn := DBConn()
+	if errConn != nil {
+		t.Fatalf("want non error, got %#v", errConn)
+	}
+	role, errSeed := seedRole(conn)
+	if errSeed != nil {
+		t.Fatalf("want non error, got %#v", errSeed)
+	}
+	repo := persistence.NewRoleRepository(conn)
+	errDelete := repo.ForceDeleteRole(role.UUID)
+	assert.NoError(t, errDelete)
+}
+
 func TestGetRole_Success(t *testing.T) {
 	SkipThis(t)
 
@@ -1,8 +1,10 @@
 package persistence
 
 import (
+	"errors"
 	"go-rest-skeleton/domain/entity"
 	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/message/exception"
 
 	"gorm.io/gorm"
 )
@@ -24,3 +26,29 @@ var _ repository.StorageCategoryRepository = &StorageCategoryRepo{}
 func (r StorageCategoryRepo) SaveCategory(category *entity.StorageCategory) (*entity.StorageCategory, map[string]string, error) {
 	panic("implement me")
 }
+
+// GetCategory will return StorageCategory by its UUID.
+func (r StorageCategoryRepo) GetCategory(uuid string) (*entity.StorageCategory, error) {
+	var category entity.StorageCategory
+	err := r.db.Where("uuid = ?", uuid).Take(&category).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.ErrorTextStorageCategoryNotFound
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+// GetCategoryBySlug will return StorageCategory by its slug.
+func (r StorageCategoryRepo) GetCategoryBySlug(slug string) (*entity.StorageCategory, error) {
+	var category entity.StorageCategory
+	err := r.db.Where("slug = ?", slug).Take(&category).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.ErrorTextStorageCategoryNotFound
+		}
+		return nil, err
+	}
+	return &category, nil
+}
@@ -0,0 +1,57 @@
+package persistence
+
+import (
+	"context"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/cache"
+	"time"
+)
+
+// storageCategoriesTag is the tag of every entry of storage categories.
+// Categories are only written by seeders, their entries expire by TTL.
+const storageCategoriesTag = "storage_categories"
+
+// StorageCategoryCacheRepo is a struct to read storage categories through the cache.
+type StorageCategoryCacheRepo struct {
+	repository.StorageCategoryRepository
+	cache *cache.Cache
+	ttl   time.Duration
+}
+
+// NewStorageCategoryCacheRepository will initialize StorageCategory repository reading categories through the cache.
+func NewStorageCategoryCacheRepository(
+	repo repository.StorageCategoryRepository,
+	c *cache.Cache,
+	ttl time.Duration) *StorageCategoryCacheRepo {
+	return &StorageCategoryCacheRepo{StorageCategoryRepository: repo, cache: c, ttl: ttl}
+}
+
+// StorageCategoryCacheRepo implements the repository.StorageCategoryRepository interface.
+var _ repository.StorageCategoryRepository = &StorageCategoryCacheRepo{}
+
+// GetCategory will return StorageCategory by its UUID through the cache.
+func (r *StorageCategoryCacheRepo) GetCategory(uuid string) (*entity.StorageCategory, error) {
+	var category entity.StorageCategory
+	err := r.cache.Remember(context.Background(), "storage_category:"+uuid, r.ttl, &category, func() (interface{}, []string, error) {
+		category, err := r.StorageCategoryRepository.GetCategory(uuid)
+		return category, []string{storageCategoriesTag}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// GetCategoryBySlug will return StorageCategory by its slug through the cache.
+func (r *StorageCategoryCacheRepo) GetCategoryBySlug(slug string) (*entity.StorageCategory, error) {
+	var category entity.StorageCategory
+	err := r.cache.Remember(context.Background(), "storage_category:slug:"+slug, r.ttl, &category, func() (interface{}, []string, error) {
+		category, err := r.StorageCategoryRepository.GetCategoryBySlug(slug)
+		return category, []string{storageCategoriesTag}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
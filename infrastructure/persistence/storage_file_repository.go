@@ -37,7 +37,49 @@ func (r *StorageFileRepo) GetFile(uuid string) (*entity.StorageFile, error) {
 	err := r.db.Where("uuid = ?", uuid).Take(&file).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, exception.ErrorTextUserNotFound
+			return nil, exception.ErrorTextStorageFileNotFound
+		}
+		return nil, err
+	}
+	return &file, nil
+}
+
+// GetUserFiles will return uploaded files of the user, latest first.
+// Category filters the files by slug of their category, empty category returns every category.
+func (r *StorageFileRepo) GetUserFiles(
+	userUUID string,
+	category string,
+	p *repository.Parameters) ([]entity.StorageFile, interface{}, error) {
+	var total int64
+	var files []entity.StorageFile
+	query := func() *gorm.DB {
+		q := r.db.Where("user_uuid = ?", userUUID).Where(p.QueryKey, p.QueryValue...)
+		if category != "" {
+			q = q.Where("category_uuid IN (?)", r.db.Model(&entity.StorageCategory{}).
+				Select("uuid").
+				Where("slug = ?", category))
+		}
+		return q
+	}
+	errTotal := query().Model(&entity.StorageFile{}).Count(&total).Error
+	errList := query().Order("created_at desc").Limit(p.Limit).Offset(p.Offset).Find(&files).Error
+	if errTotal != nil {
+		return nil, nil, errTotal
+	}
+	if errList != nil {
+		return nil, nil, errList
+	}
+	meta := repository.NewMeta(p, total)
+	return files, meta, nil
+}
+
+// GetUserFile will return file detail, file of another user is not found.
+func (r *StorageFileRepo) GetUserFile(userUUID string, uuid string) (*entity.StorageFile, error) {
+	var file entity.StorageFile
+	err := r.db.Where("user_uuid = ? AND uuid = ?", userUUID, uuid).Take(&file).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.ErrorTextStorageFileNotFound
 		}
 		return nil, err
 	}
@@ -5,6 +5,8 @@ import (
 	"go-rest-skeleton/domain/entity"
 	"go-rest-skeleton/domain/repository"
 	"go-rest-skeleton/infrastructure/message/exception"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -32,23 +34,122 @@ func (r TourRepo) SaveTour(Tour *entity.Tour) (*entity.Tour, map[string]string,
 	return Tour, nil, nil
 }
 
+// UpdateTour will update specified Tour.
+// When the version of Tour is set, the Tour is only updated while it is still at that version.
 func (r TourRepo) UpdateTour(uuid string, tour *entity.Tour) (*entity.Tour, map[string]string, error) {
-	panic("implement me")
+	errDesc := map[string]string{}
+	version := tour.Version
+	tourData := map[string]interface{}{
+		"name":    tour.Name,
+		"slug":    tour.Slug,
+		"version": gorm.Expr("version + 1"),
+	}
+	if tour.ExpiredAt != nil {
+		tourData["expired_at"] = tour.ExpiredAt
+	}
+	err := r.db.First(&tour, "uuid = ?", uuid).Error
+	if err != nil {
+		//If record not found
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errDesc, exception.ErrorTextTourNotFound
+		}
+		return nil, errDesc, exception.ErrorTextAnErrorOccurred
+	}
+
+	db := r.db.Model(tour)
+	if version > 0 {
+		db = db.Where("version = ?", version)
+	}
+	result := db.Updates(tourData)
+	if result.Error != nil {
+		return nil, errDesc, exception.ErrorTextAnErrorOccurred
+	}
+	//If the Tour has been modified by another request
+	if result.RowsAffected == 0 {
+		return nil, errDesc, exception.ErrorTextPreconditionFailed
+	}
+
+	err = r.db.First(&tour, "uuid = ?", uuid).Error
+	if err != nil {
+		return nil, errDesc, exception.ErrorTextAnErrorOccurred
+	}
+	return tour, nil, nil
 }
 
+// DeleteTour will soft delete Tour.
 func (r TourRepo) DeleteTour(uuid string) error {
-	panic("implement me")
+	var tour entity.Tour
+	err := r.db.Where("uuid = ?", uuid).Take(&tour).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return exception.ErrorTextTourNotFound
+		}
+		return err
+	}
+	return softDelete(r.db, &tour)
+}
+
+// RestoreTour will restore soft deleted Tour.
+func (r TourRepo) RestoreTour(uuid string) (*entity.Tour, error) {
+	var tour entity.Tour
+	err := r.db.Unscoped().Where("uuid = ? AND deleted_at IS NOT NULL", uuid).Take(&tour).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.ErrorTextTourNotFound
+		}
+		return nil, err
+	}
+
+	err = restore(r.db, &tour, uuid)
+	if err != nil {
+		//If the slug is taken by another tour since it was deleted
+		if strings.Contains(err.Error(), "duplicate") || strings.Contains(err.Error(), "Duplicate") {
+			return nil, exception.ErrorTextRestoreConflict
+		}
+		return nil, err
+	}
+	return &tour, nil
+}
+
+// ForceDeleteTour will permanently delete Tour, deleted or not.
+func (r TourRepo) ForceDeleteTour(uuid string) error {
+	var tour entity.Tour
+	err := r.db.Unscoped().Where("uuid = ?", uuid).Take(&tour).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return exception.ErrorTextTourNotFound
+		}
+		return err
+	}
+	return r.db.Unscoped().Delete(&tour).Error
+}
+
+// PurgeTours will permanently delete tours soft deleted before the time, returning number of tours purged.
+func (r TourRepo) PurgeTours(before time.Time) (int64, error) {
+	var purged int64
+	uuids, err := trashedBefore(r.db, &entity.Tour{}, before)
+	if err != nil {
+		return purged, err
+	}
+	for _, uuid := range uuids {
+		err = r.ForceDeleteTour(uuid)
+		if err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
 }
 
 func (r TourRepo) GetTour(uuid string) (*entity.Tour, error) {
 	var tour entity.Tour
 	err := r.db.Where("uuid = ?", uuid).Take(&tour).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.ErrorTextTourNotFound
+		}
 		return nil, err
 	}
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return nil, exception.ErrorTextRoleNotFound
-	}
 	return &tour, nil
 }
 
@@ -70,8 +171,8 @@ func (r TourRepo) GetTourBySlug(t *entity.Tour) (*entity.Tour, map[string]string
 func (r TourRepo) GetTours(p *repository.Parameters) ([]entity.Tour, interface{}, error) {
 	var total int64
 	var tours []entity.Tour
-	errTotal := r.db.Where(p.QueryKey, p.QueryValue...).Find(&tours).Count(&total).Error
-	errList := r.db.Where(p.QueryKey, p.QueryValue...).Limit(p.Limit).Offset(p.Offset).Find(&tours).Error
+	errTotal := r.db.Scopes(trashed(p)).Where(p.QueryKey, p.QueryValue...).Find(&tours).Count(&total).Error
+	errList := r.db.Scopes(trashed(p)).Where(p.QueryKey, p.QueryValue...).Limit(p.Limit).Offset(p.Offset).Find(&tours).Error
 	if errTotal != nil {
 		return nil, nil, errTotal
 	}
@@ -1 +1,32 @@
 package persistence_test
+
+import (
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/infrastructure/persistence"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateTour_StaleVersion(t *testing.T) {
+	SkipThis(t)
+
+	conn, errConn := DBConn()
+	if errConn != nil {
+		t.Fatalf("want non error, got %#v", errConn)
+	}
+
+	tour, errSeed := seedTour(conn)
+	if errSeed != nil {
+		t.Fatalf("want non error, got %#v", errSeed)
+	}
+	repo := persistence.NewTourRepository(conn)
+
+	tr, _, errUpdate := repo.UpdateTour(tour.UUID, &entity.Tour{Name: "Updated", Slug: tour.Slug, Version: tour.Version})
+	assert.NoError(t, errUpdate)
+	assert.EqualValues(t, tour.Version+1, tr.Version)
+
+	_, _, errStale := repo.UpdateTour(tour.UUID, &entity.Tour{Name: "Stale", Slug: tour.Slug, Version: tour.Version})
+	assert.Equal(t, exception.ErrorTextPreconditionFailed, errStale)
+}
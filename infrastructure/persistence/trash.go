@@ -0,0 +1,50 @@
+package persistence
+
+import (
+	"go-rest-skeleton/domain/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// trashed is a scope to include soft deleted records, or list only them, as requested by parameters.
+func trashed(p *repository.Parameters) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		switch p.Trashed {
+		case repository.TrashedWith:
+			return db.Unscoped()
+		case repository.TrashedOnly:
+			return db.Unscoped().Where("deleted_at IS NOT NULL")
+		}
+		return db
+	}
+}
+
+// softDelete will mark the record as deleted and not live, so its unique values can be taken again.
+func softDelete(db *gorm.DB, model interface{}) error {
+	return db.Model(model).UpdateColumns(map[string]interface{}{
+		"deleted_at": db.NowFunc(),
+		"live":       nil,
+	}).Error
+}
+
+// restore will mark the soft deleted record as live again, then read it again so model holds the restored record.
+func restore(db *gorm.DB, model interface{}, uuid string) error {
+	err := db.Unscoped().Model(model).UpdateColumns(map[string]interface{}{
+		"deleted_at": nil,
+		"live":       true,
+	}).Error
+	if err != nil {
+		return err
+	}
+	return db.Where("uuid = ?", uuid).Take(model).Error
+}
+
+// trashedBefore will return uuid of records of model soft deleted before the time.
+func trashedBefore(db *gorm.DB, model interface{}, before time.Time) ([]string, error) {
+	var uuids []string
+	err := db.Unscoped().Model(model).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Pluck("uuid", &uuids).Error
+	return uuids, err
+}
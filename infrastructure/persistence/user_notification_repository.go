@@ -0,0 +1,122 @@
+package persistence
+
+import (
+	"errors"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UserNotificationRepo is a struct to store db connection.
+type UserNotificationRepo struct {
+	db *gorm.DB
+}
+
+// NewUserNotificationRepository will initialize user notification repository.
+func NewUserNotificationRepository(db *gorm.DB) *UserNotificationRepo {
+	return &UserNotificationRepo{db}
+}
+
+// UserNotificationRepo implements the repository.UserNotificationRepository interface.
+var _ repository.UserNotificationRepository = &UserNotificationRepo{}
+
+// SaveUserNotification will store a notification into the inbox of the user.
+func (r *UserNotificationRepo) SaveUserNotification(
+	notification *entity.UserNotification) (*entity.UserNotification, error) {
+	err := r.db.Create(&notification).Error
+	if err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
+
+// GetUserNotifications will return notification inbox of the user, latest first.
+// Status filters the inbox by read or unread notification, empty status returns both.
+func (r *UserNotificationRepo) GetUserNotifications(
+	userUUID string,
+	status string,
+	p *repository.Parameters) ([]entity.UserNotification, interface{}, error) {
+	var total int64
+	var notifications []entity.UserNotification
+	query := func() *gorm.DB {
+		q := r.db.Where("user_uuid = ?", userUUID).Where(p.QueryKey, p.QueryValue...)
+		switch status {
+		case entity.UserNotificationStatusRead:
+			q = q.Where("read_at IS NOT NULL")
+		case entity.UserNotificationStatusUnread:
+			q = q.Where("read_at IS NULL")
+		}
+		return q
+	}
+	errTotal := query().Model(&entity.UserNotification{}).Count(&total).Error
+	errList := query().Order("created_at desc").Limit(p.Limit).Offset(p.Offset).Find(&notifications).Error
+	if errTotal != nil {
+		return nil, nil, errTotal
+	}
+	if errList != nil {
+		return nil, nil, errList
+	}
+	meta := repository.NewMeta(p, total)
+	return notifications, meta, nil
+}
+
+// MarkUserNotificationRead will mark a notification of the user as read.
+func (r *UserNotificationRepo) MarkUserNotificationRead(userUUID string, uuid string) (*entity.UserNotification, error) {
+	notification, err := r.getUserNotification(userUUID, uuid)
+	if err != nil {
+		return nil, err
+	}
+	if notification.IsRead() {
+		return notification, nil
+	}
+
+	readAt := time.Now()
+	err = r.db.Model(notification).Update("read_at", readAt).Error
+	if err != nil {
+		return nil, err
+	}
+	notification.ReadAt = &readAt
+	return notification, nil
+}
+
+// MarkUserNotificationUnread will mark a notification of the user as unread.
+func (r *UserNotificationRepo) MarkUserNotificationUnread(userUUID string, uuid string) (*entity.UserNotification, error) {
+	notification, err := r.getUserNotification(userUUID, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Model(notification).Update("read_at", nil).Error
+	if err != nil {
+		return nil, err
+	}
+	notification.ReadAt = nil
+	return notification, nil
+}
+
+// MarkAllUserNotificationsRead will mark every unread notification of the user as read.
+// It returns number of notifications marked.
+func (r *UserNotificationRepo) MarkAllUserNotificationsRead(userUUID string) (int64, error) {
+	result := r.db.Model(&entity.UserNotification{}).
+		Where("user_uuid = ? AND read_at IS NULL", userUUID).
+		Update("read_at", time.Now())
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *UserNotificationRepo) getUserNotification(userUUID string, uuid string) (*entity.UserNotification, error) {
+	var notification entity.UserNotification
+	err := r.db.Where("user_uuid = ? AND uuid = ?", userUUID, uuid).Take(&notification).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.ErrorTextNotificationNotFound
+		}
+		return nil, err
+	}
+	return &notification, nil
+}
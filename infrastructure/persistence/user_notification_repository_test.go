@@ -0,0 +1,51 @@
+package persistence_test
+
+import (
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/infrastructure/persistence"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkUserNotificationRead_Success(t *testing.T) {
+	SkipThis(t)
+
+	conn, errConn := DBConn()
+	if errConn != nil {
+		t.Fatalf("want non error, got %#v", errConn)
+	}
+
+	repo := persistence.NewUserNotificationRepository(conn)
+	userUUID := uuid.New().String()
+	for i := 0; i < 2; i++ {
+		_, errSave := repo.SaveUserNotification(&entity.UserNotification{
+			UserUUID: userUUID,
+			Type:     "forgot_password",
+			Title:    "Reset Your Password",
+		})
+		assert.NoError(t, errSave)
+	}
+
+	p := &repository.Parameters{Limit: 5, Page: 1, PerPage: 5}
+	unread, _, errGet := repo.GetUserNotifications(userUUID, entity.UserNotificationStatusUnread, p)
+	assert.NoError(t, errGet)
+	assert.Len(t, unread, 2)
+
+	n, errRead := repo.MarkUserNotificationRead(userUUID, unread[0].UUID)
+	assert.NoError(t, errRead)
+	assert.True(t, n.IsRead())
+
+	read, _, _ := repo.GetUserNotifications(userUUID, entity.UserNotificationStatusRead, p)
+	assert.Len(t, read, 1)
+
+	total, errReadAll := repo.MarkAllUserNotificationsRead(userUUID)
+	assert.NoError(t, errReadAll)
+	assert.EqualValues(t, 1, total)
+
+	_, errOther := repo.MarkUserNotificationRead(uuid.New().String(), unread[0].UUID)
+	assert.Equal(t, exception.ErrorTextNotificationNotFound, errOther)
+}
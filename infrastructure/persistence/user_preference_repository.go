@@ -1,6 +1,7 @@
 package persistence
 
 import (
+	"encoding/json"
 	"errors"
 	"go-rest-skeleton/domain/entity"
 	"go-rest-skeleton/domain/repository"
@@ -81,3 +82,25 @@ func (up UserPreferenceRepo) ResetUserPreference(userUUID string) (*entity.UserP
 	}
 	return &userPreference, nil
 }
+
+// GetUserLanguage returns language preferred by the user without creating its preference,
+// it is empty when the user has no preference yet.
+func (up UserPreferenceRepo) GetUserLanguage(userUUID string) (string, error) {
+	var userPreference entity.UserPreference
+	err := up.db.Where("user_uuid = ?", userUUID).Take(&userPreference).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	var preferenceDetail entity.DetailUserPreference
+	if userPreference.Preference != nil {
+		if err := json.Unmarshal(*userPreference.Preference, &preferenceDetail); err != nil {
+			return "", err
+		}
+	}
+
+	return preferenceDetail.Language, nil
+}
@@ -75,3 +75,25 @@ func TestResetUserPreference_Success(t *testing.T) {
 	assert.NoError(t, errReset)
 	assert.NotEqualValues(t, u.Preference, r.Preference)
 }
+
+func TestGetUserLanguage_Success(t *testing.T) {
+	SkipThis(t)
+
+	conn, errConn := DBConn()
+	if errConn != nil {
+		t.Fatalf("want non error, got %#v", errConn)
+	}
+	userPreference, errSeed := seedUserPreference(conn)
+	if errSeed != nil {
+		t.Fatalf("want non error, got %#v", errSeed)
+	}
+	repo := persistence.NewUserPreferenceRepository(conn)
+	lang, errGet := repo.GetUserLanguage(userPreference.UserUUID)
+
+	assert.NoError(t, errGet)
+	assert.Equal(t, "en", lang)
+
+	lang, errGet = repo.GetUserLanguage("unknown-user")
+	assert.NoError(t, errGet)
+	assert.Empty(t, lang)
+}
@@ -7,6 +7,7 @@ import (
 	"go-rest-skeleton/infrastructure/message/exception"
 	"go-rest-skeleton/pkg/security"
 	"strings"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 
@@ -40,47 +41,139 @@ func (r *UserRepo) SaveUser(user *entity.User) (*entity.User, map[string]string,
 	return user, nil, nil
 }
 
-// UpdateUser will create a new user.
+// UpdateUser will update specified user.
+// When the version of user is set, the user is only updated while it is still at that version.
 func (r *UserRepo) UpdateUser(uuid string, user *entity.User) (*entity.User, map[string]string, error) {
 	errDesc := map[string]string{}
-	userData := &entity.User{
-		Name:  user.Name,
-		Email: user.Email,
-		Phone: user.Phone,
+	version := user.Version
+	userData := map[string]interface{}{
+		"name":    user.Name,
+		"email":   user.Email,
+		"version": gorm.Expr("version + 1"),
+	}
+
+	if user.Phone != "" {
+		userData["phone"] = user.Phone
 	}
 
 	if user.Password != "" {
-		userData.Password = user.Password
+		userData["password"] = user.Password
 	}
 
-	err := r.db.First(&user, "uuid = ?", uuid).Updates(userData).Error
+	err := r.db.First(&user, "uuid = ?", uuid).Error
 	if err != nil {
 		//If record not found
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			errDesc["uuid"] = exception.ErrorTextUserInvalidUUID.Error()
 			return nil, errDesc, exception.ErrorTextUserNotFound
 		}
+		return nil, errDesc, exception.ErrorTextAnErrorOccurred
+	}
+
+	db := r.db.Model(user)
+	if version > 0 {
+		db = db.Where("version = ?", version)
+	}
+	result := db.Updates(userData)
+	if result.Error != nil {
 		//If the email is already taken
-		if strings.Contains(err.Error(), "duplicate") || strings.Contains(err.Error(), "Duplicate") {
+		if strings.Contains(result.Error.Error(), "duplicate") || strings.Contains(result.Error.Error(), "Duplicate") {
 			errDesc["email"] = exception.ErrorTextUserEmailAlreadyTaken.Error()
 			return nil, errDesc, exception.ErrorTextUnprocessableEntity
 		}
 		return nil, errDesc, exception.ErrorTextAnErrorOccurred
 	}
+	//If the user has been modified by another request
+	if result.RowsAffected == 0 {
+		return nil, errDesc, exception.ErrorTextPreconditionFailed
+	}
+
+	err = r.db.First(&user, "uuid = ?", uuid).Error
+	if err != nil {
+		return nil, errDesc, exception.ErrorTextAnErrorOccurred
+	}
 	return user, nil, nil
 }
 
-// DeleteUser will return user detail.
+// DeleteUser will soft delete user.
 func (r *UserRepo) DeleteUser(uuid string) error {
 	var user entity.User
-	err := r.db.Where("uuid = ?", uuid).Take(&user).Delete(&user).Error
+	err := r.db.Where("uuid = ?", uuid).Take(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return exception.ErrorTextUserNotFound
+		}
+		return err
+	}
+	return softDelete(r.db, &user)
+}
+
+// RestoreUser will restore soft deleted user.
+func (r *UserRepo) RestoreUser(uuid string) (*entity.User, error) {
+	var user entity.User
+	err := r.db.Unscoped().Where("uuid = ? AND deleted_at IS NOT NULL", uuid).Take(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.ErrorTextUserNotFound
+		}
+		return nil, err
+	}
+
+	err = restore(r.db, &user, uuid)
+	if err != nil {
+		//If the email is taken by another user since it was deleted
+		if strings.Contains(err.Error(), "duplicate") || strings.Contains(err.Error(), "Duplicate") {
+			return nil, exception.ErrorTextRestoreConflict
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// ForceDeleteUser will permanently delete user, deleted or not, along with its roles, logins and preferences.
+func (r *UserRepo) ForceDeleteUser(uuid string) error {
+	var user entity.User
+	err := r.db.Unscoped().Where("uuid = ?", uuid).Take(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return exception.ErrorTextUserNotFound
 		}
 		return err
 	}
-	return nil
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		dependents := []interface{}{
+			&entity.UserRole{},
+			&entity.UserLogin{},
+			&entity.UserForgotPassword{},
+			&entity.UserPreference{},
+			&entity.UserNotification{},
+		}
+		for _, dependent := range dependents {
+			err := tx.Unscoped().Where("user_uuid = ?", uuid).Delete(dependent).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Delete(&user).Error
+	})
+}
+
+// PurgeUsers will permanently delete users soft deleted before the time, returning number of users purged.
+func (r *UserRepo) PurgeUsers(before time.Time) (int64, error) {
+	var purged int64
+	uuids, err := trashedBefore(r.db, &entity.User{}, before)
+	if err != nil {
+		return purged, err
+	}
+	for _, uuid := range uuids {
+		err = r.ForceDeleteUser(uuid)
+		if err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
 }
 
 // GetUser will return user detail.
@@ -126,8 +219,8 @@ func (r *UserRepo) GetUserWithRoles(uuid string) (*entity.User, error) {
 func (r *UserRepo) GetUsers(p *repository.Parameters) ([]*entity.User, *repository.Meta, error) {
 	var total int64
 	var users []*entity.User
-	errTotal := r.db.Where(p.QueryKey, p.QueryValue...).Find(&users).Count(&total).Error
-	errList := r.db.Where(p.QueryKey, p.QueryValue...).Order(p.Order).Limit(p.Limit).Offset(p.Offset).Find(&users).Error
+	errTotal := r.db.Scopes(trashed(p)).Where(p.QueryKey, p.QueryValue...).Find(&users).Count(&total).Error
+	errList := r.db.Scopes(trashed(p)).Where(p.QueryKey, p.QueryValue...).Order(p.Order).Limit(p.Limit).Offset(p.Offset).Find(&users).Error
 	if errTotal != nil {
 		return nil, nil, errTotal
 	}
@@ -195,8 +288,9 @@ func (r *UserRepo) GetUserByEmailAndPassword(u *entity.User) (*entity.User, map[
 // UpdateUserAvatar will create a new user.
 func (r *UserRepo) UpdateUserAvatar(uuid string, user *entity.User) (*entity.User, map[string]string, error) {
 	errDesc := map[string]string{}
-	userData := &entity.User{
-		AvatarUUID: user.AvatarUUID,
+	userData := map[string]interface{}{
+		"avatar_uuid": user.AvatarUUID,
+		"version":     gorm.Expr("version + 1"),
 	}
 	err := r.db.First(&user, "uuid = ?", uuid).Updates(userData).Error
 	if err != nil {
@@ -0,0 +1,135 @@
+package persistence
+
+import (
+	"context"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/cache"
+	"time"
+)
+
+// UserCacheRepo is a struct to read users through the cache, other methods are passed to the wrapped repository.
+type UserCacheRepo struct {
+	repository.UserRepository
+	cache *cache.Cache
+	ttl   time.Duration
+}
+
+// NewUserCacheRepository will initialize user repository reading users through the cache.
+func NewUserCacheRepository(repo repository.UserRepository, c *cache.Cache, ttl time.Duration) *UserCacheRepo {
+	return &UserCacheRepo{UserRepository: repo, cache: c, ttl: ttl}
+}
+
+// UserCacheRepo implements the repository.UserRepository interface.
+var _ repository.UserRepository = &UserCacheRepo{}
+
+// userTag is the tag of every entry of the user.
+func userTag(uuid string) string {
+	return "user:" + uuid
+}
+
+// userRolesTags returns tags of entries of the user holding its roles, any of the roles changing invalidates them.
+func userRolesTags(uuid string, userRoles []entity.UserRole) []string {
+	tags := []string{userTag(uuid)}
+	for _, userRole := range userRoles {
+		tags = append(tags, roleTag(userRole.RoleUUID))
+	}
+	return tags
+}
+
+// withoutPassword returns a copy of the user without the password hash, so the hash is never stored in the cache.
+func withoutPassword(user *entity.User) *entity.User {
+	cached := *user
+	cached.Password = ""
+	return &cached
+}
+
+// UpdateUser will update specified user and invalidate its entries.
+func (r *UserCacheRepo) UpdateUser(uuid string, user *entity.User) (*entity.User, map[string]string, error) {
+	updatedUser, errDesc, err := r.UserRepository.UpdateUser(uuid, user)
+	if err == nil {
+		_ = r.cache.Invalidate(context.Background(), userTag(uuid))
+	}
+	return updatedUser, errDesc, err
+}
+
+// DeleteUser will soft delete user and invalidate its entries.
+func (r *UserCacheRepo) DeleteUser(uuid string) error {
+	err := r.UserRepository.DeleteUser(uuid)
+	if err == nil {
+		_ = r.cache.Invalidate(context.Background(), userTag(uuid))
+	}
+	return err
+}
+
+// RestoreUser will restore soft deleted user and invalidate its entries.
+func (r *UserCacheRepo) RestoreUser(uuid string) (*entity.User, error) {
+	user, err := r.UserRepository.RestoreUser(uuid)
+	if err == nil {
+		_ = r.cache.Invalidate(context.Background(), userTag(uuid))
+	}
+	return user, err
+}
+
+// ForceDeleteUser will permanently delete user and invalidate its entries.
+func (r *UserCacheRepo) ForceDeleteUser(uuid string) error {
+	err := r.UserRepository.ForceDeleteUser(uuid)
+	if err == nil {
+		_ = r.cache.Invalidate(context.Background(), userTag(uuid))
+	}
+	return err
+}
+
+// UpdateUserAvatar will update avatar of user and invalidate its entries.
+func (r *UserCacheRepo) UpdateUserAvatar(uuid string, user *entity.User) (*entity.User, map[string]string, error) {
+	updatedUser, errDesc, err := r.UserRepository.UpdateUserAvatar(uuid, user)
+	if err == nil {
+		_ = r.cache.Invalidate(context.Background(), userTag(uuid))
+	}
+	return updatedUser, errDesc, err
+}
+
+// GetUser will return user detail through the cache.
+func (r *UserCacheRepo) GetUser(uuid string) (*entity.User, error) {
+	var user entity.User
+	err := r.cache.Remember(context.Background(), "user:"+uuid, r.ttl, &user, func() (interface{}, []string, error) {
+		user, err := r.UserRepository.GetUser(uuid)
+		if err != nil {
+			return nil, nil, err
+		}
+		return withoutPassword(user), []string{userTag(uuid)}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUserRoles will return user roles through the cache.
+func (r *UserCacheRepo) GetUserRoles(uuid string) ([]entity.UserRole, error) {
+	var userRoles []entity.UserRole
+	err := r.cache.Remember(context.Background(), "user:"+uuid+":roles", r.ttl, &userRoles, func() (interface{}, []string, error) {
+		userRoles, err := r.UserRepository.GetUserRoles(uuid)
+		return userRoles, userRolesTags(uuid, userRoles), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return userRoles, nil
+}
+
+// GetUserWithRoles will return user detail with roles through the cache.
+func (r *UserCacheRepo) GetUserWithRoles(uuid string) (*entity.User, error) {
+	var user entity.User
+	err := r.cache.Remember(context.Background(), "user:"+uuid+":with_roles", r.ttl, &user, func() (interface{}, []string, error) {
+		user, err := r.UserRepository.GetUserWithRoles(uuid)
+		if err != nil {
+			return nil, nil, err
+		}
+		return withoutPassword(user), userRolesTags(uuid, user.UserRoles), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,122 @@
+package persistence_test
+
+import (
+	"context"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/cache"
+	"go-rest-skeleton/infrastructure/persistence"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryCacheStore is a cache.Store keeping entries in memory.
+type memoryCacheStore struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+	tags    map[string][]string
+}
+
+func (s *memoryCacheStore) Get(ctx context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.entries[key], nil
+}
+
+func (s *memoryCacheStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration, tags ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = value
+	for _, tag := range tags {
+		s.tags[tag] = append(s.tags[tag], key)
+	}
+	return nil
+}
+
+func (s *memoryCacheStore) Invalidate(ctx context.Context, tags ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, tag := range tags {
+		for _, key := range s.tags[tag] {
+			delete(s.entries, key)
+		}
+		delete(s.tags, tag)
+	}
+	return nil
+}
+
+// countingUserRepo is a repository.UserRepository counting reads of user roles.
+type countingUserRepo struct {
+	repository.UserRepository
+	userRoles []entity.UserRole
+	reads     int
+}
+
+func (r *countingUserRepo) GetUserRoles(uuid string) ([]entity.UserRole, error) {
+	r.reads++
+	return r.userRoles, nil
+}
+
+func TestUserCacheRepo_GetUserRoles(t *testing.T) {
+	c := cache.New(&memoryCacheStore{entries: map[string][]byte{}, tags: map[string][]string{}})
+	userRepo := &countingUserRepo{userRoles: []entity.UserRole{{UserUUID: "user-a", RoleUUID: "role-a"}}}
+	repo := persistence.NewUserCacheRepository(userRepo, c, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		userRoles, err := repo.GetUserRoles("user-a")
+		assert.NoError(t, err)
+		assert.Equal(t, "role-a", userRoles[0].RoleUUID)
+	}
+	assert.Equal(t, 1, userRepo.reads)
+
+	// A role of the user changing invalidates roles of the user.
+	roleRepo := persistence.NewRoleCacheRepository(&deletingRoleRepo{}, c, 0)
+	assert.NoError(t, roleRepo.DeleteRole("role-a"))
+	_, err := repo.GetUserRoles("user-a")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, userRepo.reads)
+}
+
+// passwordUserRepo is a repository.UserRepository returning users holding a password hash.
+type passwordUserRepo struct {
+	repository.UserRepository
+}
+
+func (r *passwordUserRepo) GetUser(uuid string) (*entity.User, error) {
+	return &entity.User{UUID: uuid, Email: "user@example.com", Password: "hash"}, nil
+}
+
+func (r *passwordUserRepo) GetUserWithRoles(uuid string) (*entity.User, error) {
+	return r.GetUser(uuid)
+}
+
+func TestUserCacheRepo_GetUser_WithoutPassword(t *testing.T) {
+	store := &memoryCacheStore{entries: map[string][]byte{}, tags: map[string][]string{}}
+	repo := persistence.NewUserCacheRepository(&passwordUserRepo{}, cache.New(store), time.Minute)
+
+	user, err := repo.GetUser("user-a")
+	assert.NoError(t, err)
+	assert.Equal(t, "user@example.com", user.Email)
+	assert.Empty(t, user.Password)
+
+	user, err = repo.GetUserWithRoles("user-a")
+	assert.NoError(t, err)
+	assert.Empty(t, user.Password)
+
+	assert.Len(t, store.entries, 2)
+	for key, entry := range store.entries {
+		assert.NotContains(t, string(entry), "hash", key)
+	}
+}
+
+// deletingRoleRepo is a repository.RoleRepository deleting any role.
+type deletingRoleRepo struct {
+	repository.RoleRepository
+}
+
+func (r *deletingRoleRepo) DeleteRole(uuid string) error {
+	return nil
+}
@@ -3,6 +3,7 @@ package persistence_test
 import (
 	"go-rest-skeleton/domain/entity"
 	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/message/exception"
 	"go-rest-skeleton/infrastructure/persistence"
 	"testing"
 
@@ -62,6 +63,35 @@ func TestUpdateUser_Success(t *testing.T) {
 	assert.EqualValues(t, u.Email, "Updated "+userFaker.Email)
 }
 
+func TestUpdateUser_StaleVersion(t *testing.T) {
+	SkipThis(t)
+
+	conn, errConn := DBConn()
+	if errConn != nil {
+		t.Fatalf("want non error, got %#v", errConn)
+	}
+	user, userFaker, errSeed := seedUser(conn)
+	if errSeed != nil {
+		t.Fatalf("want non error, got %#v", errSeed)
+	}
+
+	repo := persistence.NewUserRepository(conn)
+	u, _, errUpdate := repo.UpdateUser(user.UUID, &entity.User{
+		Name:    "Updated " + userFaker.Name,
+		Email:   userFaker.Email,
+		Version: user.Version,
+	})
+	assert.NoError(t, errUpdate)
+	assert.EqualValues(t, user.Version+1, u.Version)
+
+	_, _, errStale := repo.UpdateUser(user.UUID, &entity.User{
+		Name:    "Stale " + userFaker.Name,
+		Email:   userFaker.Email,
+		Version: user.Version,
+	})
+	assert.Equal(t, exception.ErrorTextPreconditionFailed, errStale)
+}
+
 func TestDeleteUser_Success(t *testing.T) {
 	SkipThis(t)
 
@@ -79,6 +109,79 @@ func TestDeleteUser_Success(t *testing.T) {
 	assert.NoError(t, errGet)
 }
 
+func TestRestoreUser_Success(t *testing.T) {
+	SkipThis(t)
+
+	conn, errConn := DBConn()
+	if errConn != nil {
+		t.Fatalf("want non error, got %#v", errConn)
+	}
+	user, _, errSeed := seedUser(conn)
+	if errSeed != nil {
+		t.Fatalf("want non error, got %#v", errSeed)
+	}
+	repo := persistence.NewUserRepository(conn)
+	errDelete := repo.DeleteUser(user.UUID)
+	if errDelete != nil {
+		t.Fatalf("want non error, got %#v", errDelete)
+	}
+
+	u, errRestore := repo.RestoreUser(user.UUID)
+	assert.NoError(t, errRestore)
+	assert.EqualValues(t, user.UUID, u.UUID)
+	assert.False(t, u.DeletedAt.Valid)
+	assert.True(t, *u.Live)
+	_, errGet := repo.GetUser(user.UUID)
+	assert.NoError(t, errGet)
+}
+
+func TestRestoreUser_Conflict(t *testing.T) {
+	SkipThis(t)
+
+	conn, errConn := DBConn()
+	if errConn != nil {
+		t.Fatalf("want non error, got %#v", errConn)
+	}
+	user, userFaker, errSeed := seedUser(conn)
+	if errSeed != nil {
+		t.Fatalf("want non error, got %#v", errSeed)
+	}
+	repo := persistence.NewUserRepository(conn)
+	errDelete := repo.DeleteUser(user.UUID)
+	if errDelete != nil {
+		t.Fatalf("want non error, got %#v", errDelete)
+	}
+
+	_, _, errSave := repo.SaveUser(&entity.User{
+		Name:     userFaker.Name,
+		Email:    userFaker.Email,
+		Phone:    userFaker.Phone,
+		Password: userFaker.Password,
+	})
+	assert.NoError(t, errSave)
+	_, errRestore := repo.RestoreUser(user.UUID)
+	assert.Equal(t, exception.ErrorTextRestoreConflict, errRestore)
+}
+
+func TestForceDeleteUser_Success(t *testing.T) {
+	SkipThis(t)
+
+	conn, errConn := DBConn()
+	if errConn != nil {
+		t.Fatalf("want non error, got %#v", errConn)
+	}
+	user, _, errSeed := seedUser(conn)
+	if errSeed != nil {
+		t.Fatalf("want non error, got %#v", errSeed)
+	}
+	repo := persistence.NewUserRepository(conn)
+	errDelete := repo.ForceDeleteUser(user.UUID)
+	assert.NoError(t, errDelete)
+
+	_, errRestore := repo.RestoreUser(user.UUID)
+	assert.Equal(t, exception.ErrorTextUserNotFound, errRestore)
+}
+
 func TestGetUser_Success(t *testing.T) {
 	SkipThis(t)
 
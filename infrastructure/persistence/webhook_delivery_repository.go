@@ -0,0 +1,66 @@
+package persistence
+
+import (
+	"errors"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/message/exception"
+
+	"gorm.io/gorm"
+)
+
+// WebhookDeliveryRepo is a struct to store db connection.
+type WebhookDeliveryRepo struct {
+	db *gorm.DB
+}
+
+// NewWebhookDeliveryRepository will initialize webhook delivery repository.
+func NewWebhookDeliveryRepository(db *gorm.DB) *WebhookDeliveryRepo {
+	return &WebhookDeliveryRepo{db}
+}
+
+// WebhookDeliveryRepo implements the repository.WebhookDeliveryRepository interface.
+var _ repository.WebhookDeliveryRepository = &WebhookDeliveryRepo{}
+
+// SaveWebhookDelivery will record a webhook delivery.
+func (r *WebhookDeliveryRepo) SaveWebhookDelivery(delivery *entity.WebhookDelivery) (*entity.WebhookDelivery, error) {
+	err := r.db.Create(&delivery).Error
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// GetWebhookDelivery will return a delivery of the webhook subscription.
+func (r *WebhookDeliveryRepo) GetWebhookDelivery(subscriptionUUID string, uuid string) (*entity.WebhookDelivery, error) {
+	var delivery entity.WebhookDelivery
+	err := r.db.Where("subscription_uuid = ? AND uuid = ?", subscriptionUUID, uuid).Take(&delivery).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.ErrorTextWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// GetWebhookDeliveries will return delivery list of the webhook subscription, latest first.
+func (r *WebhookDeliveryRepo) GetWebhookDeliveries(
+	subscriptionUUID string,
+	p *repository.Parameters) ([]entity.WebhookDelivery, interface{}, error) {
+	var total int64
+	var deliveries []entity.WebhookDelivery
+	query := func() *gorm.DB {
+		return r.db.Where("subscription_uuid = ?", subscriptionUUID).Where(p.QueryKey, p.QueryValue...)
+	}
+	errTotal := query().Model(&entity.WebhookDelivery{}).Count(&total).Error
+	errList := query().Order("created_at desc").Limit(p.Limit).Offset(p.Offset).Find(&deliveries).Error
+	if errTotal != nil {
+		return nil, nil, errTotal
+	}
+	if errList != nil {
+		return nil, nil, errList
+	}
+	meta := repository.NewMeta(p, total)
+	return deliveries, meta, nil
+}
@@ -0,0 +1,165 @@
+package persistence
+
+import (
+	"errors"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WebhookSubscriptionRepo is a struct to store db connection.
+type WebhookSubscriptionRepo struct {
+	db *gorm.DB
+}
+
+// NewWebhookSubscriptionRepository will initialize webhook subscription repository.
+func NewWebhookSubscriptionRepository(db *gorm.DB) *WebhookSubscriptionRepo {
+	return &WebhookSubscriptionRepo{db}
+}
+
+// WebhookSubscriptionRepo implements the repository.WebhookSubscriptionRepository interface.
+var _ repository.WebhookSubscriptionRepository = &WebhookSubscriptionRepo{}
+
+// SaveWebhookSubscription will create a new webhook subscription.
+func (r *WebhookSubscriptionRepo) SaveWebhookSubscription(
+	subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, map[string]string, error) {
+	errDesc := map[string]string{}
+	err := r.db.Create(&subscription).Error
+	if err != nil {
+		return nil, errDesc, exception.ErrorTextAnErrorOccurred
+	}
+	return subscription, nil, nil
+}
+
+// UpdateWebhookSubscription will update target url, event types and secret of specified webhook subscription.
+func (r *WebhookSubscriptionRepo) UpdateWebhookSubscription(
+	uuid string,
+	subscription *entity.WebhookSubscription) (*entity.WebhookSubscription, map[string]string, error) {
+	errDesc := map[string]string{}
+	subscriptionData := &entity.WebhookSubscription{
+		TargetURL:  subscription.TargetURL,
+		EventTypes: subscription.EventTypes,
+		Secret:     subscription.Secret,
+	}
+
+	err := r.db.First(&subscription, "uuid = ?", uuid).Updates(subscriptionData).Error
+	if err != nil {
+		//If record not found
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			errDesc["uuid"] = exception.ErrorTextWebhookSubscriptionInvalidUUID.Error()
+			return nil, errDesc, exception.ErrorTextWebhookSubscriptionNotFound
+		}
+		return nil, errDesc, exception.ErrorTextAnErrorOccurred
+	}
+	return subscription, nil, nil
+}
+
+// EnableWebhookSubscription will activate a disabled webhook subscription and reset its failure counter.
+func (r *WebhookSubscriptionRepo) EnableWebhookSubscription(uuid string) error {
+	var subscription entity.WebhookSubscription
+	err := r.db.Where("uuid = ?", uuid).Take(&subscription).Updates(map[string]interface{}{
+		"is_active":     true,
+		"failure_count": 0,
+		"disabled_at":   nil,
+	}).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return exception.ErrorTextWebhookSubscriptionNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// DeleteWebhookSubscription will delete webhook subscription.
+func (r *WebhookSubscriptionRepo) DeleteWebhookSubscription(uuid string) error {
+	var subscription entity.WebhookSubscription
+	err := r.db.Where("uuid = ?", uuid).Take(&subscription).Delete(&subscription).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return exception.ErrorTextWebhookSubscriptionNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// GetWebhookSubscription will return a webhook subscription.
+func (r *WebhookSubscriptionRepo) GetWebhookSubscription(uuid string) (*entity.WebhookSubscription, error) {
+	var subscription entity.WebhookSubscription
+	err := r.db.Where("uuid = ?", uuid).Take(&subscription).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.ErrorTextWebhookSubscriptionNotFound
+		}
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// GetWebhookSubscriptions will return webhook subscription list.
+func (r *WebhookSubscriptionRepo) GetWebhookSubscriptions(
+	p *repository.Parameters) ([]entity.WebhookSubscription, interface{}, error) {
+	var total int64
+	var subscriptions []entity.WebhookSubscription
+	errTotal := r.db.Where(p.QueryKey, p.QueryValue...).Find(&subscriptions).Count(&total).Error
+	errList := r.db.Where(p.QueryKey, p.QueryValue...).Limit(p.Limit).Offset(p.Offset).Find(&subscriptions).Error
+	if errTotal != nil {
+		return nil, nil, errTotal
+	}
+	if errList != nil {
+		return nil, nil, errList
+	}
+	meta := repository.NewMeta(p, total)
+	return subscriptions, meta, nil
+}
+
+// GetActiveWebhookSubscriptionsByEventType will return active webhook subscriptions receiving the event type.
+func (r *WebhookSubscriptionRepo) GetActiveWebhookSubscriptionsByEventType(
+	eventType string) ([]entity.WebhookSubscription, error) {
+	var subscriptions []entity.WebhookSubscription
+	err := r.db.Where("is_active = ?", true).Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var subscribed []entity.WebhookSubscription
+	for _, subscription := range subscriptions {
+		if subscription.Subscribes(eventType) {
+			subscribed = append(subscribed, subscription)
+		}
+	}
+	return subscribed, nil
+}
+
+// RecordWebhookSubscriptionSuccess will reset the consecutive failure counter of webhook subscription.
+func (r *WebhookSubscriptionRepo) RecordWebhookSubscriptionSuccess(uuid string) error {
+	return r.db.Model(&entity.WebhookSubscription{}).
+		Where("uuid = ? AND failure_count > 0", uuid).
+		Update("failure_count", 0).Error
+}
+
+// RecordWebhookSubscriptionFailure will increase the consecutive failure counter of webhook subscription
+// and disable it once the counter reaches maxFailures. It returns true when the subscription got disabled.
+func (r *WebhookSubscriptionRepo) RecordWebhookSubscriptionFailure(uuid string, maxFailures int) (bool, error) {
+	err := r.db.Model(&entity.WebhookSubscription{}).
+		Where("uuid = ?", uuid).
+		Update("failure_count", gorm.Expr("failure_count + 1")).Error
+	if err != nil {
+		return false, err
+	}
+
+	result := r.db.Model(&entity.WebhookSubscription{}).
+		Where("uuid = ? AND is_active = ? AND failure_count >= ?", uuid, true, maxFailures).
+		Updates(map[string]interface{}{
+			"is_active":   false,
+			"disabled_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,69 @@
+package persistence_test
+
+import (
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/event"
+	"go-rest-skeleton/infrastructure/persistence"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveWebhookSubscription_Success(t *testing.T) {
+	SkipThis(t)
+
+	conn, errConn := DBConn()
+	if errConn != nil {
+		t.Fatalf("want non error, got %#v", errConn)
+	}
+
+	repo := persistence.NewWebhookSubscriptionRepository(conn)
+	subscription := entity.WebhookSubscription{
+		ApplicationUUID: uuid.New().String(),
+		TargetURL:       "https://example.com/webhook",
+		EventTypes:      event.UserCreated,
+	}
+	s, _, errSave := repo.SaveWebhookSubscription(&subscription)
+	assert.NoError(t, errSave)
+	assert.NotEmpty(t, s.Secret)
+	assert.True(t, s.IsActive)
+}
+
+func TestRecordWebhookSubscriptionFailure_DisablesSubscription(t *testing.T) {
+	SkipThis(t)
+
+	conn, errConn := DBConn()
+	if errConn != nil {
+		t.Fatalf("want non error, got %#v", errConn)
+	}
+
+	repo := persistence.NewWebhookSubscriptionRepository(conn)
+	subscription := entity.WebhookSubscription{
+		ApplicationUUID: uuid.New().String(),
+		TargetURL:       "https://example.com/webhook",
+		EventTypes:      entity.WebhookAllEvents,
+	}
+	s, _, errSave := repo.SaveWebhookSubscription(&subscription)
+	assert.NoError(t, errSave)
+
+	disabled, errRecord := repo.RecordWebhookSubscriptionFailure(s.UUID, 2)
+	assert.NoError(t, errRecord)
+	assert.False(t, disabled)
+
+	disabled, errRecord = repo.RecordWebhookSubscriptionFailure(s.UUID, 2)
+	assert.NoError(t, errRecord)
+	assert.True(t, disabled)
+
+	subscriptions, errGet := repo.GetActiveWebhookSubscriptionsByEventType(event.UserCreated)
+	assert.NoError(t, errGet)
+	for _, active := range subscriptions {
+		assert.NotEqual(t, s.UUID, active.UUID)
+	}
+
+	assert.NoError(t, repo.EnableWebhookSubscription(s.UUID))
+	enabled, errGet := repo.GetWebhookSubscription(s.UUID)
+	assert.NoError(t, errGet)
+	assert.True(t, enabled.IsActive)
+	assert.Equal(t, 0, enabled.FailureCount)
+}
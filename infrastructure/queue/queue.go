@@ -0,0 +1,135 @@
+// Package queue performs background job handling backed by redis. Jobs are typed by name and carry
+// a JSON payload, so producers (http handlers, application services) only need to dispatch a job and
+// return, while a pool of workers started by the 'queue:work' command will process it.
+// Failed jobs are retried with exponential backoff and moved to a dead-letter list once the maximum
+// number of attempts is reached. Jobs can also be scheduled to be processed at a later time.
+// Design pattern: Producer Consumer - Concurrency Design Pattern.
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultQueue is the name of queue used when no queue name is configured.
+	DefaultQueue = "default"
+
+	// DefaultMaxRetries is the maximum number of retries before a job is moved to the dead-letter list.
+	DefaultMaxRetries = 5
+
+	// DefaultVisibilityTimeout is how long a reserved job is hidden from other workers.
+	// A job reserved longer than this is considered abandoned (e.g. the worker crashed) and released back.
+	DefaultVisibilityTimeout = 5 * time.Minute
+)
+
+// Job represents a unit of work stored in the queue.
+type Job struct {
+	UUID        string          `json:"uuid"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	Attempts    int             `json:"attempts"`
+	MaxRetries  int             `json:"max_retries"`
+	LastError   string          `json:"last_error,omitempty"`
+	AvailableAt time.Time       `json:"available_at"`
+	CreatedAt   time.Time       `json:"created_at"`
+	raw         string
+}
+
+// Handler is a function that processes a job of a given type.
+// Returning an error will retry the job until it reaches its maximum number of retries.
+type Handler func(ctx context.Context, job *Job) error
+
+// QueueInterface is an interface. Needs to be implemented by queue backend.
+type QueueInterface interface {
+	Dispatch(job *Job) error
+}
+
+// NewJob creates a new Job of the given type with the payload encoded as json.
+func NewJob(jobType string, payload interface{}) (*Job, error) {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Job{
+		UUID:        uuid.New().String(),
+		Type:        jobType,
+		Payload:     payloadBytes,
+		MaxRetries:  DefaultMaxRetries,
+		AvailableAt: now,
+		CreatedAt:   now,
+	}, nil
+}
+
+// Delay schedules the job to be processed after the given duration.
+func (j *Job) Delay(d time.Duration) *Job {
+	j.AvailableAt = time.Now().Add(d)
+
+	return j
+}
+
+// At schedules the job to be processed at the given time.
+func (j *Job) At(t time.Time) *Job {
+	j.AvailableAt = t
+
+	return j
+}
+
+// WithMaxRetries overrides the maximum number of retries of the job.
+func (j *Job) WithMaxRetries(maxRetries int) *Job {
+	j.MaxRetries = maxRetries
+
+	return j
+}
+
+// Bind decodes the job payload into v.
+func (j *Job) Bind(v interface{}) error {
+	return json.Unmarshal(j.Payload, v)
+}
+
+// Backoff returns the delay before the next attempt of a job that already failed the given number of attempts.
+// The delay doubles on every attempt, starting at 2 seconds and capped at 1 hour.
+func Backoff(attempts int) time.Duration {
+	const (
+		base    = 2 * time.Second
+		maxWait = time.Hour
+	)
+
+	if attempts < 1 {
+		return base
+	}
+
+	delay := base
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxWait {
+			return maxWait
+		}
+	}
+
+	return delay
+}
+
+func encodeJob(job *Job) (string, error) {
+	jobBytes, err := json.Marshal(job)
+	if err != nil {
+		return "", err
+	}
+
+	return string(jobBytes), nil
+}
+
+func decodeJob(raw string) (*Job, error) {
+	var job Job
+	if err := json.Unmarshal([]byte(raw), &job); err != nil {
+		return nil, err
+	}
+	job.raw = raw
+
+	return &job, nil
+}
@@ -0,0 +1,276 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// reserveScript pops a job from the ready list and keeps it in the reserved set until it is acknowledged.
+var reserveScript = redis.NewScript(`
+local job = redis.call('RPOP', KEYS[1])
+if job then
+	redis.call('ZADD', KEYS[2], ARGV[1], job)
+end
+return job
+`)
+
+// migrateScript moves jobs whose score is already due from a sorted set to the ready list.
+var migrateScript = redis.NewScript(`
+local jobs = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, 100)
+for _, job in ipairs(jobs) do
+	redis.call('ZREM', KEYS[1], job)
+	redis.call('LPUSH', KEYS[2], job)
+end
+return #jobs
+`)
+
+// failScript removes a job from the reserved set then pushes its next attempt either to the delayed set
+// or, once it has exhausted its retries, to the dead-letter list. Nothing is pushed when the job is no longer reserved.
+var failScript = redis.NewScript(`
+if redis.call('ZREM', KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+if ARGV[3] == '1' then
+	redis.call('LPUSH', KEYS[3], ARGV[2])
+else
+	redis.call('ZADD', KEYS[2], ARGV[4], ARGV[2])
+end
+return 1
+`)
+
+// ErrNotReserved is returned when a job is acknowledged or failed after its reservation expired,
+// the job has been released back to the ready list and may be processed again by another worker.
+var ErrNotReserved = errors.New("queue: job is no longer reserved")
+
+// RedisQueue represents it self.
+type RedisQueue struct {
+	client            *redis.Client
+	name              string
+	visibilityTimeout time.Duration
+}
+
+// RedisQueue implements the QueueInterface.
+var _ QueueInterface = &RedisQueue{}
+
+// Stats represents the number of jobs on each state of the queue.
+type Stats struct {
+	Ready    int64 `json:"ready"`
+	Delayed  int64 `json:"delayed"`
+	Reserved int64 `json:"reserved"`
+	Dead     int64 `json:"dead"`
+}
+
+// NewRedisQueue creates new RedisQueue.
+func NewRedisQueue(client *redis.Client, name string) *RedisQueue {
+	if name == "" {
+		name = DefaultQueue
+	}
+
+	return &RedisQueue{
+		client:            client,
+		name:              name,
+		visibilityTimeout: DefaultVisibilityTimeout,
+	}
+}
+
+// WithVisibilityTimeout overrides how long a reserved job is hidden from other workers.
+func (q *RedisQueue) WithVisibilityTimeout(d time.Duration) *RedisQueue {
+	if d > 0 {
+		q.visibilityTimeout = d
+	}
+
+	return q
+}
+
+// VisibilityTimeout returns how long a reserved job is hidden from other workers.
+func (q *RedisQueue) VisibilityTimeout() time.Duration {
+	return q.visibilityTimeout
+}
+
+// Name returns the name of the queue.
+func (q *RedisQueue) Name() string {
+	return q.name
+}
+
+// Dispatch pushes the given job to the queue. Jobs scheduled in the future are stored as delayed jobs.
+func (q *RedisQueue) Dispatch(job *Job) error {
+	ctx := q.client.Context()
+	raw, err := encodeJob(job)
+	if err != nil {
+		return err
+	}
+
+	if job.AvailableAt.After(time.Now()) {
+		return q.client.ZAdd(ctx, q.key("delayed"), &redis.Z{
+			Score:  float64(job.AvailableAt.Unix()),
+			Member: raw,
+		}).Err()
+	}
+
+	return q.client.LPush(ctx, q.key("ready"), raw).Err()
+}
+
+// Reserve takes the next ready job and hides it from other workers until it is acknowledged.
+// It returns nil job when there is no ready job.
+func (q *RedisQueue) Reserve(ctx context.Context) (*Job, error) {
+	deadline := time.Now().Add(q.visibilityTimeout).Unix()
+	raw, err := reserveScript.Run(ctx, q.client, []string{q.key("ready"), q.key("reserved")}, deadline).Text()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	job, err := decodeJob(raw)
+	if err != nil {
+		// Payload can't be decoded, no handler will ever process it.
+		_ = q.client.ZRem(ctx, q.key("reserved"), raw).Err()
+		_ = q.client.LPush(ctx, q.key("dead"), raw).Err()
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// Ack removes a successfully processed job from the queue.
+// ErrNotReserved is returned when the reservation of the job has already expired.
+func (q *RedisQueue) Ack(ctx context.Context, job *Job) error {
+	removed, err := q.client.ZRem(ctx, q.key("reserved"), job.raw).Result()
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return ErrNotReserved
+	}
+
+	return nil
+}
+
+// Fail records the error of a job then schedules its next attempt using exponential backoff.
+// Once the job has exhausted its retries, it is moved to the dead-letter list.
+// ErrNotReserved is returned, and nothing is scheduled, when the reservation of the job has already expired.
+func (q *RedisQueue) Fail(ctx context.Context, job *Job, jobErr error) error {
+	previous := job.raw
+	job.Attempts++
+	job.LastError = jobErr.Error()
+
+	dead := job.Attempts > job.MaxRetries
+	if !dead {
+		job.AvailableAt = time.Now().Add(Backoff(job.Attempts))
+	}
+
+	raw, err := encodeJob(job)
+	if err != nil {
+		return err
+	}
+
+	deadFlag := "0"
+	if dead {
+		deadFlag = "1"
+	}
+	keys := []string{q.key("reserved"), q.key("delayed"), q.key("dead")}
+	moved, err := failScript.Run(ctx, q.client, keys, previous, raw, deadFlag, job.AvailableAt.Unix()).Int()
+	if err != nil {
+		return err
+	}
+	if moved == 0 {
+		return ErrNotReserved
+	}
+
+	return nil
+}
+
+// Release moves delayed jobs that are due and reserved jobs whose visibility timeout expired back to the ready list.
+func (q *RedisQueue) Release(ctx context.Context) error {
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	for _, set := range []string{q.key("delayed"), q.key("reserved")} {
+		if err := migrateScript.Run(ctx, q.client, []string{set, q.key("ready")}, now).Err(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeadJobs returns jobs on the dead-letter list.
+func (q *RedisQueue) DeadJobs(ctx context.Context) ([]*Job, error) {
+	raws, err := q.client.LRange(ctx, q.key("dead"), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*Job, 0, len(raws))
+	for _, raw := range raws {
+		job, errDecode := decodeJob(raw)
+		if errDecode != nil {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// RetryDeadJobs moves all jobs on the dead-letter list back to the ready list with a fresh attempt counter.
+func (q *RedisQueue) RetryDeadJobs(ctx context.Context) (int, error) {
+	jobs, err := q.DeadJobs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, job := range jobs {
+		previous := job.raw
+		job.Attempts = 0
+		job.LastError = ""
+		job.AvailableAt = time.Now()
+		raw, errEncode := encodeJob(job)
+		if errEncode != nil {
+			return 0, errEncode
+		}
+
+		_, errTx := q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.LRem(ctx, q.key("dead"), 1, previous)
+			pipe.LPush(ctx, q.key("ready"), raw)
+			return nil
+		})
+		if errTx != nil {
+			return 0, errTx
+		}
+	}
+
+	return len(jobs), nil
+}
+
+// Stats returns the number of jobs on each state of the queue.
+func (q *RedisQueue) Stats(ctx context.Context) (*Stats, error) {
+	var (
+		ready, delayed, reserved, dead *redis.IntCmd
+	)
+	_, err := q.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		ready = pipe.LLen(ctx, q.key("ready"))
+		delayed = pipe.ZCard(ctx, q.key("delayed"))
+		reserved = pipe.ZCard(ctx, q.key("reserved"))
+		dead = pipe.LLen(ctx, q.key("dead"))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Stats{
+		Ready:    ready.Val(),
+		Delayed:  delayed.Val(),
+		Reserved: reserved.Val(),
+		Dead:     dead.Val(),
+	}, nil
+}
+
+func (q *RedisQueue) key(state string) string {
+	return fmt.Sprintf("queue:%s:%s", q.name, state)
+}
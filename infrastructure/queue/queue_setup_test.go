@@ -0,0 +1,45 @@
+package queue_test
+
+import (
+	"fmt"
+	"go-rest-skeleton/config"
+	"go-rest-skeleton/infrastructure/queue"
+	"go-rest-skeleton/pkg/util"
+	"log"
+	"testing"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+)
+
+// SkipThis is a function.
+func SkipThis(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skip this test")
+	}
+}
+
+// InitConfig will initialize config.
+func InitConfig() *config.Config {
+	if err := godotenv.Load(fmt.Sprintf("%s/.env", util.RootDir())); err != nil {
+		log.Println("no .env file provided")
+	}
+
+	return config.New()
+}
+
+// RedisConnSetup will initialize connection to redis server.
+func RedisConnSetup(config config.RedisTestConfig) *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:     config.RedisHost + ":" + config.RedisPort,
+		Password: config.RedisPassword,
+		DB:       config.RedisDB,
+	})
+}
+
+// QueueSetup will initialize a queue with unique name, so tests do not share jobs.
+func QueueSetup() *queue.RedisQueue {
+	conf := InitConfig()
+	return queue.NewRedisQueue(RedisConnSetup(conf.RedisTestConfig), "test-"+uuid.New().String())
+}
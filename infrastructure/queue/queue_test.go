@@ -0,0 +1,135 @@
+package queue_test
+
+import (
+	"context"
+	"errors"
+	"go-rest-skeleton/infrastructure/queue"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type examplePayload struct {
+	Name string `json:"name"`
+}
+
+func TestNewJob(t *testing.T) {
+	job, err := queue.NewJob("example", examplePayload{Name: "Example"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, job.UUID)
+	assert.Equal(t, "example", job.Type)
+	assert.Equal(t, queue.DefaultMaxRetries, job.MaxRetries)
+	assert.False(t, job.AvailableAt.After(time.Now()))
+
+	var payload examplePayload
+	assert.NoError(t, job.Bind(&payload))
+	assert.Equal(t, "Example", payload.Name)
+}
+
+func TestJob_Delay(t *testing.T) {
+	job, _ := queue.NewJob("example", nil)
+	job.Delay(time.Minute)
+
+	assert.True(t, job.AvailableAt.After(time.Now().Add(59*time.Second)))
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 2*time.Second, queue.Backoff(1))
+	assert.Equal(t, 4*time.Second, queue.Backoff(2))
+	assert.Equal(t, 8*time.Second, queue.Backoff(3))
+	assert.Equal(t, time.Hour, queue.Backoff(20))
+}
+
+func TestRedisQueue_DispatchAndReserve(t *testing.T) {
+	SkipThis(t)
+
+	q := QueueSetup()
+	ctx := context.Background()
+	job, _ := queue.NewJob("example", examplePayload{Name: "Example"})
+	assert.NoError(t, q.Dispatch(job))
+
+	reserved, err := q.Reserve(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, job.UUID, reserved.UUID)
+
+	stats, _ := q.Stats(ctx)
+	assert.EqualValues(t, 0, stats.Ready)
+	assert.EqualValues(t, 1, stats.Reserved)
+
+	assert.NoError(t, q.Ack(ctx, reserved))
+	stats, _ = q.Stats(ctx)
+	assert.EqualValues(t, 0, stats.Reserved)
+}
+
+func TestRedisQueue_DispatchDelayed(t *testing.T) {
+	SkipThis(t)
+
+	q := QueueSetup()
+	ctx := context.Background()
+	job, _ := queue.NewJob("example", nil)
+	assert.NoError(t, q.Dispatch(job.Delay(time.Hour)))
+
+	reserved, err := q.Reserve(ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, reserved)
+
+	stats, _ := q.Stats(ctx)
+	assert.EqualValues(t, 1, stats.Delayed)
+}
+
+func TestRedisQueue_FailMovesToDeadLetter(t *testing.T) {
+	SkipThis(t)
+
+	q := QueueSetup()
+	ctx := context.Background()
+	job, _ := queue.NewJob("example", nil)
+	assert.NoError(t, q.Dispatch(job.WithMaxRetries(0)))
+
+	reserved, _ := q.Reserve(ctx)
+	assert.NoError(t, q.Fail(ctx, reserved, assert.AnError))
+
+	deadJobs, err := q.DeadJobs(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, deadJobs, 1)
+	assert.Equal(t, assert.AnError.Error(), deadJobs[0].LastError)
+
+	total, err := q.RetryDeadJobs(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+
+	stats, _ := q.Stats(ctx)
+	assert.EqualValues(t, 1, stats.Ready)
+	assert.EqualValues(t, 0, stats.Dead)
+}
+
+func TestRedisQueue_ExpiredReservation(t *testing.T) {
+	SkipThis(t)
+
+	q := QueueSetup().WithVisibilityTimeout(time.Second)
+	ctx := context.Background()
+	assert.Equal(t, time.Second, q.VisibilityTimeout())
+
+	job, _ := queue.NewJob("example", nil)
+	assert.NoError(t, q.Dispatch(job))
+	reserved, _ := q.Reserve(ctx)
+
+	// The reservation expires, the job is released and reserved again by another worker
+	time.Sleep(2 * time.Second)
+	assert.NoError(t, q.Release(ctx))
+	again, _ := q.Reserve(ctx)
+	assert.NotNil(t, again)
+
+	assert.NoError(t, q.Ack(ctx, again))
+	assert.True(t, errors.Is(q.Ack(ctx, reserved), queue.ErrNotReserved))
+	assert.True(t, errors.Is(q.Fail(ctx, reserved, assert.AnError), queue.ErrNotReserved))
+
+	stats, _ := q.Stats(ctx)
+	assert.EqualValues(t, 0, stats.Delayed)
+	assert.EqualValues(t, 0, stats.Reserved)
+}
+
+func TestJobTimeout(t *testing.T) {
+	assert.Equal(t, 270*time.Second, queue.JobTimeout(queue.DefaultVisibilityTimeout))
+	assert.Equal(t, 9*time.Second, queue.JobTimeout(10*time.Second))
+}
@@ -0,0 +1,176 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultConcurrency is the number of jobs processed at the same time by a worker.
+	DefaultConcurrency = 4
+
+	// DefaultPollInterval is how long a worker waits before polling again when the queue is empty.
+	DefaultPollInterval = time.Second
+)
+
+// Worker represents a pool of goroutines processing jobs from a RedisQueue.
+type Worker struct {
+	queue        *RedisQueue
+	handlers     map[string]Handler
+	concurrency  int
+	pollInterval time.Duration
+	jobTimeout   time.Duration
+}
+
+// NewWorker creates new Worker.
+func NewWorker(queue *RedisQueue, concurrency int) *Worker {
+	if concurrency < 1 {
+		concurrency = DefaultConcurrency
+	}
+
+	return &Worker{
+		queue:        queue,
+		handlers:     make(map[string]Handler),
+		concurrency:  concurrency,
+		pollInterval: DefaultPollInterval,
+		jobTimeout:   JobTimeout(queue.VisibilityTimeout()),
+	}
+}
+
+// JobTimeout returns the deadline given to a handler, it is a tenth shorter than the visibility timeout,
+// so the job is acknowledged or failed before its reservation expires and another worker takes it.
+func JobTimeout(visibilityTimeout time.Duration) time.Duration {
+	return visibilityTimeout - visibilityTimeout/10
+}
+
+// Register registers the handler of the given job type.
+func (w *Worker) Register(jobType string, handler Handler) *Worker {
+	w.handlers[jobType] = handler
+
+	return w
+}
+
+// Run starts processing jobs and blocks until the given context is canceled.
+// Cancelling the context stops reserving new jobs and waits for in-flight jobs to finish.
+func (w *Worker) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		w.release(ctx)
+	}()
+
+	for i := 0; i < w.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.consume(ctx)
+		}()
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+// release periodically moves due delayed jobs and abandoned reserved jobs to the ready list.
+func (w *Worker) release(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.queue.Release(ctx); err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Str("queue", w.queue.Name()).Msg("failed to release jobs")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Worker) consume(ctx context.Context) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		job, err := w.queue.Reserve(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Str("queue", w.queue.Name()).Msg("failed to reserve job")
+		}
+
+		if job == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(w.pollInterval):
+			}
+			continue
+		}
+
+		// In-flight jobs are not bound to the worker context, so they can finish during shutdown,
+		// but they must finish before their reservation expires.
+		jobCtx, cancel := context.WithTimeout(context.Background(), w.jobTimeout)
+		w.process(jobCtx, job)
+		cancel()
+	}
+}
+
+func (w *Worker) process(ctx context.Context, job *Job) {
+	logger := log.With().
+		Str("queue", w.queue.Name()).
+		Str("job", job.UUID).
+		Str("type", job.Type).
+		Int("attempts", job.Attempts).
+		Logger()
+
+	err := w.handle(ctx, job)
+
+	// Ack and Fail must run even when the job has used up its deadline.
+	ctx = context.Background()
+	if err == nil {
+		if errAck := w.queue.Ack(ctx, job); errAck != nil {
+			if errors.Is(errAck, ErrNotReserved) {
+				logger.Warn().Msg("job processed after its reservation expired, it may be processed again")
+				return
+			}
+			logger.Error().Err(errAck).Msg("failed to acknowledge job")
+			return
+		}
+		logger.Info().Msg("job processed")
+		return
+	}
+
+	logger.Warn().Err(err).Msg("job failed")
+	if errFail := w.queue.Fail(ctx, job, err); errFail != nil {
+		if errors.Is(errFail, ErrNotReserved) {
+			logger.Warn().Msg("job failed after its reservation expired, it is not rescheduled")
+			return
+		}
+		logger.Error().Err(errFail).Msg("failed to reschedule job")
+	}
+}
+
+func (w *Worker) handle(ctx context.Context, job *Job) (err error) {
+	handler, ok := w.handlers[job.Type]
+	if !ok {
+		return fmt.Errorf("no handler registered for job type %s", job.Type)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, job)
+}
@@ -0,0 +1,78 @@
+package queue_test
+
+import (
+	"context"
+	"errors"
+	"go-rest-skeleton/infrastructure/queue"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorker_Run(t *testing.T) {
+	SkipThis(t)
+
+	q := QueueSetup()
+	processed := make(chan string, 1)
+	worker := queue.NewWorker(q, 1).Register("example", func(ctx context.Context, job *queue.Job) error {
+		var payload examplePayload
+		if err := job.Bind(&payload); err != nil {
+			return err
+		}
+		processed <- payload.Name
+		return nil
+	})
+
+	job, _ := queue.NewJob("example", examplePayload{Name: "Example"})
+	assert.NoError(t, q.Dispatch(job))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- worker.Run(ctx) }()
+
+	select {
+	case name := <-processed:
+		assert.Equal(t, "Example", name)
+	case <-time.After(5 * time.Second):
+		t.Fatal("job was not processed")
+	}
+
+	cancel()
+	assert.NoError(t, <-done)
+
+	stats, _ := q.Stats(context.Background())
+	assert.EqualValues(t, 0, stats.Ready)
+	assert.EqualValues(t, 0, stats.Reserved)
+}
+
+func TestWorker_Run_FailedJobIsRetried(t *testing.T) {
+	SkipThis(t)
+
+	q := QueueSetup()
+	attempted := make(chan struct{}, 1)
+	worker := queue.NewWorker(q, 1).Register("example", func(ctx context.Context, job *queue.Job) error {
+		attempted <- struct{}{}
+		return errors.New("failed")
+	})
+
+	job, _ := queue.NewJob("example", nil)
+	assert.NoError(t, q.Dispatch(job))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- worker.Run(ctx) }()
+
+	select {
+	case <-attempted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("job was not processed")
+	}
+
+	cancel()
+	<-done
+
+	stats, _ := q.Stats(context.Background())
+	assert.EqualValues(t, 1, stats.Delayed)
+	assert.EqualValues(t, 0, stats.Reserved)
+}
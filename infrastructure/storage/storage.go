@@ -1,30 +1,60 @@
 // Package storage performs file handling (get, upload, and delete file).
-// This package support multiple storage clients, such as minio, s3, google cloud storage.
+// This package support multiple storage clients, such as minio, s3, google cloud storage and local disk.
 // Storage clients called 'driver'.
 // Possible to switch storage driver
 // Design pattern: Adapter - Behavioral Design Pattern.
+// Category lookup, validation and file records are shared by every driver in fileStorage,
+// a driver only needs to implement ObjectStorage.
 package storage
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"io"
 	"mime/multipart"
+	"net/http"
+	"net/url"
 	"path"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 const (
-	// Max allowed file size.
+	// Max allowed file size of category without its own MaxSize.
 	MaxSize = 2048000
 
+	// Number of bytes used to detect content type of file.
+	sniffLength = 512
+
 	// Expiry of signed URL.
 	ReqExpired = 24 * 60 * 60 * time.Second
 
+	// Cache control of uploaded object.
+	CacheControl = "max-age=31536000"
+
+	// Collections of driver.
+	DriverMinio = "minio"
+	DriverS3    = "s3"
+	DriverGCS   = "gcs"
+	DriverLocal = "local"
+
 	// Collections of category.
 	CategoryAvatar    = "avatar"
 	CategoryDocument  = "document"
 	CategoryFile      = "file"
 	CategoryThumbnail = "thumbnail"
+
+	// Name of the uploaded file among its variants.
+	VariantOriginal = "original"
 )
 
 // FileName represents it self.
@@ -34,10 +64,70 @@ type FileName struct {
 	Extension        string
 }
 
+var (
+	// ErrObjectNotFound is returned by ObjectStorage when the object does not exist in the bucket.
+	ErrObjectNotFound = errors.New("object not found")
+
+	// ErrBucketNotFound is returned by BucketStorage when the bucket does not exist.
+	ErrBucketNotFound = errors.New("bucket not found")
+)
+
 // FileStorageInterface is an interface. Needs to be implemented in StorageDriver.
 type FileStorageInterface interface {
-	UploadFile(file *multipart.FileHeader, category string) (string, map[string]string, error, interface{})
-	GetFile(UUID string) (interface{}, error)
+	UploadFile(file *multipart.FileHeader, category string, userUUID string) (string, map[string]string, error, interface{})
+	GetFile(UUID string, variant string) (interface{}, error)
+	GetFileVariants(UUID string) (map[string]interface{}, error)
+	DeleteFile(UUID string) error
+	CollectGarbage(olderThan time.Duration) (*GarbageReport, error)
+	PendingScans(olderThan time.Duration) ([]string, error)
+	SetReferences(references ...FileReference)
+	SetScanner(scanner Scanner)
+	SetCategories(categories repository.StorageCategoryRepository)
+	CreateDirectUpload(upload *DirectUploadRequest) (*DirectUpload, error, interface{})
+	ConfirmDirectUpload(UUID string, userUUID string) (string, error, interface{})
+	CreateResumableUpload(upload *ResumableUploadRequest) (*entity.StorageUpload, error, interface{})
+	GetResumableUpload(UUID string, userUUID string) (*entity.StorageUpload, error)
+	WriteResumableUpload(
+		UUID string,
+		userUUID string,
+		offset int64,
+		reader io.Reader) (*entity.StorageUpload, error, interface{})
+	DeleteResumableUpload(UUID string, userUUID string) error
+	SetUploadPath(dir string)
+}
+
+// ObjectStorage is an interface. Needs to be implemented in StorageDriver to store object and sign its URL.
+type ObjectStorage interface {
+	PutObject(ctx context.Context, objectPath string, reader io.Reader, size int64, contentType string) error
+	GetObject(ctx context.Context, objectPath string) (io.ReadCloser, error)
+	StatObject(ctx context.Context, objectPath string) (int64, error)
+	RemoveObject(ctx context.Context, objectPath string) error
+	SignedURL(ctx context.Context, objectPath string, fileName string, expiry time.Duration) (string, error)
+	PresignedPutURL(ctx context.Context, objectPath string, contentType string, expiry time.Duration) (string, map[string]string, error)
+}
+
+// PostPolicyStorage is an interface. Implemented by StorageDriver able to sign browser based POST upload,
+// the policy enforces size and content type of the upload on the bucket side.
+type PostPolicyStorage interface {
+	PresignedPostPolicy(
+		ctx context.Context,
+		objectPath string,
+		contentType string,
+		maxSize int64,
+		expiry time.Duration) (string, map[string]string, error)
+}
+
+// BucketStorage is an interface. Implemented by every storage driver to check its bucket is reachable,
+// uses by readiness check.
+type BucketStorage interface {
+	CheckBucket(ctx context.Context) error
+}
+
+// Driver is an interface. Implemented by every storage driver.
+type Driver interface {
+	FileStorageInterface
+	ObjectStorage
+	BucketStorage
 }
 
 // FileStorageClient represents it self.
@@ -66,3 +156,280 @@ func FormatFileName(fileName string) *FileName {
 func (fn *FileName) String() string {
 	return fn.NewFileName + fn.Extension
 }
+
+// fileStorage implements FileStorageInterface on top of ObjectStorage of a driver.
+type fileStorage struct {
+	objects    ObjectStorage
+	db         *gorm.DB
+	testMode   bool
+	uploadPath string
+	references []FileReference
+	scanner    Scanner
+	categories repository.StorageCategoryRepository
+}
+
+// UploadFile validates the given file against its category, stores it and records it into database owned by userUUID.
+// The file is streamed to the driver, only its first bytes are kept in memory to detect the content type.
+func (s *fileStorage) UploadFile(
+	file *multipart.FileHeader,
+	category string,
+	userUUID string) (string, map[string]string, error, interface{}) {
+	var fileEntity entity.StorageFile
+
+	fileCategory, err := s.findCategory(category)
+	if err != nil {
+		return "", nil, err, nil
+	}
+
+	fileSize := file.Size
+	if maxSize := fileCategory.AllowedSize(MaxSize); fileSize > maxSize {
+		return "", nil, exception.ErrorTextStorageUploadInvalidSize, sizeArgs(maxSize)
+	}
+
+	fileOpen, err := file.Open()
+	if err != nil {
+		return "", nil, exception.ErrorTextStorageUploadCannotOpenFile, nil
+	}
+	defer fileOpen.Close()
+
+	header, err := readHeader(fileOpen)
+	if err != nil {
+		return "", nil, exception.ErrorTextStorageUploadCannotOpenFile, nil
+	}
+
+	fileType, fileAllowed := DetectAllowedType(fileCategory, header)
+	if !fileAllowed {
+		return "", nil, exception.ErrorTextStorageUploadInvalidFileType, typeArgs(fileCategory)
+	}
+	if IsImageCategory(fileCategory) {
+		if err := ValidateImage(file); err != nil {
+			return "", nil, exception.ErrorTextStorageUploadInvalidFileType, typeArgs(fileCategory)
+		}
+	}
+
+	fileName := FormatFileName(file.Filename).String()
+	filePath := s.FormatFilePath(fileCategory.Path, fileName)
+	reader := io.MultiReader(bytes.NewReader(header), fileOpen)
+	err = s.objects.PutObject(context.Background(), filePath, reader, fileSize, fileType)
+	if err != nil {
+		return "", nil, err, nil
+	}
+
+	fileEntity.CategoryUUID = fileCategory.UUID
+	fileEntity.UserUUID = userUUID
+	fileEntity.OriginalName = file.Filename
+	fileEntity.Name = fileName
+	fileEntity.Type = fileType
+	fileEntity.Size = fileSize
+	fileEntity.Path = filePath
+	fileEntity.Status = entity.StorageFileStatusUploaded
+	fileEntity.ScanStatus = s.initialScanStatus()
+	err = s.db.Create(&fileEntity).Error
+	if err != nil {
+		return "", nil, err, nil
+	}
+
+	return fileEntity.UUID, nil, nil, nil
+}
+
+// GetFile gets file by UUID and return signed URL of the given variant, empty variant is the uploaded file.
+// Variant which has not been generated returns nil like a file which does not exist.
+// File which is not clean is refused with ErrorTextStorageFileScanPending or ErrorTextStorageFileQuarantined.
+func (s *fileStorage) GetFile(UUID string, variant string) (interface{}, error) {
+	fileEntity, err := s.findUploadedFile(UUID)
+	if err != nil || fileEntity == nil {
+		return nil, err
+	}
+	switch fileEntity.ScanStatus {
+	case entity.StorageFileScanPending:
+		return nil, exception.ErrorTextStorageFileScanPending
+	case entity.StorageFileScanQuarantined:
+		return nil, exception.ErrorTextStorageFileQuarantined
+	}
+	if variant == "" || variant == VariantOriginal {
+		return s.objects.SignedURL(context.Background(), fileEntity.Path, fileEntity.Name, ReqExpired)
+	}
+
+	var variantEntity entity.StorageFileVariant
+	err = s.db.Where("file_uuid = ? AND name = ?", UUID, variant).Take(&variantEntity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return s.objects.SignedURL(context.Background(), variantEntity.Path, path.Base(variantEntity.Path), ReqExpired)
+}
+
+// GetFileVariants gets file by UUID and return signed URL of the uploaded file and each generated variant by name.
+// File which is not clean returns nil like a file which does not exist, so its owner can still be shown.
+func (s *fileStorage) GetFileVariants(UUID string) (map[string]interface{}, error) {
+	fileEntity, err := s.findUploadedFile(UUID)
+	if err != nil || fileEntity == nil || !fileEntity.IsClean() {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	fileURL, err := s.objects.SignedURL(ctx, fileEntity.Path, fileEntity.Name, ReqExpired)
+	if err != nil {
+		return nil, err
+	}
+	urls := map[string]interface{}{VariantOriginal: fileURL}
+
+	var variants []entity.StorageFileVariant
+	err = s.db.Where("file_uuid = ?", UUID).Find(&variants).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, variant := range variants {
+		variantURL, err := s.objects.SignedURL(ctx, variant.Path, path.Base(variant.Path), ReqExpired)
+		if err != nil {
+			return nil, err
+		}
+		urls[variant.Name] = variantURL
+	}
+
+	return urls, nil
+}
+
+// findUploadedFile gets file by UUID, nil when it does not exist or has not been uploaded.
+func (s *fileStorage) findUploadedFile(UUID string) (*entity.StorageFile, error) {
+	var fileEntity entity.StorageFile
+
+	err := s.db.Where("uuid = ?", UUID).Take(&fileEntity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !fileEntity.IsUploaded() {
+		return nil, nil
+	}
+
+	return &fileEntity, nil
+}
+
+// SetScanner sets scanner of uploaded files, files stored afterwards can not be downloaded until they are scanned.
+// The scan itself is done by ScanProcessor on queue worker.
+func (s *fileStorage) SetScanner(scanner Scanner) {
+	s.scanner = scanner
+}
+
+// SetCategories sets repository of storage categories, categories are read from database directly when it is not set.
+func (s *fileStorage) SetCategories(categories repository.StorageCategoryRepository) {
+	s.categories = categories
+}
+
+// findCategory gets storage category by its slug.
+func (s *fileStorage) findCategory(slug string) (*entity.StorageCategory, error) {
+	if s.categories != nil {
+		return s.categories.GetCategoryBySlug(slug)
+	}
+	return FindCategory(s.db, slug)
+}
+
+// findCategoryByUUID gets storage category by its UUID.
+func (s *fileStorage) findCategoryByUUID(UUID string) (*entity.StorageCategory, error) {
+	if s.categories != nil {
+		return s.categories.GetCategory(UUID)
+	}
+	return findCategoryByUUID(s.db, UUID)
+}
+
+// initialScanStatus returns scan status of file being stored, it is clean right away while scanning is disabled.
+func (s *fileStorage) initialScanStatus() string {
+	if s.scanner == nil {
+		return entity.StorageFileScanClean
+	}
+
+	return entity.StorageFileScanPending
+}
+
+// FormatFilePath formats file path by given fileName and category path.
+func (s fileStorage) FormatFilePath(fileCategoryPath string, fileName string) string {
+	filePath := fileCategoryPath + "/" + fileName
+	if s.testMode {
+		return "test/" + filePath
+	}
+
+	return filePath
+}
+
+// SetUploadPath sets directory keeping the received parts of resumable uploads.
+func (s *fileStorage) SetUploadPath(dir string) {
+	s.uploadPath = dir
+}
+
+// FindCategory gets storage category by its slug.
+func FindCategory(db *gorm.DB, slug string) (*entity.StorageCategory, error) {
+	var fileCategory entity.StorageCategory
+	err := db.Where("slug = ?", slug).Take(&fileCategory).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.ErrorTextStorageCategoryNotFound
+		}
+		return nil, err
+	}
+
+	return &fileCategory, nil
+}
+
+// DetectAllowedType detects content type of the given file header bytes and checks it against mime types of category.
+func DetectAllowedType(category *entity.StorageCategory, header []byte) (string, bool) {
+	fileType := http.DetectContentType(header)
+	return fileType, IsAllowedType(category, fileType)
+}
+
+// IsAllowedType checks the given content type against mime types of category.
+func IsAllowedType(category *entity.StorageCategory, fileType string) bool {
+	for _, v := range strings.Split(category.MimeTypes, ",") {
+		v = strings.TrimSpace(v)
+		if v != "" && strings.HasPrefix(fileType, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readHeader reads bytes used to detect content type, a file shorter than that is read entirely.
+func readHeader(reader io.Reader) ([]byte, error) {
+	header := make([]byte, sniffLength)
+	n, err := io.ReadFull(reader, header)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+
+	return header[:n], nil
+}
+
+// sizeArgs returns translation args of ErrorTextStorageUploadInvalidSize.
+func sizeArgs(maxSize int64) string {
+	return fmt.Sprintf("Size:%s", strconv.FormatFloat(float64(maxSize)/1000000, 'f', -1, 64))
+}
+
+// typeArgs returns translation args of ErrorTextStorageUploadInvalidFileType.
+func typeArgs(category *entity.StorageCategory) string {
+	return fmt.Sprintf("Type:%s", category.MimeTypes)
+}
+
+// contentDisposition returns inline content disposition of the given file name.
+func contentDisposition(fileName string) string {
+	return fmt.Sprintf("inline; filename=%s", fileName)
+}
+
+// escapeObjectPath escapes each segment of the object path, keeping the separators.
+func escapeObjectPath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = escapeURIComponent(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// escapeURIComponent escapes everything except unreserved characters of RFC 3986.
+func escapeURIComponent(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
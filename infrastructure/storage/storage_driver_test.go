@@ -0,0 +1,236 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/infrastructure/storage"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+func TestIsAllowedType(t *testing.T) {
+	category := &entity.StorageCategory{MimeTypes: "image/png, application/pdf"}
+
+	assert.True(t, storage.IsAllowedType(category, "application/pdf"))
+	assert.True(t, storage.IsAllowedType(category, "image/png"))
+	assert.False(t, storage.IsAllowedType(category, "image/gif"))
+}
+
+func TestStorageCategory_AllowedSize(t *testing.T) {
+	assert.Equal(t, int64(storage.MaxSize), (&entity.StorageCategory{}).AllowedSize(storage.MaxSize))
+	assert.Equal(t, int64(104857600), (&entity.StorageCategory{MaxSize: 104857600}).AllowedSize(storage.MaxSize))
+}
+
+func TestDetectAllowedType(t *testing.T) {
+	category := &entity.StorageCategory{MimeTypes: "image/jpg,image/jpeg,image/png"}
+	png := []byte("\x89PNG\r\n\x1a\n")
+
+	fileType, allowed := storage.DetectAllowedType(category, png)
+	assert.Equal(t, "image/png", fileType)
+	assert.True(t, allowed)
+
+	fileType, allowed = storage.DetectAllowedType(category, []byte("plain text"))
+	assert.Equal(t, "text/plain; charset=utf-8", fileType)
+	assert.False(t, allowed)
+}
+
+func TestLocalDriver_SignedURL(t *testing.T) {
+	root := t.TempDir()
+	driver, err := storage.NewLocalDriver(root, "http://localhost:8888/", "secret", nil)
+	assert.NoError(t, err)
+
+	objectPath := "avatar/image name.png"
+	err = driver.PutObject(context.Background(), objectPath, bytes.NewReader([]byte("content")), 7, "image/png")
+	assert.NoError(t, err)
+
+	content, err := ioutil.ReadFile(filepath.Join(root, "avatar", "image name.png"))
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+
+	signedURL, err := driver.SignedURL(context.Background(), objectPath, "image name.png", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(signedURL, "http://localhost:8888/storage/avatar/image%20name.png?"))
+
+	parsed, _ := url.Parse(signedURL)
+	query := parsed.Query()
+	assert.NoError(t, driver.Verify(objectPath, query.Get("expires"), query.Get("signature")))
+	assert.Equal(t, storage.ErrLocalSignatureInvalid, driver.Verify("avatar/other.png", query.Get("expires"), query.Get("signature")))
+	assert.Equal(t, storage.ErrLocalSignatureInvalid, driver.Verify(objectPath, "9999999999", query.Get("signature")))
+}
+
+func TestLocalDriver_PresignedPutURL(t *testing.T) {
+	driver, _ := storage.NewLocalDriver(t.TempDir(), "http://localhost:8888", "secret", nil)
+
+	uploadURL, headers, err := driver.PresignedPutURL(context.Background(), "document/report.pdf", "application/pdf", time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, "application/pdf", headers["Content-Type"])
+
+	parsed, _ := url.Parse(uploadURL)
+	query := parsed.Query()
+	assert.NoError(t, driver.VerifyUpload("document/report.pdf", query.Get("expires"), query.Get("signature")))
+	assert.Equal(t, storage.ErrLocalSignatureInvalid, driver.Verify("document/report.pdf", query.Get("expires"), query.Get("signature")))
+}
+
+func TestLocalDriver_Object(t *testing.T) {
+	driver, _ := storage.NewLocalDriver(t.TempDir(), "http://localhost:8888", "secret", nil)
+	ctx := context.Background()
+
+	_, err := driver.StatObject(ctx, "document/report.pdf")
+	assert.Equal(t, storage.ErrObjectNotFound, err)
+
+	_ = driver.PutObject(ctx, "document/report.pdf", bytes.NewReader([]byte("%PDF-1.4")), 8, "application/pdf")
+	size, err := driver.StatObject(ctx, "document/report.pdf")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), size)
+
+	object, err := driver.GetObject(ctx, "document/report.pdf")
+	assert.NoError(t, err)
+	content, _ := ioutil.ReadAll(object)
+	_ = object.Close()
+	assert.Equal(t, "%PDF-1.4", string(content))
+
+	assert.NoError(t, driver.RemoveObject(ctx, "document/report.pdf"))
+	assert.NoError(t, driver.RemoveObject(ctx, "document/report.pdf"))
+	_, err = driver.GetObject(ctx, "document/report.pdf")
+	assert.Equal(t, storage.ErrObjectNotFound, err)
+}
+
+func TestLocalDriver_CheckBucket(t *testing.T) {
+	root := t.TempDir()
+	driver, _ := storage.NewLocalDriver(root, "http://localhost:8888", "secret", nil)
+	assert.NoError(t, driver.CheckBucket(context.Background()))
+
+	driver, _ = storage.NewLocalDriver(filepath.Join(root, "missing"), "http://localhost:8888", "secret", nil)
+	assert.Equal(t, storage.ErrBucketNotFound, driver.CheckBucket(context.Background()))
+}
+
+func TestLocalDriver_Verify_Expired(t *testing.T) {
+	driver, _ := storage.NewLocalDriver(t.TempDir(), "http://localhost:8888", "secret", nil)
+	signedURL, _ := driver.SignedURL(context.Background(), "avatar/image.png", "image.png", -time.Minute)
+
+	parsed, _ := url.Parse(signedURL)
+	query := parsed.Query()
+	assert.Equal(t, storage.ErrLocalSignatureExpired, driver.Verify("avatar/image.png", query.Get("expires"), query.Get("signature")))
+}
+
+func TestLocalDriver_FullPath(t *testing.T) {
+	root := t.TempDir()
+	driver, _ := storage.NewLocalDriver(root, "http://localhost:8888", "secret", nil)
+
+	fullPath, err := driver.FullPath("../../etc/passwd")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "etc", "passwd"), fullPath)
+
+	_, err = driver.FullPath("")
+	assert.Error(t, err)
+}
+
+func TestNewLocalDriver_WithoutSigningKey(t *testing.T) {
+	_, err := storage.NewLocalDriver(t.TempDir(), "http://localhost:8888", "", nil)
+	assert.Error(t, err)
+}
+
+func TestS3Driver_SignedURL(t *testing.T) {
+	sess, _ := session.NewSession(&aws.Config{
+		Region:      aws.String("us-east-1"),
+		Credentials: credentials.NewStaticCredentials("key", "secret", ""),
+	})
+	driver := storage.NewS3Driver(s3.New(sess), "bucket", nil)
+
+	signedURL, err := driver.SignedURL(context.Background(), "avatar/image.png", "image.png", time.Minute)
+	assert.NoError(t, err)
+	assert.Contains(t, signedURL, "/avatar/image.png")
+	assert.Contains(t, signedURL, "X-Amz-Signature=")
+	assert.Contains(t, signedURL, "response-content-disposition=")
+}
+
+func gcsCredentialsSetup(t *testing.T) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("want non error, got %#v", err)
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	credentials, _ := json.Marshal(storage.GCSCredentials{
+		PrivateKey:  string(privateKey),
+		ClientEmail: "storage@example.iam.gserviceaccount.com",
+	})
+	return credentials
+}
+
+func TestGCSDriver_SignedURL(t *testing.T) {
+	driver, err := storage.NewGCSDriver(gcsCredentialsSetup(t), "bucket", nil)
+	assert.NoError(t, err)
+
+	signedURL, err := driver.SignedURL(context.Background(), "avatar/image.png", "image.png", time.Minute)
+	assert.NoError(t, err)
+
+	parsed, _ := url.Parse(signedURL)
+	query := parsed.Query()
+	assert.Equal(t, "storage.googleapis.com", parsed.Host)
+	assert.Equal(t, "/bucket/avatar/image.png", parsed.Path)
+	assert.Equal(t, "GOOG4-RSA-SHA256", query.Get("X-Goog-Algorithm"))
+	assert.Equal(t, "60", query.Get("X-Goog-Expires"))
+	assert.True(t, strings.HasPrefix(query.Get("X-Goog-Credential"), "storage@example.iam.gserviceaccount.com/"))
+	assert.Len(t, query.Get("X-Goog-Signature"), 512)
+}
+
+func TestGCSDriver_PutObject(t *testing.T) {
+	var uploaded []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer access-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "/upload/storage/v1/b/bucket/o", r.URL.Path)
+		assert.Equal(t, "avatar/image.png", r.URL.Query().Get("name"))
+		assert.Equal(t, "image/png", r.Header.Get("Content-Type"))
+		uploaded, _ = ioutil.ReadAll(r.Body)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	driver, _ := storage.NewGCSDriver(gcsCredentialsSetup(t), "bucket", nil)
+	driver.Endpoint = server.URL
+	driver.TokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "access-token", TokenType: "Bearer"})
+
+	err := driver.PutObject(context.Background(), "avatar/image.png", bytes.NewReader([]byte("content")), 7, "image/png")
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(uploaded))
+}
+
+func TestGCSDriver_CheckBucket(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/storage/v1/b/bucket" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"bucket"}`))
+	}))
+	defer server.Close()
+
+	driver, _ := storage.NewGCSDriver(gcsCredentialsSetup(t), "bucket", nil)
+	driver.Endpoint = server.URL
+	driver.TokenSource = oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "access-token", TokenType: "Bearer"})
+	assert.NoError(t, driver.CheckBucket(context.Background()))
+
+	missing, _ := storage.NewGCSDriver(gcsCredentialsSetup(t), "missing", nil)
+	missing.Endpoint = server.URL
+	missing.TokenSource = driver.TokenSource
+	assert.Equal(t, storage.ErrBucketNotFound, missing.CheckBucket(context.Background()))
+}
@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FileReference represents column of another table keeping UUID of storage file.
+// Files of Category are collected as garbage once no row of Table refers to them by Column.
+type FileReference struct {
+	Category string
+	Table    string
+	Column   string
+}
+
+// GarbageReport represents what has been removed by CollectGarbage.
+type GarbageReport struct {
+	Files   int64
+	Uploads int64
+}
+
+// SetReferences sets columns referring to storage file, a referenced file can not be deleted.
+func (s *fileStorage) SetReferences(references ...FileReference) {
+	s.references = references
+}
+
+// DeleteFile removes the object of the file and its record.
+// File still referenced by another record is kept and ErrorTextStorageFileInUse is returned.
+func (s *fileStorage) DeleteFile(UUID string) error {
+	var fileEntity entity.StorageFile
+
+	err := s.db.Where("uuid = ?", UUID).Take(&fileEntity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return exception.ErrorTextStorageFileNotFound
+		}
+		return err
+	}
+
+	referenced, err := s.isReferenced(UUID)
+	if err != nil {
+		return err
+	}
+	if referenced {
+		return exception.ErrorTextStorageFileInUse
+	}
+
+	return s.purgeFile(context.Background(), &fileEntity)
+}
+
+// CollectGarbage removes files created before olderThan ago which are no longer needed:
+// soft deleted files, direct uploads never confirmed and files of referenced categories no record refers to.
+// Resumable uploads which have expired are removed along with their received parts.
+func (s *fileStorage) CollectGarbage(olderThan time.Duration) (*GarbageReport, error) {
+	report := &GarbageReport{}
+	ctx := context.Background()
+	createdBefore := time.Now().Add(-olderThan)
+
+	candidates := []*gorm.DB{
+		s.db.Unscoped().Where("deleted_at IS NOT NULL"),
+		s.db.Where("status = ?", entity.StorageFileStatusPending),
+	}
+	for category, references := range s.referencesByCategory() {
+		fileCategory, err := s.findCategory(category)
+		if err != nil {
+			if errors.Is(err, exception.ErrorTextStorageCategoryNotFound) {
+				continue
+			}
+			return report, err
+		}
+
+		query := s.db.Where("category_uuid = ?", fileCategory.UUID)
+		for _, reference := range references {
+			query = query.Where("uuid NOT IN (?)", s.db.Table(reference.Table).
+				Select(reference.Column).
+				Where(reference.Column+" IS NOT NULL"))
+		}
+		candidates = append(candidates, query)
+	}
+
+	for _, candidate := range candidates {
+		var files []entity.StorageFile
+		err := candidate.Where("created_at < ?", createdBefore).Find(&files).Error
+		if err != nil {
+			return report, err
+		}
+
+		for index := range files {
+			if err := s.purgeFile(ctx, &files[index]); err != nil {
+				return report, err
+			}
+			report.Files++
+		}
+	}
+
+	var uploads []entity.StorageUpload
+	err := s.db.Where("expires_at < ?", time.Now()).Find(&uploads).Error
+	if err != nil {
+		return report, err
+	}
+	for _, upload := range uploads {
+		if err := s.deleteResumableUpload(upload.UUID); err != nil {
+			return report, err
+		}
+		report.Uploads++
+	}
+
+	return report, nil
+}
+
+// PendingScans returns UUID of uploaded files created before olderThan ago which are still waiting to be scanned,
+// their processing job has been lost when dispatching it failed.
+func (s *fileStorage) PendingScans(olderThan time.Duration) ([]string, error) {
+	var UUIDs []string
+	err := s.db.Model(&entity.StorageFile{}).
+		Where("status = ? AND scan_status = ?", entity.StorageFileStatusUploaded, entity.StorageFileScanPending).
+		Where("created_at < ?", time.Now().Add(-olderThan)).
+		Pluck("uuid", &UUIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return UUIDs, nil
+}
+
+// isReferenced returns true when any reference column keeps the given file UUID, soft deleted rows included.
+func (s *fileStorage) isReferenced(UUID string) (bool, error) {
+	for _, reference := range s.references {
+		var total int64
+		err := s.db.Table(reference.Table).Where(reference.Column+" = ?", UUID).Count(&total).Error
+		if err != nil {
+			return false, err
+		}
+		if total > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// referencesByCategory groups references by category of the referenced files.
+func (s *fileStorage) referencesByCategory() map[string][]FileReference {
+	references := make(map[string][]FileReference)
+	for _, reference := range s.references {
+		references[reference.Category] = append(references[reference.Category], reference)
+	}
+
+	return references
+}
+
+// purgeFile removes objects before their records, so a failure leaves a record to retry with.
+// Variants generated from the file are removed first.
+func (s *fileStorage) purgeFile(ctx context.Context, fileEntity *entity.StorageFile) error {
+	var variants []entity.StorageFileVariant
+	err := s.db.Where("file_uuid = ?", fileEntity.UUID).Find(&variants).Error
+	if err != nil {
+		return err
+	}
+	for index := range variants {
+		err := s.objects.RemoveObject(ctx, variants[index].Path)
+		if err != nil && !errors.Is(err, ErrObjectNotFound) {
+			return err
+		}
+		if err := s.db.Delete(&variants[index]).Error; err != nil {
+			return err
+		}
+	}
+
+	err = s.objects.RemoveObject(ctx, fileEntity.Path)
+	if err != nil && !errors.Is(err, ErrObjectNotFound) {
+		return err
+	}
+
+	return s.db.Unscoped().Delete(fileEntity).Error
+}
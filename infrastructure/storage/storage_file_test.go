@@ -0,0 +1,97 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/infrastructure/storage"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func storedFileSetup(t *testing.T, driver *storage.LocalDriver, dbConn *gorm.DB, createdAt time.Time) *entity.StorageFile {
+	fileCategory, err := storage.FindCategory(dbConn, storage.CategoryAvatar)
+	if err != nil {
+		t.Fatalf("want non error, got %#v", err)
+	}
+
+	content := []byte("content")
+	file := entity.StorageFile{
+		CategoryUUID: fileCategory.UUID,
+		UserUUID:     uuid.New().String(),
+		OriginalName: "content.txt",
+		Name:         "content.txt",
+		Path:         "test/avatar/" + uuid.New().String() + ".txt",
+		Type:         "text/plain",
+		Size:         int64(len(content)),
+		Status:       entity.StorageFileStatusUploaded,
+		CreatedAt:    createdAt,
+	}
+	if err := driver.PutObject(context.Background(), file.Path, bytes.NewReader(content), file.Size, file.Type); err != nil {
+		t.Fatalf("want non error, got %#v", err)
+	}
+	if err := dbConn.Create(&file).Error; err != nil {
+		t.Fatalf("want non error, got %#v", err)
+	}
+	return &file
+}
+
+func TestDeleteFile_InUse(t *testing.T) {
+	SkipThis(t)
+
+	driver, dbConn := localDriverSetup(t)
+	driver.SetReferences(storage.FileReference{Category: storage.CategoryAvatar, Table: "users", Column: "avatar_uuid"})
+	file := storedFileSetup(t, driver, dbConn, time.Now())
+
+	user := entity.User{Email: uuid.New().String() + "@example.com", AvatarUUID: file.UUID}
+	assert.NoError(t, dbConn.Create(&user).Error)
+	defer dbConn.Unscoped().Delete(&user)
+
+	assert.Equal(t, exception.ErrorTextStorageFileInUse, driver.DeleteFile(file.UUID))
+
+	assert.NoError(t, dbConn.Model(&user).Update("avatar_uuid", "").Error)
+	assert.NoError(t, driver.DeleteFile(file.UUID))
+	assert.Equal(t, exception.ErrorTextStorageFileNotFound, driver.DeleteFile(file.UUID))
+
+	_, err := driver.StatObject(context.Background(), file.Path)
+	assert.Equal(t, storage.ErrObjectNotFound, err)
+}
+
+func TestCollectGarbage_Success(t *testing.T) {
+	SkipThis(t)
+
+	driver, dbConn := localDriverSetup(t)
+	driver.SetReferences(storage.FileReference{Category: storage.CategoryAvatar, Table: "users", Column: "avatar_uuid"})
+	orphan := storedFileSetup(t, driver, dbConn, time.Now().Add(-48*time.Hour))
+	recent := storedFileSetup(t, driver, dbConn, time.Now())
+
+	report, err := driver.CollectGarbage(24 * time.Hour)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, report.Files, int64(1))
+
+	assert.Error(t, dbConn.Unscoped().Where("uuid = ?", orphan.UUID).Take(&entity.StorageFile{}).Error)
+	assert.NoError(t, dbConn.Where("uuid = ?", recent.UUID).Take(&entity.StorageFile{}).Error)
+}
+
+func TestPendingScans_Success(t *testing.T) {
+	SkipThis(t)
+
+	driver, dbConn := localDriverSetup(t)
+	stale := storedFileSetup(t, driver, dbConn, time.Now().Add(-2*time.Hour))
+	recent := storedFileSetup(t, driver, dbConn, time.Now())
+	scanned := storedFileSetup(t, driver, dbConn, time.Now().Add(-2*time.Hour))
+	for _, file := range []*entity.StorageFile{stale, recent} {
+		assert.NoError(t, dbConn.Model(file).Update("scan_status", entity.StorageFileScanPending).Error)
+	}
+
+	UUIDs, err := driver.PendingScans(time.Hour)
+	assert.NoError(t, err)
+	assert.Contains(t, UUIDs, stale.UUID)
+	assert.NotContains(t, UUIDs, recent.UUID)
+	assert.NotContains(t, UUIDs, scanned.UUID)
+}
@@ -1 +1,318 @@
 package storage
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultGCSEndpoint is the base URL of google cloud storage.
+	DefaultGCSEndpoint = "https://storage.googleapis.com"
+
+	gcsScope            = "https://www.googleapis.com/auth/devstorage.read_write"
+	gcsTokenURL         = "https://oauth2.googleapis.com/token"
+	gcsSigningAlgorithm = "GOOG4-RSA-SHA256"
+	gcsMaxExpiry        = 7 * 24 * time.Hour
+)
+
+// GCSCredentials represent service account credentials of google cloud.
+type GCSCredentials struct {
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// GCSDriver represents it self.
+type GCSDriver struct {
+	fileStorage
+	Endpoint    string
+	TokenSource oauth2.TokenSource
+	bucket      string
+	email       string
+	privateKey  *rsa.PrivateKey
+	now         func() time.Time
+}
+
+// NewGCSDriver creates new GCSDriver authorized by the service account credentials json.
+// Objects are uploaded by JSON API and downloaded by V4 signed URL.
+func NewGCSDriver(credentials []byte, bucket string, db *gorm.DB) (*GCSDriver, error) {
+	var account GCSCredentials
+	if err := json.Unmarshal(credentials, &account); err != nil {
+		return nil, err
+	}
+	if account.ClientEmail == "" || account.PrivateKey == "" {
+		return nil, errors.New("gcs credentials must contain client_email and private_key")
+	}
+	if account.TokenURI == "" {
+		account.TokenURI = gcsTokenURL
+	}
+
+	privateKey, err := parseRSAPrivateKey([]byte(account.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+
+	conf := &jwt.Config{
+		Email:        account.ClientEmail,
+		PrivateKey:   []byte(account.PrivateKey),
+		PrivateKeyID: account.PrivateKeyID,
+		Scopes:       []string{gcsScope},
+		TokenURL:     account.TokenURI,
+	}
+
+	d := &GCSDriver{
+		Endpoint:    DefaultGCSEndpoint,
+		TokenSource: conf.TokenSource(context.Background()),
+		bucket:      bucket,
+		email:       account.ClientEmail,
+		privateKey:  privateKey,
+		now:         time.Now,
+	}
+	d.fileStorage = fileStorage{objects: d, db: db}
+	return d, nil
+}
+
+// TestMode sets current GCSDriver run as test mode. Uses when running unit test or integration test.
+func (g GCSDriver) TestMode() *GCSDriver {
+	g.testMode = true
+	return &g
+}
+
+// PutObject uploads the object to the bucket by simple media upload.
+func (g *GCSDriver) PutObject(
+	ctx context.Context,
+	objectPath string,
+	reader io.Reader,
+	size int64,
+	contentType string) error {
+	query := url.Values{}
+	query.Set("uploadType", "media")
+	query.Set("name", objectPath)
+	endpoint := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?%s", g.Endpoint, url.PathEscape(g.bucket), query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, reader)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := oauth2.NewClient(ctx, g.TokenSource).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("gcs responded with status %d: %s", resp.StatusCode, body)
+	}
+
+	return nil
+}
+
+// GetObject returns reader of the object stored in the bucket.
+func (g *GCSDriver) GetObject(ctx context.Context, objectPath string) (io.ReadCloser, error) {
+	resp, err := g.do(ctx, http.MethodGet, g.objectEndpoint(objectPath)+"?alt=media")
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// StatObject returns size of the object stored in the bucket.
+func (g *GCSDriver) StatObject(ctx context.Context, objectPath string) (int64, error) {
+	resp, err := g.do(ctx, http.MethodGet, g.objectEndpoint(objectPath))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var metadata struct {
+		Size string `json:"size"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(metadata.Size, 10, 64)
+}
+
+// CheckBucket checks the bucket exists and is accessible by the service account.
+func (g *GCSDriver) CheckBucket(ctx context.Context) error {
+	resp, err := g.do(ctx, http.MethodGet, fmt.Sprintf("%s/storage/v1/b/%s", g.Endpoint, url.PathEscape(g.bucket)))
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			return ErrBucketNotFound
+		}
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// CloseIdleConnections closes connections to google cloud storage kept idle by the default http transport,
+// which is used by requests of the driver.
+func (g *GCSDriver) CloseIdleConnections() {
+	http.DefaultClient.CloseIdleConnections()
+}
+
+// RemoveObject removes the object from the bucket.
+func (g *GCSDriver) RemoveObject(ctx context.Context, objectPath string) error {
+	resp, err := g.do(ctx, http.MethodDelete, g.objectEndpoint(objectPath))
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// SignedURL returns V4 signed URL of the object, signed by private key of the service account.
+func (g *GCSDriver) SignedURL(ctx context.Context, objectPath string, fileName string, expiry time.Duration) (string, error) {
+	return g.signURL(http.MethodGet, objectPath, map[string]string{
+		"response-content-disposition": contentDisposition(fileName),
+	}, expiry)
+}
+
+// PresignedPutURL returns V4 signed URL to upload the object by PUT request.
+func (g *GCSDriver) PresignedPutURL(
+	ctx context.Context,
+	objectPath string,
+	contentType string,
+	expiry time.Duration) (string, map[string]string, error) {
+	fileURL, err := g.signURL(http.MethodPut, objectPath, nil, expiry)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fileURL, map[string]string{"Content-Type": contentType}, nil
+}
+
+func (g *GCSDriver) signURL(method string, objectPath string, params map[string]string, expiry time.Duration) (string, error) {
+	if expiry > gcsMaxExpiry {
+		expiry = gcsMaxExpiry
+	}
+
+	endpoint, err := url.Parse(g.Endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	now := g.now().UTC()
+	timestamp := now.Format("20060102T150405Z")
+	scope := fmt.Sprintf("%s/auto/storage/goog4_request", now.Format("20060102"))
+	canonicalURI := "/" + escapeObjectPath(g.bucket) + "/" + escapeObjectPath(objectPath)
+
+	query := map[string]string{
+		"X-Goog-Algorithm":     gcsSigningAlgorithm,
+		"X-Goog-Credential":    g.email + "/" + scope,
+		"X-Goog-Date":          timestamp,
+		"X-Goog-Expires":       fmt.Sprintf("%d", int64(expiry.Seconds())),
+		"X-Goog-SignedHeaders": "host",
+	}
+	for key, value := range params {
+		query[key] = value
+	}
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = escapeURIComponent(key) + "=" + escapeURIComponent(query[key])
+	}
+	canonicalQuery := strings.Join(pairs, "&")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		canonicalURI,
+		canonicalQuery,
+		"host:" + endpoint.Host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+	hashedRequest := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		gcsSigningAlgorithm,
+		timestamp,
+		scope,
+		hex.EncodeToString(hashedRequest[:]),
+	}, "\n")
+
+	hashed := sha256.Sum256([]byte(stringToSign))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, g.privateKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s://%s%s?%s&X-Goog-Signature=%s",
+		endpoint.Scheme, endpoint.Host, canonicalURI, canonicalQuery, hex.EncodeToString(signature)), nil
+}
+
+func (g *GCSDriver) objectEndpoint(objectPath string) string {
+	return fmt.Sprintf("%s/storage/v1/b/%s/o/%s", g.Endpoint, url.PathEscape(g.bucket), url.PathEscape(objectPath))
+}
+
+// do sends authorized request to JSON API, missing object is returned as ErrObjectNotFound.
+func (g *GCSDriver) do(ctx context.Context, method string, endpoint string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := oauth2.NewClient(ctx, g.TokenSource).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		return nil, ErrObjectNotFound
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("gcs responded with status %d: %s", resp.StatusCode, body)
+	}
+
+	return resp, nil
+}
+
+func parseRSAPrivateKey(key []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, errors.New("private key is not pem encoded")
+	}
+
+	if parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		if privateKey, ok := parsed.(*rsa.PrivateKey); ok {
+			return privateKey, nil
+		}
+		return nil, errors.New("private key is not a rsa key")
+	}
+
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
@@ -0,0 +1,535 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/pkg/webp"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"path"
+	"strconv"
+	"strings"
+
+	// Decoders of image formats accepted by image categories.
+	_ "image/gif"
+
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/webp"
+
+	"github.com/rs/zerolog/log"
+	xdraw "golang.org/x/image/draw"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// Max number of pixels of uploaded image, larger image is rejected before it is decoded.
+	MaxImagePixels = 40000000
+
+	// Quality of JPEG encoded by ImageProcessor when none is configured.
+	DefaultImageQuality = 90
+
+	// Collections of image variant format.
+	ImageFormatSource = ""
+	ImageFormatJPEG   = "jpeg"
+	ImageFormatPNG    = "png"
+	ImageFormatWebP   = "webp"
+
+	// Suffix of variant name encoded as WebP.
+	webpVariantSuffix = "_webp"
+)
+
+// ImageVariant represents a resized copy generated from uploaded image.
+// Size is the maximum width and height, smaller image keeps its size.
+// ImageFormatSource keeps JPEG as JPEG, anything else is encoded as PNG.
+type ImageVariant struct {
+	Name   string
+	Size   int
+	Format string
+}
+
+// ParseImageVariants parses variants written as name:size, such as small:64.
+// When withWebP is true every variant has a WebP copy named with _webp suffix, such as small_webp.
+func ParseImageVariants(specs []string, withWebP bool) ([]ImageVariant, error) {
+	var variants []ImageVariant
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		fields := strings.Split(spec, ":")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid image variant %s, want name:size", spec)
+		}
+		size, err := strconv.Atoi(fields[1])
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("invalid size of image variant %s", spec)
+		}
+
+		variants = append(variants, ImageVariant{Name: fields[0], Size: size, Format: ImageFormatSource})
+		if withWebP {
+			variants = append(variants, ImageVariant{Name: fields[0] + webpVariantSuffix, Size: size, Format: ImageFormatWebP})
+		}
+	}
+
+	return variants, nil
+}
+
+// ImageProcessor is a FileProcessor of files in image categories.
+// It validates the file by decoding it, strips EXIF metadata of JPEG and generates the configured variants.
+type ImageProcessor struct {
+	objects  ObjectStorage
+	db       *gorm.DB
+	variants []ImageVariant
+	quality  int
+}
+
+// ImageProcessor implements the FileProcessor interface.
+var _ FileProcessor = &ImageProcessor{}
+
+// NewImageProcessor will initialize image processor storing variants by the given object storage.
+func NewImageProcessor(objects ObjectStorage, db *gorm.DB, variants []ImageVariant, quality int) *ImageProcessor {
+	if quality <= 0 || quality > 100 {
+		quality = DefaultImageQuality
+	}
+
+	return &ImageProcessor{
+		objects:  objects,
+		db:       db,
+		variants: variants,
+		quality:  quality,
+	}
+}
+
+// Process handles the uploaded file, files of other categories or which are not clean are skipped.
+// A file which can not be decoded is removed, processing it again would fail the same way.
+func (p *ImageProcessor) Process(ctx context.Context, file *entity.StorageFile) error {
+	if !file.IsUploaded() || !file.IsClean() {
+		return nil
+	}
+	fileCategory, err := findCategoryByUUID(p.db, file.CategoryUUID)
+	if err != nil {
+		return err
+	}
+	if !IsImageCategory(fileCategory) {
+		return nil
+	}
+
+	object, err := p.objects.GetObject(ctx, file.Path)
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(object, fileCategory.AllowedSize(MaxSize)+1))
+	_ = object.Close()
+	if err != nil {
+		return err
+	}
+
+	img, format, err := decodeImage(bytes.NewReader(data))
+	if err != nil {
+		log.Warn().Err(err).Str("file", file.UUID).Msg("removing file which is not a valid image")
+		if err := p.objects.RemoveObject(ctx, file.Path); err != nil {
+			return err
+		}
+		return p.db.Delete(file).Error
+	}
+
+	orientation := 1
+	if format == ImageFormatJPEG {
+		orientation = jpegOrientation(data)
+		img = orientImage(img, orientation)
+		if err := p.stripMetadata(ctx, file, data, img, orientation); err != nil {
+			return err
+		}
+	}
+
+	variants, err := p.EncodeVariants(img, format)
+	if err != nil {
+		return err
+	}
+	for _, variant := range variants {
+		if err := p.storeVariant(ctx, file, variant); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// stripMetadata replaces the original JPEG by a copy without EXIF. Image rotated by EXIF orientation
+// is encoded again in its upright orientation, otherwise metadata segments are removed losslessly.
+func (p *ImageProcessor) stripMetadata(
+	ctx context.Context,
+	file *entity.StorageFile,
+	data []byte,
+	img image.Image,
+	orientation int) error {
+	stripped, changed := stripJPEGMetadata(data)
+	if orientation > 1 {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: p.quality}); err != nil {
+			return err
+		}
+		stripped, changed = buf.Bytes(), true
+	}
+	if !changed {
+		return nil
+	}
+
+	size := int64(len(stripped))
+	err := p.objects.PutObject(ctx, file.Path, bytes.NewReader(stripped), size, file.Type)
+	if err != nil {
+		return err
+	}
+	file.Size = size
+
+	return p.db.Model(file).Update("size", size).Error
+}
+
+// EncodedImageVariant is an ImageVariant encoded by ImageProcessor, ready to be stored.
+type EncodedImageVariant struct {
+	ImageVariant
+	ContentType string
+	Extension   string
+	Data        []byte
+	Width       int
+	Height      int
+}
+
+// EncodeVariants encodes the variants of img decoded from format. A WebP variant larger than the variant
+// of the same size in the format of the source is left out, clients use that variant instead.
+func (p *ImageProcessor) EncodeVariants(img image.Image, format string) ([]EncodedImageVariant, error) {
+	var encoded []EncodedImageVariant
+	sourceSizes := make(map[int]int)
+	for _, variant := range p.variants {
+		if variant.Format == ImageFormatWebP {
+			sourceSize, ok := sourceSizes[variant.Size]
+			if !ok {
+				source, err := encodeVariant(img, format, ImageVariant{Size: variant.Size}, p.quality)
+				if err != nil {
+					return nil, err
+				}
+				sourceSize = len(source.Data)
+				sourceSizes[variant.Size] = sourceSize
+			}
+
+			webpVariant, err := encodeVariant(img, format, variant, p.quality)
+			if err != nil {
+				return nil, err
+			}
+			if len(webpVariant.Data) > sourceSize {
+				log.Debug().Str("variant", variant.Name).Int("size", len(webpVariant.Data)).Int("source_size", sourceSize).
+					Msg("skipping WebP variant larger than the source format")
+				continue
+			}
+			encoded = append(encoded, webpVariant)
+			continue
+		}
+
+		sourceVariant, err := encodeVariant(img, format, variant, p.quality)
+		if err != nil {
+			return nil, err
+		}
+		if variant.Format == ImageFormatSource {
+			sourceSizes[variant.Size] = len(sourceVariant.Data)
+		}
+		encoded = append(encoded, sourceVariant)
+	}
+
+	return encoded, nil
+}
+
+// encodeVariant resizes img and encodes it in the format of the variant.
+func encodeVariant(img image.Image, format string, variant ImageVariant, quality int) (EncodedImageVariant, error) {
+	resized := resizeImage(img, variant.Size)
+
+	variantFormat := variant.Format
+	if variantFormat == ImageFormatSource {
+		variantFormat = ImageFormatPNG
+		if format == ImageFormatJPEG {
+			variantFormat = ImageFormatJPEG
+		}
+	}
+
+	var buf bytes.Buffer
+	var err error
+	switch variantFormat {
+	case ImageFormatJPEG:
+		err = jpeg.Encode(&buf, resized, &jpeg.Options{Quality: quality})
+	case ImageFormatWebP:
+		err = webp.Encode(&buf, resized, quality)
+	default:
+		variantFormat = ImageFormatPNG
+		err = png.Encode(&buf, resized)
+	}
+	if err != nil {
+		return EncodedImageVariant{}, err
+	}
+
+	bounds := resized.Bounds()
+	return EncodedImageVariant{
+		ImageVariant: variant,
+		ContentType:  "image/" + variantFormat,
+		Extension:    variantFormat,
+		Data:         buf.Bytes(),
+		Width:        bounds.Dx(),
+		Height:       bounds.Dy(),
+	}, nil
+}
+
+func (p *ImageProcessor) storeVariant(ctx context.Context, file *entity.StorageFile, variant EncodedImageVariant) error {
+	variantPath := strings.TrimSuffix(file.Path, path.Ext(file.Path)) + "_" + variant.Name + "." + variant.Extension
+	size := int64(len(variant.Data))
+	err := p.objects.PutObject(ctx, variantPath, bytes.NewReader(variant.Data), size, variant.ContentType)
+	if err != nil {
+		return err
+	}
+
+	return p.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "file_uuid"}, {Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"path", "type", "size", "width", "height", "updated_at"}),
+	}).Create(&entity.StorageFileVariant{
+		FileUUID: file.UUID,
+		Name:     variant.Name,
+		Path:     variantPath,
+		Type:     variant.ContentType,
+		Size:     size,
+		Width:    variant.Width,
+		Height:   variant.Height,
+	}).Error
+}
+
+// IsImageCategory returns true when every mime type of category is an image.
+func IsImageCategory(category *entity.StorageCategory) bool {
+	isImage := false
+	for _, v := range strings.Split(category.MimeTypes, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if !strings.HasPrefix(v, "image/") {
+			return false
+		}
+		isImage = true
+	}
+
+	return isImage
+}
+
+// ValidateImage decodes the uploaded file, content type detected from its first bytes is not enough.
+func ValidateImage(file *multipart.FileHeader) error {
+	fileOpen, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer fileOpen.Close()
+
+	_, _, err = decodeImage(fileOpen)
+	return err
+}
+
+// decodeImage decodes image of at most MaxImagePixels pixels and returns its format.
+func decodeImage(reader io.ReadSeeker) (image.Image, string, error) {
+	config, _, err := image.DecodeConfig(reader)
+	if err != nil {
+		return nil, "", err
+	}
+	if config.Width <= 0 || config.Height <= 0 || int64(config.Width)*int64(config.Height) > MaxImagePixels {
+		return nil, "", fmt.Errorf("image of %dx%d pixels is not allowed", config.Width, config.Height)
+	}
+
+	if _, err := reader.Seek(0, io.SeekStart); err != nil {
+		return nil, "", err
+	}
+	return image.Decode(reader)
+}
+
+// resizeImage scales the image down to fit within size x size, keeping its aspect ratio.
+func resizeImage(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return img
+	}
+
+	if width >= height {
+		height = maxInt(1, height*size/width)
+		width = size
+	} else {
+		width = maxInt(1, width*size/height)
+		height = size
+	}
+
+	resized := image.NewNRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(resized, resized.Bounds(), img, bounds, xdraw.Src, nil)
+	return resized
+}
+
+// orientImage transforms the image as described by EXIF orientation, so it is upright without EXIF.
+func orientImage(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	src := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+	w, h := bounds.Dx(), bounds.Dy()
+
+	dstWidth, dstHeight := w, h
+	if orientation >= 5 {
+		dstWidth, dstHeight = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		for x := 0; x < dstWidth; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			}
+			dst.SetNRGBA(x, y, src.NRGBAAt(sx, sy))
+		}
+	}
+
+	return dst
+}
+
+// jpegOrientation returns EXIF orientation of JPEG data, 1 when there is none.
+func jpegOrientation(data []byte) int {
+	orientation := 1
+	walkJPEGSegments(data, func(marker byte, segment []byte) bool {
+		if marker == 0xe1 && len(segment) > 6 && string(segment[:6]) == "Exif\x00\x00" {
+			orientation = tiffOrientation(segment[6:])
+			return false
+		}
+		return true
+	})
+
+	return orientation
+}
+
+// tiffOrientation reads orientation tag of the first IFD of EXIF TIFF structure.
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	for n := 0; n < count; n++ {
+		entry := offset + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8:]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+
+	return 1
+}
+
+// stripJPEGMetadata removes EXIF, XMP and IPTC segments of JPEG data, other segments are kept as is.
+// It returns false when there is nothing to remove or the data can not be parsed.
+func stripJPEGMetadata(data []byte) ([]byte, bool) {
+	stripped := make([]byte, 0, len(data))
+	stripped = append(stripped, data[:minInt(2, len(data))]...)
+	offset, changed := 2, false
+	complete := walkJPEGSegments(data, func(marker byte, segment []byte) bool {
+		end := offset + 4 + len(segment)
+		if marker == 0xe1 || marker == 0xed {
+			changed = true
+		} else {
+			stripped = append(stripped, data[offset:end]...)
+		}
+		offset = end
+		return true
+	})
+	if !complete || !changed {
+		return data, false
+	}
+
+	return append(stripped, data[offset:]...), true
+}
+
+// walkJPEGSegments calls fn with each segment before start of scan, until fn returns false.
+// It returns false when the data is not a JPEG or a segment is truncated.
+func walkJPEGSegments(data []byte, fn func(marker byte, segment []byte) bool) bool {
+	if len(data) < 4 || data[0] != 0xff || data[1] != 0xd8 {
+		return false
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xff {
+			return false
+		}
+		marker := data[i+1]
+		if marker == 0xda || marker == 0xd9 {
+			return true
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return false
+		}
+		if !fn(marker, data[i+4:i+2+length]) {
+			return true
+		}
+		i += 2 + length
+	}
+
+	return false
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
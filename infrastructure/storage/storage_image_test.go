@@ -0,0 +1,203 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/infrastructure/storage"
+	"go-rest-skeleton/pkg/webp"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"math/rand"
+	"mime/multipart"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// jpegWithOrientation encodes a width x height JPEG carrying EXIF orientation.
+func jpegWithOrientation(t *testing.T, width int, height int, orientation uint16) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 0x80, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatalf("want non error, got %#v", err)
+	}
+
+	tiff := []byte("II*\x00\x08\x00\x00\x00\x01\x00")
+	entry := make([]byte, 12)
+	binary.LittleEndian.PutUint16(entry[0:], 0x0112)
+	binary.LittleEndian.PutUint16(entry[2:], 3)
+	binary.LittleEndian.PutUint32(entry[4:], 1)
+	binary.LittleEndian.PutUint16(entry[8:], orientation)
+	tiff = append(append(tiff, entry...), 0, 0, 0, 0)
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+
+	app1 := []byte{0xff, 0xe1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segment)+2))
+	data := append([]byte{0xff, 0xd8}, app1...)
+	data = append(data, segment...)
+	return append(data, buf.Bytes()[2:]...)
+}
+
+func multipartFile(t *testing.T, name string, content []byte) *multipart.FileHeader {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", name)
+	_, _ = part.Write(content)
+	_ = writer.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, "/", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	_, file, err := req.FormFile("file")
+	if err != nil {
+		t.Fatalf("want non error, got %#v", err)
+	}
+	return file
+}
+
+func TestParseImageVariants(t *testing.T) {
+	variants, err := storage.ParseImageVariants([]string{"small:64", " large:1024 "}, true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []storage.ImageVariant{
+		{Name: "small", Size: 64, Format: storage.ImageFormatSource},
+		{Name: "small_webp", Size: 64, Format: storage.ImageFormatWebP},
+		{Name: "large", Size: 1024, Format: storage.ImageFormatSource},
+		{Name: "large_webp", Size: 1024, Format: storage.ImageFormatWebP},
+	}, variants)
+
+	_, err = storage.ParseImageVariants([]string{"small"}, false)
+	assert.Error(t, err)
+	_, err = storage.ParseImageVariants([]string{"small:0"}, false)
+	assert.Error(t, err)
+}
+
+func TestIsImageCategory(t *testing.T) {
+	assert.True(t, storage.IsImageCategory(&entity.StorageCategory{MimeTypes: "image/jpeg, image/png"}))
+	assert.False(t, storage.IsImageCategory(&entity.StorageCategory{MimeTypes: "image/png,application/pdf"}))
+	assert.False(t, storage.IsImageCategory(&entity.StorageCategory{MimeTypes: ""}))
+}
+
+func TestValidateImage(t *testing.T) {
+	valid := jpegWithOrientation(t, 8, 4, 1)
+	assert.NoError(t, storage.ValidateImage(multipartFile(t, "valid.jpeg", valid)))
+
+	// Starts like a JPEG, so it passes content type detection, but can not be decoded.
+	truncated := valid[:len(valid)/2]
+	assert.Equal(t, "image/jpeg", http.DetectContentType(truncated))
+	assert.Error(t, storage.ValidateImage(multipartFile(t, "truncated.jpeg", truncated)))
+}
+
+func TestImageProcessor_EncodeVariants(t *testing.T) {
+	if !webp.Available {
+		t.Skip("webp needs a build with cgo")
+	}
+
+	gradient := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			gradient.Set(x, y, color.RGBA{R: uint8(x * 4), G: uint8(y * 4), B: 0x80, A: 0xff})
+		}
+	}
+	noisy := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	random := rand.New(rand.NewSource(1))
+	random.Read(noisy.Pix)
+
+	variants := []storage.ImageVariant{
+		{Name: "small", Size: 32, Format: storage.ImageFormatSource},
+		{Name: "small_webp", Size: 32, Format: storage.ImageFormatWebP},
+	}
+	processor := storage.NewImageProcessor(nil, nil, variants, 0)
+
+	// WebP of a photo-like image is smaller than JPEG of the same quality, so both are kept.
+	encoded, err := processor.EncodeVariants(gradient, storage.ImageFormatJPEG)
+	assert.NoError(t, err)
+	if assert.Len(t, encoded, 2) {
+		assert.Equal(t, "image/jpeg", encoded[0].ContentType)
+		assert.Equal(t, 32, encoded[0].Width)
+		assert.Equal(t, "small_webp", encoded[1].Name)
+		assert.Equal(t, "image/webp", encoded[1].ContentType)
+		assert.Less(t, len(encoded[1].Data), len(encoded[0].Data))
+	}
+
+	// WebP of noise is larger than its JPEG, so only the JPEG is kept.
+	encoded, err = processor.EncodeVariants(noisy, storage.ImageFormatJPEG)
+	assert.NoError(t, err)
+	if assert.Len(t, encoded, 1) {
+		assert.Equal(t, "small", encoded[0].Name)
+	}
+
+	// Without a variant of the same size, WebP is compared to an encoding that is not kept.
+	webpOnly := storage.NewImageProcessor(nil, nil, variants[1:], 0)
+	encoded, err = webpOnly.EncodeVariants(noisy, storage.ImageFormatJPEG)
+	assert.NoError(t, err)
+	assert.Empty(t, encoded)
+}
+
+func TestImageProcessor_Process(t *testing.T) {
+	SkipThis(t)
+
+	driver, dbConn := localDriverSetup(t)
+	fileCategory, err := storage.FindCategory(dbConn, storage.CategoryAvatar)
+	if err != nil {
+		t.Fatalf("want non error, got %#v", err)
+	}
+
+	content := jpegWithOrientation(t, 40, 20, 6)
+	file := entity.StorageFile{
+		CategoryUUID: fileCategory.UUID,
+		OriginalName: "image.jpeg",
+		Name:         "image.jpeg",
+		Path:         "test/avatar/" + uuid.New().String() + ".jpeg",
+		Type:         "image/jpeg",
+		Size:         int64(len(content)),
+		Status:       entity.StorageFileStatusUploaded,
+		CreatedAt:    time.Now(),
+	}
+	ctx := context.Background()
+	assert.NoError(t, driver.PutObject(ctx, file.Path, bytes.NewReader(content), file.Size, file.Type))
+	assert.NoError(t, dbConn.Create(&file).Error)
+
+	variants := []storage.ImageVariant{
+		{Name: "small", Size: 10, Format: storage.ImageFormatSource},
+		{Name: "small_webp", Size: 10, Format: storage.ImageFormatWebP},
+	}
+	processor := storage.NewImageProcessor(driver, dbConn, variants, 0)
+	assert.NoError(t, processor.Process(ctx, &file))
+
+	// Orientation 6 turns the 40x20 image upright into 20x40, EXIF is gone afterwards.
+	object, _ := driver.GetObject(ctx, file.Path)
+	config, _, err := image.DecodeConfig(object)
+	_ = object.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, 20, config.Width)
+	assert.Equal(t, 40, config.Height)
+
+	var stored []entity.StorageFileVariant
+	assert.NoError(t, dbConn.Where("file_uuid = ?", file.UUID).Order("name").Find(&stored).Error)
+	if assert.Len(t, stored, 2) {
+		assert.Equal(t, "image/jpeg", stored[0].Type)
+		assert.Equal(t, 5, stored[0].Width)
+		assert.Equal(t, 10, stored[0].Height)
+		assert.Equal(t, "image/webp", stored[1].Type)
+	}
+
+	urls, err := driver.GetFileVariants(file.UUID)
+	assert.NoError(t, err)
+	assert.Contains(t, urls, storage.VariantOriginal)
+	assert.Contains(t, urls, "small_webp")
+
+	assert.NoError(t, driver.DeleteFile(file.UUID))
+	_, err = driver.StatObject(ctx, stored[0].Path)
+	assert.Equal(t, storage.ErrObjectNotFound, err)
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/infrastructure/queue"
+
+	"gorm.io/gorm"
+)
+
+// JobProcessFile is the job type of post-processing an uploaded file.
+const JobProcessFile = "storage:process_file"
+
+// ProcessFilePayload represents payload of JobProcessFile.
+type ProcessFilePayload struct {
+	UUID string `json:"uuid"`
+}
+
+// FileProcessor is an interface. Needs to be implemented by each post-processing stage of uploaded file.
+type FileProcessor interface {
+	Process(ctx context.Context, file *entity.StorageFile) error
+}
+
+// NewProcessFileJob creates a job to post-process the uploaded file by queue worker.
+func NewProcessFileJob(UUID string) (*queue.Job, error) {
+	return queue.NewJob(JobProcessFile, ProcessFilePayload{UUID: UUID})
+}
+
+// ProcessFileHandler returns queue handler of JobProcessFile. It runs the given processors in order.
+func ProcessFileHandler(db *gorm.DB, processors ...FileProcessor) queue.Handler {
+	return func(ctx context.Context, job *queue.Job) error {
+		var payload ProcessFilePayload
+		if err := job.Bind(&payload); err != nil {
+			return err
+		}
+
+		var fileEntity entity.StorageFile
+		err := db.Where("uuid = ?", payload.UUID).Take(&fileEntity).Error
+		if err != nil {
+			// File has been deleted since the job was dispatched, nothing to process.
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		for _, processor := range processors {
+			if err := processor.Process(ctx, &fileEntity); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,219 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LocalDownloadPath is the route serving and receiving files of LocalDriver.
+const LocalDownloadPath = "/storage"
+
+var (
+	// ErrLocalSignatureInvalid is returned when signature of the download URL does not match.
+	ErrLocalSignatureInvalid = errors.New("invalid signature")
+
+	// ErrLocalSignatureExpired is returned when the download URL has expired.
+	ErrLocalSignatureExpired = errors.New("signature has expired")
+)
+
+// LocalDriver stores files on local disk, uses by development and tests.
+// Files are served and received by the application through signed and expiring URL.
+type LocalDriver struct {
+	fileStorage
+	root       string
+	baseURL    string
+	signingKey []byte
+	now        func() time.Time
+}
+
+// NewLocalDriver creates new LocalDriver storing files under root.
+// Signed URL points to baseURL followed by LocalDownloadPath.
+func NewLocalDriver(root string, baseURL string, signingKey string, db *gorm.DB) (*LocalDriver, error) {
+	if signingKey == "" {
+		return nil, errors.New("local storage requires a signing key")
+	}
+
+	d := &LocalDriver{
+		root:       root,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		signingKey: []byte(signingKey),
+		now:        time.Now,
+	}
+	d.fileStorage = fileStorage{objects: d, db: db}
+	return d, nil
+}
+
+// TestMode sets current LocalDriver run as test mode. Uses when running unit test or integration test.
+func (l LocalDriver) TestMode() *LocalDriver {
+	l.testMode = true
+	return &l
+}
+
+// PutObject writes the object under root directory.
+func (l *LocalDriver) PutObject(
+	ctx context.Context,
+	objectPath string,
+	reader io.Reader,
+	size int64,
+	contentType string) error {
+	fullPath, err := l.FullPath(objectPath)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(fullPath)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, reader); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// GetObject opens the object stored under root directory.
+func (l *LocalDriver) GetObject(ctx context.Context, objectPath string) (io.ReadCloser, error) {
+	fullPath, err := l.FullPath(objectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(fullPath)
+	if os.IsNotExist(err) {
+		return nil, ErrObjectNotFound
+	}
+	return file, err
+}
+
+// StatObject returns size of the object stored under root directory.
+func (l *LocalDriver) StatObject(ctx context.Context, objectPath string) (int64, error) {
+	fullPath, err := l.FullPath(objectPath)
+	if err != nil {
+		return 0, err
+	}
+
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, ErrObjectNotFound
+		}
+		return 0, err
+	}
+
+	return info.Size(), nil
+}
+
+// CheckBucket checks the root directory exists.
+func (l *LocalDriver) CheckBucket(ctx context.Context) error {
+	info, err := os.Stat(l.root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ErrBucketNotFound
+		}
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", l.root)
+	}
+
+	return nil
+}
+
+// RemoveObject removes the object from root directory.
+func (l *LocalDriver) RemoveObject(ctx context.Context, objectPath string) error {
+	fullPath, err := l.FullPath(objectPath)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// SignedURL returns download URL of the object which is valid until expiry.
+func (l *LocalDriver) SignedURL(ctx context.Context, objectPath string, fileName string, expiry time.Duration) (string, error) {
+	return l.signURL(http.MethodGet, objectPath, expiry), nil
+}
+
+// PresignedPutURL returns URL to upload the object by PUT request which is valid until expiry.
+func (l *LocalDriver) PresignedPutURL(
+	ctx context.Context,
+	objectPath string,
+	contentType string,
+	expiry time.Duration) (string, map[string]string, error) {
+	return l.signURL(http.MethodPut, objectPath, expiry), map[string]string{"Content-Type": contentType}, nil
+}
+
+// Verify checks signature and expiry of the download URL of the object.
+func (l *LocalDriver) Verify(objectPath string, expires string, signature string) error {
+	return l.verify(http.MethodGet, objectPath, expires, signature)
+}
+
+// VerifyUpload checks signature and expiry of the upload URL of the object.
+func (l *LocalDriver) VerifyUpload(objectPath string, expires string, signature string) error {
+	return l.verify(http.MethodPut, objectPath, expires, signature)
+}
+
+func (l *LocalDriver) signURL(method string, objectPath string, expiry time.Duration) string {
+	expires := strconv.FormatInt(l.now().Add(expiry).Unix(), 10)
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", l.sign(method, objectPath, expires))
+
+	return fmt.Sprintf("%s%s/%s?%s", l.baseURL, LocalDownloadPath, escapeObjectPath(objectPath), query.Encode())
+}
+
+func (l *LocalDriver) verify(method string, objectPath string, expires string, signature string) error {
+	expected := l.sign(method, objectPath, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrLocalSignatureInvalid
+	}
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrLocalSignatureInvalid
+	}
+	if l.now().Unix() > expiresAt {
+		return ErrLocalSignatureExpired
+	}
+
+	return nil
+}
+
+// FullPath returns path on disk of the object, the object must be inside root directory.
+func (l *LocalDriver) FullPath(objectPath string) (string, error) {
+	cleaned := path.Clean("/" + objectPath)
+	if cleaned == "/" {
+		return "", ErrLocalSignatureInvalid
+	}
+
+	return filepath.Join(l.root, filepath.FromSlash(cleaned)), nil
+}
+
+func (l *LocalDriver) sign(method string, objectPath string, expires string) string {
+	mac := hmac.New(sha256.New, l.signingKey)
+	mac.Write([]byte(method + "\n" + objectPath + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
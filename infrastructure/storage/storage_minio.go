@@ -1,16 +1,10 @@
 package storage
 
 import (
-	"bytes"
 	"context"
-	"errors"
-	"fmt"
-	"go-rest-skeleton/domain/entity"
-	"go-rest-skeleton/infrastructure/message/exception"
-	"mime/multipart"
-	"net/http"
+	"io"
 	"net/url"
-	"strings"
+	"time"
 
 	"github.com/minio/minio-go/v7"
 	"gorm.io/gorm"
@@ -18,15 +12,19 @@ import (
 
 // MinioDriver represents it self.
 type MinioDriver struct {
-	client   *minio.Client
-	bucket   string
-	db       *gorm.DB
-	testMode bool
+	fileStorage
+	client *minio.Client
+	bucket string
 }
 
+// MinioDriver implements the PostPolicyStorage interface.
+var _ PostPolicyStorage = &MinioDriver{}
+
 // MinioDriver creates new MinioDriver.
 func NewMinioDriver(client *minio.Client, bucket string, db *gorm.DB) *MinioDriver {
-	return &MinioDriver{client: client, bucket: bucket, db: db}
+	d := &MinioDriver{client: client, bucket: bucket}
+	d.fileStorage = fileStorage{objects: d, db: db}
+	return d
 }
 
 // TestMode sets current MinioDriver run as test mode. Uses when running unit test or integration test.
@@ -35,111 +33,105 @@ func (c MinioDriver) TestMode() *MinioDriver {
 	return &c
 }
 
-// UploadFile uploads the given file to minio server.
-func (c *MinioDriver) UploadFile(file *multipart.FileHeader, category string) (string, map[string]string, error, interface{}) {
-	var fileEntity entity.StorageFile
-	var fileCategory entity.StorageCategory
-	var fileAllowed bool
-
-	fileOpen, err := file.Open()
-	if err != nil {
-		return "", nil, exception.ErrorTextStorageUploadCannotOpenFile, nil
-	}
-	defer fileOpen.Close()
-
-	fileSize := file.Size
-	if fileSize > int64(MaxSize) {
-		return "", nil, exception.ErrorTextStorageUploadInvalidSize, fmt.Sprintf("Size:%d", MaxSize/1000000)
-	}
+// PutObject uploads the object to minio server.
+func (c *MinioDriver) PutObject(
+	ctx context.Context,
+	objectPath string,
+	reader io.Reader,
+	size int64,
+	contentType string) error {
+	userMetaData := map[string]string{"x-amz-acl": "public-read"}
+	fileMetaData := minio.PutObjectOptions{ContentType: contentType, CacheControl: CacheControl, UserMetadata: userMetaData}
+	_, err := c.client.PutObject(ctx, c.bucket, objectPath, reader, size, fileMetaData)
+	return err
+}
 
-	buffer := make([]byte, fileSize)
-	_, _ = fileOpen.Read(buffer)
-	fileType := http.DetectContentType(buffer)
+// SignedURL returns presigned URL of the object.
+func (c *MinioDriver) SignedURL(ctx context.Context, objectPath string, fileName string, expiry time.Duration) (string, error) {
+	reqParams := make(url.Values)
+	reqParams.Set("response-content-disposition", contentDisposition(fileName))
 
-	err = c.db.Where("slug = ?", category).Take(&fileCategory).Error
+	fileURL, err := c.client.PresignedGetObject(ctx, c.bucket, objectPath, expiry, reqParams)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return "", nil, exception.ErrorTextStorageCategoryNotFound, nil
-		}
-		return "", nil, err, nil
+		return "", err
 	}
 
-	fileMimeTypes := strings.Split(fileCategory.MimeTypes, ",")
-	fileAllowed = false
-	for _, v := range fileMimeTypes {
-		if strings.HasPrefix(fileType, v) {
-			fileAllowed = true
-		}
-	}
-	if !fileAllowed {
-		return "", nil, exception.ErrorTextStorageUploadInvalidFileType, fmt.Sprintf("Type:%s", fileCategory.MimeTypes)
-	}
+	return fileURL.String(), nil
+}
 
-	fileOriginalName := file.Filename
-	fileName := FormatFileName(file.Filename).String()
-	filePath := c.FormatFilePath(fileCategory.Path, fileName)
-	fileBytes := bytes.NewReader(buffer)
-	cacheControl := "max-age=31536000"
-	userMetaData := map[string]string{"x-amz-acl": "public-read"}
-	fileMetaData := minio.PutObjectOptions{ContentType: fileType, CacheControl: cacheControl, UserMetadata: userMetaData}
-	_, err = c.client.PutObject(context.Background(), c.bucket, filePath, fileBytes, fileSize, fileMetaData)
-	if err != nil {
-		return "", nil, err, nil
-	}
+// GetObject returns reader of the object stored in minio server.
+func (c *MinioDriver) GetObject(ctx context.Context, objectPath string) (io.ReadCloser, error) {
+	return c.client.GetObject(ctx, c.bucket, objectPath, minio.GetObjectOptions{})
+}
 
-	fileEntity.CategoryUUID = fileCategory.UUID
-	fileEntity.OriginalName = fileOriginalName
-	fileEntity.Name = fileName
-	fileEntity.Type = fileType
-	fileEntity.Size = fileSize
-	fileEntity.Path = filePath
-	err = c.db.Create(&fileEntity).Error
+// StatObject returns size of the object stored in minio server.
+func (c *MinioDriver) StatObject(ctx context.Context, objectPath string) (int64, error) {
+	info, err := c.client.StatObject(ctx, c.bucket, objectPath, minio.StatObjectOptions{})
 	if err != nil {
-		return "", nil, err, nil
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return 0, ErrObjectNotFound
+		}
+		return 0, err
 	}
 
-	return fileEntity.UUID, nil, nil, nil
+	return info.Size, nil
 }
 
-// GetFile gets file from minio server and return signed URL.
-func (c *MinioDriver) GetFile(UUID string) (interface{}, error) {
-	var fileEntity entity.StorageFile
-	var fileCategory entity.StorageCategory
+// RemoveObject removes the object from minio server.
+func (c *MinioDriver) RemoveObject(ctx context.Context, objectPath string) error {
+	return c.client.RemoveObject(ctx, c.bucket, objectPath, minio.RemoveObjectOptions{})
+}
 
-	err := c.db.Where("uuid = ?", UUID).Take(&fileEntity).Error
+// CheckBucket checks the bucket exists in minio server.
+func (c *MinioDriver) CheckBucket(ctx context.Context) error {
+	exists, err := c.client.BucketExists(ctx, c.bucket)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, nil
-		}
-		return nil, err
+		return err
 	}
-
-	err = c.db.Where("uuid = ?", fileEntity.CategoryUUID).Take(&fileCategory).Error
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, exception.ErrorTextStorageCategoryNotFound
-		}
-		return nil, err
+	if !exists {
+		return ErrBucketNotFound
 	}
 
-	reqParams := make(url.Values)
-	reqParams.Set("response-content-disposition", fmt.Sprintf("inline; filename=%s", fileEntity.Name))
+	return nil
+}
 
-	filePath := c.FormatFilePath(fileCategory.Path, fileEntity.Name)
-	fileURL, err := c.client.PresignedGetObject(context.Background(), c.bucket, filePath, ReqExpired, reqParams)
+// PresignedPutURL returns presigned URL to upload the object by PUT request.
+func (c *MinioDriver) PresignedPutURL(
+	ctx context.Context,
+	objectPath string,
+	contentType string,
+	expiry time.Duration) (string, map[string]string, error) {
+	fileURL, err := c.client.PresignedPutObject(ctx, c.bucket, objectPath, expiry)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
 
-	return fileURL.String(), nil
+	return fileURL.String(), map[string]string{"Content-Type": contentType}, nil
 }
 
-// FormatFilePath formats file path by given fileName and category path.
-func (c MinioDriver) FormatFilePath(fileCategoryPath string, fileName string) string {
-	filePath := fileCategoryPath + "/" + fileName
-	if c.testMode {
-		return "test/" + filePath
+// PresignedPostPolicy returns URL and form fields to upload the object by browser based POST request.
+// The policy limits content type and size of the object.
+func (c *MinioDriver) PresignedPostPolicy(
+	ctx context.Context,
+	objectPath string,
+	contentType string,
+	maxSize int64,
+	expiry time.Duration) (string, map[string]string, error) {
+	policy := minio.NewPostPolicy()
+	_ = policy.SetBucket(c.bucket)
+	_ = policy.SetKey(objectPath)
+	_ = policy.SetExpires(time.Now().UTC().Add(expiry))
+	if err := policy.SetContentType(contentType); err != nil {
+		return "", nil, err
+	}
+	if err := policy.SetContentLengthRange(1, maxSize); err != nil {
+		return "", nil, err
+	}
+
+	fileURL, formData, err := c.client.PresignedPostPolicy(ctx, policy)
+	if err != nil {
+		return "", nil, err
 	}
 
-	return filePath
+	return fileURL.String(), formData, nil
 }
@@ -1,47 +1,150 @@
 package storage
 
 import (
-	"mime/multipart"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/s3"
-
-	"github.com/google/uuid"
-
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"gorm.io/gorm"
 )
 
-type S3Adapter struct {
-	S3Driver *S3Driver
+// S3Driver represents it self.
+type S3Driver struct {
+	fileStorage
+	client   *s3.S3
+	uploader *s3manager.Uploader
+	bucket   string
 }
 
+// NewS3Driver creates new S3Driver.
 func NewS3Driver(client *s3.S3, bucket string, db *gorm.DB) *S3Driver {
-	return &S3Driver{client: client, bucket: bucket, db: db}
+	d := &S3Driver{client: client, uploader: s3manager.NewUploaderWithClient(client), bucket: bucket}
+	d.fileStorage = fileStorage{objects: d, db: db}
+	return d
 }
 
+// TestMode sets current S3Driver run as test mode. Uses when running unit test or integration test.
 func (w S3Driver) TestMode() *S3Driver {
 	w.testMode = true
 	return &w
 }
 
-func (w *S3Adapter) UploadFile(file *multipart.FileHeader, category string) (string, map[string]string, error, interface{}) {
-	return w.S3Driver.UploadFile(file, category)
+// PutObject uploads the object to s3 bucket, large object is uploaded in parts.
+func (w *S3Driver) PutObject(
+	ctx context.Context,
+	objectPath string,
+	reader io.Reader,
+	size int64,
+	contentType string) error {
+	_, err := w.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket:       aws.String(w.bucket),
+		Key:          aws.String(objectPath),
+		Body:         reader,
+		ContentType:  aws.String(contentType),
+		CacheControl: aws.String(CacheControl),
+	})
+	return err
 }
 
-func (w *S3Adapter) GetFile(UUID string) (interface{}, error) {
-	return w.S3Driver.GetFile(UUID)
+// SignedURL returns presigned URL of the object.
+func (w *S3Driver) SignedURL(ctx context.Context, objectPath string, fileName string, expiry time.Duration) (string, error) {
+	req, _ := w.client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket:                     aws.String(w.bucket),
+		Key:                        aws.String(objectPath),
+		ResponseContentDisposition: aws.String(contentDisposition(fileName)),
+	})
+	req.SetContext(ctx)
+
+	return req.Presign(expiry)
 }
 
-type S3Driver struct {
-	client   *s3.S3
-	bucket   string
-	db       *gorm.DB
-	testMode bool
+// GetObject returns reader of the object stored in s3 bucket.
+func (w *S3Driver) GetObject(ctx context.Context, objectPath string) (io.ReadCloser, error) {
+	output, err := w.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(w.bucket),
+		Key:    aws.String(objectPath),
+	})
+	if err != nil {
+		return nil, s3Error(err)
+	}
+
+	return output.Body, nil
 }
 
-func (w *S3Driver) UploadFile(file *multipart.FileHeader, category string) (string, map[string]string, error, interface{}) {
-	return uuid.New().String(), nil, nil, nil
+// StatObject returns size of the object stored in s3 bucket.
+func (w *S3Driver) StatObject(ctx context.Context, objectPath string) (int64, error) {
+	output, err := w.client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(w.bucket),
+		Key:    aws.String(objectPath),
+	})
+	if err != nil {
+		return 0, s3Error(err)
+	}
+
+	return aws.Int64Value(output.ContentLength), nil
 }
 
-func (w *S3Driver) GetFile(UUID string) (interface{}, error) {
-	return UUID, nil
+// CheckBucket checks the bucket exists and is accessible by the credentials.
+func (w *S3Driver) CheckBucket(ctx context.Context) error {
+	_, err := w.client.HeadBucketWithContext(ctx, &s3.HeadBucketInput{Bucket: aws.String(w.bucket)})
+	if errors.Is(s3Error(err), ErrObjectNotFound) {
+		return ErrBucketNotFound
+	}
+
+	return err
+}
+
+// CloseIdleConnections closes connections to s3 kept idle by the http client of the driver.
+func (w *S3Driver) CloseIdleConnections() {
+	if w.client.Config.HTTPClient != nil {
+		w.client.Config.HTTPClient.CloseIdleConnections()
+	}
+}
+
+// RemoveObject removes the object from s3 bucket.
+func (w *S3Driver) RemoveObject(ctx context.Context, objectPath string) error {
+	_, err := w.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(w.bucket),
+		Key:    aws.String(objectPath),
+	})
+	return err
+}
+
+// PresignedPutURL returns presigned URL to upload the object by PUT request.
+// Content type is part of the signature, the client must send the returned headers.
+func (w *S3Driver) PresignedPutURL(
+	ctx context.Context,
+	objectPath string,
+	contentType string,
+	expiry time.Duration) (string, map[string]string, error) {
+	req, _ := w.client.PutObjectRequest(&s3.PutObjectInput{
+		Bucket:       aws.String(w.bucket),
+		Key:          aws.String(objectPath),
+		ContentType:  aws.String(contentType),
+		CacheControl: aws.String(CacheControl),
+	})
+	req.SetContext(ctx)
+
+	fileURL, err := req.Presign(expiry)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fileURL, map[string]string{"Content-Type": contentType, "Cache-Control": CacheControl}, nil
+}
+
+// s3Error maps missing object error of s3 to ErrObjectNotFound.
+func s3Error(err error) error {
+	var requestFailure awserr.RequestFailure
+	if errors.As(err, &requestFailure) && requestFailure.StatusCode() == http.StatusNotFound {
+		return ErrObjectNotFound
+	}
+
+	return err
 }
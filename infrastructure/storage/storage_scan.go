@@ -0,0 +1,219 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"go-rest-skeleton/domain/entity"
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+const (
+	// Collections of scanner.
+	ScannerNone   = ""
+	ScannerClamAV = "clamav"
+	ScannerEICAR  = "eicar"
+
+	// Size of each chunk streamed to clamd.
+	clamAVChunkSize = 32 * 1024
+
+	// Signature reported by EICARScanner.
+	eicarSignature = "Eicar-Test-Signature"
+)
+
+// eicarTestString is the standard antivirus test file, harmless by design.
+// It is split so this source file is not detected itself.
+var eicarTestString = []byte(`X5O!P%@AP[4\PZX54(P^)7CC)7}$` + `EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`)
+
+// ScanResult represents the verdict of a Scanner, Signature names the malware found.
+type ScanResult struct {
+	Infected  bool
+	Signature string
+}
+
+// Scanner is an interface. Needs to be implemented by each malware scanner of uploaded files.
+type Scanner interface {
+	Scan(ctx context.Context, reader io.Reader) (*ScanResult, error)
+}
+
+// NewScanner will initialize scanner by its name, ScannerNone returns nil as scanning is disabled.
+func NewScanner(name string, clamAVAddress string, clamAVTimeout time.Duration) (Scanner, error) {
+	switch name {
+	case ScannerNone:
+		return nil, nil
+	case ScannerClamAV:
+		return NewClamAVScanner(clamAVAddress, clamAVTimeout), nil
+	case ScannerEICAR:
+		return &EICARScanner{}, nil
+	default:
+		return nil, fmt.Errorf("unknown storage scanner %s", name)
+	}
+}
+
+// ClamAVScanner is a Scanner sending the file to clamd over TCP by its INSTREAM command.
+type ClamAVScanner struct {
+	address string
+	timeout time.Duration
+}
+
+// ClamAVScanner implements the Scanner interface.
+var _ Scanner = &ClamAVScanner{}
+
+// NewClamAVScanner will initialize scanner of clamd listening on the given address, such as 127.0.0.1:3310.
+func NewClamAVScanner(address string, timeout time.Duration) *ClamAVScanner {
+	return &ClamAVScanner{
+		address: address,
+		timeout: timeout,
+	}
+}
+
+// Scan streams the reader to clamd in chunks. The file must not be larger than StreamMaxLength of clamd.
+func (s *ClamAVScanner) Scan(ctx context.Context, reader io.Reader) (*ScanResult, error) {
+	dialer := net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if s.timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(s.timeout))
+	}
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return nil, err
+	}
+
+	chunk := make([]byte, clamAVChunkSize)
+	size := make([]byte, 4)
+	for {
+		n, errRead := reader.Read(chunk)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size, uint32(n))
+			if _, err := conn.Write(size); err != nil {
+				return nil, err
+			}
+			if _, err := conn.Write(chunk[:n]); err != nil {
+				return nil, err
+			}
+		}
+		if errRead == io.EOF {
+			break
+		}
+		if errRead != nil {
+			return nil, errRead
+		}
+	}
+	binary.BigEndian.PutUint32(size, 0)
+	if _, err := conn.Write(size); err != nil {
+		return nil, err
+	}
+
+	reply, err := ioutil.ReadAll(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseClamAVReply(string(reply))
+}
+
+// parseClamAVReply parses reply of INSTREAM, such as "stream: OK" or "stream: Eicar-Signature FOUND".
+func parseClamAVReply(reply string) (*ScanResult, error) {
+	reply = strings.TrimSpace(strings.TrimRight(reply, "\x00"))
+	verdict := strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case verdict == "OK":
+		return &ScanResult{}, nil
+	case strings.HasSuffix(verdict, " FOUND"):
+		return &ScanResult{Infected: true, Signature: strings.TrimSuffix(verdict, " FOUND")}, nil
+	default:
+		return nil, fmt.Errorf("clamd responded with %s", reply)
+	}
+}
+
+// EICARScanner is a Scanner only detecting the EICAR test file, it is meant for tests and development.
+type EICARScanner struct{}
+
+// EICARScanner implements the Scanner interface.
+var _ Scanner = &EICARScanner{}
+
+// Scan looks for the EICAR test string anywhere in the reader.
+func (s *EICARScanner) Scan(ctx context.Context, reader io.Reader) (*ScanResult, error) {
+	// The tail of the previous chunk is kept, so the test string split across two reads is still found.
+	window := make([]byte, 0, clamAVChunkSize+len(eicarTestString))
+	chunk := make([]byte, clamAVChunkSize)
+	for {
+		n, err := reader.Read(chunk)
+		window = append(window, chunk[:n]...)
+		if bytes.Contains(window, eicarTestString) {
+			return &ScanResult{Infected: true, Signature: eicarSignature}, nil
+		}
+		if keep := len(eicarTestString) - 1; len(window) > keep {
+			window = append(window[:0], window[len(window)-keep:]...)
+		}
+		if err == io.EOF {
+			return &ScanResult{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// ScanProcessor is a FileProcessor scanning uploaded files for malware.
+// It must run before any other processor, they skip files which are not clean.
+type ScanProcessor struct {
+	objects ObjectStorage
+	db      *gorm.DB
+	scanner Scanner
+}
+
+// ScanProcessor implements the FileProcessor interface.
+var _ FileProcessor = &ScanProcessor{}
+
+// NewScanProcessor will initialize processor scanning objects of the given object storage.
+func NewScanProcessor(objects ObjectStorage, db *gorm.DB, scanner Scanner) *ScanProcessor {
+	return &ScanProcessor{
+		objects: objects,
+		db:      db,
+		scanner: scanner,
+	}
+}
+
+// Process scans the file waiting to be scanned and marks it clean or quarantined.
+// The object of a quarantined file is kept for inspection, but it is never served.
+func (p *ScanProcessor) Process(ctx context.Context, file *entity.StorageFile) error {
+	if !file.IsUploaded() || file.ScanStatus != entity.StorageFileScanPending {
+		return nil
+	}
+
+	object, err := p.objects.GetObject(ctx, file.Path)
+	if err != nil {
+		return err
+	}
+	result, err := p.scanner.Scan(ctx, object)
+	_ = object.Close()
+	if err != nil {
+		return err
+	}
+
+	file.ScanStatus = entity.StorageFileScanClean
+	file.ScanResult = ""
+	if result.Infected {
+		log.Warn().Str("file", file.UUID).Str("signature", result.Signature).Msg("quarantining infected file")
+		file.ScanStatus = entity.StorageFileScanQuarantined
+		file.ScanResult = result.Signature
+	}
+
+	return p.db.Model(file).Updates(map[string]interface{}{
+		"scan_status": file.ScanStatus,
+		"scan_result": file.ScanResult,
+	}).Error
+}
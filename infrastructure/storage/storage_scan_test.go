@@ -0,0 +1,163 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/infrastructure/storage"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// eicar is the standard antivirus test file, split so the source itself is not detected.
+var eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$` + `EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// fakeClamd accepts a single INSTREAM command and replies with the given reply, received stream is sent to the channel.
+func fakeClamd(t *testing.T, reply string) (string, <-chan []byte) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("want non error, got %#v", err)
+	}
+	received := make(chan []byte, 1)
+
+	go func() {
+		defer listener.Close()
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		command := make([]byte, len("zINSTREAM\x00"))
+		if _, err := io.ReadFull(conn, command); err != nil || string(command) != "zINSTREAM\x00" {
+			return
+		}
+		var stream []byte
+		size := make([]byte, 4)
+		for {
+			if _, err := io.ReadFull(conn, size); err != nil {
+				return
+			}
+			length := binary.BigEndian.Uint32(size)
+			if length == 0 {
+				break
+			}
+			chunk := make([]byte, length)
+			if _, err := io.ReadFull(conn, chunk); err != nil {
+				return
+			}
+			stream = append(stream, chunk...)
+		}
+		received <- stream
+		_, _ = conn.Write([]byte(reply + "\x00"))
+	}()
+
+	return listener.Addr().String(), received
+}
+
+func TestNewScanner(t *testing.T) {
+	scanner, err := storage.NewScanner(storage.ScannerNone, "", 0)
+	assert.NoError(t, err)
+	assert.Nil(t, scanner)
+
+	scanner, err = storage.NewScanner(storage.ScannerEICAR, "", 0)
+	assert.NoError(t, err)
+	assert.IsType(t, &storage.EICARScanner{}, scanner)
+
+	_, err = storage.NewScanner("unknown", "", 0)
+	assert.Error(t, err)
+}
+
+func TestEICARScanner_Scan(t *testing.T) {
+	scanner := &storage.EICARScanner{}
+
+	result, err := scanner.Scan(context.Background(), strings.NewReader("harmless document"))
+	assert.NoError(t, err)
+	assert.False(t, result.Infected)
+
+	// Test string crossing the boundary of two reads.
+	padding := bytes.Repeat([]byte("a"), 32*1024-10)
+	result, err = scanner.Scan(context.Background(), io.MultiReader(bytes.NewReader(padding), strings.NewReader(eicar)))
+	assert.NoError(t, err)
+	assert.True(t, result.Infected)
+	assert.NotEmpty(t, result.Signature)
+}
+
+func TestClamAVScanner_Scan(t *testing.T) {
+	content := bytes.Repeat([]byte("document"), 10000)
+
+	address, received := fakeClamd(t, "stream: OK")
+	result, err := storage.NewClamAVScanner(address, 5*time.Second).Scan(context.Background(), bytes.NewReader(content))
+	assert.NoError(t, err)
+	assert.False(t, result.Infected)
+	assert.Equal(t, content, <-received)
+
+	address, _ = fakeClamd(t, "stream: Win.Test.EICAR_HDB-1 FOUND")
+	result, err = storage.NewClamAVScanner(address, 5*time.Second).Scan(context.Background(), strings.NewReader(eicar))
+	assert.NoError(t, err)
+	assert.True(t, result.Infected)
+	assert.Equal(t, "Win.Test.EICAR_HDB-1", result.Signature)
+
+	address, _ = fakeClamd(t, "INSTREAM size limit exceeded. ERROR")
+	_, err = storage.NewClamAVScanner(address, 5*time.Second).Scan(context.Background(), bytes.NewReader(content))
+	assert.Error(t, err)
+}
+
+func TestScanProcessor_Process(t *testing.T) {
+	SkipThis(t)
+
+	driver, dbConn := localDriverSetup(t)
+	driver.SetScanner(&storage.EICARScanner{})
+	fileCategory, err := storage.FindCategory(dbConn, storage.CategoryDocument)
+	if err != nil {
+		t.Fatalf("want non error, got %#v", err)
+	}
+
+	processor := storage.NewScanProcessor(driver, dbConn, &storage.EICARScanner{})
+	samples := map[string]string{
+		"harmless document": entity.StorageFileScanClean,
+		eicar:               entity.StorageFileScanQuarantined,
+	}
+	for content, scanStatus := range samples {
+		file := entity.StorageFile{
+			CategoryUUID: fileCategory.UUID,
+			OriginalName: "document.txt",
+			Name:         "document.txt",
+			Path:         "test/document/" + uuid.New().String() + ".txt",
+			Type:         "text/plain",
+			Size:         int64(len(content)),
+			Status:       entity.StorageFileStatusUploaded,
+			ScanStatus:   entity.StorageFileScanPending,
+		}
+		ctx := context.Background()
+		assert.NoError(t, driver.PutObject(ctx, file.Path, strings.NewReader(content), file.Size, file.Type))
+		assert.NoError(t, dbConn.Create(&file).Error)
+
+		_, err := driver.GetFile(file.UUID, storage.VariantOriginal)
+		assert.Equal(t, exception.ErrorTextStorageFileScanPending, err)
+
+		assert.NoError(t, processor.Process(ctx, &file))
+
+		var stored entity.StorageFile
+		assert.NoError(t, dbConn.Where("uuid = ?", file.UUID).Take(&stored).Error)
+		assert.Equal(t, scanStatus, stored.ScanStatus)
+
+		fileURL, err := driver.GetFile(file.UUID, storage.VariantOriginal)
+		if scanStatus == entity.StorageFileScanClean {
+			assert.NoError(t, err)
+			assert.NotNil(t, fileURL)
+		} else {
+			assert.Equal(t, exception.ErrorTextStorageFileQuarantined, err)
+		}
+
+		assert.NoError(t, driver.DeleteFile(file.UUID))
+	}
+}
@@ -71,7 +71,7 @@ func TestUploadFile_Success(t *testing.T) {
 			t.Fatalf("want non error, got %#v", errGetFile)
 		}
 
-		fileName, _, errException, _ := storageService.Storage.UploadFile(file, "avatar")
+		fileName, _, errException, _ := storageService.Storage.UploadFile(file, "avatar", "")
 		if errException != nil {
 			t.Fatalf("want non error, got %#v", errException)
 		}
@@ -147,7 +147,7 @@ func TestGetFile_Success(t *testing.T) {
 			t.Fatalf("want non error, got %#v", errGetFile)
 		}
 
-		fileName, _, errException, _ := storageService.Storage.UploadFile(file, "avatar")
+		fileName, _, errException, _ := storageService.Storage.UploadFile(file, "avatar", "")
 		if errException != nil {
 			t.Fatalf("want non error, got %#v", errException)
 		}
@@ -168,7 +168,7 @@ func TestGetFile_Success(t *testing.T) {
 	response := encoder.ResponseDecoder(w.Body)
 
 	fileUUID := response["data"].(string)
-	url, errGetURL := storageService.Storage.GetFile(fileUUID)
+	url, errGetURL := storageService.Storage.GetFile(fileUUID, storage.VariantOriginal)
 	if errGetURL != nil {
 		t.Fatalf("want non error, got %#v", errGetURL)
 	}
@@ -0,0 +1,504 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/pkg/response"
+	"go-rest-skeleton/pkg/validator"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// Collections of direct upload method.
+	UploadMethodPut  = "PUT"
+	UploadMethodPost = "POST"
+
+	// Expiry of presigned upload URL.
+	DirectUploadExpired = 60 * 60 * time.Second
+
+	// Expiry of resumable upload, unfinished upload can not be resumed afterward.
+	ResumableUploadExpired = 24 * 60 * 60 * time.Second
+)
+
+// uploadLocks serializes writes to resumable uploads. It is local to this instance like the received parts,
+// resumable uploads are disabled by STORAGE_RESUMABLE_UPLOAD when requests may reach another instance.
+var uploadLocks sync.Map
+
+// DirectUploadRequest represents file the client is going to upload directly to the bucket.
+type DirectUploadRequest struct {
+	Category    string `json:"category" form:"category"`
+	FileName    string `json:"file_name" form:"file_name"`
+	Size        int64  `json:"size" form:"size"`
+	ContentType string `json:"content_type" form:"content_type"`
+	Method      string `json:"method" form:"method"`
+	UserUUID    string `json:"-" form:"-"`
+}
+
+// ResumableUploadRequest represents file the client is going to upload in parts.
+type ResumableUploadRequest struct {
+	Category string
+	FileName string
+	Length   int64
+	UserUUID string
+}
+
+// DirectUpload represents presigned request the client uses to upload directly to the bucket.
+// Headers must be sent along with PUT request, FormData must be sent as fields of POST multipart form.
+type DirectUpload struct {
+	UUID      string            `json:"uuid"`
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	FormData  map[string]string `json:"form_data,omitempty"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// ValidateDirectUploadRequest will validate create direct upload request.
+func (r *DirectUploadRequest) ValidateDirectUploadRequest() []response.ErrorForm {
+	validation := validator.New()
+	validation.
+		Set("category", r.Category, validation.AddRule().Required().Apply()).
+		Set("file_name", r.FileName, validation.AddRule().Required().MaxLength(255).Apply()).
+		Set("size", r.Size, validation.AddRule().Required().MinValue(1).Apply()).
+		Set("content_type", r.ContentType, validation.AddRule().Required().MaxLength(36).Apply()).
+		Set("method", strings.ToUpper(r.Method), validation.AddRule().In("", UploadMethodPut, UploadMethodPost).Apply())
+
+	return validation.Validate()
+}
+
+// ValidateResumableUploadRequest will validate create resumable upload request.
+func (r *ResumableUploadRequest) ValidateResumableUploadRequest() []response.ErrorForm {
+	validation := validator.New()
+	validation.
+		Set("category", r.Category, validation.AddRule().Required().Apply()).
+		Set("file_name", r.FileName, validation.AddRule().Required().MaxLength(255).Apply()).
+		Set("length", r.Length, validation.AddRule().Required().MinValue(1).Apply())
+
+	return validation.Validate()
+}
+
+// CreateDirectUpload validates the declared file against its category, records it as pending file
+// and returns presigned request to upload it. The file is usable after ConfirmDirectUpload.
+func (s *fileStorage) CreateDirectUpload(upload *DirectUploadRequest) (*DirectUpload, error, interface{}) {
+	fileCategory, err := s.findCategory(upload.Category)
+	if err != nil {
+		return nil, err, nil
+	}
+
+	maxSize := fileCategory.AllowedSize(MaxSize)
+	if upload.Size <= 0 || upload.Size > maxSize {
+		return nil, exception.ErrorTextStorageUploadInvalidSize, sizeArgs(maxSize)
+	}
+	if !IsAllowedType(fileCategory, upload.ContentType) {
+		return nil, exception.ErrorTextStorageUploadInvalidFileType, typeArgs(fileCategory)
+	}
+
+	method := strings.ToUpper(upload.Method)
+	if method == "" {
+		method = UploadMethodPut
+	}
+	postPolicy, supportPost := s.objects.(PostPolicyStorage)
+	if method != UploadMethodPut && !(method == UploadMethodPost && supportPost) {
+		return nil, exception.ErrorTextStorageUploadMethodNotSupported, nil
+	}
+
+	fileName := FormatFileName(upload.FileName).String()
+	filePath := s.FormatFilePath(fileCategory.Path, fileName)
+	ctx := context.Background()
+	directUpload := &DirectUpload{Method: method, ExpiresAt: time.Now().Add(DirectUploadExpired)}
+	if method == UploadMethodPost {
+		directUpload.URL, directUpload.FormData, err = postPolicy.PresignedPostPolicy(
+			ctx, filePath, upload.ContentType, maxSize, DirectUploadExpired)
+	} else {
+		directUpload.URL, directUpload.Headers, err = s.objects.PresignedPutURL(
+			ctx, filePath, upload.ContentType, DirectUploadExpired)
+	}
+	if err != nil {
+		return nil, err, nil
+	}
+
+	fileEntity := entity.StorageFile{
+		CategoryUUID: fileCategory.UUID,
+		UserUUID:     upload.UserUUID,
+		OriginalName: upload.FileName,
+		Name:         fileName,
+		Type:         upload.ContentType,
+		Size:         upload.Size,
+		Path:         filePath,
+		Status:       entity.StorageFileStatusPending,
+		ScanStatus:   s.initialScanStatus(),
+	}
+	err = s.db.Create(&fileEntity).Error
+	if err != nil {
+		return nil, err, nil
+	}
+	directUpload.UUID = fileEntity.UUID
+
+	return directUpload, nil, nil
+}
+
+// ConfirmDirectUpload checks the object uploaded by the client against its category and marks the file as uploaded.
+// Object violating the category, or an image which can not be decoded, is removed together with its file record.
+// File of another user is not found.
+func (s *fileStorage) ConfirmDirectUpload(UUID string, userUUID string) (string, error, interface{}) {
+	var fileEntity entity.StorageFile
+
+	err := s.db.Where("uuid = ? AND user_uuid = ?", UUID, userUUID).Take(&fileEntity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", exception.ErrorTextStorageFileNotFound, nil
+		}
+		return "", err, nil
+	}
+	if fileEntity.IsUploaded() {
+		return fileEntity.UUID, nil, nil
+	}
+
+	fileCategory, err := s.findCategoryByUUID(fileEntity.CategoryUUID)
+	if err != nil {
+		return "", err, nil
+	}
+
+	ctx := context.Background()
+	fileSize, err := s.objects.StatObject(ctx, fileEntity.Path)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			return "", exception.ErrorTextStorageUploadNotUploaded, nil
+		}
+		return "", err, nil
+	}
+
+	if maxSize := fileCategory.AllowedSize(MaxSize); fileSize > maxSize {
+		return "", s.rejectDirectUpload(ctx, &fileEntity, exception.ErrorTextStorageUploadInvalidSize), sizeArgs(maxSize)
+	}
+
+	object, err := s.objects.GetObject(ctx, fileEntity.Path)
+	if err != nil {
+		return "", err, nil
+	}
+	header, err := readHeader(object)
+	_ = object.Close()
+	if err != nil {
+		return "", err, nil
+	}
+
+	fileType, fileAllowed := DetectAllowedType(fileCategory, header)
+	if !fileAllowed {
+		return "", s.rejectDirectUpload(ctx, &fileEntity, exception.ErrorTextStorageUploadInvalidFileType), typeArgs(fileCategory)
+	}
+	if IsImageCategory(fileCategory) {
+		data, err := s.readObject(ctx, fileEntity.Path, fileSize)
+		if err != nil {
+			return "", err, nil
+		}
+		if _, _, err := decodeImage(bytes.NewReader(data)); err != nil {
+			return "", s.rejectDirectUpload(ctx, &fileEntity, exception.ErrorTextStorageUploadInvalidFileType), typeArgs(fileCategory)
+		}
+	}
+
+	err = s.db.Model(&fileEntity).Updates(map[string]interface{}{
+		"size":   fileSize,
+		"type":   fileType,
+		"status": entity.StorageFileStatusUploaded,
+	}).Error
+	if err != nil {
+		return "", err, nil
+	}
+
+	return fileEntity.UUID, nil, nil
+}
+
+// CreateResumableUpload validates the declared length against the category and starts a resumable upload.
+func (s *fileStorage) CreateResumableUpload(upload *ResumableUploadRequest) (*entity.StorageUpload, error, interface{}) {
+	fileCategory, err := s.findCategory(upload.Category)
+	if err != nil {
+		return nil, err, nil
+	}
+
+	maxSize := fileCategory.AllowedSize(MaxSize)
+	if upload.Length <= 0 || upload.Length > maxSize {
+		return nil, exception.ErrorTextStorageUploadInvalidSize, sizeArgs(maxSize)
+	}
+
+	storageUpload := entity.StorageUpload{
+		CategoryUUID: fileCategory.UUID,
+		UserUUID:     upload.UserUUID,
+		FileName:     upload.FileName,
+		Length:       upload.Length,
+		ExpiresAt:    time.Now().Add(ResumableUploadExpired),
+	}
+	err = s.db.Create(&storageUpload).Error
+	if err != nil {
+		return nil, err, nil
+	}
+
+	if err := os.MkdirAll(s.uploadDir(), 0755); err != nil {
+		return nil, err, nil
+	}
+	part, err := os.Create(s.partPath(storageUpload.UUID))
+	if err != nil {
+		return nil, err, nil
+	}
+	if err := part.Close(); err != nil {
+		return nil, err, nil
+	}
+
+	return &storageUpload, nil, nil
+}
+
+// GetResumableUpload gets resumable upload of userUUID which has not expired by UUID.
+func (s *fileStorage) GetResumableUpload(UUID string, userUUID string) (*entity.StorageUpload, error) {
+	var upload entity.StorageUpload
+
+	err := s.db.Where("uuid = ? AND user_uuid = ?", UUID, userUUID).Take(&upload).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.ErrorTextStorageUploadNotFound
+		}
+		return nil, err
+	}
+	if upload.IsExpired(time.Now()) {
+		return nil, exception.ErrorTextStorageUploadNotFound
+	}
+
+	return &upload, nil
+}
+
+// WriteResumableUpload appends bytes of reader to the upload starting at offset, which must be the current offset.
+// Once every byte has been received the file is stored by the driver and recorded with UUID of the upload.
+func (s *fileStorage) WriteResumableUpload(
+	UUID string,
+	userUUID string,
+	offset int64,
+	reader io.Reader) (*entity.StorageUpload, error, interface{}) {
+	unlock := lockUpload(UUID)
+	defer unlock()
+
+	upload, err := s.GetResumableUpload(UUID, userUUID)
+	if err != nil {
+		return nil, err, nil
+	}
+	if offset != upload.Offset {
+		return nil, exception.ErrorTextStorageUploadOffsetMismatch, nil
+	}
+
+	fileCategory, err := s.findCategoryByUUID(upload.CategoryUUID)
+	if err != nil {
+		return nil, err, nil
+	}
+
+	// Bytes received before the connection broke are kept, the client resumes from the recorded offset.
+	written, errWrite := s.appendPart(upload, reader)
+	if errWrite != nil && written == 0 {
+		return nil, errWrite, nil
+	}
+	upload.Offset += written
+
+	if upload.Type == "" && (upload.Offset >= sniffLength || upload.IsComplete()) {
+		header, err := s.readPartHeader(upload.UUID)
+		if err != nil {
+			return nil, err, nil
+		}
+
+		fileType, fileAllowed := DetectAllowedType(fileCategory, header)
+		if !fileAllowed {
+			_ = s.deleteResumableUpload(upload.UUID)
+			return nil, exception.ErrorTextStorageUploadInvalidFileType, typeArgs(fileCategory)
+		}
+		upload.Type = fileType
+	}
+
+	result := s.db.Model(&entity.StorageUpload{}).
+		Where("uuid = ? AND upload_offset = ?", upload.UUID, offset).
+		Updates(map[string]interface{}{"upload_offset": upload.Offset, "type": upload.Type})
+	if result.Error != nil {
+		return nil, result.Error, nil
+	}
+	if result.RowsAffected == 0 {
+		return nil, exception.ErrorTextStorageUploadOffsetMismatch, nil
+	}
+	if errWrite != nil {
+		return nil, errWrite, nil
+	}
+
+	if upload.IsComplete() {
+		if err, errArgs := s.completeResumableUpload(upload, fileCategory); err != nil {
+			return nil, err, errArgs
+		}
+	}
+
+	return upload, nil, nil
+}
+
+// DeleteResumableUpload terminates the upload of userUUID and removes bytes received so far.
+func (s *fileStorage) DeleteResumableUpload(UUID string, userUUID string) error {
+	if _, err := s.GetResumableUpload(UUID, userUUID); err != nil {
+		return err
+	}
+
+	return s.deleteResumableUpload(UUID)
+}
+
+// deleteResumableUpload removes the upload record and bytes received so far, regardless of its owner.
+func (s *fileStorage) deleteResumableUpload(UUID string) error {
+	err := s.db.Where("uuid = ?", UUID).Delete(&entity.StorageUpload{}).Error
+	if err != nil {
+		return err
+	}
+
+	uploadLocks.Delete(UUID)
+	if err := os.Remove(s.partPath(UUID)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// completeResumableUpload stores the received file, an image which can not be decoded is rejected and removed.
+func (s *fileStorage) completeResumableUpload(
+	upload *entity.StorageUpload,
+	fileCategory *entity.StorageCategory) (error, interface{}) {
+	part, err := os.Open(s.partPath(upload.UUID))
+	if err != nil {
+		return err, nil
+	}
+	defer part.Close()
+
+	if IsImageCategory(fileCategory) {
+		if _, _, err := decodeImage(part); err != nil {
+			_ = part.Close()
+			if err := s.deleteResumableUpload(upload.UUID); err != nil {
+				return err, nil
+			}
+			return exception.ErrorTextStorageUploadInvalidFileType, typeArgs(fileCategory)
+		}
+		if _, err := part.Seek(0, io.SeekStart); err != nil {
+			return err, nil
+		}
+	}
+
+	fileName := FormatFileName(upload.FileName).String()
+	filePath := s.FormatFilePath(fileCategory.Path, fileName)
+	err = s.objects.PutObject(context.Background(), filePath, part, upload.Length, upload.Type)
+	if err != nil {
+		return err, nil
+	}
+
+	fileEntity := entity.StorageFile{
+		UUID:         upload.UUID,
+		CategoryUUID: fileCategory.UUID,
+		UserUUID:     upload.UserUUID,
+		OriginalName: upload.FileName,
+		Name:         fileName,
+		Type:         upload.Type,
+		Size:         upload.Length,
+		Path:         filePath,
+		Status:       entity.StorageFileStatusUploaded,
+		ScanStatus:   s.initialScanStatus(),
+	}
+	err = s.db.Create(&fileEntity).Error
+	if err != nil {
+		return err, nil
+	}
+
+	return s.deleteResumableUpload(upload.UUID), nil
+}
+
+// lockUpload serializes writes to the same upload within this instance and returns its unlock function.
+// The lock is released from uploadLocks when the upload is deleted.
+func lockUpload(UUID string) func() {
+	lock, _ := uploadLocks.LoadOrStore(UUID, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	return lock.(*sync.Mutex).Unlock
+}
+
+// appendPart appends the reader to the received part, never beyond length of the upload.
+func (s *fileStorage) appendPart(upload *entity.StorageUpload, reader io.Reader) (int64, error) {
+	part, err := os.OpenFile(s.partPath(upload.UUID), os.O_WRONLY, 0644)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, exception.ErrorTextStorageUploadNotFound
+		}
+		return 0, err
+	}
+	defer part.Close()
+
+	// Bytes beyond the recorded offset belong to a failed request, they are overwritten.
+	if err := part.Truncate(upload.Offset); err != nil {
+		return 0, err
+	}
+	if _, err := part.Seek(upload.Offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	return io.Copy(part, io.LimitReader(reader, upload.Length-upload.Offset))
+}
+
+func (s *fileStorage) readPartHeader(UUID string) ([]byte, error) {
+	part, err := os.Open(s.partPath(UUID))
+	if err != nil {
+		return nil, err
+	}
+	defer part.Close()
+
+	return readHeader(part)
+}
+
+// readObject reads the stored object of at most size bytes.
+func (s *fileStorage) readObject(ctx context.Context, objectPath string, size int64) ([]byte, error) {
+	object, err := s.objects.GetObject(ctx, objectPath)
+	if err != nil {
+		return nil, err
+	}
+	defer object.Close()
+
+	return ioutil.ReadAll(io.LimitReader(object, size))
+}
+
+func (s *fileStorage) rejectDirectUpload(ctx context.Context, fileEntity *entity.StorageFile, reason error) error {
+	if err := s.objects.RemoveObject(ctx, fileEntity.Path); err != nil {
+		return err
+	}
+	if err := s.db.Delete(fileEntity).Error; err != nil {
+		return err
+	}
+
+	return reason
+}
+
+func (s *fileStorage) uploadDir() string {
+	if s.uploadPath == "" {
+		return filepath.Join(os.TempDir(), "go-rest-skeleton-uploads")
+	}
+
+	return s.uploadPath
+}
+
+func (s *fileStorage) partPath(UUID string) string {
+	return filepath.Join(s.uploadDir(), filepath.Base(UUID)+".part")
+}
+
+// findCategoryByUUID gets storage category by its UUID.
+func findCategoryByUUID(db *gorm.DB, UUID string) (*entity.StorageCategory, error) {
+	var fileCategory entity.StorageCategory
+	err := db.Where("uuid = ?", UUID).Take(&fileCategory).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.ErrorTextStorageCategoryNotFound
+		}
+		return nil, err
+	}
+
+	return &fileCategory, nil
+}
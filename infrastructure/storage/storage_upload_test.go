@@ -0,0 +1,205 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/infrastructure/storage"
+	"go-rest-skeleton/pkg/util"
+	"io/ioutil"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func localDriverSetup(t *testing.T) (*storage.LocalDriver, *gorm.DB) {
+	conf := InitConfig()
+	dbConn, err := DBConnSetup(conf.DBTestConfig)
+	if err != nil {
+		t.Fatalf("want non error, got %#v", err)
+	}
+	if _, err := SeedStorageCategories(dbConn); err != nil {
+		t.Fatalf("want non error, got %#v", err)
+	}
+
+	driver, err := storage.NewLocalDriver(t.TempDir(), "http://localhost:8888", "secret", dbConn)
+	if err != nil {
+		t.Fatalf("want non error, got %#v", err)
+	}
+	driver.SetUploadPath(t.TempDir())
+	return driver, dbConn
+}
+
+func TestResumableUpload_Success(t *testing.T) {
+	SkipThis(t)
+
+	driver, dbConn := localDriverSetup(t)
+	content, _ := ioutil.ReadFile(fmt.Sprintf("%s/tests/file/image.jpeg", util.RootDir()))
+	length := int64(len(content))
+
+	userUUID := uuid.New().String()
+	upload, errException, _ := driver.CreateResumableUpload(&storage.ResumableUploadRequest{
+		Category: "avatar",
+		FileName: "image.jpeg",
+		Length:   length,
+		UserUUID: userUUID,
+	})
+	assert.NoError(t, errException)
+
+	upload, errException, _ = driver.WriteResumableUpload(upload.UUID, userUUID, 0, bytes.NewReader(content[:1024]))
+	assert.NoError(t, errException)
+	assert.Equal(t, int64(1024), upload.Offset)
+
+	_, errException, _ = driver.WriteResumableUpload(upload.UUID, userUUID, 0, bytes.NewReader(content[:1024]))
+	assert.Equal(t, exception.ErrorTextStorageUploadOffsetMismatch, errException)
+
+	foreignUUID := uuid.New().String()
+	_, errException, _ = driver.WriteResumableUpload(upload.UUID, foreignUUID, 1024, bytes.NewReader(content[1024:]))
+	assert.Equal(t, exception.ErrorTextStorageUploadNotFound, errException)
+	assert.Equal(t, exception.ErrorTextStorageUploadNotFound, driver.DeleteResumableUpload(upload.UUID, foreignUUID))
+
+	upload, errException, _ = driver.WriteResumableUpload(upload.UUID, userUUID, 1024, bytes.NewReader(content[1024:]))
+	assert.NoError(t, errException)
+	assert.True(t, upload.IsComplete())
+
+	var file entity.StorageFile
+	assert.NoError(t, dbConn.Where("uuid = ?", upload.UUID).Take(&file).Error)
+	assert.Equal(t, entity.StorageFileStatusUploaded, file.Status)
+	assert.Equal(t, "image/jpeg", file.Type)
+	assert.Equal(t, length, file.Size)
+
+	_, err := driver.GetResumableUpload(upload.UUID, userUUID)
+	assert.Equal(t, exception.ErrorTextStorageUploadNotFound, err)
+}
+
+func TestDirectUpload_Success(t *testing.T) {
+	SkipThis(t)
+
+	driver, dbConn := localDriverSetup(t)
+	content, _ := ioutil.ReadFile(fmt.Sprintf("%s/tests/file/image.jpeg", util.RootDir()))
+
+	userUUID := uuid.New().String()
+	directUpload, errException, _ := driver.CreateDirectUpload(&storage.DirectUploadRequest{
+		Category:    "avatar",
+		FileName:    "image.jpeg",
+		Size:        int64(len(content)),
+		ContentType: "image/jpeg",
+		UserUUID:    userUUID,
+	})
+	assert.NoError(t, errException)
+	assert.Equal(t, storage.UploadMethodPut, directUpload.Method)
+
+	fileURL, _ := driver.GetFile(directUpload.UUID, storage.VariantOriginal)
+	assert.Nil(t, fileURL)
+
+	_, errException, _ = driver.ConfirmDirectUpload(directUpload.UUID, userUUID)
+	assert.Equal(t, exception.ErrorTextStorageUploadNotUploaded, errException)
+
+	var file entity.StorageFile
+	_ = dbConn.Where("uuid = ?", directUpload.UUID).Take(&file).Error
+	_ = driver.PutObject(context.Background(), file.Path, bytes.NewReader(content), int64(len(content)), "image/jpeg")
+
+	_, errException, _ = driver.ConfirmDirectUpload(directUpload.UUID, uuid.New().String())
+	assert.Equal(t, exception.ErrorTextStorageFileNotFound, errException)
+
+	fileUUID, errException, _ := driver.ConfirmDirectUpload(directUpload.UUID, userUUID)
+	assert.NoError(t, errException)
+	assert.Equal(t, directUpload.UUID, fileUUID)
+
+	fileURL, _ = driver.GetFile(fileUUID, storage.VariantOriginal)
+	assert.NotNil(t, fileURL)
+}
+
+func TestDirectUpload_InvalidFileType(t *testing.T) {
+	SkipThis(t)
+
+	driver, dbConn := localDriverSetup(t)
+	content := []byte("plain text pretending to be an image")
+
+	directUpload, errException, _ := driver.CreateDirectUpload(&storage.DirectUploadRequest{
+		Category:    "avatar",
+		FileName:    "image.png",
+		Size:        int64(len(content)),
+		ContentType: "image/png",
+	})
+	assert.NoError(t, errException)
+
+	var file entity.StorageFile
+	_ = dbConn.Where("uuid = ?", directUpload.UUID).Take(&file).Error
+	_ = driver.PutObject(context.Background(), file.Path, bytes.NewReader(content), int64(len(content)), "image/png")
+
+	_, errException, _ = driver.ConfirmDirectUpload(directUpload.UUID, "")
+	assert.Equal(t, exception.ErrorTextStorageUploadInvalidFileType, errException)
+
+	_, err := driver.StatObject(context.Background(), file.Path)
+	assert.Equal(t, storage.ErrObjectNotFound, err)
+	assert.Error(t, dbConn.Where("uuid = ?", directUpload.UUID).Take(&entity.StorageFile{}).Error)
+}
+
+func TestDirectUpload_InvalidImage(t *testing.T) {
+	SkipThis(t)
+
+	driver, dbConn := localDriverSetup(t)
+	content, _ := ioutil.ReadFile(fmt.Sprintf("%s/tests/file/image.jpeg", util.RootDir()))
+	truncated := content[:len(content)/2]
+
+	directUpload, errException, _ := driver.CreateDirectUpload(&storage.DirectUploadRequest{
+		Category:    "avatar",
+		FileName:    "image.jpeg",
+		Size:        int64(len(truncated)),
+		ContentType: "image/jpeg",
+	})
+	assert.NoError(t, errException)
+
+	var file entity.StorageFile
+	_ = dbConn.Where("uuid = ?", directUpload.UUID).Take(&file).Error
+	_ = driver.PutObject(context.Background(), file.Path, bytes.NewReader(truncated), int64(len(truncated)), "image/jpeg")
+
+	_, errException, _ = driver.ConfirmDirectUpload(directUpload.UUID, "")
+	assert.Equal(t, exception.ErrorTextStorageUploadInvalidFileType, errException)
+
+	_, err := driver.StatObject(context.Background(), file.Path)
+	assert.Equal(t, storage.ErrObjectNotFound, err)
+	assert.Error(t, dbConn.Where("uuid = ?", directUpload.UUID).Take(&entity.StorageFile{}).Error)
+}
+
+func TestResumableUpload_InvalidImage(t *testing.T) {
+	SkipThis(t)
+
+	driver, dbConn := localDriverSetup(t)
+	content, _ := ioutil.ReadFile(fmt.Sprintf("%s/tests/file/image.jpeg", util.RootDir()))
+	truncated := content[:len(content)/2]
+
+	upload, errException, _ := driver.CreateResumableUpload(&storage.ResumableUploadRequest{
+		Category: "avatar",
+		FileName: "image.jpeg",
+		Length:   int64(len(truncated)),
+	})
+	assert.NoError(t, errException)
+
+	_, errException, _ = driver.WriteResumableUpload(upload.UUID, "", 0, bytes.NewReader(truncated))
+	assert.Equal(t, exception.ErrorTextStorageUploadInvalidFileType, errException)
+
+	assert.Error(t, dbConn.Where("uuid = ?", upload.UUID).Take(&entity.StorageFile{}).Error)
+	_, err := driver.GetResumableUpload(upload.UUID, "")
+	assert.Equal(t, exception.ErrorTextStorageUploadNotFound, err)
+}
+
+func TestCreateDirectUpload_InvalidSize(t *testing.T) {
+	SkipThis(t)
+
+	driver, _ := localDriverSetup(t)
+
+	_, errException, errArgs := driver.CreateDirectUpload(&storage.DirectUploadRequest{
+		Category:    "avatar",
+		FileName:    "image.png",
+		Size:        storage.MaxSize + 1,
+		ContentType: "image/png",
+	})
+	assert.Equal(t, exception.ErrorTextStorageUploadInvalidSize, errException)
+	assert.Equal(t, "Size:2.048", errArgs)
+}
@@ -0,0 +1,135 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"google.golang.org/grpc"
+)
+
+// HTTPServer is a Component serving http requests, shutdown waits for requests in flight.
+type HTTPServer struct {
+	name   string
+	server *http.Server
+}
+
+// NewHTTPServer creates new HTTPServer.
+func NewHTTPServer(name string, server *http.Server) *HTTPServer {
+	return &HTTPServer{name: name, server: server}
+}
+
+// Name returns name of the server.
+func (h *HTTPServer) Name() string {
+	return h.name
+}
+
+// Serve listens on address of the server and serves requests until Shutdown.
+func (h *HTTPServer) Serve() error {
+	err := h.server.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+// Shutdown closes listeners then waits for requests in flight until ctx is done.
+func (h *HTTPServer) Shutdown(ctx context.Context) error {
+	return h.server.Shutdown(ctx)
+}
+
+// GRPCServer is a Component serving gRPC calls, shutdown waits for calls in flight.
+type GRPCServer struct {
+	name   string
+	server *grpc.Server
+	addr   string
+}
+
+// NewGRPCServer creates new GRPCServer listening on addr.
+func NewGRPCServer(name string, server *grpc.Server, addr string) *GRPCServer {
+	return &GRPCServer{name: name, server: server, addr: addr}
+}
+
+// Name returns name of the server.
+func (g *GRPCServer) Name() string {
+	return g.name
+}
+
+// Serve listens on addr and serves calls until Shutdown.
+func (g *GRPCServer) Serve() error {
+	listener, err := net.Listen("tcp", g.addr)
+	if err != nil {
+		return err
+	}
+
+	err = g.server.Serve(listener)
+	if errors.Is(err, grpc.ErrServerStopped) {
+		return nil
+	}
+
+	return err
+}
+
+// Shutdown stops accepting calls and waits for calls in flight, those still running when ctx is done are cancelled.
+func (g *GRPCServer) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		g.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		g.server.Stop()
+		return ctx.Err()
+	}
+}
+
+// Worker is a Component running a background loop, such as the queue worker or the outbox relay.
+type Worker struct {
+	name    string
+	run     func(ctx context.Context) error
+	ctx     context.Context
+	cancel  context.CancelFunc
+	stopped chan struct{}
+}
+
+// NewWorker creates new Worker, run must return once its context is cancelled.
+func NewWorker(name string, run func(ctx context.Context) error) *Worker {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Worker{name: name, run: run, ctx: ctx, cancel: cancel, stopped: make(chan struct{})}
+}
+
+// Name returns name of the worker.
+func (w *Worker) Name() string {
+	return w.name
+}
+
+// Serve runs the worker until Shutdown.
+func (w *Worker) Serve() error {
+	defer close(w.stopped)
+
+	err := w.run(w.ctx)
+	if w.ctx.Err() != nil && errors.Is(err, context.Canceled) {
+		return nil
+	}
+
+	return err
+}
+
+// Shutdown cancels context of the worker and waits for it to return until ctx is done.
+func (w *Worker) Shutdown(ctx context.Context) error {
+	w.cancel()
+
+	select {
+	case <-w.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,181 @@
+// Package supervisor runs components of the app (http server, gRPC server, background workers) in one process
+// and stops them gracefully. On SIGINT or SIGTERM, or once any component fails, every component stops accepting
+// new work and is given the shutdown timeout to drain work in flight. Services shared by the components
+// (database, redis, storage) are closed afterwards, in reverse order of registration.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultShutdownTimeout is the time given to components to drain work in flight.
+const DefaultShutdownTimeout = 30 * time.Second
+
+// Component is an interface. Needs to be implemented by each part of the app run by Supervisor.
+type Component interface {
+	// Name returns name of the component, uses by logs.
+	Name() string
+
+	// Serve blocks until the component stops, it returns nil once stopped by Shutdown.
+	Serve() error
+
+	// Shutdown stops accepting new work and waits for work in flight until ctx is done.
+	Shutdown(ctx context.Context) error
+}
+
+type closer struct {
+	name  string
+	close func() error
+}
+
+// Supervisor runs components and stops them gracefully.
+type Supervisor struct {
+	components      []Component
+	closers         []closer
+	shutdownTimeout time.Duration
+	signals         []os.Signal
+}
+
+// New creates new Supervisor, shutdownTimeout less than or equal to zero uses DefaultShutdownTimeout.
+func New(shutdownTimeout time.Duration) *Supervisor {
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = DefaultShutdownTimeout
+	}
+
+	return &Supervisor{
+		shutdownTimeout: shutdownTimeout,
+		signals:         []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+	}
+}
+
+// Add adds the component run by the supervisor.
+func (s *Supervisor) Add(component Component) *Supervisor {
+	s.components = append(s.components, component)
+	return s
+}
+
+// OnShutdown adds a function closing a service once every component has stopped.
+func (s *Supervisor) OnShutdown(name string, close func() error) *Supervisor {
+	s.closers = append(s.closers, closer{name: name, close: close})
+	return s
+}
+
+// Components returns name of every component.
+func (s *Supervisor) Components() []string {
+	names := make([]string, 0, len(s.components))
+	for _, component := range s.components {
+		names = append(names, component.Name())
+	}
+
+	return names
+}
+
+// Run starts every component and blocks until they have stopped and services are closed.
+// Components are stopped when ctx is done, when a signal is received, or when any of them fails.
+// The first error of a component or of its shutdown is returned.
+func (s *Supervisor) Run(ctx context.Context) error {
+	if len(s.components) == 0 {
+		return errors.New("supervisor: no component to run")
+	}
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, s.signals...)
+	defer signal.Stop(quit)
+
+	failed := make(chan error, len(s.components))
+	var served sync.WaitGroup
+	for _, component := range s.components {
+		served.Add(1)
+		go func(component Component) {
+			defer served.Done()
+			log.Info().Str("component", component.Name()).Msg("starting")
+			if err := component.Serve(); err != nil {
+				failed <- fmt.Errorf("%s: %w", component.Name(), err)
+			}
+		}(component)
+	}
+
+	var err error
+	select {
+	case <-ctx.Done():
+		log.Info().Msg("shutting down")
+	case sig := <-quit:
+		log.Info().Str("signal", sig.String()).Msg("shutting down")
+	case err = <-failed:
+		log.Error().Err(err).Msg("shutting down, a component has failed")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+	if shutdownErr := s.shutdown(shutdownCtx); err == nil {
+		err = shutdownErr
+	}
+
+	// A component still serving after the timeout is abandoned, services are closed under it.
+	stopped := make(chan struct{})
+	go func() {
+		served.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		log.Warn().Msg("components are still running after the shutdown timeout")
+	}
+
+	select {
+	case serveErr := <-failed:
+		if err == nil {
+			err = serveErr
+		}
+	default:
+	}
+	s.close()
+
+	return err
+}
+
+// shutdown stops every component concurrently until ctx is done.
+func (s *Supervisor) shutdown(ctx context.Context) error {
+	errs := make([]error, len(s.components))
+	var wg sync.WaitGroup
+	for i, component := range s.components {
+		wg.Add(1)
+		go func(i int, component Component) {
+			defer wg.Done()
+			if err := component.Shutdown(ctx); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", component.Name(), err)
+				log.Error().Err(err).Str("component", component.Name()).Msg("failed to shut down gracefully")
+				return
+			}
+			log.Info().Str("component", component.Name()).Msg("stopped")
+		}(i, component)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// close closes services in reverse order of registration, failures are only logged.
+func (s *Supervisor) close() {
+	for i := len(s.closers) - 1; i >= 0; i-- {
+		if err := s.closers[i].close(); err != nil {
+			log.Error().Err(err).Str("service", s.closers[i].name).Msg("failed to close")
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package supervisor_test
+
+import (
+	"context"
+	"errors"
+	"go-rest-skeleton/infrastructure/supervisor"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupervisor_Run(t *testing.T) {
+	var closed []string
+	var workerStopped bool
+	s := supervisor.New(time.Second).
+		Add(supervisor.NewWorker("worker", func(ctx context.Context) error {
+			<-ctx.Done()
+			workerStopped = true
+			return nil
+		})).
+		OnShutdown("db", func() error {
+			closed = append(closed, "db")
+			return nil
+		}).
+		OnShutdown("redis", func() error {
+			closed = append(closed, "redis")
+			return nil
+		})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.NoError(t, s.Run(ctx))
+	assert.True(t, workerStopped)
+	assert.Equal(t, []string{"redis", "db"}, closed)
+	assert.Equal(t, []string{"worker"}, s.Components())
+}
+
+func TestSupervisor_Run_Failed(t *testing.T) {
+	errFailed := errors.New("failed")
+	var workerStopped bool
+	s := supervisor.New(time.Second).
+		Add(supervisor.NewWorker("worker", func(ctx context.Context) error {
+			<-ctx.Done()
+			workerStopped = true
+			return ctx.Err()
+		})).
+		Add(supervisor.NewWorker("failing", func(ctx context.Context) error {
+			return errFailed
+		}))
+
+	err := s.Run(context.Background())
+	assert.True(t, errors.Is(err, errFailed))
+	assert.True(t, workerStopped)
+}
+
+func TestSupervisor_Run_Timeout(t *testing.T) {
+	var closed bool
+	s := supervisor.New(20*time.Millisecond).
+		Add(supervisor.NewWorker("stuck", func(ctx context.Context) error {
+			select {}
+		})).
+		OnShutdown("db", func() error {
+			closed = true
+			return nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := s.Run(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, closed)
+}
+
+func TestHTTPServer_Shutdown(t *testing.T) {
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := listener.Addr().String()
+	_ = listener.Close()
+
+	started := make(chan struct{})
+	server := &http.Server{Addr: addr, Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(50 * time.Millisecond)
+		_, _ = w.Write([]byte("done"))
+	})}
+	s := supervisor.New(time.Second).Add(supervisor.NewHTTPServer("http", server))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	body := make(chan string, 1)
+	go func() {
+		for i := 0; i < 50; i++ {
+			resp, err := http.Get("http://" + addr)
+			if err != nil {
+				time.Sleep(10 * time.Millisecond)
+				continue
+			}
+			content, _ := ioutil.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			body <- string(content)
+			return
+		}
+		body <- ""
+	}()
+
+	assert.NoError(t, s.Run(ctx))
+	assert.Equal(t, "done", <-body)
+}
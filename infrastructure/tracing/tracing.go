@@ -0,0 +1,106 @@
+// Package tracing performs distributed tracing with OpenTelemetry. Spans are started per http request, gRPC call
+// and GraphQL field, database queries and redis commands run within them are recorded as child spans.
+// Spans are exported to an OTLP collector, or printed to stdout for local use and tests.
+// The trace context is propagated by W3C Trace Context headers, on http and gRPC metadata alike.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"go-rest-skeleton/config"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer of the app.
+const TracerName = "go-rest-skeleton"
+
+// Collections of exporter.
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// Tracer returns the tracer of the app from the global trace provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// TraceID returns the trace ID of the span of ctx, empty when ctx has no span.
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+
+	return spanContext.TraceID().String()
+}
+
+// Init will install the trace provider exporting spans by the exporter of conf as the global trace provider.
+// Nothing is installed while tracing is disabled, spans are then not recorded.
+// The returned function flushes spans not exported yet, it must be called before the app exits.
+func Init(conf config.TracingConfig) (func(), error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	switch conf.TracingExporter {
+	case "":
+		return func() {}, nil
+	case ExporterStdout:
+		provider, err := NewStdoutProvider(conf, os.Stdout)
+		if err != nil {
+			return nil, err
+		}
+		otel.SetTracerProvider(provider)
+
+		return func() {
+			_ = provider.Shutdown(context.Background())
+		}, nil
+	case ExporterOTLP:
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(conf.TracingOTLPEndpoint)}
+		if conf.TracingOTLPInsecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+		exporter, err := otlptracegrpc.New(context.Background(), options...)
+		if err != nil {
+			return nil, err
+		}
+		provider := sdktrace.NewTracerProvider(append(newOptions(conf), sdktrace.WithBatcher(exporter))...)
+		otel.SetTracerProvider(provider)
+
+		return func() {
+			_ = provider.Shutdown(context.Background())
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %s", conf.TracingExporter)
+	}
+}
+
+// NewStdoutProvider creates the trace provider writing each span to w as soon as it ends.
+func NewStdoutProvider(conf config.TracingConfig, w io.Writer) (*sdktrace.TracerProvider, error) {
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(w))
+	if err != nil {
+		return nil, err
+	}
+
+	return sdktrace.NewTracerProvider(append(newOptions(conf), sdktrace.WithSyncer(exporter))...), nil
+}
+
+// newOptions returns options of the trace provider, spans of requests not traced by the caller are sampled by ratio.
+func newOptions(conf config.TracingConfig) []sdktrace.TracerProviderOption {
+	return []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(conf.TracingSampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(conf.TracingServiceName),
+		)),
+	}
+}
@@ -0,0 +1,53 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// GraphQL is a gqlgen extension recording each resolver called by a GraphQL operation as a child span of the request.
+// Fields resolved from their parent object are not recorded, so a span is started only where a resolver may query.
+type GraphQL struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.FieldInterceptor
+} = GraphQL{}
+
+// ExtensionName returns the name of the extension.
+func (GraphQL) ExtensionName() string {
+	return "Tracing"
+}
+
+// Validate implements the graphql.HandlerExtension interface.
+func (GraphQL) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptField starts a span around each resolver.
+func (GraphQL) InterceptField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || !fc.IsResolver {
+		return next(ctx)
+	}
+
+	ctx, span := Tracer().Start(ctx, "graphql."+fc.Object+"."+fc.Field.Name,
+		trace.WithAttributes(
+			attribute.String("graphql.object", fc.Object),
+			attribute.String("graphql.field", fc.Field.Name),
+		),
+	)
+	defer span.End()
+
+	res, err := next(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return res, err
+}
@@ -0,0 +1,96 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go-rest-skeleton/config"
+	"go-rest-skeleton/infrastructure/tracing"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/ast"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// setupTracer will install a trace provider writing spans to the returned buffer.
+func setupTracer(t *testing.T) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	provider, err := tracing.NewStdoutProvider(config.TracingConfig{
+		TracingServiceName: "go-rest-skeleton-test",
+		TracingSampleRatio: 1,
+	}, buf)
+	assert.NoError(t, err)
+	otel.SetTracerProvider(provider)
+
+	return buf
+}
+
+func TestInit(t *testing.T) {
+	shutdown, err := tracing.Init(config.TracingConfig{})
+	assert.NoError(t, err)
+	shutdown()
+
+	_, err = tracing.Init(config.TracingConfig{TracingExporter: "unknown"})
+	assert.Error(t, err)
+}
+
+func TestTraceID(t *testing.T) {
+	setupTracer(t)
+	assert.Empty(t, tracing.TraceID(context.Background()))
+
+	ctx, span := tracing.Tracer().Start(context.Background(), "test")
+	defer span.End()
+	assert.Equal(t, span.SpanContext().TraceID().String(), tracing.TraceID(ctx))
+}
+
+type tracedRow struct {
+	ID   int
+	Name string
+}
+
+func TestGormPlugin(t *testing.T) {
+	buf := setupTracer(t)
+	db, err := gorm.Open(mysql.New(mysql.Config{SkipInitializeWithVersion: true}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, db.Use(tracing.NewGormPlugin()))
+
+	// Queries without a traced context are not recorded.
+	var rows []tracedRow
+	db.Find(&rows)
+	assert.Empty(t, buf.String())
+
+	ctx, span := tracing.Tracer().Start(context.Background(), "request")
+	db.WithContext(ctx).Where("name = ?", "a").Find(&rows)
+	span.End()
+	assert.Contains(t, buf.String(), `"Name":"gorm.query"`)
+	assert.Contains(t, buf.String(), "SELECT * FROM `traced_rows` WHERE name = ?")
+	assert.Contains(t, buf.String(), span.SpanContext().TraceID().String())
+}
+
+func TestGraphQL_InterceptField(t *testing.T) {
+	buf := setupTracer(t)
+	ctx, span := tracing.Tracer().Start(context.Background(), "request")
+	ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{
+		Object:     "Query",
+		Field:      graphql.CollectedField{Field: &ast.Field{Name: "users"}},
+		IsResolver: true,
+	})
+
+	_, err := tracing.GraphQL{}.InterceptField(ctx, func(ctx context.Context) (interface{}, error) {
+		field := trace.SpanFromContext(ctx).SpanContext()
+		assert.Equal(t, span.SpanContext().TraceID(), field.TraceID())
+		assert.NotEqual(t, span.SpanContext().SpanID(), field.SpanID())
+		return nil, errors.New("failed")
+	})
+	span.End()
+	assert.Error(t, err)
+	assert.Contains(t, buf.String(), `"Name":"graphql.Query.users"`)
+}
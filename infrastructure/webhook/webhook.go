@@ -0,0 +1,76 @@
+// Package webhook delivers domain events to the webhook subscriptions of registered applications.
+// The outbox dispatcher hands every event to Sink, which dispatches a delivery job per subscription
+// receiving the event type. The queue worker performs the signed HTTP request, records the delivery log
+// and retries failed deliveries with backoff. A subscription failing consecutively is disabled.
+//
+// Each request carries the headers below. Receivers verify the request by computing HMAC-SHA256 of
+// "<timestamp>.<body>" using the subscription secret and comparing it with the signature header,
+// see Verify.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	// HeaderID is the header carrying the unique id of the delivery.
+	HeaderID = "X-Webhook-Id"
+
+	// HeaderEvent is the header carrying the event type.
+	HeaderEvent = "X-Webhook-Event"
+
+	// HeaderTimestamp is the header carrying the unix timestamp of the delivery, it is part of the signature.
+	HeaderTimestamp = "X-Webhook-Timestamp"
+
+	// HeaderSignature is the header carrying the signature of the delivery.
+	HeaderSignature = "X-Webhook-Signature"
+
+	// HeaderIdempotencyKey is the header carrying the idempotency key of the event.
+	HeaderIdempotencyKey = "Idempotency-Key"
+
+	// signaturePrefix is the prefix of the signature header value.
+	signaturePrefix = "sha256="
+)
+
+var (
+	errInvalidTimestamp = errors.New("invalid webhook timestamp")
+	errExpiredTimestamp = errors.New("webhook timestamp is outside of the tolerance")
+	errInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// Sign returns the signature of the body sent at the given unix timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = fmt.Fprintf(mac, "%d.", timestamp)
+	_, _ = mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a received delivery. Deliveries older than tolerance are rejected
+// to prevent replay attacks, a zero tolerance skips the check.
+func Verify(secret string, timestamp string, body []byte, signature string, tolerance time.Duration) error {
+	unixTimestamp, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errInvalidTimestamp
+	}
+
+	if tolerance > 0 {
+		age := time.Since(time.Unix(unixTimestamp, 0))
+		if age > tolerance || age < -tolerance {
+			return errExpiredTimestamp
+		}
+	}
+
+	if !hmac.Equal([]byte(Sign(secret, unixTimestamp, body)), []byte(signature)) {
+		return errInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/event"
+	"go-rest-skeleton/domain/repository"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultMaxFailures is the number of consecutive failed deliveries before a subscription is disabled.
+	DefaultMaxFailures = 10
+
+	// DefaultTimeout is the maximum duration of a delivery request.
+	DefaultTimeout = 10 * time.Second
+
+	// maxResponseBodySize is the maximum size of response body stored in the delivery log.
+	maxResponseBodySize = 64 * 1024
+)
+
+// Deliverer represent it self.
+type Deliverer struct {
+	subscriptions repository.WebhookSubscriptionRepository
+	deliveries    repository.WebhookDeliveryRepository
+	client        *http.Client
+	maxFailures   int
+}
+
+// NewDeliverer creates new Deliverer.
+func NewDeliverer(
+	subscriptions repository.WebhookSubscriptionRepository,
+	deliveries repository.WebhookDeliveryRepository,
+	timeout time.Duration,
+	maxFailures int) *Deliverer {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	if maxFailures < 1 {
+		maxFailures = DefaultMaxFailures
+	}
+
+	return &Deliverer{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		client:        &http.Client{Timeout: timeout},
+		maxFailures:   maxFailures,
+	}
+}
+
+// Deliver sends the signed event to the subscription target url and records the delivery log.
+// Any transport error or non 2xx response is returned as error and counted as a failure of the subscription.
+func (d *Deliverer) Deliver(
+	ctx context.Context,
+	subscription *entity.WebhookSubscription,
+	e *event.Event,
+	attempt int) (*entity.WebhookDelivery, error) {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &entity.WebhookDelivery{
+		UUID:             uuid.New().String(),
+		SubscriptionUUID: subscription.UUID,
+		EventUUID:        e.UUID,
+		EventType:        e.Type,
+		Attempt:          attempt,
+		RequestURL:       subscription.TargetURL,
+		RequestBody:      string(body),
+	}
+
+	errDeliver := d.send(ctx, subscription, e, body, delivery)
+	delivery.Status = entity.WebhookDeliveryStatusSuccess
+	if errDeliver != nil {
+		delivery.Status = entity.WebhookDeliveryStatusFailed
+		delivery.Error = errDeliver.Error()
+	}
+
+	if _, errSave := d.deliveries.SaveWebhookDelivery(delivery); errSave != nil {
+		log.Error().Err(errSave).Str("subscription", subscription.UUID).Msg("failed to record webhook delivery")
+	}
+
+	if errDeliver != nil {
+		disabled, errRecord := d.subscriptions.RecordWebhookSubscriptionFailure(subscription.UUID, d.maxFailures)
+		if errRecord != nil {
+			log.Error().Err(errRecord).Str("subscription", subscription.UUID).Msg("failed to record webhook failure")
+		}
+		if disabled {
+			log.Warn().Str("subscription", subscription.UUID).
+				Int("failures", d.maxFailures).
+				Msg("webhook subscription disabled after repeated failures")
+		}
+		return delivery, errDeliver
+	}
+
+	if errRecord := d.subscriptions.RecordWebhookSubscriptionSuccess(subscription.UUID); errRecord != nil {
+		log.Error().Err(errRecord).Str("subscription", subscription.UUID).Msg("failed to record webhook success")
+	}
+
+	return delivery, nil
+}
+
+func (d *Deliverer) send(
+	ctx context.Context,
+	subscription *entity.WebhookSubscription,
+	e *event.Event,
+	body []byte,
+	delivery *entity.WebhookDelivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.TargetURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "go-rest-skeleton-webhook")
+	req.Header.Set(HeaderID, delivery.UUID)
+	req.Header.Set(HeaderEvent, e.Type)
+	req.Header.Set(HeaderIdempotencyKey, e.IdempotencyKey)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(subscription.Secret, timestamp, body))
+	delivery.RequestHeaders = encodeHeaders(req.Header)
+
+	start := time.Now()
+	resp, err := d.client.Do(req)
+	delivery.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	responseBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+	delivery.ResponseStatus = resp.StatusCode
+	delivery.ResponseHeaders = encodeHeaders(resp.Header)
+	delivery.ResponseBody = string(responseBody)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func encodeHeaders(header http.Header) string {
+	headerBytes, _ := json.Marshal(header)
+
+	return string(headerBytes)
+}
@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"go-rest-skeleton/domain/event"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/infrastructure/queue"
+)
+
+// JobDeliverWebhook is the job type of delivering an event to a webhook subscription.
+const JobDeliverWebhook = "webhook:deliver"
+
+// DeliverWebhookPayload represents payload of JobDeliverWebhook.
+type DeliverWebhookPayload struct {
+	SubscriptionUUID string       `json:"subscription_uuid"`
+	Event            *event.Event `json:"event"`
+}
+
+// NewDeliverWebhookJob creates a job to deliver the event to the webhook subscription by queue worker.
+func NewDeliverWebhookJob(subscriptionUUID string, e *event.Event) (*queue.Job, error) {
+	return queue.NewJob(JobDeliverWebhook, DeliverWebhookPayload{
+		SubscriptionUUID: subscriptionUUID,
+		Event:            e,
+	})
+}
+
+// Handler returns queue handler of JobDeliverWebhook.
+// Deliveries to deleted or disabled subscriptions are dropped.
+func (d *Deliverer) Handler() queue.Handler {
+	return func(ctx context.Context, job *queue.Job) error {
+		var payload DeliverWebhookPayload
+		if err := job.Bind(&payload); err != nil {
+			return err
+		}
+
+		subscription, err := d.subscriptions.GetWebhookSubscription(payload.SubscriptionUUID)
+		if err != nil {
+			if errors.Is(err, exception.ErrorTextWebhookSubscriptionNotFound) {
+				return nil
+			}
+			return err
+		}
+		if !subscription.IsActive {
+			return nil
+		}
+
+		_, err = d.Deliver(ctx, subscription, payload.Event, job.Attempts+1)
+		return err
+	}
+}
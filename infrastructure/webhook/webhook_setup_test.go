@@ -0,0 +1,151 @@
+package webhook_test
+
+import (
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/event"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/infrastructure/queue"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// subscriptionRepo is an in-memory implementation of repository.WebhookSubscriptionRepository.
+type subscriptionRepo struct {
+	mu            sync.Mutex
+	subscriptions map[string]*entity.WebhookSubscription
+}
+
+func newSubscriptionRepo(subscriptions ...*entity.WebhookSubscription) *subscriptionRepo {
+	repo := &subscriptionRepo{subscriptions: map[string]*entity.WebhookSubscription{}}
+	for _, subscription := range subscriptions {
+		repo.subscriptions[subscription.UUID] = subscription
+	}
+	return repo
+}
+
+func (r *subscriptionRepo) SaveWebhookSubscription(
+	s *entity.WebhookSubscription) (*entity.WebhookSubscription, map[string]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscriptions[s.UUID] = s
+	return s, nil, nil
+}
+
+func (r *subscriptionRepo) UpdateWebhookSubscription(
+	UUID string, s *entity.WebhookSubscription) (*entity.WebhookSubscription, map[string]string, error) {
+	return s, nil, nil
+}
+
+func (r *subscriptionRepo) EnableWebhookSubscription(UUID string) error {
+	return nil
+}
+
+func (r *subscriptionRepo) DeleteWebhookSubscription(UUID string) error {
+	return nil
+}
+
+func (r *subscriptionRepo) GetWebhookSubscription(UUID string) (*entity.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	subscription, ok := r.subscriptions[UUID]
+	if !ok {
+		return nil, exception.ErrorTextWebhookSubscriptionNotFound
+	}
+	copied := *subscription
+	return &copied, nil
+}
+
+func (r *subscriptionRepo) GetWebhookSubscriptions(
+	p *repository.Parameters) ([]entity.WebhookSubscription, interface{}, error) {
+	return nil, nil, nil
+}
+
+func (r *subscriptionRepo) GetActiveWebhookSubscriptionsByEventType(
+	eventType string) ([]entity.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var subscriptions []entity.WebhookSubscription
+	for _, subscription := range r.subscriptions {
+		if subscription.IsActive && subscription.Subscribes(eventType) {
+			subscriptions = append(subscriptions, *subscription)
+		}
+	}
+	return subscriptions, nil
+}
+
+func (r *subscriptionRepo) RecordWebhookSubscriptionSuccess(UUID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscriptions[UUID].FailureCount = 0
+	return nil
+}
+
+func (r *subscriptionRepo) RecordWebhookSubscriptionFailure(UUID string, maxFailures int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	subscription := r.subscriptions[UUID]
+	subscription.FailureCount++
+	if subscription.IsActive && subscription.FailureCount >= maxFailures {
+		now := time.Now()
+		subscription.IsActive = false
+		subscription.DisabledAt = &now
+		return true, nil
+	}
+	return false, nil
+}
+
+// deliveryRepo is an in-memory implementation of repository.WebhookDeliveryRepository.
+type deliveryRepo struct {
+	mu         sync.Mutex
+	deliveries []entity.WebhookDelivery
+}
+
+func (r *deliveryRepo) SaveWebhookDelivery(d *entity.WebhookDelivery) (*entity.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries = append(r.deliveries, *d)
+	return d, nil
+}
+
+func (r *deliveryRepo) GetWebhookDelivery(subscriptionUUID string, UUID string) (*entity.WebhookDelivery, error) {
+	return nil, exception.ErrorTextWebhookDeliveryNotFound
+}
+
+func (r *deliveryRepo) GetWebhookDeliveries(
+	subscriptionUUID string, p *repository.Parameters) ([]entity.WebhookDelivery, interface{}, error) {
+	return r.deliveries, nil, nil
+}
+
+// jobQueue is an in-memory implementation of queue.QueueInterface.
+type jobQueue struct {
+	jobs []*queue.Job
+}
+
+func (q *jobQueue) Dispatch(job *queue.Job) error {
+	q.jobs = append(q.jobs, job)
+	return nil
+}
+
+// SubscriptionSetup will initialize an active subscription to the given target url.
+func SubscriptionSetup(targetURL string, eventTypes string) *entity.WebhookSubscription {
+	return &entity.WebhookSubscription{
+		UUID:       uuid.New().String(),
+		TargetURL:  targetURL,
+		EventTypes: eventTypes,
+		Secret:     "a-very-secret-webhook-key",
+		IsActive:   true,
+	}
+}
+
+// EventSetup will initialize an user.created event.
+func EventSetup(t *testing.T) *event.Event {
+	e, err := event.New(event.UserCreated, event.AggregateUser, "user-uuid", event.UserPayload{Name: "Example"})
+	if err != nil {
+		t.Fatalf("want non error, got %#v", err)
+	}
+	return e
+}
@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"context"
+	"go-rest-skeleton/domain/event"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/outbox"
+	"go-rest-skeleton/infrastructure/queue"
+)
+
+// Sink represent outbox sink dispatching a delivery job to each subscription receiving the event.
+type Sink struct {
+	subscriptions repository.WebhookSubscriptionRepository
+	queue         queue.QueueInterface
+}
+
+// Sink implements the outbox.Sink interface.
+var _ outbox.Sink = &Sink{}
+
+// NewSink creates new Sink.
+func NewSink(subscriptions repository.WebhookSubscriptionRepository, q queue.QueueInterface) *Sink {
+	return &Sink{
+		subscriptions: subscriptions,
+		queue:         q,
+	}
+}
+
+// Name returns name of the sink.
+func (s *Sink) Name() string {
+	return "webhook_subscription"
+}
+
+// Publish dispatches a delivery job to each active subscription of the event type.
+func (s *Sink) Publish(ctx context.Context, e *event.Event) error {
+	subscriptions, err := s.subscriptions.GetActiveWebhookSubscriptionsByEventType(e.Type)
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		job, errJob := NewDeliverWebhookJob(subscription.UUID, e)
+		if errJob != nil {
+			return errJob
+		}
+		if errDispatch := s.queue.Dispatch(job); errDispatch != nil {
+			return errDispatch
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/event"
+	"go-rest-skeleton/infrastructure/queue"
+	"go-rest-skeleton/infrastructure/webhook"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"type":"user.created"}`)
+	timestamp := time.Now().Unix()
+	signature := webhook.Sign("secret", timestamp, body)
+
+	assert.NoError(t, webhook.Verify("secret", strconv.FormatInt(timestamp, 10), body, signature, time.Minute))
+	assert.Error(t, webhook.Verify("other", strconv.FormatInt(timestamp, 10), body, signature, time.Minute))
+	assert.Error(t, webhook.Verify("secret", strconv.FormatInt(timestamp, 10), []byte("{}"), signature, time.Minute))
+
+	expired := time.Now().Add(-time.Hour).Unix()
+	expiredSignature := webhook.Sign("secret", expired, body)
+	assert.Error(t, webhook.Verify("secret", strconv.FormatInt(expired, 10), body, expiredSignature, time.Minute))
+}
+
+func TestDeliverer_Deliver(t *testing.T) {
+	var received event.Event
+	var errVerify error
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		errVerify = webhook.Verify("a-very-secret-webhook-key",
+			r.Header.Get(webhook.HeaderTimestamp), body, r.Header.Get(webhook.HeaderSignature), time.Minute)
+		_ = json.Unmarshal(body, &received)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer receiver.Close()
+
+	subscription := SubscriptionSetup(receiver.URL, event.UserCreated)
+	subscription.FailureCount = 3
+	subscriptions := newSubscriptionRepo(subscription)
+	deliveries := &deliveryRepo{}
+	deliverer := webhook.NewDeliverer(subscriptions, deliveries, time.Second, 3)
+
+	e := EventSetup(t)
+	delivery, err := deliverer.Deliver(context.Background(), subscription, e, 1)
+	assert.NoError(t, err)
+	assert.NoError(t, errVerify)
+	assert.Equal(t, e.UUID, received.UUID)
+	assert.Equal(t, entity.WebhookDeliveryStatusSuccess, delivery.Status)
+	assert.Equal(t, http.StatusOK, delivery.ResponseStatus)
+	assert.Equal(t, "ok", delivery.ResponseBody)
+	assert.Len(t, deliveries.deliveries, 1)
+	assert.Equal(t, 0, subscription.FailureCount)
+}
+
+func TestDeliverer_Deliver_DisablesAfterRepeatedFailures(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	subscription := SubscriptionSetup(receiver.URL, entity.WebhookAllEvents)
+	subscriptions := newSubscriptionRepo(subscription)
+	deliveries := &deliveryRepo{}
+	deliverer := webhook.NewDeliverer(subscriptions, deliveries, time.Second, 2)
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		delivery, err := deliverer.Deliver(context.Background(), subscription, EventSetup(t), attempt)
+		assert.Error(t, err)
+		assert.Equal(t, entity.WebhookDeliveryStatusFailed, delivery.Status)
+		assert.Equal(t, http.StatusInternalServerError, delivery.ResponseStatus)
+	}
+
+	assert.Len(t, deliveries.deliveries, 2)
+	assert.False(t, subscription.IsActive)
+	assert.NotNil(t, subscription.DisabledAt)
+}
+
+func TestDeliverer_Handler_SkipsDisabledSubscription(t *testing.T) {
+	called := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer receiver.Close()
+
+	subscription := SubscriptionSetup(receiver.URL, event.UserCreated)
+	subscription.IsActive = false
+	deliverer := webhook.NewDeliverer(newSubscriptionRepo(subscription), &deliveryRepo{}, time.Second, 2)
+
+	job, _ := webhook.NewDeliverWebhookJob(subscription.UUID, EventSetup(t))
+	assert.NoError(t, deliverer.Handler()(context.Background(), decodeJob(t, job)))
+	assert.False(t, called)
+}
+
+func TestSink_Publish(t *testing.T) {
+	subscribed := SubscriptionSetup("http://localhost/subscribed", event.UserCreated+","+event.RoleUpdated)
+	other := SubscriptionSetup("http://localhost/other", event.DocumentApproved)
+	q := &jobQueue{}
+	sink := webhook.NewSink(newSubscriptionRepo(subscribed, other), q)
+
+	assert.NoError(t, sink.Publish(context.Background(), EventSetup(t)))
+	if assert.Len(t, q.jobs, 1) {
+		var payload webhook.DeliverWebhookPayload
+		assert.NoError(t, q.jobs[0].Bind(&payload))
+		assert.Equal(t, subscribed.UUID, payload.SubscriptionUUID)
+		assert.Equal(t, event.UserCreated, payload.Event.Type)
+	}
+}
+
+func decodeJob(t *testing.T, job *queue.Job) *queue.Job {
+	jobBytes, err := json.Marshal(job)
+	assert.NoError(t, err)
+	var decoded queue.Job
+	assert.NoError(t, json.Unmarshal(jobBytes, &decoded))
+	return &decoded
+}
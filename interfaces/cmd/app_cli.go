@@ -7,5 +7,11 @@ import (
 func NewCli() *cli.App {
 	c := cli.NewApp()
 
+	// Config flags are read by config.ParseFlags before the app runs, they are declared to be accepted and documented.
+	c.Flags = []cli.Flag{
+		&cli.StringFlag{Name: "config", Usage: "read config from a yaml or toml file, defaults to CONFIG_FILE"},
+		&cli.StringSliceFlag{Name: "set", Usage: "set a config key, e.g. --set APP_ENV=production, may be repeated"},
+	}
+
 	return c
 }
@@ -1,8 +1,10 @@
 package cmd_test
 
 import (
+	"go-rest-skeleton/config"
 	"go-rest-skeleton/infrastructure/persistence"
 	"go-rest-skeleton/interfaces/cmd"
+	"go-rest-skeleton/pkg/util"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,9 +19,37 @@ func TestNewCli(t *testing.T) {
 }
 
 func TestNewCommand(t *testing.T) {
+	var conf *config.Config
 	var repositories *persistence.Repositories
-	newCommand := cmd.NewCommand(repositories)
+	var storageService *persistence.StorageService
+	var notificationService *persistence.NotificationService
+	var queueService *persistence.QueueService
+	var outboxService *persistence.OutboxService
+	var webhookService *persistence.WebhookService
+	newCommand := cmd.NewCommand(conf, repositories, storageService, notificationService, queueService, outboxService, webhookService)
 
 	var cliCommand []*cli.Command
 	assert.IsType(t, cliCommand, newCommand)
 }
+
+func TestNewServeCommand(t *testing.T) {
+	var conf *config.Config
+	serveCommand := cmd.NewServeCommand(conf, &cmd.ServeServices{})
+
+	assert.Equal(t, "serve", serveCommand.Name)
+	var flags []string
+	for _, flag := range serveCommand.Flags {
+		flags = append(flags, flag.Names()[0])
+	}
+	assert.Equal(t, []string{"http", "grpc", "workers", "shutdown-timeout"}, flags)
+}
+
+func TestLangCheck(t *testing.T) {
+	report, err := cmd.LangCheck(util.RootDir(), "en")
+	assert.NoError(t, err)
+	for lang, keys := range report.Missing {
+		assert.Empty(t, keys, "keys missing in %s", lang)
+	}
+	assert.Empty(t, report.Unused, "unused keys")
+	assert.Empty(t, report.Mismatched, "mismatched template variables")
+}
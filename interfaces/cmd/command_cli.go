@@ -1,17 +1,36 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"go-rest-skeleton/config"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/infrastructure/notify/notification"
 	"go-rest-skeleton/infrastructure/persistence"
+	"go-rest-skeleton/infrastructure/queue"
+	"go-rest-skeleton/infrastructure/storage"
+	"go-rest-skeleton/infrastructure/webhook"
 	"go-rest-skeleton/pkg/encoder"
 	"go-rest-skeleton/pkg/security"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
 
 	"github.com/urfave/cli/v2"
 )
 
 // NewCommand construct a CLI commands.
-func NewCommand(dbService *persistence.Repositories) []*cli.Command {
+func NewCommand(
+	conf *config.Config,
+	dbService *persistence.Repositories,
+	storageService *persistence.StorageService,
+	notificationService *persistence.NotificationService,
+	queueService *persistence.QueueService,
+	outboxService *persistence.OutboxService,
+	webhookService *persistence.WebhookService) []*cli.Command {
 	return []*cli.Command{
 		{
 			Name:  "create:secret",
@@ -25,6 +44,52 @@ func NewCommand(dbService *persistence.Repositories) []*cli.Command {
 				return nil
 			},
 		},
+		{
+			Name:  "error:catalog",
+			Usage: "print every error response with its code, HTTP status, translation key and description",
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "format", Value: "markdown", Usage: "output format, markdown or json"},
+			},
+			Action: func(c *cli.Context) error {
+				switch c.String("format") {
+				case "markdown":
+					fmt.Print(exception.CatalogMarkdown(exception.Catalog()))
+				case "json":
+					fmt.Println(encoder.PrettyJSONWithIndent(exception.Catalog()))
+				default:
+					return fmt.Errorf("unknown format %s, use markdown or json", c.String("format"))
+				}
+				return nil
+			},
+		},
+		{
+			Name:  "config:show",
+			Usage: "print the effective config with the source of each value, secrets are redacted",
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "format", Value: "text", Usage: "output format, text or json"},
+			},
+			Action: func(c *cli.Context) error {
+				entries := conf.Entries()
+				for i := range entries {
+					entries[i].Value = entries[i].Redacted()
+				}
+
+				switch c.String("format") {
+				case "text":
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+					for _, entry := range entries {
+						fmt.Fprintf(w, "%s\t%s\t%s\n", entry.Key, entry.Value, entry.Source)
+					}
+					return w.Flush()
+				case "json":
+					fmt.Println(encoder.PrettyJSONWithIndent(entries))
+				default:
+					return fmt.Errorf("unknown format %s, use text or json", c.String("format"))
+				}
+				return nil
+			},
+		},
+		newLangCheckCommand(),
 		{
 			Name:  "db:migrate",
 			Usage: "run database migration",
@@ -58,5 +123,183 @@ func NewCommand(dbService *persistence.Repositories) []*cli.Command {
 				return nil
 			},
 		},
+		{
+			Name:  "db:purge",
+			Usage: "permanently delete users, roles and tours soft deleted longer than the retention period",
+			Flags: []cli.Flag{
+				&cli.DurationFlag{
+					Name:  "older-than",
+					Usage: "only purge records deleted before this duration ago, defaults to DB_PURGE_RETENTION_DAYS",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				olderThan := time.Duration(conf.DBConfig.DBPurgeRetentionDays) * 24 * time.Hour
+				if c.IsSet("older-than") {
+					olderThan = c.Duration("older-than")
+				}
+				before := time.Now().Add(-olderThan)
+				purges := []struct {
+					name  string
+					purge func(time.Time) (int64, error)
+				}{
+					{"users", dbService.User.PurgeUsers},
+					{"roles", dbService.Role.PurgeRoles},
+					{"tours", dbService.Tour.PurgeTours},
+				}
+				for _, p := range purges {
+					purged, err := p.purge(before)
+					if err != nil {
+						log.Println(err)
+					}
+					log.Printf("%d %s purged", purged, p.name)
+				}
+				return nil
+			},
+		},
+		{
+			Name:  "storage:gc",
+			Usage: "remove stored files no longer referenced, unconfirmed or deleted, and expired resumable uploads",
+			Flags: []cli.Flag{
+				&cli.DurationFlag{
+					Name:  "older-than",
+					Value: 24 * time.Hour,
+					Usage: "only remove files created before this duration ago",
+				},
+				&cli.DurationFlag{
+					Name:  "rescan-after",
+					Value: time.Hour,
+					Usage: "dispatch again processing of files still waiting to be scanned this duration after upload",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				report, err := storageService.Storage.CollectGarbage(c.Duration("older-than"))
+				if err != nil {
+					log.Println(err)
+				}
+				if report != nil {
+					log.Printf("%d files and %d resumable uploads removed", report.Files, report.Uploads)
+				}
+
+				pending, err := storageService.Storage.PendingScans(c.Duration("rescan-after"))
+				if err != nil {
+					log.Println(err)
+				}
+				dispatched := 0
+				for _, UUID := range pending {
+					processJob, err := storage.NewProcessFileJob(UUID)
+					if err == nil {
+						err = queueService.Queue.Dispatch(processJob)
+					}
+					if err != nil {
+						log.Println(err)
+						continue
+					}
+					dispatched++
+				}
+				log.Printf("%d files waiting to be scanned dispatched again", dispatched)
+				return nil
+			},
+		},
+		{
+			Name:  "queue:work",
+			Usage: "start processing jobs on the queue until receiving SIGINT or SIGTERM",
+			Flags: []cli.Flag{
+				&cli.IntFlag{Name: "concurrency", Usage: "number of jobs processed at the same time"},
+			},
+			Action: func(c *cli.Context) error {
+				concurrency := c.Int("concurrency")
+				if concurrency == 0 {
+					concurrency = conf.QueueConcurrency
+				}
+
+				worker := newQueueWorker(
+					concurrency, dbService, storageService, notificationService, queueService, webhookService)
+
+				ctx, cancel := context.WithCancel(context.Background())
+				quit := make(chan os.Signal, 1)
+				signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+				go func() {
+					<-quit
+					log.Println("shutting down queue worker, waiting for in-flight jobs")
+					cancel()
+				}()
+
+				log.Printf("processing queue %s with %d workers", queueService.Queue.Name(), concurrency)
+				return worker.Run(ctx)
+			},
+		},
+		{
+			Name:  "queue:failed",
+			Usage: "list jobs on the dead-letter list",
+			Action: func(c *cli.Context) error {
+				jobs, err := queueService.Queue.DeadJobs(context.Background())
+				if err != nil {
+					log.Println(err)
+					return nil
+				}
+				fmt.Println(encoder.PrettyJSONWithIndent(jobs))
+				return nil
+			},
+		},
+		{
+			Name:  "queue:retry",
+			Usage: "move jobs on the dead-letter list back to the queue",
+			Action: func(c *cli.Context) error {
+				total, err := queueService.Queue.RetryDeadJobs(context.Background())
+				if err != nil {
+					log.Println(err)
+					return nil
+				}
+				log.Printf("%d jobs pushed back to queue %s", total, queueService.Queue.Name())
+				return nil
+			},
+		},
+		{
+			Name:  "outbox:relay",
+			Usage: "start relaying domain events from the outbox until receiving SIGINT or SIGTERM",
+			Flags: []cli.Flag{
+				&cli.BoolFlag{Name: "once", Usage: "relay a single batch of pending events then exit"},
+			},
+			Action: func(c *cli.Context) error {
+				if c.Bool("once") {
+					total, err := outboxService.Dispatcher.Relay(context.Background())
+					if err != nil {
+						log.Println(err)
+						return nil
+					}
+					log.Printf("%d events relayed", total)
+					return nil
+				}
+
+				ctx, cancel := context.WithCancel(context.Background())
+				quit := make(chan os.Signal, 1)
+				signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+				go func() {
+					<-quit
+					log.Println("shutting down outbox relay")
+					cancel()
+				}()
+
+				log.Println("relaying outbox events")
+				return outboxService.Dispatcher.Run(ctx)
+			},
+		},
 	}
 }
+
+// newQueueWorker creates worker processing every job type pushed to the queue.
+func newQueueWorker(
+	concurrency int,
+	dbService *persistence.Repositories,
+	storageService *persistence.StorageService,
+	notificationService *persistence.NotificationService,
+	queueService *persistence.QueueService,
+	webhookService *persistence.WebhookService) *queue.Worker {
+	return queue.NewWorker(queueService.Queue, concurrency).
+		Register(notification.JobSendNotification,
+			notification.SendNotificationHandler(notificationService.NewNotification)).
+		Register(storage.JobProcessFile,
+			storage.ProcessFileHandler(dbService.DB, storageService.Processors...)).
+		Register(webhook.JobDeliverWebhook,
+			webhookService.Deliverer.Handler())
+}
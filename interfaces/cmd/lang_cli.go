@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/pkg/encoder"
+	"go-rest-skeleton/pkg/translation"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// newLangCheckCommand construct lang:check command, reporting message keys missing in each language, unused keys
+// and mismatched template variables, or scaffolding the message file of a new language.
+func newLangCheckCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "lang:check",
+		Usage: "report message keys missing in each language, unused keys and mismatched template variables",
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: "dir", Value: ".", Usage: "root directory of the source code, holding languages directory"},
+			&cli.StringFlag{Name: "reference", Value: translation.FallbackLanguage, Usage: "language others are compared with"},
+			&cli.StringFlag{Name: "format", Value: "text", Usage: "output format, text or json"},
+			&cli.StringFlag{Name: "scaffold", Usage: "write the message file of the given new language from the reference"},
+		},
+		Action: func(c *cli.Context) error {
+			if lang := c.String("scaffold"); lang != "" {
+				path, err := ScaffoldLanguage(c.String("dir"), c.String("reference"), lang)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("%s is created, translate its messages\n", path)
+				return nil
+			}
+
+			report, err := LangCheck(c.String("dir"), c.String("reference"))
+			if err != nil {
+				return err
+			}
+
+			switch c.String("format") {
+			case "text":
+				fmt.Print(langCheckText(report))
+			case "json":
+				fmt.Println(encoder.PrettyJSONWithIndent(report))
+			default:
+				return fmt.Errorf("unknown format %s, use text or json", c.String("format"))
+			}
+			if !report.OK() {
+				return errors.New("translation check failed")
+			}
+			return nil
+		},
+	}
+}
+
+// LangCheck checks message files of languages directory in dir against the reference language, message keys
+// of the error catalog and message keys referenced by the source code in dir.
+func LangCheck(dir string, reference string) (*translation.CheckReport, error) {
+	catalog := translation.NewCatalog(reference)
+	if err := catalog.LoadDir(filepath.Join(dir, "languages")); err != nil {
+		return nil, err
+	}
+	if !catalog.IsAvailable(reference) {
+		return nil, fmt.Errorf("reference language %s is not available", reference)
+	}
+
+	usage, err := translation.Extract(dir, catalog.Roots(reference))
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range exception.Catalog() {
+		usage.Add(e.Key, "error catalog "+e.Code)
+	}
+
+	return catalog.Check(reference, usage), nil
+}
+
+// ScaffoldLanguage writes the message file of lang in languages directory of dir, holding messages
+// of the reference language to be translated. It returns path of the file.
+func ScaffoldLanguage(dir string, reference string, lang string) (string, error) {
+	catalog := translation.NewCatalog(reference)
+	if err := catalog.LoadDir(filepath.Join(dir, "languages")); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, "languages", fmt.Sprintf("global.%s.yaml", lang))
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("%s already exists", path)
+	}
+	content, err := catalog.Scaffold(reference)
+	if err != nil {
+		return "", err
+	}
+
+	return path, ioutil.WriteFile(path, content, 0644)
+}
+
+// langCheckText formats the report as text, one problem per line.
+func langCheckText(report *translation.CheckReport) string {
+	var builder strings.Builder
+	for lang, keys := range report.Missing {
+		for _, key := range keys {
+			builder.WriteString(fmt.Sprintf("missing\t%s\t%s\n", lang, key))
+		}
+	}
+	for _, key := range report.Unused {
+		builder.WriteString(fmt.Sprintf("unused\t%s\t%s\n", report.Reference, key))
+	}
+	for _, m := range report.Mismatched {
+		builder.WriteString(fmt.Sprintf("mismatched\t%s\t%s\texpected %v, got %v\n", m.Language, m.Key, m.Expected, m.Actual))
+	}
+	if report.OK() {
+		builder.WriteString("every message is translated\n")
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"go-rest-skeleton/config"
+	"go-rest-skeleton/grpc/services"
+	"go-rest-skeleton/infrastructure/metrics"
+	"go-rest-skeleton/infrastructure/persistence"
+	"go-rest-skeleton/infrastructure/supervisor"
+	"net/http"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// ServeServices keeps services used by components of serve command, they are closed once every component has stopped.
+type ServeServices struct {
+	// Router returns the http handler of the app, it is only called when the http server is started.
+	Router       func() http.Handler
+	DB           *persistence.Repositories
+	Redis        *persistence.RedisService
+	Storage      *persistence.StorageService
+	Notification *persistence.NotificationService
+	Queue        *persistence.QueueService
+	Outbox       *persistence.OutboxService
+	Webhook      *persistence.WebhookService
+}
+
+// NewServeCommand construct serve command, running the http server, the gRPC server and background workers
+// in one process until receiving SIGINT or SIGTERM. Components not selected by flags are selected by config.
+func NewServeCommand(conf *config.Config, s *ServeServices) *cli.Command {
+	return &cli.Command{
+		Name:  "serve",
+		Usage: "start the http server, the gRPC server and background workers until receiving SIGINT or SIGTERM",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "http", Usage: "serve http requests, defaults to SERVE_HTTP"},
+			&cli.BoolFlag{Name: "grpc", Usage: "serve gRPC calls on GRPC_PORT, defaults to SERVE_GRPC"},
+			&cli.BoolFlag{Name: "workers", Usage: "process queue jobs and relay outbox events, defaults to SERVE_WORKERS"},
+			&cli.DurationFlag{
+				Name:  "shutdown-timeout",
+				Usage: "time given to drain work in flight on shutdown, defaults to SHUTDOWN_TIMEOUT",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			shutdownTimeout := time.Duration(conf.ShutdownTimeout) * time.Second
+			if c.IsSet("shutdown-timeout") {
+				shutdownTimeout = c.Duration("shutdown-timeout")
+			}
+
+			sv := newSupervisor(conf, s, serveOptions{
+				http:            boolFlag(c, "http", conf.ServeHTTP),
+				grpc:            boolFlag(c, "grpc", conf.ServeGRPC),
+				workers:         boolFlag(c, "workers", conf.ServeWorkers),
+				shutdownTimeout: shutdownTimeout,
+			})
+
+			return sv.Run(context.Background())
+		},
+	}
+}
+
+// serveOptions selects components started by serve command.
+type serveOptions struct {
+	http            bool
+	grpc            bool
+	workers         bool
+	shutdownTimeout time.Duration
+}
+
+// newSupervisor creates supervisor running the selected components, services are closed once they have stopped.
+func newSupervisor(conf *config.Config, s *ServeServices, opts serveOptions) *supervisor.Supervisor {
+	sv := supervisor.New(opts.shutdownTimeout)
+
+	if opts.http {
+		server := &http.Server{Addr: ":" + conf.AppPort, Handler: s.Router()}
+
+		// Shutdown does not cancel requests in flight, end notification streams so it does not wait for them
+		server.RegisterOnShutdown(s.Notification.Broadcaster.Close)
+		sv.Add(supervisor.NewHTTPServer("http", server))
+
+		// Deliver in-app notifications published by any instance to clients connected to this instance
+		sv.Add(supervisor.NewWorker("notification broadcaster", s.Notification.Broadcaster.Run))
+	}
+
+	if opts.grpc {
+		sv.Add(supervisor.NewGRPCServer(
+			"grpc", services.NewUser(s.DB).NewServer(), fmt.Sprintf(":%d", conf.GRPCPort)))
+	}
+
+	if opts.workers {
+		worker := newQueueWorker(conf.QueueConcurrency, s.DB, s.Storage, s.Notification, s.Queue, s.Webhook)
+		sv.Add(supervisor.NewWorker("queue worker", worker.Run))
+		sv.Add(supervisor.NewWorker("outbox relay", s.Outbox.Dispatcher.Run))
+	}
+
+	// Serve metrics on a separate listener
+	if conf.MetricsEnabled && conf.MetricsListen != "" {
+		sv.Add(supervisor.NewHTTPServer(
+			"metrics", metrics.NewServer(conf.MetricsListen, conf.MetricsUsername, conf.MetricsPassword)))
+	}
+
+	return sv.
+		OnShutdown("db", s.DB.Close).
+		OnShutdown("redis", s.Redis.Close).
+		OnShutdown("storage", s.Storage.Close)
+}
+
+// boolFlag returns value of the flag when it is set, otherwise the given default.
+func boolFlag(c *cli.Context, name string, value bool) bool {
+	if c.IsSet(name) {
+		return c.Bool(name)
+	}
+
+	return value
+}
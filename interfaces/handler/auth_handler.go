@@ -10,6 +10,7 @@ import (
 	"go-rest-skeleton/infrastructure/notify"
 	"go-rest-skeleton/infrastructure/notify/notification"
 	"go-rest-skeleton/interfaces/service"
+	"go-rest-skeleton/pkg/encoder"
 	"go-rest-skeleton/pkg/response"
 	"go-rest-skeleton/pkg/security"
 	"go-rest-skeleton/pkg/translation"
@@ -26,6 +27,7 @@ type Authenticate struct {
 	rd authorization.AuthInterface
 	tk authorization.TokenInterface
 	ni application.NotifyAppInterface
+	qu application.QueueAppInterface
 }
 
 // NewAuthenticate will initialize authenticate interface for login handler.
@@ -33,12 +35,14 @@ func NewAuthenticate(
 	aa service.AuthService,
 	rd authorization.AuthInterface,
 	tk authorization.TokenInterface,
-	ni application.NotifyAppInterface) *Authenticate {
+	ni application.NotifyAppInterface,
+	qu application.QueueAppInterface) *Authenticate {
 	return &Authenticate{
 		aa: aa,
 		rd: rd,
 		tk: tk,
 		ni: ni,
+		qu: qu,
 	}
 }
 
@@ -61,13 +65,13 @@ func NewAuthenticate(
 func (au *Authenticate) Profile(c *gin.Context) {
 	UUID, exists := c.Get("UUID")
 	if !exists {
-		_ = c.AbortWithError(http.StatusUnauthorized, exception.ErrorTextUnauthorized)
+		response.Abort(c, exception.ErrorTextUnauthorized)
 		return
 	}
 
 	userData, _ := au.aa.GetUserWithRoles(UUID.(string))
 
-	response.NewSuccess(c, userData.DetailUser(), success.AuthSuccessfullyGetProfile).JSON()
+	response.NewSuccess(c, userData.DetailUser(), success.AuthSuccessfullyGetProfile).Render()
 }
 
 // @Summary Authentication login
@@ -91,8 +95,8 @@ func (au *Authenticate) Login(c *gin.Context) {
 	var user *entity.User
 	var errToken = map[string]string{}
 
-	if err := c.ShouldBind(&user); err != nil {
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+	if err := encoder.BindRequest(c, &user); err != nil {
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 
@@ -100,26 +104,26 @@ func (au *Authenticate) Login(c *gin.Context) {
 	if len(validateErr) > 0 {
 		exceptionData := response.TranslateErrorForm(c, validateErr)
 		c.Set("data", exceptionData)
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 
 	u, _, errException := au.aa.GetUserByEmailAndPassword(user)
 	if errException != nil {
-		_ = c.AbortWithError(http.StatusUnauthorized, errException)
+		response.Abort(c, exception.WithStatus(errException, http.StatusUnauthorized))
 		return
 	}
 
 	ts, tErr := au.tk.CreateToken(u.UUID, "")
 	if tErr != nil {
 		errToken["token_error"] = tErr.Error()
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, tErr)
+		response.Abort(c, exception.WithStatus(tErr, http.StatusUnprocessableEntity))
 		return
 	}
 
 	errSave := au.rd.CreateAuth(u.UUID, ts)
 	if errSave != nil {
-		_ = c.AbortWithError(http.StatusInternalServerError, errSave)
+		response.Abort(c, errSave)
 		return
 	}
 
@@ -127,7 +131,7 @@ func (au *Authenticate) Login(c *gin.Context) {
 	userData["access_token"] = ts.AccessToken
 	userData["refresh_token"] = ts.RefreshToken
 
-	response.NewSuccess(c, userData, success.AuthSuccessfullyLogin).JSON()
+	response.NewSuccess(c, userData, success.AuthSuccessfullyLogin).Render()
 }
 
 // @Summary Authentication logout
@@ -147,18 +151,18 @@ func (au *Authenticate) Login(c *gin.Context) {
 func (au *Authenticate) Logout(c *gin.Context) {
 	metadata, err := au.tk.ExtractTokenMetadata(c)
 	if err != nil {
-		_ = c.AbortWithError(http.StatusUnauthorized, exception.ErrorTextUnauthorized)
+		response.Abort(c, exception.ErrorTextUnauthorized)
 		return
 	}
 
 	// If the access token exist and it is still valid, then delete both the access token and the refresh token
 	errDelete := au.rd.DeleteTokens(metadata)
 	if errDelete != nil {
-		_ = c.AbortWithError(http.StatusUnauthorized, errDelete)
+		response.Abort(c, exception.WithStatus(errDelete, http.StatusUnauthorized))
 		return
 	}
 
-	response.NewSuccess(c, nil, success.AuthSuccessfullyLogout).JSON()
+	response.NewSuccess(c, nil, success.AuthSuccessfullyLogout).Render()
 }
 
 // @Summary Authentication refresh
@@ -177,8 +181,8 @@ func (au *Authenticate) Logout(c *gin.Context) {
 // Refresh will handle request to generate new pairs of refresh and access tokens.
 func (au *Authenticate) Refresh(c *gin.Context) {
 	var mapToken *authorization.JWTRefreshToken
-	if err := c.ShouldBind(&mapToken); err != nil {
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+	if err := encoder.BindRequest(c, &mapToken); err != nil {
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 	refreshToken := mapToken.RefreshToken
@@ -195,29 +199,29 @@ func (au *Authenticate) Refresh(c *gin.Context) {
 
 	// Any error may be due to token expiration
 	if errToken != nil {
-		_ = c.AbortWithError(http.StatusUnauthorized, errToken)
+		response.Abort(c, exception.WithStatus(errToken, http.StatusUnauthorized))
 		return
 	}
 
 	// Is token valid?
 	if _, ok := token.Claims.(jwt.Claims); !ok && !token.Valid {
-		_ = c.AbortWithError(http.StatusUnauthorized, errToken)
+		response.Abort(c, exception.ErrorTextUnauthorized)
 		return
 	}
 
 	// Since token is valid, get the uuid:
 	claims, okClaims := token.Claims.(jwt.MapClaims)
 	if !okClaims {
-		_ = c.AbortWithError(http.StatusInternalServerError, exception.ErrorTextAnErrorOccurred)
+		response.Abort(c, exception.ErrorTextAnErrorOccurred)
 	}
 
 	if !token.Valid {
-		_ = c.AbortWithError(http.StatusUnauthorized, exception.ErrorTextRefreshTokenIsExpired)
+		response.Abort(c, exception.ErrorTextRefreshTokenIsExpired)
 	}
 
 	refreshUUID, ok := claims["refresh_uuid"].(string)
 	if !ok {
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 	UUID := claims["uuid"].(string)
@@ -225,21 +229,21 @@ func (au *Authenticate) Refresh(c *gin.Context) {
 	// Delete the previous Refresh Token
 	errDelete := au.rd.DeleteRefresh(refreshUUID)
 	if errDelete != nil {
-		_ = c.AbortWithError(http.StatusUnauthorized, exception.ErrorTextUnauthorized)
+		response.Abort(c, exception.ErrorTextUnauthorized)
 		return
 	}
 
 	// Create new pairs of refresh and access tokens
 	ts, errCreate := au.tk.CreateToken(UUID, "")
 	if errCreate != nil {
-		_ = c.AbortWithError(http.StatusForbidden, errCreate)
+		response.Abort(c, exception.WithStatus(errCreate, http.StatusForbidden))
 		return
 	}
 
 	// Save the tokens metadata to redis
 	errSave := au.rd.CreateAuth(UUID, ts)
 	if errSave != nil {
-		_ = c.AbortWithError(http.StatusForbidden, errSave)
+		response.Abort(c, exception.WithStatus(errSave, http.StatusForbidden))
 		return
 	}
 
@@ -248,7 +252,7 @@ func (au *Authenticate) Refresh(c *gin.Context) {
 		"refresh_token": ts.RefreshToken,
 	}
 
-	response.NewSuccess(c, tokens, success.AuthSuccessfullyRefreshToken).JSON()
+	response.NewSuccess(c, tokens, success.AuthSuccessfullyRefreshToken).Render()
 }
 
 // @Summary Authentication forgot password
@@ -267,8 +271,8 @@ func (au *Authenticate) Refresh(c *gin.Context) {
 // ForgotPassword will handle request to send email contain link to reset password.
 func (au *Authenticate) ForgotPassword(c *gin.Context) {
 	var user entity.User
-	if err := c.ShouldBind(&user); err != nil {
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+	if err := encoder.BindRequest(c, &user); err != nil {
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 
@@ -276,31 +280,31 @@ func (au *Authenticate) ForgotPassword(c *gin.Context) {
 	if len(validateErr) > 0 {
 		exceptionData := response.TranslateErrorForm(c, validateErr)
 		c.Set("data", exceptionData)
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 
 	userData, _, errException := au.aa.GetUserByEmail(&user)
 	if errException != nil {
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, errException)
+		response.Abort(c, exception.WithStatus(errException, http.StatusUnprocessableEntity))
 		return
 	}
 
 	forgotPasswordToken, _, errException := au.aa.CreateToken(userData)
 	if errException != nil {
-		_ = c.AbortWithError(http.StatusInternalServerError, exception.ErrorTextAnErrorOccurred)
+		response.Abort(c, exception.ErrorTextAnErrorOccurred)
 		return
 	}
 
 	nOptions := notify.NotificationOptions{URLPath: forgotPasswordToken.Token}
 	nForgotPassword := notification.NewForgotPassword(userData, au.ni, translation.GetLanguage(c), nOptions)
-	err := nForgotPassword.Send()
-	if len(err) > 0 {
-		_ = c.AbortWithError(http.StatusInternalServerError, exception.ErrorTextAnErrorOccurred)
+	err := nForgotPassword.Dispatch(au.qu)
+	if err != nil {
+		response.Abort(c, exception.ErrorTextAnErrorOccurred)
 		return
 	}
 
-	response.NewSuccess(c, nil, success.AuthSuccessfullyForgotPassword).JSON()
+	response.NewSuccess(c, nil, success.AuthSuccessfullyForgotPassword).Render()
 }
 
 // @Summary Authentication reset password
@@ -323,12 +327,12 @@ func (au *Authenticate) ResetPassword(c *gin.Context) {
 	var userForgotPassword entity.UserForgotPassword
 	var userResetPassword entity.UserResetPassword
 	if err := c.ShouldBindUri(&userForgotPassword.Token); err != nil {
-		_ = c.AbortWithError(http.StatusBadRequest, exception.ErrorTextBadRequest)
+		response.Abort(c, exception.ErrorTextBadRequest)
 		return
 	}
 
-	if err := c.ShouldBind(&userResetPassword); err != nil {
-		_ = c.AbortWithError(http.StatusBadRequest, exception.ErrorTextBadRequest)
+	if err := encoder.BindRequest(c, &userResetPassword); err != nil {
+		response.Abort(c, exception.ErrorTextBadRequest)
 		return
 	}
 
@@ -336,20 +340,20 @@ func (au *Authenticate) ResetPassword(c *gin.Context) {
 	tokenForgotPassword, err := au.aa.GetToken(token)
 	if err != nil {
 		if errors.Is(err, exception.ErrorTextUserForgotPasswordTokenNotFound) {
-			_ = c.AbortWithError(http.StatusNotFound, exception.ErrorTextUserForgotPasswordTokenNotFound)
+			response.Abort(c, exception.ErrorTextUserForgotPasswordTokenNotFound)
 			return
 		}
-		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		response.Abort(c, err)
 		return
 	}
 
 	user, err := au.aa.GetUser(tokenForgotPassword.UserUUID)
 	if err != nil {
 		if errors.Is(err, exception.ErrorTextUserNotFound) {
-			_ = c.AbortWithError(http.StatusNotFound, exception.ErrorTextUserNotFound)
+			response.Abort(c, exception.ErrorTextUserNotFound)
 			return
 		}
-		_ = c.AbortWithError(http.StatusInternalServerError, exception.ErrorTextUserForgotPasswordTokenNotFound)
+		response.Abort(c, exception.ErrorTextAnErrorOccurred)
 		return
 	}
 
@@ -357,13 +361,13 @@ func (au *Authenticate) ResetPassword(c *gin.Context) {
 	if len(validateErr) > 0 {
 		exceptionData := response.TranslateErrorForm(c, validateErr)
 		c.Set("data", exceptionData)
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 
 	hashPassword, errHash := security.Hash(userResetPassword.NewPassword)
 	if errHash != nil {
-		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		response.Abort(c, err)
 		return
 	}
 
@@ -372,18 +376,18 @@ func (au *Authenticate) ResetPassword(c *gin.Context) {
 	if errException != nil {
 		c.Set("data", errDesc)
 		if errors.Is(errException, exception.ErrorTextUserNotFound) {
-			_ = c.AbortWithError(http.StatusNotFound, errException)
+			response.Abort(c, errException)
 			return
 		}
 		if errors.Is(errException, exception.ErrorTextUnprocessableEntity) {
-			_ = c.AbortWithError(http.StatusUnprocessableEntity, errException)
+			response.Abort(c, errException)
 			return
 		}
-		_ = c.AbortWithError(http.StatusInternalServerError, exception.ErrorTextInternalServerError)
+		response.Abort(c, exception.ErrorTextInternalServerError)
 		return
 	}
 
 	_ = au.aa.DeactivateToken(tokenForgotPassword.UUID)
 
-	response.NewSuccess(c, nil, success.AuthSuccessfullyResetPassword).JSON()
+	response.NewSuccess(c, nil, success.AuthSuccessfullyResetPassword).Render()
 }
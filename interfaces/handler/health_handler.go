@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"go-rest-skeleton/infrastructure/health"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler is a struct.
+type HealthHandler struct {
+	registry *health.Registry
+	operator func(c *gin.Context) bool
+}
+
+// HealthResponse is a struct, Checks is only shown to operators.
+type HealthResponse struct {
+	Status string                   `json:"status"`
+	Checks map[string]health.Result `json:"checks,omitempty"`
+}
+
+// NewHealthHandler will initialize health handler.
+// operator reports whether the request is allowed to see result of each check.
+func NewHealthHandler(registry *health.Registry, operator func(c *gin.Context) bool) *HealthHandler {
+	return &HealthHandler{registry: registry, operator: operator}
+}
+
+// @Summary Liveness probe
+// @Description Check the server is running, dependencies are not checked.
+// @Tags health
+// @Produce  json
+// @Success 200 {object} handler.HealthResponse
+// @Router /healthz [get]
+// Liveness will handle liveness probe.
+func (h *HealthHandler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, HealthResponse{Status: health.StatusUp})
+}
+
+// @Summary Readiness probe
+// @Description Check the server is able to serve requests, result of each check is only shown to operators.
+// @Tags health
+// @Produce  json
+// @Success 200 {object} handler.HealthResponse
+// @Failure 503 {object} handler.HealthResponse
+// @Router /readyz [get]
+// Readiness will handle readiness probe.
+func (h *HealthHandler) Readiness(c *gin.Context) {
+	// Results are cached and shared by every probe, so checks are not bound to the request.
+	report := h.registry.Run(context.Background())
+
+	status := http.StatusOK
+	if !report.Up() {
+		status = http.StatusServiceUnavailable
+	}
+
+	body := HealthResponse{Status: report.Status}
+	if h.operator != nil && h.operator(c) {
+		body.Checks = report.Checks
+	}
+
+	c.JSON(status, body)
+}
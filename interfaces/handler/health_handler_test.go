@@ -0,0 +1,58 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"go-rest-skeleton/infrastructure/health"
+	"go-rest-skeleton/interfaces/handler"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func healthRequest(target string, handle gin.HandlerFunc) (int, handler.HealthResponse) {
+	var body handler.HealthResponse
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	r.GET(target, handle)
+	req, _ := http.NewRequest(http.MethodGet, target, nil)
+	r.ServeHTTP(w, req)
+	_ = json.Unmarshal(w.Body.Bytes(), &body)
+
+	return w.Code, body
+}
+
+func TestHealth_Liveness(t *testing.T) {
+	registry := health.NewRegistry(0).
+		Register("db", health.CheckerFunc(func(ctx context.Context) error { return errors.New("refused") }), time.Second)
+	h := handler.NewHealthHandler(registry, nil)
+
+	code, body := healthRequest("/healthz", h.Liveness)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, health.StatusUp, body.Status)
+}
+
+func TestHealth_Readiness(t *testing.T) {
+	registry := health.NewRegistry(0).
+		Register("db", health.CheckerFunc(func(ctx context.Context) error { return nil }), time.Second).
+		Register("redis", health.CheckerFunc(func(ctx context.Context) error { return errors.New("refused") }), time.Second)
+
+	h := handler.NewHealthHandler(registry, func(c *gin.Context) bool { return false })
+	code, body := healthRequest("/readyz", h.Readiness)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, health.StatusDown, body.Status)
+	assert.Empty(t, body.Checks)
+
+	h = handler.NewHealthHandler(registry, func(c *gin.Context) bool { return true })
+	code, body = healthRequest("/readyz", h.Readiness)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, health.StatusUp, body.Checks["db"].Status)
+	assert.Equal(t, "refused", body.Checks["redis"].Error)
+}
@@ -7,6 +7,7 @@ import (
 	"go-rest-skeleton/infrastructure/authorization"
 	"go-rest-skeleton/infrastructure/message/exception"
 	"go-rest-skeleton/interfaces/service"
+	"go-rest-skeleton/pkg/encoder"
 	"go-rest-skeleton/pkg/response"
 	"net/http"
 
@@ -44,8 +45,8 @@ func (o *Oauth) Login(c *gin.Context) {
 	}
 
 	var user *entity.User
-	if err := c.ShouldBind(&user); err != nil {
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+	if err := encoder.BindRequest(c, &user); err != nil {
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 
@@ -54,20 +55,20 @@ func (o *Oauth) Login(c *gin.Context) {
 		if len(validateErr) > 0 {
 			exceptionData := response.TranslateErrorForm(c, validateErr)
 			c.Set("data", exceptionData)
-			_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+			response.Abort(c, exception.ErrorTextUnprocessableEntity)
 			return
 		}
 
 		u, _, errException := o.aa.GetUserByEmailAndPassword(user)
 		if errException != nil {
-			_ = c.AbortWithError(http.StatusUnauthorized, errException)
+			response.Abort(c, exception.WithStatus(errException, http.StatusUnauthorized))
 			return
 		}
 
 		sessionStore.Set("LoggedInUserID", u.UUID)
 		errStore := sessionStore.Save()
 		if errStore != nil {
-			_ = c.AbortWithError(http.StatusInternalServerError, errStore)
+			response.Abort(c, errStore)
 			return
 		}
 
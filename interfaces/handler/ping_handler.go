@@ -1,17 +1,17 @@
 package handler
 
 import (
-	"go-rest-skeleton/config"
-	"go-rest-skeleton/infrastructure/persistence"
+	"context"
+	"errors"
+	"go-rest-skeleton/infrastructure/health"
 	"go-rest-skeleton/pkg/response"
-	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
 // PingHandler is a struct.
 type PingHandler struct {
-	cf *config.Config
+	registry *health.Registry
 }
 
 // PingResponse is a struct.
@@ -20,9 +20,9 @@ type PingResponse struct {
 	Redis string `json:"redis"`
 }
 
-// NewPingHandler will initialize Ping handler.
-func NewPingHandler(config *config.Config) *PingHandler {
-	return &PingHandler{cf: config}
+// NewPingHandler will initialize Ping handler, database and redis are checked by checks of registry named db and redis.
+func NewPingHandler(registry *health.Registry) *PingHandler {
+	return &PingHandler{registry: registry}
 }
 
 // @Summary Ping server
@@ -39,22 +39,19 @@ func NewPingHandler(config *config.Config) *PingHandler {
 func (p *PingHandler) Ping(c *gin.Context) {
 	var pingData PingResponse
 
-	_, errDBConn := persistence.NewDBConnection(p.cf.DBConfig)
-	if errDBConn != nil {
-		_ = c.AbortWithError(http.StatusInternalServerError, errDBConn)
-		pingData.DB = "Not OK"
-	} else {
-		pingData.DB = "OK"
-	}
+	report := p.registry.Run(context.Background())
+	pingData.DB = p.status(c, report.Checks["db"])
+	pingData.Redis = p.status(c, report.Checks["redis"])
+
+	response.NewSuccess(c, pingData, "PONG").Render()
+}
 
-	redisConnection, errRedisConn := persistence.NewRedisConnection(p.cf.RedisConfig)
-	if errRedisConn != nil {
-		_ = c.AbortWithError(http.StatusInternalServerError, errRedisConn)
-		pingData.Redis = "Not OK"
-	} else {
-		_ = redisConnection.Close()
-		pingData.Redis = "OK"
+// status returns OK when the check is up, otherwise aborts the request with error of the check.
+func (p *PingHandler) status(c *gin.Context, result health.Result) string {
+	if result.Status != health.StatusUp {
+		response.Abort(c, errors.New(result.Error))
+		return "Not OK"
 	}
 
-	response.NewSuccess(c, pingData, "PONG").JSON()
+	return "OK"
 }
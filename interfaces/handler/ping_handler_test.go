@@ -1,9 +1,13 @@
 package handler_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go-rest-skeleton/config"
+	"go-rest-skeleton/infrastructure/health"
+	"go-rest-skeleton/infrastructure/persistence"
 	"go-rest-skeleton/interfaces/handler"
 	"go-rest-skeleton/pkg/encoder"
 	"go-rest-skeleton/pkg/util"
@@ -11,6 +15,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/joho/godotenv"
 
@@ -28,7 +33,18 @@ func TestPing_Success(t *testing.T) {
 	}
 
 	conf := config.New()
-	pingHandler := handler.NewPingHandler(conf)
+	dbService, err := persistence.NewDBService(conf.DBConfig)
+	if err != nil {
+		t.Fatalf("want non error, got %#v", err)
+	}
+	redisService, err := persistence.NewRedisService(conf.RedisConfig)
+	if err != nil {
+		t.Fatalf("want non error, got %#v", err)
+	}
+	registry := health.NewRegistry(0).
+		Register("db", health.DB(dbService.DB), time.Second).
+		Register("redis", health.Redis(redisService.Client), time.Second)
+	pingHandler := handler.NewPingHandler(registry)
 
 	gin.SetMode(gin.TestMode)
 	w := httptest.NewRecorder()
@@ -36,7 +52,6 @@ func TestPing_Success(t *testing.T) {
 	v1 := r.Group("/api/v1/")
 	v1.GET("/ping", pingHandler.Ping)
 
-	var err error
 	c.Request, err = http.NewRequest(http.MethodGet, "/api/v1/ping", nil)
 	if err != nil {
 		t.Errorf("this is the error: %v\n", err)
@@ -54,14 +69,15 @@ func TestPing_Success(t *testing.T) {
 }
 
 func TestPing_Failed(t *testing.T) {
-	SkipThis(t)
-
 	var pingData handler.PingResponse
 
-	conf := config.New()
-	conf.DBConfig.DBUser = "invalid user"
-	conf.RedisConfig.RedisHost = "invalid host"
-	pingHandler := handler.NewPingHandler(conf)
+	failed := health.CheckerFunc(func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})
+	registry := health.NewRegistry(0).
+		Register("db", failed, time.Second).
+		Register("redis", failed, time.Second)
+	pingHandler := handler.NewPingHandler(registry)
 
 	gin.SetMode(gin.TestMode)
 	w := httptest.NewRecorder()
@@ -7,8 +7,8 @@ import (
 	"go-rest-skeleton/infrastructure/authorization"
 	"go-rest-skeleton/infrastructure/message/exception"
 	"go-rest-skeleton/infrastructure/message/success"
+	"go-rest-skeleton/pkg/encoder"
 	"go-rest-skeleton/pkg/response"
-	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
@@ -50,18 +50,18 @@ func NewPreference(
 func (up *Preference) GerPreference(c *gin.Context) {
 	UUID, exists := c.Get("UUID")
 	if !exists {
-		_ = c.AbortWithError(http.StatusUnauthorized, exception.ErrorTextUnauthorized)
+		response.Abort(c, exception.ErrorTextUnauthorized)
 		return
 	}
 
 	userPreferenceData, err := up.up.GetUserPreference(UUID.(string))
 	if err != nil {
-		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		response.Abort(c, err)
 		return
 	}
 
 	userPreference := userPreferenceData.DetailUserPreference()
-	response.NewSuccess(c, userPreference, success.UserSuccessfullyGetUserPreference).JSON()
+	response.NewSuccess(c, userPreference, success.UserSuccessfullyGetUserPreference).Render()
 }
 
 // @Summary Update user preference
@@ -85,13 +85,13 @@ func (up *Preference) GerPreference(c *gin.Context) {
 func (up *Preference) UpdatePreference(c *gin.Context) {
 	UUID, exists := c.Get("UUID")
 	if !exists {
-		_ = c.AbortWithError(http.StatusUnauthorized, exception.ErrorTextUnauthorized)
+		response.Abort(c, exception.ErrorTextUnauthorized)
 		return
 	}
 
 	var preference entity.DetailUserPreference
-	if err := c.ShouldBind(&preference); err != nil {
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+	if err := encoder.BindRequest(c, &preference); err != nil {
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 
@@ -99,7 +99,7 @@ func (up *Preference) UpdatePreference(c *gin.Context) {
 	if len(validateErr) > 0 {
 		exceptionData := response.TranslateErrorForm(c, validateErr)
 		c.Set("data", exceptionData)
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 
@@ -107,19 +107,19 @@ func (up *Preference) UpdatePreference(c *gin.Context) {
 	if errException != nil {
 		c.Set("data", errDesc)
 		if errors.Is(errException, exception.ErrorTextUserNotFound) {
-			_ = c.AbortWithError(http.StatusNotFound, errException)
+			response.Abort(c, errException)
 			return
 		}
 		if errors.Is(errException, exception.ErrorTextUnprocessableEntity) {
-			_ = c.AbortWithError(http.StatusUnprocessableEntity, errException)
+			response.Abort(c, errException)
 			return
 		}
-		_ = c.AbortWithError(http.StatusInternalServerError, exception.ErrorTextInternalServerError)
+		response.Abort(c, exception.ErrorTextInternalServerError)
 		return
 	}
 
 	userPreference := userPreferenceData.DetailUserPreference()
-	response.NewSuccess(c, userPreference, success.UserSuccessfullyUpdateUserPreference).JSON()
+	response.NewSuccess(c, userPreference, success.UserSuccessfullyUpdateUserPreference).Render()
 }
 
 // @Summary Reset user preference
@@ -140,16 +140,16 @@ func (up *Preference) UpdatePreference(c *gin.Context) {
 func (up *Preference) ResetPreference(c *gin.Context) {
 	UUID, exists := c.Get("UUID")
 	if !exists {
-		_ = c.AbortWithError(http.StatusUnauthorized, exception.ErrorTextUnauthorized)
+		response.Abort(c, exception.ErrorTextUnauthorized)
 		return
 	}
 
 	userPreferenceData, err := up.up.ResetUserPreference(UUID.(string))
 	if err != nil {
-		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		response.Abort(c, err)
 		return
 	}
 
 	userPreference := userPreferenceData.DetailUserPreference()
-	response.NewSuccess(c, userPreference, success.UserSuccessfullyResetUserPreference).JSON()
+	response.NewSuccess(c, userPreference, success.UserSuccessfullyResetUserPreference).Render()
 }
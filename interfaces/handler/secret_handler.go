@@ -4,7 +4,6 @@ import (
 	"go-rest-skeleton/infrastructure/message/success"
 	"go-rest-skeleton/pkg/response"
 	"go-rest-skeleton/pkg/security"
-	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
@@ -33,7 +32,7 @@ func NewSecretHandler() *SecretHandler {
 func (s *SecretHandler) GenerateSecret(c *gin.Context) {
 	secretPriPubKey, err := security.GenerateSecret()
 	if err != nil {
-		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		response.Abort(c, err)
 	}
-	response.NewSuccess(c, secretPriPubKey, success.DevSuccessfullyGenerateRSAKey).JSON()
+	response.NewSuccess(c, secretPriPubKey, success.DevSuccessfullyGenerateRSAKey).Render()
 }
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"errors"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/infrastructure/storage"
+	"go-rest-skeleton/pkg/response"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StorageHandler is a struct.
+type StorageHandler struct {
+	ld *storage.LocalDriver
+}
+
+// NewStorageHandler will initialize handler serving files of local storage driver.
+func NewStorageHandler(ld *storage.LocalDriver) *StorageHandler {
+	return &StorageHandler{ld: ld}
+}
+
+// Download will serve file of the signed URL generated by local storage driver.
+func (s *StorageHandler) Download(c *gin.Context) {
+	objectPath := strings.TrimPrefix(c.Param("filepath"), "/")
+	err := s.ld.Verify(objectPath, c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		abortWithSignatureError(c, err)
+		return
+	}
+
+	fullPath, err := s.ld.FullPath(objectPath)
+	if err != nil {
+		response.Abort(c, exception.ErrorTextStorageInvalidSignature)
+		return
+	}
+	if _, err := os.Stat(fullPath); err != nil {
+		response.Abort(c, exception.ErrorTextStorageFileNotFound)
+		return
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(fullPath))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", "inline; filename="+filepath.Base(fullPath))
+	c.File(fullPath)
+}
+
+// Upload will store file sent to the presigned upload URL generated by local storage driver.
+// Size and type of the file are checked when the upload is confirmed.
+func (s *StorageHandler) Upload(c *gin.Context) {
+	objectPath := strings.TrimPrefix(c.Param("filepath"), "/")
+	err := s.ld.VerifyUpload(objectPath, c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		abortWithSignatureError(c, err)
+		return
+	}
+
+	err = s.ld.PutObject(c.Request.Context(), objectPath, c.Request.Body, c.Request.ContentLength, c.ContentType())
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+func abortWithSignatureError(c *gin.Context, err error) {
+	if errors.Is(err, storage.ErrLocalSignatureExpired) {
+		response.Abort(c, exception.ErrorTextStorageExpiredSignature)
+		return
+	}
+	response.Abort(c, exception.ErrorTextStorageInvalidSignature)
+}
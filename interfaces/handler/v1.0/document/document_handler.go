@@ -0,0 +1,60 @@
+package document
+
+import (
+	"errors"
+	"go-rest-skeleton/application"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/infrastructure/message/success"
+	"go-rest-skeleton/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Documents is a struct defines the dependencies that will be used.
+type Documents struct {
+	ds application.DocumentAppInterface
+}
+
+// NewDocuments is constructor will initialize document handler.
+func NewDocuments(ds application.DocumentAppInterface) *Documents {
+	return &Documents{ds: ds}
+}
+
+// @Summary Approve document
+// @Description Approve an existing document, approving an approved document changes nothing.
+// @Tags documents
+// @Produce json
+// @Param Accept-Language header string false "Language code" Enums(en, id) default(id)
+// @Param Set-Request-Id header string false "Request id"
+// @Security BasicAuth
+// @Security JWTAuth
+// @Param uuid path string true "Document UUID"
+// @Success 200 {object} response.successOutput
+// @Failure 400 {object} response.errorOutput
+// @Failure 401 {object} response.errorOutput
+// @Failure 403 {object} response.errorOutput
+// @Failure 404 {object} response.errorOutput
+// @Failure 500 {object} response.errorOutput
+// @Router /api/v1/external/documents/{uuid}/approve [post]
+// ApproveDocument is a function uses to handle approve document by UUID.
+func (s *Documents) ApproveDocument(c *gin.Context) {
+	var documentEntity entity.Document
+	if err := c.ShouldBindUri(&documentEntity.UUID); err != nil {
+		response.Abort(c, exception.ErrorTextBadRequest)
+		return
+	}
+
+	UUID := c.Param("uuid")
+	approvedDocument, errDesc, err := s.ds.ApproveDocument(UUID)
+	if err != nil {
+		c.Set("data", errDesc)
+		if errors.Is(err, exception.ErrorTextDocumentNotFound) {
+			response.Abort(c, exception.ErrorTextDocumentNotFound)
+			return
+		}
+		response.Abort(c, exception.ErrorTextInternalServerError)
+		return
+	}
+	response.NewSuccess(c, approvedDocument.DetailDocument(), success.DocumentSuccessfullyApproveDocument).Render()
+}
@@ -0,0 +1,75 @@
+package document
+
+import (
+	"encoding/json"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/pkg/encoder"
+	"go-rest-skeleton/tests/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestApproveDocument_Success Test.
+func TestApproveDocument_Success(t *testing.T) {
+	var documentData entity.DetailDocument
+	var documentApp mock.DocumentAppInterface
+	documentHandler := NewDocuments(&documentApp)
+	UUID := uuid.New().String()
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, r := gin.CreateTestContext(w)
+	v1 := r.Group("/api/v1/external/")
+	v1.POST("/documents/:uuid/approve", documentHandler.ApproveDocument)
+
+	documentApp.ApproveDocumentFn = func(UUID string) (*entity.Document, map[string]string, error) {
+		return &entity.Document{UUID: UUID, Title: "Example", Workflow: entity.DocumentWorkflowApproved}, nil, nil
+	}
+
+	var err error
+	c.Request, err = http.NewRequest(http.MethodPost, "/api/v1/external/documents/"+UUID+"/approve", nil)
+	if err != nil {
+		t.Errorf("this is the error: %v\n", err)
+	}
+	r.ServeHTTP(w, c.Request)
+
+	response := encoder.ResponseDecoder(w.Body)
+	data, _ := json.Marshal(response["data"])
+	_ = json.Unmarshal(data, &documentData)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.EqualValues(t, UUID, documentData.UUID)
+	assert.EqualValues(t, entity.DocumentWorkflowApproved, documentData.Workflow)
+}
+
+// TestApproveDocument_Failed_DocumentNotFound Test.
+func TestApproveDocument_Failed_DocumentNotFound(t *testing.T) {
+	var documentApp mock.DocumentAppInterface
+	documentHandler := NewDocuments(&documentApp)
+	UUID := uuid.New().String()
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, r := gin.CreateTestContext(w)
+	v1 := r.Group("/api/v1/external/")
+	v1.POST("/documents/:uuid/approve", documentHandler.ApproveDocument)
+
+	documentApp.ApproveDocumentFn = func(UUID string) (*entity.Document, map[string]string, error) {
+		return nil, nil, exception.ErrorTextDocumentNotFound
+	}
+
+	var err error
+	c.Request, err = http.NewRequest(http.MethodPost, "/api/v1/external/documents/"+UUID+"/approve", nil)
+	if err != nil {
+		t.Errorf("this is the error: %v\n", err)
+	}
+	r.ServeHTTP(w, c.Request)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
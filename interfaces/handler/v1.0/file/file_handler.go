@@ -0,0 +1,101 @@
+package file
+
+import (
+	"go-rest-skeleton/application"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/infrastructure/message/success"
+	"go-rest-skeleton/pkg/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Files is a struct defines the dependencies that will be used.
+type Files struct {
+	fa application.StorageFileAppInterface
+	ss application.StorageAppInterface
+}
+
+// NewFiles is constructor will initialize file handler.
+func NewFiles(fa application.StorageFileAppInterface, ss application.StorageAppInterface) *Files {
+	return &Files{
+		fa: fa,
+		ss: ss,
+	}
+}
+
+// GetFiles is a function uses to handle get files uploaded by current logged in user.
+// Use query category to filter the files by slug of their category.
+func (f *Files) GetFiles(c *gin.Context) {
+	UUID, exists := c.Get("UUID")
+	if !exists {
+		response.Abort(c, exception.ErrorTextUnauthorized)
+		return
+	}
+
+	var file entity.StorageFile
+	var files entity.StorageFiles
+	var err error
+	parameters := repository.NewGinParameters(c)
+	validateErr := parameters.ValidateParameter(file.FilterableFields()...)
+	if len(validateErr) > 0 {
+		exceptionData := response.TranslateErrorForm(c, validateErr)
+		c.Set("data", exceptionData)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	files, meta, err := f.fa.GetUserFiles(UUID.(string), c.DefaultQuery("category", ""), parameters)
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, files.DetailStorageFiles(), success.StorageSuccessfullyGetFileList).WithMeta(meta).Render()
+}
+
+// GetFile is a function uses to handle get detail and signed URL of a file uploaded by current logged in user.
+// Use query variant to get signed URL of a generated image variant instead, such as variant=small.
+// File which has not been scanned or has been quarantined is refused.
+func (f *Files) GetFile(c *gin.Context) {
+	UUID, exists := c.Get("UUID")
+	if !exists {
+		response.Abort(c, exception.ErrorTextUnauthorized)
+		return
+	}
+
+	file, err := f.fa.GetUserFile(UUID.(string), c.Param("uuid"))
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+
+	fileURL, err := f.ss.GetFile(file.UUID, c.DefaultQuery("variant", ""))
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, file.DetailStorageFile(fileURL), success.StorageSuccessfullyGetFileDetail).Render()
+}
+
+// DeleteFile is a function uses to handle delete a file uploaded by current logged in user.
+// File still in use, such as the current avatar, can not be deleted.
+func (f *Files) DeleteFile(c *gin.Context) {
+	UUID, exists := c.Get("UUID")
+	if !exists {
+		response.Abort(c, exception.ErrorTextUnauthorized)
+		return
+	}
+
+	file, err := f.fa.GetUserFile(UUID.(string), c.Param("uuid"))
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+
+	if err := f.ss.DeleteFile(file.UUID); err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, nil, success.StorageSuccessfullyDeleteFile).Render()
+}
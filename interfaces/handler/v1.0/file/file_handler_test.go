@@ -0,0 +1,128 @@
+package file
+
+import (
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/tests/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var userUUID = uuid.New().String()
+
+func fileRouterSetup(fileApp *mock.StorageFileAppInterface, storageApp *mock.StorageAppInterface) *gin.Engine {
+	fileHandler := NewFiles(fileApp, storageApp)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("UUID", userUUID)
+	})
+	v1 := r.Group("/api/v1/external")
+	v1.GET("/files", fileHandler.GetFiles)
+	v1.GET("/files/:uuid", fileHandler.GetFile)
+	v1.DELETE("/files/:uuid", fileHandler.DeleteFile)
+	return r
+}
+
+func TestGetFiles_Success(t *testing.T) {
+	var fileApp mock.StorageFileAppInterface
+	var storageApp mock.StorageAppInterface
+	fileApp.GetUserFilesFn = func(UUID string, category string, p *repository.Parameters) ([]entity.StorageFile, interface{}, error) {
+		assert.Equal(t, userUUID, UUID)
+		assert.Equal(t, "document", category)
+		return []entity.StorageFile{{UUID: uuid.New().String()}}, nil, nil
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/external/files?category=document", nil)
+	w := httptest.NewRecorder()
+	fileRouterSetup(&fileApp, &storageApp).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestGetFile_NotFound(t *testing.T) {
+	var fileApp mock.StorageFileAppInterface
+	var storageApp mock.StorageAppInterface
+	fileApp.GetUserFileFn = func(string, string) (*entity.StorageFile, error) {
+		return nil, exception.ErrorTextStorageFileNotFound
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/external/files/"+uuid.New().String(), nil)
+	w := httptest.NewRecorder()
+	fileRouterSetup(&fileApp, &storageApp).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetFile(t *testing.T) {
+	samples := []struct {
+		err        error
+		statusCode int
+	}{
+		{statusCode: http.StatusOK},
+		{err: exception.ErrorTextStorageFileScanPending, statusCode: http.StatusConflict},
+		{err: exception.ErrorTextStorageFileQuarantined, statusCode: http.StatusForbidden},
+	}
+
+	for _, v := range samples {
+		var fileApp mock.StorageFileAppInterface
+		var storageApp mock.StorageAppInterface
+		sample := v
+		fileUUID := uuid.New().String()
+		fileApp.GetUserFileFn = func(string, string) (*entity.StorageFile, error) {
+			return &entity.StorageFile{UUID: fileUUID, UserUUID: userUUID}, nil
+		}
+		storageApp.GetFileFn = func(UUID string, variant string) (interface{}, error) {
+			assert.Equal(t, fileUUID, UUID)
+			assert.Equal(t, "small", variant)
+			if sample.err != nil {
+				return nil, sample.err
+			}
+			return "http://localhost/" + fileUUID, nil
+		}
+
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/external/files/"+fileUUID+"?variant=small", nil)
+		w := httptest.NewRecorder()
+		fileRouterSetup(&fileApp, &storageApp).ServeHTTP(w, req)
+
+		assert.Equal(t, sample.statusCode, w.Code)
+	}
+}
+
+func TestDeleteFile(t *testing.T) {
+	samples := []struct {
+		err        error
+		statusCode int
+	}{
+		{statusCode: http.StatusOK},
+		{err: exception.ErrorTextStorageFileInUse, statusCode: http.StatusConflict},
+		{err: exception.ErrorTextStorageFileNotFound, statusCode: http.StatusNotFound},
+	}
+
+	for _, v := range samples {
+		var fileApp mock.StorageFileAppInterface
+		var storageApp mock.StorageAppInterface
+		sample := v
+		fileUUID := uuid.New().String()
+		fileApp.GetUserFileFn = func(string, string) (*entity.StorageFile, error) {
+			return &entity.StorageFile{UUID: fileUUID, UserUUID: userUUID}, nil
+		}
+		storageApp.DeleteFileFn = func(UUID string) error {
+			assert.Equal(t, fileUUID, UUID)
+			return sample.err
+		}
+
+		req, _ := http.NewRequest(http.MethodDelete, "/api/v1/external/files/"+fileUUID, nil)
+		w := httptest.NewRecorder()
+		fileRouterSetup(&fileApp, &storageApp).ServeHTTP(w, req)
+
+		assert.Equal(t, sample.statusCode, w.Code)
+	}
+}
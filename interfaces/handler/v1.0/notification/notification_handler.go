@@ -0,0 +1,152 @@
+package notification
+
+import (
+	"go-rest-skeleton/application"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/infrastructure/message/success"
+	"go-rest-skeleton/infrastructure/notify"
+	"go-rest-skeleton/pkg/response"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// heartbeatInterval is the interval of comment sent to stream, so proxies do not close an idle connection.
+const heartbeatInterval = 25 * time.Second
+
+// Notifications is a struct defines the dependencies that will be used.
+type Notifications struct {
+	na application.UserNotificationAppInterface
+	br notify.InAppBroadcaster
+}
+
+// NewNotifications is constructor will initialize notification handler.
+func NewNotifications(na application.UserNotificationAppInterface, br notify.InAppBroadcaster) *Notifications {
+	return &Notifications{
+		na: na,
+		br: br,
+	}
+}
+
+// GetNotifications is a function uses to handle get notification inbox of current logged in user.
+// Use query status=read or status=unread to filter the inbox.
+func (n *Notifications) GetNotifications(c *gin.Context) {
+	UUID, exists := c.Get("UUID")
+	if !exists {
+		response.Abort(c, exception.ErrorTextUnauthorized)
+		return
+	}
+
+	var notification entity.UserNotification
+	var notifications entity.UserNotifications
+	var err error
+	parameters := repository.NewGinParameters(c)
+	validateErr := parameters.ValidateParameter(notification.FilterableFields()...)
+	status := c.DefaultQuery("status", "")
+	validateErr = append(validateErr, notification.ValidateUserNotificationStatus(status)...)
+	if len(validateErr) > 0 {
+		exceptionData := response.TranslateErrorForm(c, validateErr)
+		c.Set("data", exceptionData)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	notifications, meta, err := n.na.GetUserNotifications(UUID.(string), status, parameters)
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, notifications.DetailUserNotifications(), success.NotificationSuccessfullyGetNotificationList).
+		WithMeta(meta).Render()
+}
+
+// MarkNotificationRead is a function uses to handle mark a notification of current logged in user as read.
+func (n *Notifications) MarkNotificationRead(c *gin.Context) {
+	UUID, exists := c.Get("UUID")
+	if !exists {
+		response.Abort(c, exception.ErrorTextUnauthorized)
+		return
+	}
+
+	notification, err := n.na.MarkUserNotificationRead(UUID.(string), c.Param("uuid"))
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, notification.DetailUserNotification(), success.NotificationSuccessfullyMarkAsRead).Render()
+}
+
+// MarkNotificationUnread is a function uses to handle mark a notification of current logged in user as unread.
+func (n *Notifications) MarkNotificationUnread(c *gin.Context) {
+	UUID, exists := c.Get("UUID")
+	if !exists {
+		response.Abort(c, exception.ErrorTextUnauthorized)
+		return
+	}
+
+	notification, err := n.na.MarkUserNotificationUnread(UUID.(string), c.Param("uuid"))
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, notification.DetailUserNotification(), success.NotificationSuccessfullyMarkAsUnread).Render()
+}
+
+// MarkAllNotificationsRead is a function uses to handle mark every notification of current logged in user as read.
+func (n *Notifications) MarkAllNotificationsRead(c *gin.Context) {
+	UUID, exists := c.Get("UUID")
+	if !exists {
+		response.Abort(c, exception.ErrorTextUnauthorized)
+		return
+	}
+
+	total, err := n.na.MarkAllUserNotificationsRead(UUID.(string))
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, map[string]int64{"total": total}, success.NotificationSuccessfullyMarkAllAsRead).Render()
+}
+
+// StreamNotifications is a function uses to stream new notifications of current logged in user as server-sent events.
+// Browsers can not set Authorization header of EventSource, so the token can be passed as access_token query.
+func (n *Notifications) StreamNotifications(c *gin.Context) {
+	UUID, exists := c.Get("UUID")
+	if !exists {
+		response.Abort(c, exception.ErrorTextUnauthorized)
+		return
+	}
+
+	subscription := n.br.Subscribe(UUID.(string))
+	defer subscription.Close()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case notification, ok := <-subscription.C:
+			if !ok {
+				return false
+			}
+			c.SSEvent("notification", notification.DetailUserNotification())
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		}
+	})
+}
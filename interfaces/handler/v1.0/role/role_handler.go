@@ -8,6 +8,7 @@ import (
 	"go-rest-skeleton/infrastructure/authorization"
 	"go-rest-skeleton/infrastructure/message/exception"
 	"go-rest-skeleton/infrastructure/message/success"
+	"go-rest-skeleton/pkg/encoder"
 	"go-rest-skeleton/pkg/response"
 	"net/http"
 
@@ -38,41 +39,41 @@ func NewRoles(
 // SaveRole is a function uses to handle create a new role.
 func (s *Roles) SaveRole(c *gin.Context) {
 	var roleEntity entity.Role
-	if err := c.ShouldBindJSON(&roleEntity); err != nil {
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+	if err := encoder.BindRequest(c, &roleEntity); err != nil {
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 	validateErr := roleEntity.ValidateSaveRole()
 	if len(validateErr) > 0 {
 		exceptionData := response.TranslateErrorForm(c, validateErr)
 		c.Set("data", exceptionData)
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 	newRole, errDesc, errException := s.ur.SaveRole(&roleEntity)
 	if errException != nil {
 		c.Set("data", errDesc)
 		if errors.Is(errException, exception.ErrorTextUnprocessableEntity) {
-			_ = c.AbortWithError(http.StatusUnprocessableEntity, errException)
+			response.Abort(c, errException)
 			return
 		}
-		_ = c.AbortWithError(http.StatusInternalServerError, exception.ErrorTextInternalServerError)
+		response.Abort(c, exception.ErrorTextInternalServerError)
 		return
 	}
 	c.Status(http.StatusCreated)
-	response.NewSuccess(c, newRole.DetailRole(), success.RoleSuccessfullyCreateRole).JSON()
+	response.NewSuccess(c, newRole.DetailRole(), success.RoleSuccessfullyCreateRole).Render()
 }
 
 // UpdateUser is a function uses to handle create a new user.
 func (s *Roles) UpdateRole(c *gin.Context) {
 	var roleEntity entity.Role
 	if err := c.ShouldBindUri(&roleEntity.UUID); err != nil {
-		_ = c.AbortWithError(http.StatusBadRequest, exception.ErrorTextBadRequest)
+		response.Abort(c, exception.ErrorTextBadRequest)
 		return
 	}
 
-	if err := c.ShouldBindJSON(&roleEntity); err != nil {
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+	if err := encoder.BindRequest(c, &roleEntity); err != nil {
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 
@@ -80,47 +81,170 @@ func (s *Roles) UpdateRole(c *gin.Context) {
 	if len(validateErr) > 0 {
 		exceptionData := response.TranslateErrorForm(c, validateErr)
 		c.Set("data", exceptionData)
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
+	version, err := response.IfMatch(c)
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	roleEntity.Version = version
 	UUID := c.Param("uuid")
 	updatedRole, errDesc, errException := s.ur.UpdateRole(UUID, &roleEntity)
 	if errException != nil {
 		c.Set("data", errDesc)
 		if errors.Is(errException, exception.ErrorTextRoleNotFound) {
-			_ = c.AbortWithError(http.StatusNotFound, errException)
+			response.Abort(c, errException)
 			return
 		}
 		if errors.Is(errException, exception.ErrorTextUnprocessableEntity) {
-			_ = c.AbortWithError(http.StatusUnprocessableEntity, errException)
+			response.Abort(c, errException)
+			return
+		}
+		if errors.Is(errException, exception.ErrorTextPreconditionFailed) {
+			response.Abort(c, errException)
 			return
 		}
-		_ = c.AbortWithError(http.StatusInternalServerError, exception.ErrorTextInternalServerError)
+		response.Abort(c, exception.ErrorTextInternalServerError)
 		return
 	}
+	response.SetETag(c, updatedRole.Version)
 	c.Status(http.StatusOK)
-	response.NewSuccess(c, updatedRole.DetailRole(), success.RoleSuccessfullyUpdateRole).JSON()
+	response.NewSuccess(c, updatedRole.DetailRole(), success.RoleSuccessfullyUpdateRole).Render()
 }
 
 // DeleteRole is a function uses to handle delete role by UUID.
 func (s *Roles) DeleteRole(c *gin.Context) {
 	var roleEntity entity.Role
 	if err := c.ShouldBindUri(&roleEntity.UUID); err != nil {
-		_ = c.AbortWithError(http.StatusBadRequest, exception.ErrorTextBadRequest)
+		response.Abort(c, exception.ErrorTextBadRequest)
 		return
 	}
 
 	UUID := c.Param("uuid")
 	err := s.ur.DeleteRole(UUID)
 	if err != nil {
-		if errors.Is(err, exception.ErrorTextUserNotFound) {
-			_ = c.AbortWithError(http.StatusNotFound, exception.ErrorTextUserNotFound)
+		if errors.Is(err, exception.ErrorTextRoleNotFound) {
+			response.Abort(c, exception.ErrorTextRoleNotFound)
 			return
 		}
-		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, nil, success.RoleSuccessfullyDeleteRole).Render()
+}
+
+// RestoreRole is a function uses to handle restore deleted role by UUID.
+func (s *Roles) RestoreRole(c *gin.Context) {
+	var roleEntity entity.Role
+	if err := c.ShouldBindUri(&roleEntity.UUID); err != nil {
+		response.Abort(c, exception.ErrorTextBadRequest)
+		return
+	}
+
+	UUID := c.Param("uuid")
+	restoredRole, err := s.ur.RestoreRole(UUID)
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.SetETag(c, restoredRole.Version)
+	response.NewSuccess(c, restoredRole.DetailRole(), success.RoleSuccessfullyRestoreRole).Render()
+}
+
+// ForceDeleteRole is a function uses to handle permanently delete role by UUID.
+func (s *Roles) ForceDeleteRole(c *gin.Context) {
+	var roleEntity entity.Role
+	if err := c.ShouldBindUri(&roleEntity.UUID); err != nil {
+		response.Abort(c, exception.ErrorTextBadRequest)
+		return
+	}
+
+	UUID := c.Param("uuid")
+	err := s.ur.ForceDeleteRole(UUID)
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, nil, success.RoleSuccessfullyForceDeleteRole).Render()
+}
+
+// BulkSaveRoles is a function uses to handle create or update many roles, an item with uuid updates the role.
+func (s *Roles) BulkSaveRoles(c *gin.Context) {
+	var bulkEntity entity.RoleBulkSave
+	if err := encoder.BindRequest(c, &bulkEntity); err != nil {
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	validateErr := bulkEntity.ValidateBulkSaveRole()
+	if len(validateErr) > 0 {
+		exceptionData := response.TranslateErrorForm(c, validateErr)
+		c.Set("data", exceptionData)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	var results response.BulkResults
+	for index := range bulkEntity.Items {
+		roleEntity := &bulkEntity.Items[index]
+		UUID := roleEntity.UUID
+		if UUID == "" {
+			validateErr := roleEntity.ValidateSaveRole()
+			if len(validateErr) > 0 {
+				results.Fail(c, index, "", exception.ErrorTextUnprocessableEntity, response.TranslateErrorForm(c, validateErr))
+				continue
+			}
+			newRole, errDesc, errException := s.ur.SaveRole(roleEntity)
+			if errException != nil {
+				results.Fail(c, index, "", errException, errDesc)
+				continue
+			}
+			results.Succeed(index, newRole.UUID, http.StatusCreated, newRole.DetailRole())
+			continue
+		}
+
+		validateErr := roleEntity.ValidateUpdateRole()
+		if len(validateErr) > 0 {
+			results.Fail(c, index, UUID, exception.ErrorTextUnprocessableEntity, response.TranslateErrorForm(c, validateErr))
+			continue
+		}
+		updatedRole, errDesc, errException := s.ur.UpdateRole(UUID, roleEntity)
+		if errException != nil {
+			results.Fail(c, index, UUID, errException, errDesc)
+			continue
+		}
+		results.Succeed(index, UUID, http.StatusOK, updatedRole.DetailRole())
+	}
+	results.Render(c, success.RoleSuccessfullyBulkSaveRole)
+}
+
+// BulkDeleteRoles is a function uses to handle delete many roles by UUID.
+func (s *Roles) BulkDeleteRoles(c *gin.Context) {
+	var bulkEntity entity.BulkDelete
+	if err := encoder.BindRequest(c, &bulkEntity); err != nil {
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	validateErr := bulkEntity.ValidateBulkDelete()
+	if len(validateErr) > 0 {
+		exceptionData := response.TranslateErrorForm(c, validateErr)
+		c.Set("data", exceptionData)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
-	response.NewSuccess(c, nil, success.RoleSuccessfullyDeleteRole).JSON()
+
+	var results response.BulkResults
+	for index, UUID := range bulkEntity.UUIDs {
+		if err := s.ur.DeleteRole(UUID); err != nil {
+			results.Fail(c, index, UUID, err, nil)
+			continue
+		}
+		results.Succeed(index, UUID, http.StatusOK, nil)
+	}
+	results.Render(c, success.RoleSuccessfullyBulkDeleteRole)
 }
 
 // GetRoles is a function uses to handle get role list.
@@ -133,23 +257,23 @@ func (s *Roles) GetRoles(c *gin.Context) {
 	if len(validateErr) > 0 {
 		exceptionData := response.TranslateErrorForm(c, validateErr)
 		c.Set("data", exceptionData)
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 
 	roles, meta, err := s.ur.GetRoles(parameters)
 	if err != nil {
-		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		response.Abort(c, err)
 		return
 	}
-	response.NewSuccess(c, roles.DetailRoles(), success.RoleSuccessfullyGetRoleList).WithMeta(meta).JSON()
+	response.NewSuccess(c, roles.DetailRoles(), success.RoleSuccessfullyGetRoleList).WithMeta(meta).Render()
 }
 
 // GetRole is a function uses to handle get role detail by UUID.
 func (s *Roles) GetRole(c *gin.Context) {
 	var roleEntity entity.Role
 	if err := c.ShouldBindUri(&roleEntity.UUID); err != nil {
-		_ = c.AbortWithError(http.StatusBadRequest, exception.ErrorTextBadRequest)
+		response.Abort(c, exception.ErrorTextBadRequest)
 		return
 	}
 
@@ -157,11 +281,14 @@ func (s *Roles) GetRole(c *gin.Context) {
 	role, err := s.ur.GetRoleWithPermissions(UUID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			_ = c.AbortWithError(http.StatusNotFound, exception.ErrorTextUserNotFound)
+			response.Abort(c, exception.ErrorTextUserNotFound)
 			return
 		}
-		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		response.Abort(c, err)
+		return
+	}
+	if response.NotModified(c, role.Version) {
 		return
 	}
-	response.NewSuccess(c, role.DetailRole(), success.RoleSuccessfullyGetRoleDetail).JSON()
+	response.NewSuccess(c, role.DetailRole(), success.RoleSuccessfullyGetRoleDetail).Render()
 }
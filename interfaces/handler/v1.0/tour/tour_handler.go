@@ -9,6 +9,7 @@ import (
 	"go-rest-skeleton/infrastructure/authorization"
 	"go-rest-skeleton/infrastructure/message/exception"
 	"go-rest-skeleton/infrastructure/message/success"
+	"go-rest-skeleton/pkg/encoder"
 	"go-rest-skeleton/pkg/response"
 	"net/http"
 
@@ -39,32 +40,24 @@ func NewTours(
 // SaveTour is a function uses to handle create a new tour.
 func (s *Tours) SaveTour(c *gin.Context) {
 	var tourEntity entity.Tour
-	if err := c.ShouldBindJSON(&tourEntity); err != nil {
+	if err := encoder.BindRequest(c, &tourEntity); err != nil {
 		fmt.Println(err)
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 	validateErr := tourEntity.ValidateSaveTour()
 	if len(validateErr) > 0 {
 		exceptionData := response.TranslateErrorForm(c, validateErr)
 		c.Set("data", exceptionData)
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 
-	_, errDesc, errException := s.ur.GetTourBySlug(&tourEntity)
-	if errException == nil {
-		exceptionData := response.TranslateErrorForm(c, []response.ErrorForm{
-			{
-				Field: "slug",
-				Msg:   exception.ErrorTextTourSlugAlreadyExists.Error(),
-				Data: map[string]interface{}{
-					"Slug": &tourEntity.Slug,
-				},
-			},
-		})
+	uniqueErr := s.validateUniqueTour(&tourEntity, "")
+	if len(uniqueErr) > 0 {
+		exceptionData := response.TranslateErrorForm(c, uniqueErr)
 		c.Set("data", exceptionData)
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 
@@ -72,22 +65,222 @@ func (s *Tours) SaveTour(c *gin.Context) {
 	if errException != nil {
 		c.Set("data", errDesc)
 		if errors.Is(errException, exception.ErrorTextUnprocessableEntity) {
-			_ = c.AbortWithError(http.StatusUnprocessableEntity, errException)
+			response.Abort(c, errException)
 			return
 		}
-		_ = c.AbortWithError(http.StatusInternalServerError, exception.ErrorTextInternalServerError)
+		response.Abort(c, exception.ErrorTextInternalServerError)
 		return
 	}
 	c.Status(http.StatusCreated)
-	response.NewSuccess(c, newTour.DetailTour(), success.TourSuccessfullyCreateTour).JSON()
+	response.NewSuccess(c, newTour.DetailTour(), success.TourSuccessfullyCreateTour).Render()
 }
 
+// UpdateTour is a function uses to handle update tour by UUID.
 func (s *Tours) UpdateTour(c *gin.Context) {
+	var tourEntity entity.Tour
+	if err := c.ShouldBindUri(&tourEntity.UUID); err != nil {
+		response.Abort(c, exception.ErrorTextBadRequest)
+		return
+	}
 
+	if err := encoder.BindRequest(c, &tourEntity); err != nil {
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	validateErr := tourEntity.ValidateUpdateTour()
+	if len(validateErr) > 0 {
+		exceptionData := response.TranslateErrorForm(c, validateErr)
+		c.Set("data", exceptionData)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+	version, err := response.IfMatch(c)
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	tourEntity.Version = version
+
+	UUID := c.Param("uuid")
+	uniqueErr := s.validateUniqueTour(&tourEntity, UUID)
+	if len(uniqueErr) > 0 {
+		exceptionData := response.TranslateErrorForm(c, uniqueErr)
+		c.Set("data", exceptionData)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	updatedTour, errDesc, errException := s.ur.UpdateTour(UUID, &tourEntity)
+	if errException != nil {
+		c.Set("data", errDesc)
+		if errors.Is(errException, exception.ErrorTextTourNotFound) {
+			response.Abort(c, errException)
+			return
+		}
+		if errors.Is(errException, exception.ErrorTextPreconditionFailed) {
+			response.Abort(c, errException)
+			return
+		}
+		response.Abort(c, exception.ErrorTextInternalServerError)
+		return
+	}
+	response.SetETag(c, updatedTour.Version)
+	c.Status(http.StatusOK)
+	response.NewSuccess(c, updatedTour.DetailTour(), success.TourSuccessfullyUpdateTour).Render()
 }
 
+// DeleteTour is a function uses to handle delete tour by UUID.
 func (s *Tours) DeleteTour(c *gin.Context) {
+	var tourEntity entity.Tour
+	if err := c.ShouldBindUri(&tourEntity.UUID); err != nil {
+		response.Abort(c, exception.ErrorTextBadRequest)
+		return
+	}
 
+	UUID := c.Param("uuid")
+	err := s.ur.DeleteTour(UUID)
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, nil, success.TourSuccessfullyDeleteTour).Render()
+}
+
+// RestoreTour is a function uses to handle restore deleted tour by UUID.
+func (s *Tours) RestoreTour(c *gin.Context) {
+	var tourEntity entity.Tour
+	if err := c.ShouldBindUri(&tourEntity.UUID); err != nil {
+		response.Abort(c, exception.ErrorTextBadRequest)
+		return
+	}
+
+	UUID := c.Param("uuid")
+	restoredTour, err := s.ur.RestoreTour(UUID)
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.SetETag(c, restoredTour.Version)
+	response.NewSuccess(c, restoredTour.DetailTour(), success.TourSuccessfullyRestoreTour).Render()
+}
+
+// ForceDeleteTour is a function uses to handle permanently delete tour by UUID.
+func (s *Tours) ForceDeleteTour(c *gin.Context) {
+	var tourEntity entity.Tour
+	if err := c.ShouldBindUri(&tourEntity.UUID); err != nil {
+		response.Abort(c, exception.ErrorTextBadRequest)
+		return
+	}
+
+	UUID := c.Param("uuid")
+	err := s.ur.ForceDeleteTour(UUID)
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, nil, success.TourSuccessfullyForceDeleteTour).Render()
+}
+
+// BulkSaveTours is a function uses to handle create or update many tours, an item with uuid updates the tour.
+func (s *Tours) BulkSaveTours(c *gin.Context) {
+	var bulkEntity entity.TourBulkSave
+	if err := encoder.BindRequest(c, &bulkEntity); err != nil {
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	validateErr := bulkEntity.ValidateBulkSaveTour()
+	if len(validateErr) > 0 {
+		exceptionData := response.TranslateErrorForm(c, validateErr)
+		c.Set("data", exceptionData)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	var results response.BulkResults
+	for index := range bulkEntity.Items {
+		tourEntity := &bulkEntity.Items[index]
+		UUID := tourEntity.UUID
+		if UUID == "" {
+			validateErr := tourEntity.ValidateSaveTour()
+			if len(validateErr) == 0 {
+				validateErr = s.validateUniqueTour(tourEntity, "")
+			}
+			if len(validateErr) > 0 {
+				results.Fail(c, index, "", exception.ErrorTextUnprocessableEntity, response.TranslateErrorForm(c, validateErr))
+				continue
+			}
+			newTour, errDesc, errException := s.ur.SaveTour(tourEntity)
+			if errException != nil {
+				results.Fail(c, index, "", errException, errDesc)
+				continue
+			}
+			results.Succeed(index, newTour.UUID, http.StatusCreated, newTour.DetailTour())
+			continue
+		}
+
+		validateErr := tourEntity.ValidateUpdateTour()
+		if len(validateErr) == 0 {
+			validateErr = s.validateUniqueTour(tourEntity, UUID)
+		}
+		if len(validateErr) > 0 {
+			results.Fail(c, index, UUID, exception.ErrorTextUnprocessableEntity, response.TranslateErrorForm(c, validateErr))
+			continue
+		}
+		updatedTour, errDesc, errException := s.ur.UpdateTour(UUID, tourEntity)
+		if errException != nil {
+			results.Fail(c, index, UUID, errException, errDesc)
+			continue
+		}
+		results.Succeed(index, UUID, http.StatusOK, updatedTour.DetailTour())
+	}
+	results.Render(c, success.TourSuccessfullyBulkSaveTour)
+}
+
+// BulkDeleteTours is a function uses to handle delete many tours by UUID.
+func (s *Tours) BulkDeleteTours(c *gin.Context) {
+	var bulkEntity entity.BulkDelete
+	if err := encoder.BindRequest(c, &bulkEntity); err != nil {
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	validateErr := bulkEntity.ValidateBulkDelete()
+	if len(validateErr) > 0 {
+		exceptionData := response.TranslateErrorForm(c, validateErr)
+		c.Set("data", exceptionData)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	var results response.BulkResults
+	for index, UUID := range bulkEntity.UUIDs {
+		if err := s.ur.DeleteTour(UUID); err != nil {
+			results.Fail(c, index, UUID, err, nil)
+			continue
+		}
+		results.Succeed(index, UUID, http.StatusOK, nil)
+	}
+	results.Render(c, success.TourSuccessfullyBulkDeleteTour)
+}
+
+// validateUniqueTour will return error form of slug already taken by another tour than the given UUID.
+func (s *Tours) validateUniqueTour(tourEntity *entity.Tour, UUID string) []response.ErrorForm {
+	tour, _, errException := s.ur.GetTourBySlug(tourEntity)
+	if errException != nil || tour.UUID == UUID {
+		return nil
+	}
+
+	return []response.ErrorForm{
+		{
+			Field: "slug",
+			Msg:   exception.ErrorTextTourSlugAlreadyExists.Error(),
+			Data: map[string]interface{}{
+				"Slug": &tourEntity.Slug,
+			},
+		},
+	}
 }
 
 func (s *Tours) GetTours(c *gin.Context) {
@@ -99,22 +292,22 @@ func (s *Tours) GetTours(c *gin.Context) {
 	if len(validateErr) > 0 {
 		exceptionData := response.TranslateErrorForm(c, validateErr)
 		c.Set("data", exceptionData)
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 
 	tours, meta, err := s.ur.GetTours(parameters)
 	if err != nil {
-		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		response.Abort(c, err)
 		return
 	}
-	response.NewSuccess(c, tours.DetailTours(), success.RoleSuccessfullyGetRoleList).WithMeta(meta).JSON()
+	response.NewSuccess(c, tours.DetailTours(), success.TourSuccessfullyGetTourList).WithMeta(meta).Render()
 }
 
 func (s *Tours) GetTour(c *gin.Context) {
-	var tourEntity entity.Role
+	var tourEntity entity.Tour
 	if err := c.ShouldBindUri(&tourEntity.UUID); err != nil {
-		_ = c.AbortWithError(http.StatusBadRequest, exception.ErrorTextBadRequest)
+		response.Abort(c, exception.ErrorTextBadRequest)
 		return
 	}
 
@@ -122,11 +315,14 @@ func (s *Tours) GetTour(c *gin.Context) {
 	tour, err := s.ur.GetTour(UUID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			_ = c.AbortWithError(http.StatusNotFound, exception.ErrorTextUserNotFound)
+			response.Abort(c, exception.ErrorTextTourNotFound)
 			return
 		}
-		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		response.Abort(c, err)
+		return
+	}
+	if response.NotModified(c, tour.Version) {
 		return
 	}
-	response.NewSuccess(c, tour.DetailTour(), success.RoleSuccessfullyGetRoleDetail).JSON()
+	response.NewSuccess(c, tour.DetailTour(), success.TourSuccessfullyGetTourDetail).Render()
 }
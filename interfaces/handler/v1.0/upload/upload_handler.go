@@ -0,0 +1,289 @@
+package upload
+
+import (
+	"encoding/base64"
+	"errors"
+	"go-rest-skeleton/application"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/infrastructure/message/success"
+	"go-rest-skeleton/infrastructure/storage"
+	"go-rest-skeleton/pkg/encoder"
+	"go-rest-skeleton/pkg/response"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// tusVersion is the version of tus resumable upload protocol supported by the handler.
+	tusVersion = "1.0.0"
+
+	// tusContentType is the content type of PATCH request of tus resumable upload protocol.
+	tusContentType = "application/offset+octet-stream"
+)
+
+// Uploads is a struct defines the dependencies that will be used.
+type Uploads struct {
+	ua application.StorageUploadAppInterface
+	ss application.StorageAppInterface
+	qu application.QueueAppInterface
+}
+
+// NewUploads is constructor will initialize upload handler.
+func NewUploads(
+	ua application.StorageUploadAppInterface,
+	ss application.StorageAppInterface,
+	qu application.QueueAppInterface) *Uploads {
+	return &Uploads{
+		ua: ua,
+		ss: ss,
+		qu: qu,
+	}
+}
+
+// CreateDirectUpload is a function uses to handle request of presigned URL to upload a file directly to the bucket.
+// Use method=POST to get form fields of browser based upload, otherwise the file is uploaded by PUT.
+func (u *Uploads) CreateDirectUpload(c *gin.Context) {
+	UUID, exists := c.Get("UUID")
+	if !exists {
+		response.Abort(c, exception.ErrorTextUnauthorized)
+		return
+	}
+
+	var uploadRequest storage.DirectUploadRequest
+	if err := encoder.BindRequest(c, &uploadRequest); err != nil {
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+	uploadRequest.UserUUID = UUID.(string)
+	validateErr := uploadRequest.ValidateDirectUploadRequest()
+	if len(validateErr) > 0 {
+		exceptionData := response.TranslateErrorForm(c, validateErr)
+		c.Set("data", exceptionData)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	directUpload, errException, errArgs := u.ua.CreateDirectUpload(&uploadRequest)
+	if errException != nil {
+		abortWithUploadError(c, errException, errArgs)
+		return
+	}
+	c.Status(http.StatusCreated)
+	response.NewSuccess(c, directUpload, success.StorageSuccessfullyCreateDirectUpload).Render()
+}
+
+// ConfirmDirectUpload is a function uses to handle confirmation of a file uploaded directly to the bucket.
+func (u *Uploads) ConfirmDirectUpload(c *gin.Context) {
+	UUID, exists := c.Get("UUID")
+	if !exists {
+		response.Abort(c, exception.ErrorTextUnauthorized)
+		return
+	}
+
+	fileUUID, errException, errArgs := u.ua.ConfirmDirectUpload(c.Param("uuid"), UUID.(string))
+	if errException != nil {
+		abortWithUploadError(c, errException, errArgs)
+		return
+	}
+	u.dispatchProcessFile(fileUUID)
+
+	// URL of a file waiting to be scanned is nil, GET /files/:uuid returns it once the file is clean.
+	fileURL, err := u.ss.GetFile(fileUUID, storage.VariantOriginal)
+	if err != nil && !errors.Is(err, exception.ErrorTextStorageFileScanPending) {
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, map[string]interface{}{"uuid": fileUUID, "url": fileURL},
+		success.StorageSuccessfullyConfirmDirectUpload).Render()
+}
+
+// CreateResumableUpload is a function uses to handle creation of tus resumable upload.
+// Category and file name are taken from category and filename keys of Upload-Metadata header.
+func (u *Uploads) CreateResumableUpload(c *gin.Context) {
+	if !checkTusResumable(c) {
+		return
+	}
+
+	UUID, exists := c.Get("UUID")
+	if !exists {
+		response.Abort(c, exception.ErrorTextUnauthorized)
+		return
+	}
+
+	length, err := strconv.ParseInt(c.GetHeader("Upload-Length"), 10, 64)
+	if err != nil {
+		response.Abort(c, exception.ErrorTextBadRequest)
+		return
+	}
+	metadata := parseUploadMetadata(c.GetHeader("Upload-Metadata"))
+	uploadRequest := storage.ResumableUploadRequest{
+		Category: metadata["category"],
+		FileName: metadata["filename"],
+		Length:   length,
+		UserUUID: UUID.(string),
+	}
+	validateErr := uploadRequest.ValidateResumableUploadRequest()
+	if len(validateErr) > 0 {
+		exceptionData := response.TranslateErrorForm(c, validateErr)
+		c.Set("data", exceptionData)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	upload, errException, errArgs := u.ua.CreateResumableUpload(&uploadRequest)
+	if errException != nil {
+		if errors.Is(errException, exception.ErrorTextStorageUploadInvalidSize) {
+			c.Set("args", errArgs)
+			response.Abort(c, exception.WithStatus(errException, http.StatusRequestEntityTooLarge))
+			return
+		}
+		abortWithUploadError(c, errException, errArgs)
+		return
+	}
+
+	c.Header("Location", strings.TrimRight(c.Request.URL.Path, "/")+"/"+upload.UUID)
+	c.Header("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	c.Status(http.StatusCreated)
+	response.NewSuccess(c, upload.DetailStorageUpload(), success.StorageSuccessfullyCreateResumableUpload).Render()
+}
+
+// GetResumableUpload is a function uses to handle offset request of tus resumable upload.
+func (u *Uploads) GetResumableUpload(c *gin.Context) {
+	if !checkTusResumable(c) {
+		return
+	}
+
+	UUID, exists := c.Get("UUID")
+	if !exists {
+		response.Abort(c, exception.ErrorTextUnauthorized)
+		return
+	}
+
+	upload, err := u.ua.GetResumableUpload(c.Param("uuid"), UUID.(string))
+	if err != nil {
+		abortWithUploadError(c, err, nil)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	c.Header("Upload-Length", strconv.FormatInt(upload.Length, 10))
+	c.Header("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	c.Status(http.StatusOK)
+}
+
+// WriteResumableUpload is a function uses to handle PATCH request of tus resumable upload.
+// Once every byte has been received, the file is available by UUID of the upload.
+func (u *Uploads) WriteResumableUpload(c *gin.Context) {
+	if !checkTusResumable(c) {
+		return
+	}
+
+	UUID, exists := c.Get("UUID")
+	if !exists {
+		response.Abort(c, exception.ErrorTextUnauthorized)
+		return
+	}
+
+	if c.ContentType() != tusContentType {
+		response.Abort(c, exception.WithStatus(exception.ErrorTextBadRequest, http.StatusUnsupportedMediaType))
+		return
+	}
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil {
+		response.Abort(c, exception.ErrorTextBadRequest)
+		return
+	}
+
+	upload, errException, errArgs := u.ua.WriteResumableUpload(c.Param("uuid"), UUID.(string), offset, c.Request.Body)
+	if errException != nil {
+		abortWithUploadError(c, errException, errArgs)
+		return
+	}
+	if upload.IsComplete() {
+		u.dispatchProcessFile(upload.UUID)
+	}
+
+	c.Header("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	c.Header("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteResumableUpload is a function uses to handle termination of tus resumable upload.
+func (u *Uploads) DeleteResumableUpload(c *gin.Context) {
+	if !checkTusResumable(c) {
+		return
+	}
+
+	UUID, exists := c.Get("UUID")
+	if !exists {
+		response.Abort(c, exception.ErrorTextUnauthorized)
+		return
+	}
+
+	if err := u.ua.DeleteResumableUpload(c.Param("uuid"), UUID.(string)); err != nil {
+		response.Abort(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// dispatchProcessFile dispatches post-processing of the uploaded file, the file is already stored when it fails.
+// A file left waiting to be scanned is dispatched again by storage:gc.
+func (u *Uploads) dispatchProcessFile(UUID string) {
+	processJob, err := storage.NewProcessFileJob(UUID)
+	if err == nil {
+		err = u.qu.Dispatch(processJob)
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("file", UUID).Msg("failed to dispatch file processing job")
+	}
+}
+
+// checkTusResumable sets Tus-Resumable header and aborts request of unsupported protocol version.
+func checkTusResumable(c *gin.Context) bool {
+	c.Header("Tus-Resumable", tusVersion)
+	if c.GetHeader("Tus-Resumable") != tusVersion {
+		c.Header("Tus-Version", tusVersion)
+		response.Abort(c, exception.WithStatus(exception.ErrorTextBadRequest, http.StatusPreconditionFailed))
+		return false
+	}
+
+	return true
+}
+
+// parseUploadMetadata decodes Upload-Metadata header, comma separated pairs of key and base64 encoded value.
+func parseUploadMetadata(header string) map[string]string {
+	metadata := make(map[string]string)
+	for _, pair := range strings.Split(header, ",") {
+		fields := strings.Fields(pair)
+		if len(fields) == 0 {
+			continue
+		}
+
+		var value []byte
+		if len(fields) > 1 {
+			decoded, err := base64.StdEncoding.DecodeString(fields[1])
+			if err != nil {
+				continue
+			}
+			value = decoded
+		}
+		metadata[fields[0]] = string(value)
+	}
+
+	return metadata
+}
+
+// abortWithUploadError sets args of the upload errors translated with them, then aborts with the error.
+func abortWithUploadError(c *gin.Context, errException error, errArgs interface{}) {
+	if errors.Is(errException, exception.ErrorTextStorageUploadInvalidSize) ||
+		errors.Is(errException, exception.ErrorTextStorageUploadInvalidFileType) {
+		c.Set("args", errArgs)
+	}
+	response.Abort(c, errException)
+}
@@ -0,0 +1,233 @@
+package upload
+
+import (
+	"bytes"
+	"encoding/base64"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/infrastructure/queue"
+	"go-rest-skeleton/infrastructure/storage"
+	"go-rest-skeleton/pkg/encoder"
+	"go-rest-skeleton/tests/mock"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var userUUID = uuid.New().String()
+
+func uploadRouterSetup(uploadApp *mock.StorageUploadAppInterface) *gin.Engine {
+	var storageApp mock.StorageAppInterface
+	var queueApp mock.QueueAppInterface
+	queueApp.DispatchFn = func(job *queue.Job) error {
+		return nil
+	}
+	uploadHandler := NewUploads(uploadApp, &storageApp, &queueApp)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("UUID", userUUID)
+	})
+	v1 := r.Group("/api/v1/external")
+	v1.POST("/uploads/direct", uploadHandler.CreateDirectUpload)
+	v1.POST("/uploads/direct/:uuid/confirm", uploadHandler.ConfirmDirectUpload)
+	v1.POST("/uploads/resumable", uploadHandler.CreateResumableUpload)
+	v1.HEAD("/uploads/resumable/:uuid", uploadHandler.GetResumableUpload)
+	v1.PATCH("/uploads/resumable/:uuid", uploadHandler.WriteResumableUpload)
+	v1.DELETE("/uploads/resumable/:uuid", uploadHandler.DeleteResumableUpload)
+	return r
+}
+
+func TestCreateDirectUpload_XML(t *testing.T) {
+	var uploadApp mock.StorageUploadAppInterface
+	UUID := uuid.New().String()
+	uploadApp.CreateDirectUploadFn = func(upload *storage.DirectUploadRequest) (*storage.DirectUpload, error, interface{}) {
+		assert.Equal(t, "document", upload.Category)
+		assert.Equal(t, "report.pdf", upload.FileName)
+		assert.Equal(t, int64(1024), upload.Size)
+		assert.Equal(t, "application/pdf", upload.ContentType)
+		assert.Equal(t, userUUID, upload.UserUUID)
+		return &storage.DirectUpload{UUID: UUID, Method: http.MethodPut, URL: "http://localhost/" + UUID}, nil, nil
+	}
+
+	body := `<request><category>document</category><file_name>report.pdf</file_name>` +
+		`<size>1024</size><content_type>application/pdf</content_type></request>`
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/external/uploads/direct", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/xml")
+	req.Header.Set("Accept", "application/xml")
+	w := httptest.NewRecorder()
+	uploadRouterSetup(&uploadApp).ServeHTTP(w, req)
+
+	decoded := encoder.XMLResponseDecoder(w.Body)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "application/xml; charset=utf-8", w.Header().Get("Content-Type"))
+	if data, ok := decoded["data"].(map[string]interface{}); assert.True(t, ok) {
+		assert.Equal(t, UUID, data["uuid"])
+	}
+}
+
+func TestCreateResumableUpload_Success(t *testing.T) {
+	var uploadApp mock.StorageUploadAppInterface
+	UUID := uuid.New().String()
+	uploadApp.CreateResumableUploadFn = func(upload *storage.ResumableUploadRequest) (*entity.StorageUpload, error, interface{}) {
+		assert.Equal(t, "document", upload.Category)
+		assert.Equal(t, "report.pdf", upload.FileName)
+		assert.Equal(t, int64(1024), upload.Length)
+		assert.Equal(t, userUUID, upload.UserUUID)
+		return &entity.StorageUpload{UUID: UUID, Length: 1024, ExpiresAt: time.Now().Add(time.Hour)}, nil, nil
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/external/uploads/resumable", nil)
+	req.Header.Set("Tus-Resumable", tusVersion)
+	req.Header.Set("Upload-Length", "1024")
+	req.Header.Set("Upload-Metadata", "filename "+base64.StdEncoding.EncodeToString([]byte("report.pdf"))+
+		",category "+base64.StdEncoding.EncodeToString([]byte("document")))
+	w := httptest.NewRecorder()
+	uploadRouterSetup(&uploadApp).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/api/v1/external/uploads/resumable/"+UUID, w.Header().Get("Location"))
+	assert.Equal(t, tusVersion, w.Header().Get("Tus-Resumable"))
+}
+
+func TestCreateResumableUpload_UnsupportedVersion(t *testing.T) {
+	var uploadApp mock.StorageUploadAppInterface
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/external/uploads/resumable", nil)
+	req.Header.Set("Upload-Length", "1024")
+	w := httptest.NewRecorder()
+	uploadRouterSetup(&uploadApp).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.Equal(t, tusVersion, w.Header().Get("Tus-Version"))
+}
+
+func TestGetResumableUpload_Success(t *testing.T) {
+	var uploadApp mock.StorageUploadAppInterface
+	uploadApp.GetResumableUploadFn = func(UUID string, ownerUUID string) (*entity.StorageUpload, error) {
+		assert.Equal(t, userUUID, ownerUUID)
+		return &entity.StorageUpload{UUID: UUID, Length: 1024, Offset: 512}, nil
+	}
+
+	req, _ := http.NewRequest(http.MethodHead, "/api/v1/external/uploads/resumable/"+uuid.New().String(), nil)
+	req.Header.Set("Tus-Resumable", tusVersion)
+	w := httptest.NewRecorder()
+	uploadRouterSetup(&uploadApp).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "512", w.Header().Get("Upload-Offset"))
+	assert.Equal(t, "1024", w.Header().Get("Upload-Length"))
+}
+
+func TestWriteResumableUpload_Success(t *testing.T) {
+	var uploadApp mock.StorageUploadAppInterface
+	uploadApp.WriteResumableUploadFn = func(
+		UUID string,
+		ownerUUID string,
+		offset int64,
+		reader io.Reader) (*entity.StorageUpload, error, interface{}) {
+		assert.Equal(t, userUUID, ownerUUID)
+		body, _ := ioutil.ReadAll(reader)
+		return &entity.StorageUpload{UUID: UUID, Length: 1024, Offset: offset + int64(len(body))}, nil, nil
+	}
+
+	req, _ := http.NewRequest(http.MethodPatch, "/api/v1/external/uploads/resumable/"+uuid.New().String(),
+		bytes.NewBufferString("content"))
+	req.Header.Set("Tus-Resumable", tusVersion)
+	req.Header.Set("Upload-Offset", "512")
+	req.Header.Set("Content-Type", tusContentType)
+	w := httptest.NewRecorder()
+	uploadRouterSetup(&uploadApp).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "519", w.Header().Get("Upload-Offset"))
+}
+
+func TestWriteResumableUpload_Failed(t *testing.T) {
+	samples := []struct {
+		contentType string
+		err         error
+		statusCode  int
+	}{
+		{contentType: "application/json", statusCode: http.StatusUnsupportedMediaType},
+		{contentType: tusContentType, err: exception.ErrorTextStorageUploadOffsetMismatch, statusCode: http.StatusConflict},
+		{contentType: tusContentType, err: exception.ErrorTextStorageUploadNotFound, statusCode: http.StatusNotFound},
+	}
+
+	for _, v := range samples {
+		var uploadApp mock.StorageUploadAppInterface
+		sample := v
+		uploadApp.WriteResumableUploadFn = func(
+			UUID string,
+			ownerUUID string,
+			offset int64,
+			reader io.Reader) (*entity.StorageUpload, error, interface{}) {
+			return nil, sample.err, nil
+		}
+
+		req, _ := http.NewRequest(http.MethodPatch, "/api/v1/external/uploads/resumable/"+uuid.New().String(),
+			bytes.NewBufferString("content"))
+		req.Header.Set("Tus-Resumable", tusVersion)
+		req.Header.Set("Upload-Offset", "0")
+		req.Header.Set("Content-Type", sample.contentType)
+		w := httptest.NewRecorder()
+		uploadRouterSetup(&uploadApp).ServeHTTP(w, req)
+
+		assert.Equal(t, sample.statusCode, w.Code)
+	}
+}
+
+func TestConfirmDirectUpload_ForeignUpload(t *testing.T) {
+	var uploadApp mock.StorageUploadAppInterface
+	ownerUUID := uuid.New().String()
+	uploadApp.ConfirmDirectUploadFn = func(UUID string, callerUUID string) (string, error, interface{}) {
+		assert.Equal(t, userUUID, callerUUID)
+		if callerUUID != ownerUUID {
+			return "", exception.ErrorTextStorageFileNotFound, nil
+		}
+		return UUID, nil, nil
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/external/uploads/direct/"+uuid.New().String()+"/confirm", nil)
+	w := httptest.NewRecorder()
+	uploadRouterSetup(&uploadApp).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDeleteResumableUpload_ForeignUpload(t *testing.T) {
+	var uploadApp mock.StorageUploadAppInterface
+	ownerUUID := uuid.New().String()
+	uploadApp.DeleteResumableUploadFn = func(UUID string, callerUUID string) error {
+		assert.Equal(t, userUUID, callerUUID)
+		if callerUUID != ownerUUID {
+			return exception.ErrorTextStorageUploadNotFound
+		}
+		return nil
+	}
+
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/external/uploads/resumable/"+uuid.New().String(), nil)
+	req.Header.Set("Tus-Resumable", tusVersion)
+	w := httptest.NewRecorder()
+	uploadRouterSetup(&uploadApp).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestParseUploadMetadata(t *testing.T) {
+	metadata := parseUploadMetadata("filename cmVwb3J0LnBkZg==, is_confidential,invalid !!!")
+
+	assert.Equal(t, "report.pdf", metadata["filename"])
+	_, exists := metadata["is_confidential"]
+	assert.True(t, exists)
+	_, exists = metadata["invalid"]
+	assert.False(t, exists)
+}
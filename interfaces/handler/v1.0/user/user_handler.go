@@ -8,9 +8,12 @@ import (
 	"go-rest-skeleton/infrastructure/message/exception"
 	"go-rest-skeleton/infrastructure/message/success"
 	"go-rest-skeleton/infrastructure/storage"
+	"go-rest-skeleton/pkg/encoder"
 	"go-rest-skeleton/pkg/response"
 	"net/http"
 
+	"github.com/rs/zerolog/log"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -22,13 +25,18 @@ const (
 type Users struct {
 	us application.UserAppInterface
 	ss application.StorageAppInterface
+	qu application.QueueAppInterface
 }
 
 // NewUsers is constructor will initialize user handler.
-func NewUsers(us application.UserAppInterface, ss application.StorageAppInterface) *Users {
+func NewUsers(
+	us application.UserAppInterface,
+	ss application.StorageAppInterface,
+	qu application.QueueAppInterface) *Users {
 	return &Users{
 		us: us,
 		ss: ss,
+		qu: qu,
 	}
 }
 
@@ -55,8 +63,8 @@ func NewUsers(us application.UserAppInterface, ss application.StorageAppInterfac
 // SaveUser is a function uses to handle create a new user.
 func (s *Users) SaveUser(c *gin.Context) {
 	var userEntity entity.User
-	if err := c.ShouldBindJSON(&userEntity); err != nil {
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+	if err := encoder.BindRequest(c, &userEntity); err != nil {
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 
@@ -64,39 +72,15 @@ func (s *Users) SaveUser(c *gin.Context) {
 	if len(validateErr) > 0 {
 		exceptionData := response.TranslateErrorForm(c, validateErr)
 		c.Set("data", exceptionData)
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
-		return
-	}
-
-	_, errDesc, errException := s.us.GetUserByEmail(&userEntity)
-	if errException == nil {
-		exceptionData := response.TranslateErrorForm(c, []response.ErrorForm{
-			{
-				Field: "email",
-				Msg:   exception.ErrorTextUserEmailAlreadyTaken.Error(),
-				Data: map[string]interface{}{
-					"Email": &userEntity.Email,
-				},
-			},
-		})
-		c.Set("data", exceptionData)
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 
-	_, errDesc, errException = s.us.GetUserByPhone(&userEntity)
-	if errException == nil {
-		exceptionData := response.TranslateErrorForm(c, []response.ErrorForm{
-			{
-				Field: "phone",
-				Msg:   exception.ErrorTextUserPhoneAlreadyTaken.Error(),
-				Data: map[string]interface{}{
-					"Phone": &userEntity.Phone,
-				},
-			},
-		})
+	uniqueErr := s.validateUniqueUser(&userEntity, nil)
+	if len(uniqueErr) > 0 {
+		exceptionData := response.TranslateErrorForm(c, uniqueErr)
 		c.Set("data", exceptionData)
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 
@@ -104,14 +88,14 @@ func (s *Users) SaveUser(c *gin.Context) {
 	if errException != nil {
 		c.Set("data", errDesc)
 		if errors.Is(errException, exception.ErrorTextUnprocessableEntity) {
-			_ = c.AbortWithError(http.StatusUnprocessableEntity, errException)
+			response.Abort(c, errException)
 			return
 		}
-		_ = c.AbortWithError(http.StatusInternalServerError, exception.ErrorTextInternalServerError)
+		response.Abort(c, exception.ErrorTextInternalServerError)
 		return
 	}
 	c.Status(http.StatusCreated)
-	response.NewSuccess(c, newUser.DetailUser(), success.UserSuccessfullyCreateUser).JSON()
+	response.NewSuccess(c, newUser.DetailUser(), success.UserSuccessfullyCreateUser).Render()
 }
 
 // @Summary Update user
@@ -139,12 +123,12 @@ func (s *Users) SaveUser(c *gin.Context) {
 func (s *Users) UpdateUser(c *gin.Context) {
 	var userEntity entity.User
 	if err := c.ShouldBindUri(&userEntity.UUID); err != nil {
-		_ = c.AbortWithError(http.StatusBadRequest, exception.ErrorTextBadRequest)
+		response.Abort(c, exception.ErrorTextBadRequest)
 		return
 	}
 
-	if err := c.ShouldBindJSON(&userEntity); err != nil {
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+	if err := encoder.BindRequest(c, &userEntity); err != nil {
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 
@@ -152,73 +136,62 @@ func (s *Users) UpdateUser(c *gin.Context) {
 	if len(validateErr) > 0 {
 		exceptionData := response.TranslateErrorForm(c, validateErr)
 		c.Set("data", exceptionData)
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	version, err := response.IfMatch(c)
+	if err != nil {
+		response.Abort(c, err)
 		return
 	}
+	userEntity.Version = version
 
 	UUID := c.Param("uuid")
 	user, err := s.us.GetUser(UUID)
 	if err != nil {
 		if errors.Is(err, exception.ErrorTextUserNotFound) {
-			_ = c.AbortWithError(http.StatusNotFound, exception.ErrorTextUserNotFound)
+			response.Abort(c, exception.ErrorTextUserNotFound)
 			return
 		}
-		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		response.Abort(c, err)
 		return
 	}
 
-	if userEntity.Email != user.Email {
-		_, _, errException := s.us.GetUserByEmail(&userEntity)
-		if errException == nil {
-			exceptionData := response.TranslateErrorForm(c, []response.ErrorForm{
-				{
-					Field: "email",
-					Msg:   exception.ErrorTextUserEmailAlreadyTaken.Error(),
-					Data: map[string]interface{}{
-						"Email": &userEntity.Email,
-					},
-				},
-			})
-			c.Set("data", exceptionData)
-			_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
-			return
-		}
+	if version > 0 && version != user.Version {
+		response.Abort(c, exception.ErrorTextPreconditionFailed)
+		return
 	}
 
-	if userEntity.Phone != user.Phone {
-		_, _, errException := s.us.GetUserByPhone(&userEntity)
-		if errException == nil {
-			exceptionData := response.TranslateErrorForm(c, []response.ErrorForm{
-				{
-					Field: "phone",
-					Msg:   exception.ErrorTextUserPhoneAlreadyTaken.Error(),
-					Data: map[string]interface{}{
-						"Phone": &userEntity.Phone,
-					},
-				},
-			})
-			c.Set("data", exceptionData)
-			_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
-			return
-		}
+	uniqueErr := s.validateUniqueUser(&userEntity, user)
+	if len(uniqueErr) > 0 {
+		exceptionData := response.TranslateErrorForm(c, uniqueErr)
+		c.Set("data", exceptionData)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
 	}
 
 	updatedUser, errDesc, errException := s.us.UpdateUser(UUID, &userEntity)
 	if errException != nil {
 		c.Set("data", errDesc)
 		if errors.Is(errException, exception.ErrorTextUserNotFound) {
-			_ = c.AbortWithError(http.StatusNotFound, errException)
+			response.Abort(c, errException)
 			return
 		}
 		if errors.Is(errException, exception.ErrorTextUnprocessableEntity) {
-			_ = c.AbortWithError(http.StatusUnprocessableEntity, errException)
+			response.Abort(c, errException)
 			return
 		}
-		_ = c.AbortWithError(http.StatusInternalServerError, exception.ErrorTextInternalServerError)
+		if errors.Is(errException, exception.ErrorTextPreconditionFailed) {
+			response.Abort(c, errException)
+			return
+		}
+		response.Abort(c, exception.ErrorTextInternalServerError)
 		return
 	}
+	response.SetETag(c, updatedUser.Version)
 	c.Status(http.StatusOK)
-	response.NewSuccess(c, updatedUser.DetailUser(), success.UserSuccessfullyUpdateUser).JSON()
+	response.NewSuccess(c, updatedUser.DetailUser(), success.UserSuccessfullyUpdateUser).Render()
 }
 
 // @Summary Delete user
@@ -241,7 +214,7 @@ func (s *Users) UpdateUser(c *gin.Context) {
 func (s *Users) DeleteUser(c *gin.Context) {
 	var userEntity entity.User
 	if err := c.ShouldBindUri(&userEntity.UUID); err != nil {
-		_ = c.AbortWithError(http.StatusBadRequest, exception.ErrorTextBadRequest)
+		response.Abort(c, exception.ErrorTextBadRequest)
 		return
 	}
 
@@ -249,13 +222,251 @@ func (s *Users) DeleteUser(c *gin.Context) {
 	err := s.us.DeleteUser(UUID)
 	if err != nil {
 		if errors.Is(err, exception.ErrorTextUserNotFound) {
-			_ = c.AbortWithError(http.StatusNotFound, exception.ErrorTextUserNotFound)
+			response.Abort(c, exception.ErrorTextUserNotFound)
 			return
 		}
-		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, nil, success.UserSuccessfullyDeleteUser).Render()
+}
+
+// @Summary Restore user
+// @Description Restore a deleted user.
+// @Tags users
+// @Produce json
+// @Param Accept-Language header string false "Language code" Enums(en, id) default(id)
+// @Param Set-Request-Id header string false "Request id"
+// @Security BasicAuth
+// @Security JWTAuth
+// @Param uuid path string true "User UUID"
+// @Success 200 {object} response.successOutput
+// @Failure 400 {object} response.errorOutput
+// @Failure 401 {object} response.errorOutput
+// @Failure 403 {object} response.errorOutput
+// @Failure 404 {object} response.errorOutput
+// @Failure 409 {object} response.errorOutput
+// @Failure 500 {object} response.errorOutput
+// @Router /api/v1/external/users/{uuid}/restore [post]
+// RestoreUser is a function uses to handle restore deleted user by UUID.
+func (s *Users) RestoreUser(c *gin.Context) {
+	var userEntity entity.User
+	if err := c.ShouldBindUri(&userEntity.UUID); err != nil {
+		response.Abort(c, exception.ErrorTextBadRequest)
+		return
+	}
+
+	UUID := c.Param("uuid")
+	restoredUser, err := s.us.RestoreUser(UUID)
+	if err != nil {
+		response.Abort(c, err)
 		return
 	}
-	response.NewSuccess(c, nil, success.UserSuccessfullyDeleteUser).JSON()
+	response.SetETag(c, restoredUser.Version)
+	response.NewSuccess(c, restoredUser.DetailUser(), success.UserSuccessfullyRestoreUser).Render()
+}
+
+// @Summary Force delete user
+// @Description Permanently delete a user, deleted or not, along with its roles.
+// @Tags users
+// @Produce json
+// @Param Accept-Language header string false "Language code" Enums(en, id) default(id)
+// @Param Set-Request-Id header string false "Request id"
+// @Security BasicAuth
+// @Security JWTAuth
+// @Param uuid path string true "User UUID"
+// @Success 200 {object} response.successOutput
+// @Failure 400 {object} response.errorOutput
+// @Failure 401 {object} response.errorOutput
+// @Failure 403 {object} response.errorOutput
+// @Failure 404 {object} response.errorOutput
+// @Failure 500 {object} response.errorOutput
+// @Router /api/v1/external/users/{uuid}/force [delete]
+// ForceDeleteUser is a function uses to handle permanently delete user by UUID.
+func (s *Users) ForceDeleteUser(c *gin.Context) {
+	var userEntity entity.User
+	if err := c.ShouldBindUri(&userEntity.UUID); err != nil {
+		response.Abort(c, exception.ErrorTextBadRequest)
+		return
+	}
+
+	UUID := c.Param("uuid")
+	err := s.us.ForceDeleteUser(UUID)
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, nil, success.UserSuccessfullyForceDeleteUser).Render()
+}
+
+// @Summary Create or update users
+// @Description Create or update many users, an item with uuid updates the user. Each item has its own result.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Accept-Language header string false "Language code" Enums(en, id) default(id)
+// @Param Set-Request-Id header string false "Request id"
+// @Security BasicAuth
+// @Security JWTAuth
+// @Param items body entity.UserBulkSave true "Users"
+// @Success 200 {object} response.successOutput
+// @Success 207 {object} response.successOutput
+// @Failure 400 {object} response.errorOutput
+// @Failure 401 {object} response.errorOutput
+// @Failure 403 {object} response.errorOutput
+// @Failure 422 {object} response.errorOutput
+// @Router /api/v1/external/users/bulk [post]
+// BulkSaveUsers is a function uses to handle create or update many users.
+func (s *Users) BulkSaveUsers(c *gin.Context) {
+	var bulkEntity entity.UserBulkSave
+	if err := encoder.BindRequest(c, &bulkEntity); err != nil {
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	validateErr := bulkEntity.ValidateBulkSaveUser()
+	if len(validateErr) > 0 {
+		exceptionData := response.TranslateErrorForm(c, validateErr)
+		c.Set("data", exceptionData)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	var results response.BulkResults
+	for index := range bulkEntity.Items {
+		userEntity := &bulkEntity.Items[index]
+		if userEntity.UUID == "" {
+			s.bulkCreateUser(c, &results, index, userEntity)
+			continue
+		}
+		s.bulkUpdateUser(c, &results, index, userEntity)
+	}
+	results.Render(c, success.UserSuccessfullyBulkSaveUser)
+}
+
+// bulkCreateUser will create a user of bulk request and add its result.
+func (s *Users) bulkCreateUser(c *gin.Context, results *response.BulkResults, index int, userEntity *entity.User) {
+	validateErr := userEntity.ValidateSaveUser()
+	if len(validateErr) == 0 {
+		validateErr = s.validateUniqueUser(userEntity, nil)
+	}
+	if len(validateErr) > 0 {
+		results.Fail(c, index, "", exception.ErrorTextUnprocessableEntity, response.TranslateErrorForm(c, validateErr))
+		return
+	}
+
+	newUser, errDesc, errException := s.us.SaveUser(userEntity)
+	if errException != nil {
+		results.Fail(c, index, "", errException, errDesc)
+		return
+	}
+	results.Succeed(index, newUser.UUID, http.StatusCreated, newUser.DetailUser())
+}
+
+// bulkUpdateUser will update a user of bulk request and add its result.
+// The version of the item is checked the same way as If-Match header of UpdateUser.
+func (s *Users) bulkUpdateUser(c *gin.Context, results *response.BulkResults, index int, userEntity *entity.User) {
+	UUID := userEntity.UUID
+	validateErr := userEntity.ValidateUpdateUser()
+	if len(validateErr) > 0 {
+		results.Fail(c, index, UUID, exception.ErrorTextUnprocessableEntity, response.TranslateErrorForm(c, validateErr))
+		return
+	}
+
+	user, err := s.us.GetUser(UUID)
+	if err != nil {
+		results.Fail(c, index, UUID, err, nil)
+		return
+	}
+	if userEntity.Version > 0 && userEntity.Version != user.Version {
+		results.Fail(c, index, UUID, exception.ErrorTextPreconditionFailed, nil)
+		return
+	}
+	if validateErr := s.validateUniqueUser(userEntity, user); len(validateErr) > 0 {
+		results.Fail(c, index, UUID, exception.ErrorTextUnprocessableEntity, response.TranslateErrorForm(c, validateErr))
+		return
+	}
+
+	updatedUser, errDesc, errException := s.us.UpdateUser(UUID, userEntity)
+	if errException != nil {
+		results.Fail(c, index, UUID, errException, errDesc)
+		return
+	}
+	results.Succeed(index, UUID, http.StatusOK, updatedUser.DetailUser())
+}
+
+// validateUniqueUser will return error form of email and phone of the user already taken by another user.
+// The existing user is given on update, then only the changed email and phone are checked.
+func (s *Users) validateUniqueUser(userEntity *entity.User, user *entity.User) []response.ErrorForm {
+	var errForm []response.ErrorForm
+	if user == nil || userEntity.Email != user.Email {
+		if _, _, errException := s.us.GetUserByEmail(userEntity); errException == nil {
+			errForm = append(errForm, response.ErrorForm{
+				Field: "email",
+				Msg:   exception.ErrorTextUserEmailAlreadyTaken.Error(),
+				Data: map[string]interface{}{
+					"Email": &userEntity.Email,
+				},
+			})
+		}
+	}
+	if user == nil || userEntity.Phone != user.Phone {
+		if _, _, errException := s.us.GetUserByPhone(userEntity); errException == nil {
+			errForm = append(errForm, response.ErrorForm{
+				Field: "phone",
+				Msg:   exception.ErrorTextUserPhoneAlreadyTaken.Error(),
+				Data: map[string]interface{}{
+					"Phone": &userEntity.Phone,
+				},
+			})
+		}
+	}
+
+	return errForm
+}
+
+// @Summary Delete users
+// @Description Delete many existing users. Each UUID has its own result.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param Accept-Language header string false "Language code" Enums(en, id) default(id)
+// @Param Set-Request-Id header string false "Request id"
+// @Security BasicAuth
+// @Security JWTAuth
+// @Param uuids body entity.BulkDelete true "User UUIDs"
+// @Success 200 {object} response.successOutput
+// @Success 207 {object} response.successOutput
+// @Failure 400 {object} response.errorOutput
+// @Failure 401 {object} response.errorOutput
+// @Failure 403 {object} response.errorOutput
+// @Failure 422 {object} response.errorOutput
+// @Router /api/v1/external/users [delete]
+// BulkDeleteUsers is a function uses to handle delete many users by UUID.
+func (s *Users) BulkDeleteUsers(c *gin.Context) {
+	var bulkEntity entity.BulkDelete
+	if err := encoder.BindRequest(c, &bulkEntity); err != nil {
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	validateErr := bulkEntity.ValidateBulkDelete()
+	if len(validateErr) > 0 {
+		exceptionData := response.TranslateErrorForm(c, validateErr)
+		c.Set("data", exceptionData)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	var results response.BulkResults
+	for index, UUID := range bulkEntity.UUIDs {
+		if err := s.us.DeleteUser(UUID); err != nil {
+			results.Fail(c, index, UUID, err, nil)
+			continue
+		}
+		results.Succeed(index, UUID, http.StatusOK, nil)
+	}
+	results.Render(c, success.UserSuccessfullyBulkDeleteUser)
 }
 
 // @Summary Get users
@@ -283,16 +494,16 @@ func (s *Users) GetUsers(c *gin.Context) {
 	if len(validateErr) > 0 {
 		exceptionData := response.TranslateErrorForm(c, validateErr)
 		c.Set("data", exceptionData)
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, exception.ErrorTextUnprocessableEntity)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
 		return
 	}
 
 	users, meta, err := s.us.GetUsers(parameters)
 	if err != nil {
-		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		response.Abort(c, err)
 		return
 	}
-	response.NewSuccess(c, users.DetailUsers(), success.UserSuccessfullyGetUserList).WithMeta(meta).JSON()
+	response.NewSuccess(c, users.DetailUsers(), success.UserSuccessfullyGetUserList).WithMeta(meta).Render()
 }
 
 // @Summary Get user
@@ -315,7 +526,7 @@ func (s *Users) GetUsers(c *gin.Context) {
 func (s *Users) GetUser(c *gin.Context) {
 	var userEntity entity.User
 	if err := c.ShouldBindUri(&userEntity.UUID); err != nil {
-		_ = c.AbortWithError(http.StatusBadRequest, exception.ErrorTextBadRequest)
+		response.Abort(c, exception.ErrorTextBadRequest)
 		return
 	}
 
@@ -323,19 +534,22 @@ func (s *Users) GetUser(c *gin.Context) {
 	user, err := s.us.GetUser(UUID)
 	if err != nil {
 		if errors.Is(err, exception.ErrorTextUserNotFound) {
-			_ = c.AbortWithError(http.StatusNotFound, exception.ErrorTextUserNotFound)
+			response.Abort(c, exception.ErrorTextUserNotFound)
 			return
 		}
-		_ = c.AbortWithError(http.StatusInternalServerError, err)
+		response.Abort(c, err)
+		return
+	}
+	if response.NotModified(c, user.Version) {
 		return
 	}
 
-	avatarURL, errAvatar := s.ss.GetFile(user.AvatarUUID)
+	avatarURLs, errAvatar := s.ss.GetFileVariants(user.AvatarUUID)
 	if errAvatar != nil {
-		_ = c.AbortWithError(http.StatusInternalServerError, errAvatar)
+		response.Abort(c, errAvatar)
 		return
 	}
-	response.NewSuccess(c, user.DetailUserAvatar(avatarURL), success.UserSuccessfullyGetUserDetail).JSON()
+	response.NewSuccess(c, user.DetailUserAvatar(avatarURLs), success.UserSuccessfullyGetUserDetail).Render()
 }
 
 // @Summary Update user avatar
@@ -360,18 +574,24 @@ func (s *Users) GetUser(c *gin.Context) {
 func (s *Users) UpdateAvatar(c *gin.Context) {
 	UUID, exists := c.Get("UUID")
 	if !exists {
-		_ = c.AbortWithError(http.StatusUnauthorized, exception.ErrorTextUnauthorized)
+		response.Abort(c, exception.ErrorTextUnauthorized)
 		return
 	}
 
 	avatar, err := c.FormFile("avatar")
 	if err != nil {
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, err)
+		response.Abort(c, exception.WithStatus(err, http.StatusUnprocessableEntity))
+		return
+	}
+
+	currentUser, err := s.us.GetUser(UUID.(string))
+	if err != nil {
+		response.Abort(c, err)
 		return
 	}
 
 	var userEntity entity.User
-	avatarData, _, errException, errArgs := s.ss.UploadFile(avatar, fileCategory)
+	avatarData, _, errException, errArgs := s.ss.UploadFile(avatar, fileCategory, UUID.(string))
 	if errException != nil {
 		if errors.Is(errException, exception.ErrorTextStorageUploadInvalidSize) {
 			c.Set("args", errArgs)
@@ -379,7 +599,7 @@ func (s *Users) UpdateAvatar(c *gin.Context) {
 		if errors.Is(errException, exception.ErrorTextStorageUploadInvalidFileType) {
 			c.Set("args", errArgs)
 		}
-		_ = c.AbortWithError(http.StatusUnprocessableEntity, errException)
+		response.Abort(c, exception.WithStatus(errException, http.StatusUnprocessableEntity))
 		return
 	}
 
@@ -388,22 +608,40 @@ func (s *Users) UpdateAvatar(c *gin.Context) {
 	if errException != nil {
 		c.Set("data", errDesc)
 		if errors.Is(errException, exception.ErrorTextUserNotFound) {
-			_ = c.AbortWithError(http.StatusNotFound, errException)
+			response.Abort(c, errException)
 			return
 		}
 		if errors.Is(errException, exception.ErrorTextUnprocessableEntity) {
-			_ = c.AbortWithError(http.StatusUnprocessableEntity, errException)
+			response.Abort(c, errException)
 			return
 		}
-		_ = c.AbortWithError(http.StatusInternalServerError, exception.ErrorTextInternalServerError)
+		response.Abort(c, exception.ErrorTextInternalServerError)
 		return
 	}
 
-	avatarURL, errAvatar := s.ss.GetFile(updatedUser.AvatarUUID)
+	// The previous avatar is no longer referenced, storage:gc collects it when it can not be deleted now.
+	if currentUser.AvatarUUID != "" && currentUser.AvatarUUID != updatedUser.AvatarUUID {
+		if errDelete := s.ss.DeleteFile(currentUser.AvatarUUID); errDelete != nil &&
+			!errors.Is(errDelete, exception.ErrorTextStorageFileNotFound) {
+			log.Warn().Err(errDelete).Str("file", currentUser.AvatarUUID).Msg("failed to delete previous avatar")
+		}
+	}
+
+	// Post-processing is not part of the request, the avatar is already stored.
+	// An avatar left waiting to be scanned is dispatched again by storage:gc.
+	processJob, errJob := storage.NewProcessFileJob(updatedUser.AvatarUUID)
+	if errJob == nil {
+		errJob = s.qu.Dispatch(processJob)
+	}
+	if errJob != nil {
+		log.Warn().Err(errJob).Str("file", updatedUser.AvatarUUID).Msg("failed to dispatch file processing job")
+	}
+
+	avatarURLs, errAvatar := s.ss.GetFileVariants(updatedUser.AvatarUUID)
 	if errAvatar != nil {
-		_ = c.AbortWithError(http.StatusInternalServerError, errAvatar)
+		response.Abort(c, errAvatar)
 		return
 	}
 	c.Status(http.StatusOK)
-	response.NewSuccess(c, updatedUser.DetailUserAvatar(avatarURL), success.UserSuccessfullyUpdateUserAvatar).JSON()
+	response.NewSuccess(c, updatedUser.DetailUserAvatar(avatarURLs), success.UserSuccessfullyUpdateUserAvatar).Render()
 }
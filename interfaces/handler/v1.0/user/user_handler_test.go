@@ -8,6 +8,7 @@ import (
 	"go-rest-skeleton/domain/repository"
 	"go-rest-skeleton/infrastructure/message/exception"
 	"go-rest-skeleton/pkg/encoder"
+	"go-rest-skeleton/pkg/response"
 	"go-rest-skeleton/pkg/util"
 	"go-rest-skeleton/tests/mock"
 	"log"
@@ -23,12 +24,23 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// mockUniqueUser mocks email and phone lookups of the user app, neither of them is taken by another user.
+func mockUniqueUser(userApp *mock.UserAppInterface) {
+	userApp.GetUserByEmailFn = func(*entity.User) (*entity.User, map[string]string, error) {
+		return nil, nil, exception.ErrorTextUserNotFound
+	}
+	userApp.GetUserByPhoneFn = func(*entity.User) (*entity.User, map[string]string, error) {
+		return nil, nil, exception.ErrorTextUserNotFound
+	}
+}
+
 // TestSaveUser_Success Test.
 func TestSaveUser_Success(t *testing.T) {
 	var userData entity.User
 	var userApp mock.UserAppInterface
 	var storageApp mock.StorageAppInterface
-	userHandler := NewUsers(&userApp, &storageApp)
+	var queueApp mock.QueueAppInterface
+	userHandler := NewUsers(&userApp, &storageApp, &queueApp)
 	userJSON := `{
 		"name": "Example",
 		"email": "example@test.com",
@@ -43,6 +55,7 @@ func TestSaveUser_Success(t *testing.T) {
 	v1 := r.Group("/api/v1/external/")
 	v1.POST("/users", userHandler.SaveUser)
 
+	mockUniqueUser(&userApp)
 	userApp.SaveUserFn = func(user *entity.User) (*entity.User, map[string]string, error) {
 		return &entity.User{
 			UUID:  UUID,
@@ -105,7 +118,8 @@ func TestSaveUser_InvalidData(t *testing.T) {
 	for _, v := range samples {
 		var userApp mock.UserAppInterface
 		var storageApp mock.StorageAppInterface
-		userHandler := NewUsers(&userApp, &storageApp)
+		var queueApp mock.QueueAppInterface
+		userHandler := NewUsers(&userApp, &storageApp, &queueApp)
 
 		gin.SetMode(gin.TestMode)
 		w := httptest.NewRecorder()
@@ -137,7 +151,8 @@ func TestUpdateUser_Success(t *testing.T) {
 	var userData entity.User
 	var userApp mock.UserAppInterface
 	var storageApp mock.StorageAppInterface
-	userHandler := NewUsers(&userApp, &storageApp)
+	var queueApp mock.QueueAppInterface
+	userHandler := NewUsers(&userApp, &storageApp, &queueApp)
 	userJSON := `{
 		"name": "Example",
 		"email": "example@test.com",
@@ -152,6 +167,10 @@ func TestUpdateUser_Success(t *testing.T) {
 	v1 := r.Group("/api/v1/external/")
 	v1.PUT("/users/:uuid", userHandler.UpdateUser)
 
+	mockUniqueUser(&userApp)
+	userApp.GetUserFn = func(UUID string) (*entity.User, error) {
+		return &entity.User{UUID: UUID, Email: "example@test.com", Phone: "+6285725833220", Version: 1}, nil
+	}
 	userApp.UpdateUserFn = func(UUID string, user *entity.User) (*entity.User, map[string]string, error) {
 		return &entity.User{
 			UUID:  UUID,
@@ -185,12 +204,13 @@ func TestGetUser_Success(t *testing.T) {
 	var userData entity.User
 	var userApp mock.UserAppInterface
 	var storageApp mock.StorageAppInterface
+	var queueApp mock.QueueAppInterface
 
 	if err := godotenv.Load(fmt.Sprintf("%s/.env", util.RootDir())); err != nil {
 		log.Println("no .env file provided")
 	}
 
-	userHandler := NewUsers(&userApp, &storageApp)
+	userHandler := NewUsers(&userApp, &storageApp, &queueApp)
 	UUID := uuid.New().String()
 
 	gin.SetMode(gin.TestMode)
@@ -208,8 +228,8 @@ func TestGetUser_Success(t *testing.T) {
 		}, nil
 	}
 
-	storageApp.GetFileFn = func(string) (interface{}, error) {
-		return UUID, nil
+	storageApp.GetFileVariantsFn = func(string) (map[string]interface{}, error) {
+		return map[string]interface{}{"original": UUID}, nil
 	}
 
 	var err error
@@ -234,9 +254,10 @@ func TestGetUser_Success(t *testing.T) {
 func TestGetUsers_Success(t *testing.T) {
 	var userApp mock.UserAppInterface
 	var storageApp mock.StorageAppInterface
+	var queueApp mock.QueueAppInterface
 	var usersData []entity.User
 	var metaData repository.Meta
-	userHandler := NewUsers(&userApp, &storageApp)
+	userHandler := NewUsers(&userApp, &storageApp, &queueApp)
 	UUID := uuid.New().String()
 
 	gin.SetMode(gin.TestMode)
@@ -244,8 +265,8 @@ func TestGetUsers_Success(t *testing.T) {
 	c, r := gin.CreateTestContext(w)
 	v1 := r.Group("/api/v1/external/")
 	v1.GET("/users", userHandler.GetUsers)
-	userApp.GetUsersFn = func(params *repository.Parameters) ([]entity.User, interface{}, error) {
-		users := []entity.User{
+	userApp.GetUsersFn = func(params *repository.Parameters) ([]*entity.User, *repository.Meta, error) {
+		users := []*entity.User{
 			{
 				UUID:  UUID,
 				Name:  "Example 1",
@@ -286,7 +307,8 @@ func TestGetUsers_Success(t *testing.T) {
 func TestDeleteUser_Success(t *testing.T) {
 	var userApp mock.UserAppInterface
 	var storageApp mock.StorageAppInterface
-	userHandler := NewUsers(&userApp, &storageApp)
+	var queueApp mock.QueueAppInterface
+	userHandler := NewUsers(&userApp, &storageApp, &queueApp)
 	UUID := uuid.New().String()
 
 	gin.SetMode(gin.TestMode)
@@ -313,7 +335,8 @@ func TestDeleteUser_Success(t *testing.T) {
 func TestDeleteUser_Failed_UserNotFound(t *testing.T) {
 	var userApp mock.UserAppInterface
 	var storageApp mock.StorageAppInterface
-	userHandler := NewUsers(&userApp, &storageApp)
+	var queueApp mock.QueueAppInterface
+	userHandler := NewUsers(&userApp, &storageApp, &queueApp)
 	UUID := uuid.New().String()
 
 	gin.SetMode(gin.TestMode)
@@ -335,3 +358,235 @@ func TestDeleteUser_Failed_UserNotFound(t *testing.T) {
 
 	assert.Equal(t, w.Code, http.StatusNotFound)
 }
+
+// TestUpdateUser_Failed_PreconditionFailed Test.
+func TestUpdateUser_Failed_PreconditionFailed(t *testing.T) {
+	var userApp mock.UserAppInterface
+	var storageApp mock.StorageAppInterface
+	var queueApp mock.QueueAppInterface
+	userHandler := NewUsers(&userApp, &storageApp, &queueApp)
+	userJSON := `{
+		"name": "Example",
+		"email": "example@test.com",
+		"phone": "+6285725833220"
+	}`
+	UUID := uuid.New().String()
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, r := gin.CreateTestContext(w)
+	v1 := r.Group("/api/v1/external/")
+	v1.PUT("/users/:uuid", userHandler.UpdateUser)
+
+	userApp.GetUserFn = func(UUID string) (*entity.User, error) {
+		return &entity.User{UUID: UUID, Email: "example@test.com", Phone: "+6285725833220", Version: 2}, nil
+	}
+
+	var err error
+	c.Request, err = http.NewRequest(http.MethodPut, "/api/v1/external/users/"+UUID, bytes.NewBufferString(userJSON))
+	if err != nil {
+		t.Errorf("this is the error: %v\n", err)
+	}
+	c.Request.Header.Set("If-Match", `"1"`)
+	r.ServeHTTP(w, c.Request)
+
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+}
+
+// TestGetUser_NotModified Test.
+func TestGetUser_NotModified(t *testing.T) {
+	var userApp mock.UserAppInterface
+	var storageApp mock.StorageAppInterface
+	var queueApp mock.QueueAppInterface
+	userHandler := NewUsers(&userApp, &storageApp, &queueApp)
+	UUID := uuid.New().String()
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, r := gin.CreateTestContext(w)
+	v1 := r.Group("/api/v1/external/")
+	v1.GET("/users/:uuid", userHandler.GetUser)
+
+	userApp.GetUserFn = func(UUID string) (*entity.User, error) {
+		return &entity.User{UUID: UUID, Name: "Example", Version: 3}, nil
+	}
+
+	var err error
+	c.Request, err = http.NewRequest(http.MethodGet, "/api/v1/external/users/"+UUID, nil)
+	if err != nil {
+		t.Errorf("this is the error: %v\n", err)
+	}
+	c.Request.Header.Set("If-None-Match", `"3"`)
+	r.ServeHTTP(w, c.Request)
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	assert.Empty(t, w.Body.String())
+}
+
+// TestBulkSaveUsers_MultiStatus Test.
+func TestBulkSaveUsers_MultiStatus(t *testing.T) {
+	var userApp mock.UserAppInterface
+	var storageApp mock.StorageAppInterface
+	var queueApp mock.QueueAppInterface
+	var results []response.BulkResult
+	var metaData response.BulkMeta
+	userHandler := NewUsers(&userApp, &storageApp, &queueApp)
+	UUID := uuid.New().String()
+	bulkJSON := fmt.Sprintf(`{"items": [
+		{"name": "Example", "email": "example@test.com", "phone": "+6285725833220", "password": "password"},
+		{"uuid": "%s", "name": "Example", "email": "stale@test.com", "phone": "+6285725833221", "version": 1}
+	]}`, UUID)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, r := gin.CreateTestContext(w)
+	v1 := r.Group("/api/v1/external/")
+	v1.POST("/users/bulk", userHandler.BulkSaveUsers)
+
+	mockUniqueUser(&userApp)
+	userApp.SaveUserFn = func(user *entity.User) (*entity.User, map[string]string, error) {
+		return &entity.User{UUID: uuid.New().String(), Name: user.Name, Email: user.Email, Phone: user.Phone}, nil, nil
+	}
+	userApp.GetUserFn = func(UUID string) (*entity.User, error) {
+		return &entity.User{UUID: UUID, Email: "stale@test.com", Phone: "+6285725833221", Version: 2}, nil
+	}
+
+	var err error
+	c.Request, err = http.NewRequest(http.MethodPost, "/api/v1/external/users/bulk", bytes.NewBufferString(bulkJSON))
+	if err != nil {
+		t.Errorf("this is the error: %v\n", err)
+	}
+	c.Request.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, c.Request)
+
+	responseBody := encoder.ResponseDecoder(w.Body)
+	data, _ := json.Marshal(responseBody["data"])
+	meta, _ := json.Marshal(responseBody["meta"])
+	_ = json.Unmarshal(data, &results)
+	_ = json.Unmarshal(meta, &metaData)
+
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+	assert.Len(t, results, 2)
+	assert.Equal(t, http.StatusCreated, results[0].Status)
+	assert.Equal(t, http.StatusPreconditionFailed, results[1].Status)
+	assert.Equal(t, UUID, results[1].UUID)
+	assert.Equal(t, response.BulkMeta{Total: 2, Succeeded: 1, Failed: 1}, metaData)
+}
+
+// TestGetUsers_Trashed Test.
+func TestGetUsers_Trashed(t *testing.T) {
+	samples := []struct {
+		trashed    string
+		statusCode int
+	}{
+		{trashed: repository.TrashedWith, statusCode: http.StatusOK},
+		{trashed: repository.TrashedOnly, statusCode: http.StatusOK},
+		{trashed: "all", statusCode: http.StatusUnprocessableEntity},
+	}
+
+	for _, v := range samples {
+		var userApp mock.UserAppInterface
+		var storageApp mock.StorageAppInterface
+		var queueApp mock.QueueAppInterface
+		userHandler := NewUsers(&userApp, &storageApp, &queueApp)
+
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		c, r := gin.CreateTestContext(w)
+		v1 := r.Group("/api/v1/external/")
+		v1.GET("/users", userHandler.GetUsers)
+
+		var trashed string
+		userApp.GetUsersFn = func(params *repository.Parameters) ([]*entity.User, *repository.Meta, error) {
+			trashed = params.Trashed
+			return []*entity.User{}, repository.NewMeta(params, 0), nil
+		}
+
+		var err error
+		c.Request, err = http.NewRequest(http.MethodGet, "/api/v1/external/users?trashed="+v.trashed, nil)
+		if err != nil {
+			t.Errorf("this is the error: %v\n", err)
+		}
+		r.ServeHTTP(w, c.Request)
+
+		assert.Equal(t, v.statusCode, w.Code, v.trashed)
+		if v.statusCode == http.StatusOK {
+			assert.Equal(t, v.trashed, trashed)
+		}
+	}
+}
+
+// TestRestoreUser_Success Test.
+func TestRestoreUser_Success(t *testing.T) {
+	var userData entity.User
+	var userApp mock.UserAppInterface
+	var storageApp mock.StorageAppInterface
+	var queueApp mock.QueueAppInterface
+	userHandler := NewUsers(&userApp, &storageApp, &queueApp)
+	UUID := uuid.New().String()
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, r := gin.CreateTestContext(w)
+	v1 := r.Group("/api/v1/external/")
+	v1.POST("/users/:uuid/restore", userHandler.RestoreUser)
+
+	userApp.RestoreUserFn = func(UUID string) (*entity.User, error) {
+		return &entity.User{UUID: UUID, Name: "Example", Email: "example@test.com", Version: 4}, nil
+	}
+
+	var err error
+	c.Request, err = http.NewRequest(http.MethodPost, "/api/v1/external/users/"+UUID+"/restore", nil)
+	if err != nil {
+		t.Errorf("this is the error: %v\n", err)
+	}
+	r.ServeHTTP(w, c.Request)
+
+	responseBody := encoder.ResponseDecoder(w.Body)
+	data, _ := json.Marshal(responseBody["data"])
+	_ = json.Unmarshal(data, &userData)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+	assert.EqualValues(t, UUID, userData.UUID)
+}
+
+// TestRestoreUser_Failed Test.
+func TestRestoreUser_Failed(t *testing.T) {
+	samples := []struct {
+		err        error
+		statusCode int
+	}{
+		{err: exception.ErrorTextUserNotFound, statusCode: http.StatusNotFound},
+		{err: exception.ErrorTextRestoreConflict, statusCode: http.StatusConflict},
+	}
+
+	for _, v := range samples {
+		var userApp mock.UserAppInterface
+		var storageApp mock.StorageAppInterface
+		var queueApp mock.QueueAppInterface
+		userHandler := NewUsers(&userApp, &storageApp, &queueApp)
+		UUID := uuid.New().String()
+
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		c, r := gin.CreateTestContext(w)
+		v1 := r.Group("/api/v1/external/")
+		v1.POST("/users/:uuid/restore", userHandler.RestoreUser)
+
+		restoreErr := v.err
+		userApp.RestoreUserFn = func(UUID string) (*entity.User, error) {
+			return nil, restoreErr
+		}
+
+		var err error
+		c.Request, err = http.NewRequest(http.MethodPost, "/api/v1/external/users/"+UUID+"/restore", nil)
+		if err != nil {
+			t.Errorf("this is the error: %v\n", err)
+		}
+		r.ServeHTTP(w, c.Request)
+
+		assert.Equal(t, v.statusCode, w.Code)
+	}
+}
@@ -0,0 +1,190 @@
+package webhook
+
+import (
+	"errors"
+	"go-rest-skeleton/application"
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/infrastructure/message/success"
+	"go-rest-skeleton/pkg/encoder"
+	"go-rest-skeleton/pkg/response"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Webhooks is a struct defines the dependencies that will be used.
+type Webhooks struct {
+	wa application.WebhookAppInterface
+}
+
+// NewWebhooks is constructor will initialize webhook handler.
+func NewWebhooks(wa application.WebhookAppInterface) *Webhooks {
+	return &Webhooks{
+		wa: wa,
+	}
+}
+
+// SaveWebhook is a function uses to handle create a new webhook subscription.
+func (s *Webhooks) SaveWebhook(c *gin.Context) {
+	var subscriptionEntity entity.WebhookSubscription
+	if err := encoder.BindRequest(c, &subscriptionEntity); err != nil {
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+	validateErr := subscriptionEntity.ValidateSaveWebhookSubscription()
+	if len(validateErr) > 0 {
+		exceptionData := response.TranslateErrorForm(c, validateErr)
+		c.Set("data", exceptionData)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	newSubscription, errDesc, errException := s.wa.SaveWebhookSubscription(&subscriptionEntity)
+	if errException != nil {
+		c.Set("data", errDesc)
+		if errors.Is(errException, exception.ErrorTextUnprocessableEntity) {
+			response.Abort(c, errException)
+			return
+		}
+		response.Abort(c, exception.ErrorTextInternalServerError)
+		return
+	}
+	c.Status(http.StatusCreated)
+	response.NewSuccess(c, newSubscription.DetailWebhookSubscription(), success.WebhookSuccessfullyCreateWebhook).Render()
+}
+
+// UpdateWebhook is a function uses to handle update webhook subscription by UUID.
+func (s *Webhooks) UpdateWebhook(c *gin.Context) {
+	var subscriptionEntity entity.WebhookSubscription
+	if err := encoder.BindRequest(c, &subscriptionEntity); err != nil {
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+	validateErr := subscriptionEntity.ValidateUpdateWebhookSubscription()
+	if len(validateErr) > 0 {
+		exceptionData := response.TranslateErrorForm(c, validateErr)
+		c.Set("data", exceptionData)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	UUID := c.Param("uuid")
+	updatedSubscription, errDesc, errException := s.wa.UpdateWebhookSubscription(UUID, &subscriptionEntity)
+	if errException != nil {
+		c.Set("data", errDesc)
+		if errors.Is(errException, exception.ErrorTextWebhookSubscriptionNotFound) {
+			response.Abort(c, errException)
+			return
+		}
+		response.Abort(c, exception.ErrorTextInternalServerError)
+		return
+	}
+	response.NewSuccess(c, updatedSubscription.DetailWebhookSubscription(), success.WebhookSuccessfullyUpdateWebhook).Render()
+}
+
+// EnableWebhook is a function uses to handle re-enable a disabled webhook subscription by UUID.
+func (s *Webhooks) EnableWebhook(c *gin.Context) {
+	UUID := c.Param("uuid")
+	err := s.wa.EnableWebhookSubscription(UUID)
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, nil, success.WebhookSuccessfullyEnableWebhook).Render()
+}
+
+// DeleteWebhook is a function uses to handle delete webhook subscription by UUID.
+func (s *Webhooks) DeleteWebhook(c *gin.Context) {
+	UUID := c.Param("uuid")
+	err := s.wa.DeleteWebhookSubscription(UUID)
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, nil, success.WebhookSuccessfullyDeleteWebhook).Render()
+}
+
+// GetWebhooks is a function uses to handle get webhook subscription list.
+func (s *Webhooks) GetWebhooks(c *gin.Context) {
+	var subscription entity.WebhookSubscription
+	var subscriptions entity.WebhookSubscriptions
+	var err error
+	parameters := repository.NewGinParameters(c)
+	validateErr := parameters.ValidateParameter(subscription.FilterableFields()...)
+	if len(validateErr) > 0 {
+		exceptionData := response.TranslateErrorForm(c, validateErr)
+		c.Set("data", exceptionData)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	subscriptions, meta, err := s.wa.GetWebhookSubscriptions(parameters)
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, subscriptions.DetailWebhookSubscriptions(), success.WebhookSuccessfullyGetWebhookList).
+		WithMeta(meta).Render()
+}
+
+// GetWebhook is a function uses to handle get webhook subscription detail by UUID.
+func (s *Webhooks) GetWebhook(c *gin.Context) {
+	UUID := c.Param("uuid")
+	subscription, err := s.wa.GetWebhookSubscription(UUID)
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, subscription.DetailWebhookSubscription(), success.WebhookSuccessfullyGetWebhookDetail).Render()
+}
+
+// GetWebhookDeliveries is a function uses to handle get delivery log of webhook subscription.
+func (s *Webhooks) GetWebhookDeliveries(c *gin.Context) {
+	var delivery entity.WebhookDelivery
+	var deliveries entity.WebhookDeliveries
+	var err error
+	parameters := repository.NewGinParameters(c)
+	validateErr := parameters.ValidateParameter(delivery.FilterableFields()...)
+	if len(validateErr) > 0 {
+		exceptionData := response.TranslateErrorForm(c, validateErr)
+		c.Set("data", exceptionData)
+		response.Abort(c, exception.ErrorTextUnprocessableEntity)
+		return
+	}
+
+	UUID := c.Param("uuid")
+	if _, err = s.wa.GetWebhookSubscription(UUID); err != nil {
+		response.Abort(c, err)
+		return
+	}
+
+	deliveries, meta, err := s.wa.GetWebhookDeliveries(UUID, parameters)
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, deliveries.DetailWebhookDeliveries(), success.WebhookSuccessfullyGetWebhookDeliveryList).
+		WithMeta(meta).Render()
+}
+
+// GetWebhookDelivery is a function uses to handle get webhook delivery detail, including request and response.
+func (s *Webhooks) GetWebhookDelivery(c *gin.Context) {
+	delivery, err := s.wa.GetWebhookDelivery(c.Param("uuid"), c.Param("delivery_uuid"))
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, delivery.DetailWebhookDelivery(), success.WebhookSuccessfullyGetWebhookDeliveryDetail).Render()
+}
+
+// ReplayWebhookDelivery is a function uses to handle sending a recorded webhook delivery again.
+func (s *Webhooks) ReplayWebhookDelivery(c *gin.Context) {
+	delivery, err := s.wa.ReplayWebhookDelivery(c.Request.Context(), c.Param("uuid"), c.Param("delivery_uuid"))
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	response.NewSuccess(c, delivery.DetailWebhookDelivery(), success.WebhookSuccessfullyReplayWebhookDelivery).Render()
+}
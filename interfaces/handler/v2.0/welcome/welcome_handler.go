@@ -17,5 +17,5 @@ func NewWelcomeHandler() *WelcomeHandler {
 
 // Index will handle request.
 func (s *WelcomeHandler) Index(c *gin.Context) {
-	response.NewSuccess(c, nil, "PONG v2.0").JSON()
+	response.NewSuccess(c, nil, "PONG v2.0").Render()
 }
@@ -3,7 +3,7 @@ package middleware
 import (
 	"go-rest-skeleton/infrastructure/authorization"
 	"go-rest-skeleton/infrastructure/message/exception"
-	"net/http"
+	"go-rest-skeleton/pkg/response"
 
 	"github.com/gin-gonic/gin"
 )
@@ -22,7 +22,7 @@ func (ag *AuthenticationGateway) Authenticate() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		_, err := authorization.AuthGateway(ag.gw, c)
 		if err != nil {
-			_ = c.AbortWithError(http.StatusUnauthorized, exception.ErrorTextUnauthorized)
+			response.Abort(c, exception.ErrorTextUnauthorized)
 			return
 		}
 		c.Next()
@@ -33,19 +33,29 @@ func (ag *AuthenticationGateway) Authorize(action string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		policy := NewPolicy(ag.gw.US, ag.gw.RS)
 		if !policy.Can(action, c) {
-			_ = c.AbortWithError(http.StatusForbidden, exception.ErrorTextForbidden)
+			response.Abort(c, exception.ErrorTextForbidden)
 			return
 		}
 		c.Next()
 	}
 }
 
+// Allows reports whether the request is authenticated and its user has permission of action, without aborting it.
+// Uses by routes serving everyone, but revealing more to privileged users.
+func (ag *AuthenticationGateway) Allows(action string, c *gin.Context) bool {
+	if _, err := authorization.AuthGateway(ag.gw, c); err != nil {
+		return false
+	}
+
+	return NewPolicy(ag.gw.US, ag.gw.RS).Can(action, c)
+}
+
 // Auth is a middleware function uses to handle request only from authorized user.
 func Auth(g *authorization.Gateway) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		_, err := authorization.AuthGateway(g, c)
 		if err != nil {
-			_ = c.AbortWithError(http.StatusUnauthorized, exception.ErrorTextUnauthorized)
+			response.Abort(c, exception.ErrorTextUnauthorized)
 			return
 		}
 		c.Next()
@@ -25,7 +25,7 @@ func TestAuth_WithJWTAuth_Success(t *testing.T) {
 		t.Fatalf("want non error, got %#v", errSeed)
 	}
 
-	jwtAuth, _ := dp.at.CreateToken(user.UUID)
+	jwtAuth, _ := dp.at.CreateToken(user.UUID, "")
 	jwtAccessToken := jwtAuth.AccessToken
 	authErr := dp.rd.Auth.CreateAuth(user.UUID, jwtAuth)
 	if authErr != nil {
@@ -60,7 +60,7 @@ func TestAuth_WithJWTAuth_Failed(t *testing.T) {
 		t.Fatalf("want non error, got %#v", errSeed)
 	}
 
-	jwtAuth, _ := dp.at.CreateToken(user.UUID)
+	jwtAuth, _ := dp.at.CreateToken(user.UUID, "")
 	jwtAccessToken := jwtAuth.AccessToken
 
 	gin.SetMode(gin.TestMode)
@@ -17,8 +17,9 @@ func CORS(options CORSOptions) gin.HandlerFunc {
 		if options.AllowSetting {
 			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 			c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-			c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
+			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, Tus-Resumable, Upload-Length, Upload-Metadata, Upload-Offset, Idempotency-Key, If-Match, If-None-Match")
+			c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, HEAD, PUT, PATCH, DELETE")
+			c.Writer.Header().Set("Access-Control-Expose-Headers", "Location, Tus-Resumable, Tus-Version, Upload-Expires, Upload-Length, Upload-Offset, Idempotent-Replayed, ETag")
 
 			if c.Request.Method == "OPTIONS" {
 				c.AbortWithStatus(http.StatusNoContent)
@@ -28,8 +28,8 @@ func TestCORS_WithAcceptedHTTPMethod(t *testing.T) {
 
 	assert.Equal(t, w.Header().Get("Access-Control-Allow-Origin"), "*")
 	assert.Equal(t, w.Header().Get("Access-Control-Allow-Credentials"), "true")
-	assert.Equal(t, w.Header().Get("Access-Control-Allow-Headers"), "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-	assert.Equal(t, w.Header().Get("Access-Control-Allow-Methods"), "POST, OPTIONS, GET, PUT, PATCH, DELETE")
+	assert.Equal(t, w.Header().Get("Access-Control-Allow-Headers"), "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, Tus-Resumable, Upload-Length, Upload-Metadata, Upload-Offset, Idempotency-Key, If-Match, If-None-Match")
+	assert.Equal(t, w.Header().Get("Access-Control-Allow-Methods"), "POST, OPTIONS, GET, HEAD, PUT, PATCH, DELETE")
 }
 
 func TestCORS_OptionsHTTPMethod(t *testing.T) {
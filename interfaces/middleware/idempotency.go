@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"bytes"
+	"go-rest-skeleton/infrastructure/idempotency"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/pkg/response"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header carrying the idempotency key.
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader is the response header telling the response is replayed.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// maxIdempotencyKeyLength is the maximum length of the idempotency key.
+	maxIdempotencyKeyLength = 255
+)
+
+// IdempotencyOptions is a struct to store options for Idempotency.
+type IdempotencyOptions struct {
+	Store   idempotency.Store
+	TTL     time.Duration
+	LockTTL time.Duration
+}
+
+// Idempotency is a middleware function uses to replay the response of a request sent again with the same
+// Idempotency-Key header. Keys are scoped by the authenticated user, so it must be used after Authenticate.
+// The same key sent with another request body responds 422, while the first request is still in flight 409.
+// Only successful responses are stored, a failed request releases the key so the client can retry it.
+func Idempotency(options IdempotencyOptions) gin.HandlerFunc {
+	if options.TTL <= 0 {
+		options.TTL = idempotency.DefaultTTL
+	}
+	if options.LockTTL <= 0 {
+		options.LockTTL = idempotency.DefaultLockTTL
+	}
+
+	return func(c *gin.Context) {
+		idempotencyKey := c.GetHeader(IdempotencyKeyHeader)
+		if idempotencyKey == "" {
+			c.Next()
+			return
+		}
+		if len(idempotencyKey) > maxIdempotencyKeyLength {
+			response.Abort(c, exception.ErrorTextBadRequest)
+			return
+		}
+
+		body, err := ioutil.ReadAll(c.Request.Body)
+		if err != nil {
+			response.Abort(c, exception.ErrorTextBadRequest)
+			return
+		}
+		c.Request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		key := idempotency.Key(idempotencyPrincipal(c), idempotencyKey)
+		fingerprint := idempotency.Fingerprint(c.Request.Method, c.Request.URL.Path, body)
+		acquired, err := options.Store.Acquire(ctx, key, &idempotency.Record{Fingerprint: fingerprint}, options.LockTTL)
+		if err != nil {
+			response.Abort(c, err)
+			return
+		}
+		if !acquired {
+			replayIdempotentResponse(c, options.Store, key, fingerprint)
+			return
+		}
+
+		w := &responseBodyWriter{body: bytes.NewBufferString(""), ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		status := c.Writer.Status()
+		if len(c.Errors) > 0 || status >= http.StatusBadRequest {
+			if err := options.Store.Release(ctx, key); err != nil {
+				log.Error().Err(err).Msg("failed to release idempotency key")
+			}
+			return
+		}
+
+		header := c.Writer.Header().Clone()
+		header.Del("X-Request-Id")
+		record := &idempotency.Record{
+			Fingerprint: fingerprint,
+			Completed:   true,
+			Status:      status,
+			Header:      header,
+			Body:        w.body.Bytes(),
+		}
+		if err := options.Store.Save(ctx, key, record, options.TTL); err != nil {
+			log.Error().Err(err).Msg("failed to store idempotent response")
+		}
+	}
+}
+
+// replayIdempotentResponse writes the stored response of the key, or aborts when it can not be replayed.
+func replayIdempotentResponse(c *gin.Context, store idempotency.Store, key string, fingerprint string) {
+	record, err := store.Get(c.Request.Context(), key)
+	if err != nil {
+		response.Abort(c, err)
+		return
+	}
+	if record == nil {
+		// Released or expired just now, the client may retry right away.
+		response.Abort(c, exception.ErrorTextIdempotencyRequestInProgress)
+		return
+	}
+	if record.Fingerprint != fingerprint {
+		response.Abort(c, exception.ErrorTextIdempotencyKeyReused)
+		return
+	}
+	if !record.Completed {
+		response.Abort(c, exception.ErrorTextIdempotencyRequestInProgress)
+		return
+	}
+
+	for name, values := range record.Header {
+		c.Writer.Header()[name] = values
+	}
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Data(record.Status, record.Header.Get("Content-Type"), record.Body)
+	c.Abort()
+}
+
+// idempotencyPrincipal returns the UUID of the authenticated user, or the client IP of an anonymous request.
+func idempotencyPrincipal(c *gin.Context) string {
+	if UUID, exists := c.Get("UUID"); exists {
+		if principal, ok := UUID.(string); ok && principal != "" {
+			return "user:" + principal
+		}
+	}
+
+	return "ip:" + c.ClientIP()
+}
@@ -0,0 +1,142 @@
+package middleware_test
+
+import (
+	"context"
+	"go-rest-skeleton/infrastructure/idempotency"
+	"go-rest-skeleton/interfaces/middleware"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStore is an idempotency.Store keeping records in memory, ttl is ignored.
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]idempotency.Record
+}
+
+func (s *memoryStore) Acquire(ctx context.Context, key string, record *idempotency.Record, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.records[key]; exists {
+		return false, nil
+	}
+	s.records[key] = *record
+	return true, nil
+}
+
+func (s *memoryStore) Get(ctx context.Context, key string) (*idempotency.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, exists := s.records[key]
+	if !exists {
+		return nil, nil
+	}
+	return &record, nil
+}
+
+func (s *memoryStore) Save(ctx context.Context, key string, record *idempotency.Record, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[key] = *record
+	return nil
+}
+
+func (s *memoryStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+func idempotencyRouter(store idempotency.Store, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	_, r := gin.CreateTestContext(httptest.NewRecorder())
+	r.Use(middleware.NewResponse(middleware.ResponseOptions{}).Handler())
+	r.POST("/users", func(c *gin.Context) {
+		c.Set("UUID", "a6f0e5f5-user")
+	}, middleware.Idempotency(middleware.IdempotencyOptions{Store: store}), handler)
+	return r
+}
+
+func idempotentRequest(r *gin.Engine, key string, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(middleware.IdempotencyKeyHeader, key)
+	}
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotency_Replay(t *testing.T) {
+	created := 0
+	r := idempotencyRouter(&memoryStore{records: map[string]idempotency.Record{}}, func(c *gin.Context) {
+		created++
+		c.Header("Location", "/users/1")
+		c.JSON(http.StatusCreated, gin.H{"created": created})
+	})
+
+	first := idempotentRequest(r, "key-1", `{"email":"me@example.com"}`)
+	replayed := idempotentRequest(r, "key-1", `{"email":"me@example.com"}`)
+
+	assert.Equal(t, 1, created)
+	assert.Equal(t, http.StatusCreated, replayed.Code)
+	assert.Equal(t, first.Body.String(), replayed.Body.String())
+	assert.Equal(t, "/users/1", replayed.Header().Get("Location"))
+	assert.Equal(t, "true", replayed.Header().Get(middleware.IdempotentReplayedHeader))
+	assert.Empty(t, first.Header().Get(middleware.IdempotentReplayedHeader))
+
+	idempotentRequest(r, "", `{"email":"me@example.com"}`)
+	idempotentRequest(r, "key-2", `{"email":"me@example.com"}`)
+	assert.Equal(t, 3, created)
+}
+
+func TestIdempotency_DifferentBody(t *testing.T) {
+	r := idempotencyRouter(&memoryStore{records: map[string]idempotency.Record{}}, func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	idempotentRequest(r, "key-1", `{"email":"me@example.com"}`)
+	w := idempotentRequest(r, "key-1", `{"email":"you@example.com"}`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestIdempotency_InFlight(t *testing.T) {
+	store := &memoryStore{records: map[string]idempotency.Record{}}
+	var inFlight *httptest.ResponseRecorder
+	var r *gin.Engine
+	r = idempotencyRouter(store, func(c *gin.Context) {
+		inFlight = idempotentRequest(r, "key-1", `{}`)
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	w := idempotentRequest(r, "key-1", `{}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, http.StatusConflict, inFlight.Code)
+}
+
+func TestIdempotency_FailedRequestReleasesKey(t *testing.T) {
+	attempts := 0
+	r := idempotencyRouter(&memoryStore{records: map[string]idempotency.Record{}}, func(c *gin.Context) {
+		attempts++
+		if attempts == 1 {
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, idempotentRequest(r, "key-1", `{}`).Code)
+	assert.Equal(t, http.StatusCreated, idempotentRequest(r, "key-1", `{}`).Code)
+	assert.Equal(t, 2, attempts)
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"go-rest-skeleton/pkg/translation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+// LanguageOptions is a struct to store options of Language.
+type LanguageOptions struct {
+	// Preference returns language preferred by the user, empty when the user has not chosen any.
+	Preference func(userUUID string) (string, error)
+}
+
+// Language is a middleware function uses to translate responses into the language preferred by the authenticated user.
+// The user is only known once the route has authenticated the request, so the preference is looked up lazily,
+// when the response is translated, and at most once per request.
+func Language(options LanguageOptions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if options.Preference == nil {
+			c.Next()
+			return
+		}
+
+		var looked bool
+		var preferred string
+		c.Set(translation.PreferenceKey, func() string {
+			if looked {
+				return preferred
+			}
+			UUID, exists := c.Get("UUID")
+			if !exists {
+				return ""
+			}
+
+			looked = true
+			lang, err := options.Preference(UUID.(string))
+			if err != nil {
+				log.Warn().Err(err).Msg("failed to get language preferred by the user")
+				return ""
+			}
+			preferred = lang
+
+			return preferred
+		})
+		c.Next()
+	}
+}
@@ -0,0 +1,40 @@
+package middleware_test
+
+import (
+	"go-rest-skeleton/interfaces/middleware"
+	"go-rest-skeleton/pkg/translation"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguage(t *testing.T) {
+	var lookups int
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, r := gin.CreateTestContext(w)
+	r.Use(middleware.Language(middleware.LanguageOptions{
+		Preference: func(userUUID string) (string, error) {
+			lookups++
+			return "id", nil
+		},
+	}))
+	r.GET("/test", func(c *gin.Context) {
+		// The preference is looked up once the user is authenticated
+		assert.Equal(t, "en", translation.GetLanguage(c))
+		c.Set("UUID", "a6f0e5f5-user")
+		assert.Equal(t, "id", translation.GetLanguage(c))
+		assert.Equal(t, "id", translation.GetLanguage(c))
+		c.Status(http.StatusOK)
+	})
+
+	c.Request, _ = http.NewRequest(http.MethodGet, "/test", nil)
+	c.Request.Header.Set("Accept-Language", "en")
+	r.ServeHTTP(w, c.Request)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, lookups)
+}
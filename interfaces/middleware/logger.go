@@ -198,6 +198,7 @@ func SetLogger(options LoggerOptions, config ...Config) gin.HandlerFunc {
 				Dur("latency", latency).
 				Str("user-agent", c.Request.UserAgent()).
 				Str("request-id", requestID).
+				Str("trace-id", c.Writer.Header().Get("X-Trace-Id")).
 				RawJSON("headers", requestHeader).
 				RawJSON("request-payload", requestBody).
 				RawJSON("request-form", requestForm).
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"go-rest-skeleton/infrastructure/metrics"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsOptions is a struct to store option of Metrics.
+type MetricsOptions struct {
+	AllowSetting bool
+}
+
+// Metrics is a middleware function uses to record count and latency of requests, labelled by route template.
+func Metrics(options MetricsOptions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !options.AllowSetting {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+		metrics.ObserveHTTPRequest(c.FullPath(), c.Request.Method, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,35 @@
+package middleware_test
+
+import (
+	"go-rest-skeleton/infrastructure/metrics"
+	"go-rest-skeleton/interfaces/middleware"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, r := gin.CreateTestContext(w)
+	r.Use(middleware.Metrics(middleware.MetricsOptions{AllowSetting: true}))
+	r.GET("/test-metrics/:uuid", func(c *gin.Context) {
+		c.Status(http.StatusAccepted)
+	})
+
+	var err error
+	c.Request, err = http.NewRequest(http.MethodGet, "/test-metrics/a", nil)
+	if err != nil {
+		t.Errorf("this is the error: %v\n", err)
+	}
+	r.ServeHTTP(w, c.Request)
+
+	scraped := httptest.NewRecorder()
+	metrics.Handler("", "").ServeHTTP(scraped, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := ioutil.ReadAll(scraped.Body)
+	assert.Contains(t, string(body), `http_requests_total{method="GET",route="/test-metrics/:uuid",status="202"} 1`)
+}
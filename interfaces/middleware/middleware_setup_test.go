@@ -67,7 +67,8 @@ func Setup() *Dependencies {
 
 	authBasic := authorization.NewBasicAuth(dbService.User)
 	authJWT := authorization.NewJWTAuth(conf.KeyConfig, redisService.Client)
-	authGateway := authorization.NewAuthGateway(authBasic, authJWT, dbService.User, dbService.Role)
+	authOauth := authorization.NewOauthAuth(conf.KeyConfig, redisService.Client)
+	authGateway := authorization.NewAuthGateway(authBasic, authJWT, authOauth, dbService.User, dbService.Role)
 	authToken := authorization.NewToken(conf.KeyConfig, redisService.Client)
 
 	return &Dependencies{
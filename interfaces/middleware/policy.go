@@ -4,7 +4,7 @@ import (
 	"go-rest-skeleton/domain/entity"
 	"go-rest-skeleton/domain/repository"
 	"go-rest-skeleton/infrastructure/message/exception"
-	"net/http"
+	"go-rest-skeleton/pkg/response"
 
 	"github.com/gin-gonic/gin"
 )
@@ -37,7 +37,7 @@ func (p *Policy) Can(action string, c *gin.Context) bool {
 	UUID, exists := c.Get("UUID")
 	if !exists {
 		c.Set("ErrorTracingCode", exception.ErrorCodeITMIPO001)
-		_ = c.AbortWithError(http.StatusUnauthorized, exception.ErrorTextUnauthorized)
+		response.Abort(c, exception.ErrorTextUnauthorized)
 		return false
 	}
 
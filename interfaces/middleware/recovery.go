@@ -2,7 +2,7 @@ package middleware
 
 import (
 	"go-rest-skeleton/infrastructure/message/exception"
-	"net/http"
+	"go-rest-skeleton/pkg/response"
 
 	"github.com/rollbar/rollbar-go"
 
@@ -17,7 +17,7 @@ func Recovery() gin.HandlerFunc {
 				rollbar.Critical(err)
 				rollbar.Wait()
 
-				_ = c.AbortWithError(http.StatusInternalServerError, exception.ErrorTextAnErrorOccurred)
+				response.Abort(c, exception.ErrorTextAnErrorOccurred)
 				return
 			}
 		}()
@@ -25,7 +25,7 @@ func TestRecovery_InternalServerError(t *testing.T) {
 		DefaultTimezone: conf.AppTimezone,
 	}
 
-	expectedResponse := "{\"code\":500,\"data\":null,\"message\":\"An error occurred\"}"
+	expectedResponse := "{\"code\":500,\"data\":null,\"message\":\"An error occurred\",\"error_code\":\"EXCOMM003\"}"
 	var actualResponse string
 
 	gin.SetMode(gin.TestMode)
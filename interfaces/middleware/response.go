@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"go-rest-skeleton/infrastructure/message/exception"
 	"go-rest-skeleton/pkg/response"
 
 	"github.com/gin-gonic/gin"
@@ -9,6 +10,18 @@ import (
 // DefaultGenericError is to define default generic error for production environment.
 const DefaultGenericError = `an_error_occurred`
 
+// Collections of error response format.
+const (
+	// ErrorFormatDefault writes error as code, data, message, args and error_code.
+	ErrorFormatDefault = "default"
+
+	// ErrorFormatProblem writes error as problem details of RFC 7807, such as application/problem+json.
+	ErrorFormatProblem = "problem"
+
+	// DefaultProblemTypeURL is the base of problem type URIs when none is configured.
+	DefaultProblemTypeURL = "/problems"
+)
+
 // ResponseOptions is a struct to store options for error response.
 type ResponseOptions struct {
 	Environment     string
@@ -16,6 +29,8 @@ type ResponseOptions struct {
 	DefaultLanguage string
 	DefaultTimezone string
 	GenericError    string
+	ErrorFormat     string
+	ProblemTypeURL  string
 	LogFunc         func(err string, code int, messages map[string]interface{})
 }
 
@@ -26,13 +41,14 @@ func NewResponse(o ResponseOptions) *ResponseOptions {
 		DefaultLanguage: o.DefaultLanguage,
 		DefaultTimezone: o.DefaultTimezone,
 		GenericError:    DefaultGenericError,
+		ErrorFormat:     o.ErrorFormat,
+		ProblemTypeURL:  o.ProblemTypeURL,
 	}
 }
 
-// Handler will handle any error response.
+// Handler will handle any error response, it is written in the format accepted by the request.
 func (r *ResponseOptions) Handler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.Header("Content-Type", "application/json; charset: utf-8")
 		c.Next()
 
 		if c.Errors.Last() == nil {
@@ -42,13 +58,23 @@ func (r *ResponseOptions) Handler() gin.HandlerFunc {
 		err := c.Errors.Last().Err
 		c.Errors = c.Errors[:0]
 		message := err.Error()
+		if code := exception.Code(err); code != "" && c.GetString("errorTracingCode") == "" {
+			c.Set("errorTracingCode", code)
+		}
 
 		if r.Environment == "production" && c.Writer.Status() == 500 {
 			message = r.GenericError
-			response.NewError(c, message).JSON()
+		}
+
+		if r.ErrorFormat == ErrorFormatProblem {
+			problemTypeURL := r.ProblemTypeURL
+			if problemTypeURL == "" {
+				problemTypeURL = DefaultProblemTypeURL
+			}
+			response.NewError(c, message).RenderProblem(problemTypeURL)
 			return
 		}
 
-		response.NewError(c, message).JSON()
+		response.NewError(c, message).Render()
 	}
 }
@@ -20,7 +20,7 @@ func TestResponseOptions_Handler(t *testing.T) {
 		DefaultTimezone: conf.AppTimezone,
 	}
 
-	expectedResponse := "{\"code\":500,\"data\":null,\"message\":\"Internal server error\"}"
+	expectedResponse := "{\"code\":500,\"data\":null,\"message\":\"Internal server error\",\"error_code\":\"EXCOMM002\"}"
 	var actualResponse string
 
 	gin.SetMode(gin.TestMode)
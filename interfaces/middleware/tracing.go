@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"go-rest-skeleton/infrastructure/tracing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/semconv/v1.17.0/httpconv"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingOptions is a struct to store option of Tracing.
+type TracingOptions struct {
+	AllowSetting bool
+	ServiceName  string
+}
+
+// Tracing is a middleware function uses to start a span per request, named by the route template.
+// The span continues the trace of the caller when the request carries a trace context.
+// The span records X-Request-Id of the request, and the trace ID is injected to X-Trace-Id of response's header.
+func Tracing(options TracingOptions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !options.AllowSetting {
+			c.Next()
+			return
+		}
+
+		route := c.FullPath()
+		name := "HTTP " + c.Request.Method
+		if route != "" {
+			name += " " + route
+		}
+
+		ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		ctx, span := tracing.Tracer().Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(httpconv.ServerRequest(options.ServiceName, c.Request)...),
+			trace.WithAttributes(
+				semconv.HTTPRoute(route),
+				attribute.String("http.request_id", c.Writer.Header().Get("X-Request-Id")),
+			),
+		)
+		defer span.End()
+
+		if traceID := tracing.TraceID(ctx); traceID != "" {
+			c.Writer.Header().Set("X-Trace-Id", traceID)
+		}
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(semconv.HTTPStatusCode(status))
+		span.SetStatus(httpconv.ServerStatus(status))
+	}
+}
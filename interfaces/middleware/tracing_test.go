@@ -0,0 +1,46 @@
+package middleware_test
+
+import (
+	"bytes"
+	"go-rest-skeleton/config"
+	"go-rest-skeleton/infrastructure/tracing"
+	"go-rest-skeleton/interfaces/middleware"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestTracing(t *testing.T) {
+	buf := &bytes.Buffer{}
+	provider, err := tracing.NewStdoutProvider(config.TracingConfig{TracingSampleRatio: 1}, buf)
+	assert.NoError(t, err)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, r := gin.CreateTestContext(w)
+	r.Use(middleware.SetRequestID(middleware.RequestIDOptions{AllowSetting: true}))
+	r.Use(middleware.Tracing(middleware.TracingOptions{AllowSetting: true}))
+	r.GET("/test-tracing/:uuid", func(c *gin.Context) {
+		assert.NotEmpty(t, tracing.TraceID(c.Request.Context()))
+		c.Status(http.StatusOK)
+	})
+
+	c.Request, err = http.NewRequest(http.MethodGet, "/test-tracing/a", nil)
+	if err != nil {
+		t.Errorf("this is the error: %v\n", err)
+	}
+	c.Request.Header.Set("Set-Request-Id", "request-a")
+	c.Request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(w, c.Request)
+
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", w.Header().Get("X-Trace-Id"))
+	assert.Contains(t, buf.String(), `"Name":"HTTP GET /test-tracing/:uuid"`)
+	assert.Contains(t, buf.String(), `request-a`)
+}
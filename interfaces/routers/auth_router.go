@@ -1,6 +1,7 @@
 package routers
 
 import (
+	"go-rest-skeleton/application"
 	"go-rest-skeleton/interfaces/handler"
 	"go-rest-skeleton/interfaces/middleware"
 	"go-rest-skeleton/interfaces/service"
@@ -13,7 +14,8 @@ func authRoutes(e *gin.Engine, r *Router, rg *RouterAuthGateway) {
 		service.NewAuthService(r.dbService.User, r.dbService.UserForgotPassword),
 		r.redisService.Auth,
 		rg.authToken,
-		r.notificationService.Notification)
+		r.notificationService.Notification,
+		application.NewQueueApp(r.queueService.Queue))
 
 	v1 := e.Group("/api/v1/external")
 
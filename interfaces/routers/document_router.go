@@ -0,0 +1,20 @@
+package routers
+
+import (
+	"go-rest-skeleton/application"
+	"go-rest-skeleton/interfaces/middleware"
+
+	documentV1Point00 "go-rest-skeleton/interfaces/handler/v1.0/document"
+
+	"github.com/gin-gonic/gin"
+)
+
+func documentRoutes(e *gin.Engine, r *Router, rg *RouterAuthGateway) {
+	documentV1 := documentV1Point00.NewDocuments(application.NewDocumentApp(r.dbService.Document, r.dbService))
+
+	guard := middleware.Guard(rg.authGateway)
+
+	v1 := e.Group("/api/v1/external")
+
+	v1.POST("/documents/:uuid/approve", guard.Authenticate(), guard.Authorize("document_approve"), documentV1.ApproveDocument)
+}
@@ -0,0 +1,25 @@
+package routers
+
+import (
+	"go-rest-skeleton/application"
+	"go-rest-skeleton/interfaces/middleware"
+
+	fileV1Point00 "go-rest-skeleton/interfaces/handler/v1.0/file"
+
+	"github.com/gin-gonic/gin"
+)
+
+func fileRoutes(e *gin.Engine, r *Router, rg *RouterAuthGateway) {
+	fileV1 := fileV1Point00.NewFiles(
+		application.NewStorageFileApp(r.dbService.StorageFile),
+		r.storageService.Storage,
+	)
+
+	guard := middleware.Guard(rg.authGateway)
+
+	v1 := e.Group("/api/v1/external")
+
+	v1.GET("/files", guard.Authenticate(), fileV1.GetFiles)
+	v1.GET("/files/:uuid", guard.Authenticate(), fileV1.GetFile)
+	v1.DELETE("/files/:uuid", guard.Authenticate(), fileV1.DeleteFile)
+}
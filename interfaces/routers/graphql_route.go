@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go-rest-skeleton/graph"
 	"go-rest-skeleton/graph/resolver"
+	"go-rest-skeleton/infrastructure/tracing"
 	"go-rest-skeleton/interfaces/middleware"
 	"go-rest-skeleton/pkg/response"
 	"log"
@@ -29,6 +30,7 @@ func graphqlHandler(r *Router) gin.HandlerFunc {
 		Resolvers: &graph.Resolver{DBServices: r.dbService},
 	}))
 
+	h.Use(tracing.GraphQL{})
 	h.SetErrorPresenter(func(ctx context.Context, e error) *gqlerror.Error {
 		c, err := ginContextFromContext(ctx)
 		if err != nil {
@@ -0,0 +1,18 @@
+package routers
+
+import (
+	"go-rest-skeleton/interfaces/handler"
+	"go-rest-skeleton/interfaces/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func healthRoutes(e *gin.Engine, r *Router, rg *RouterAuthGateway) {
+	guard := middleware.Guard(rg.authGateway)
+	healthHandler := handler.NewHealthHandler(r.healthService.Registry, func(c *gin.Context) bool {
+		return guard.Allows("health_detail", c)
+	})
+
+	e.GET("/healthz", healthHandler.Liveness)
+	e.GET("/readyz", healthHandler.Readiness)
+}
@@ -0,0 +1,22 @@
+package routers
+
+import (
+	"go-rest-skeleton/infrastructure/metrics"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog/log"
+)
+
+func metricsRoutes(e *gin.Engine, r *Router) {
+	if !r.conf.MetricsEnabled || r.conf.MetricsListen != "" {
+		return
+	}
+
+	// Metrics served on the app port are never exposed without credentials.
+	if r.conf.MetricsUsername == "" || r.conf.MetricsPassword == "" {
+		log.Warn().Msg("metrics are not exposed: METRICS_USERNAME and METRICS_PASSWORD are required without METRICS_LISTEN")
+		return
+	}
+
+	e.GET("/metrics", gin.WrapH(metrics.Handler(r.conf.MetricsUsername, r.conf.MetricsPassword)))
+}
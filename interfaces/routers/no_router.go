@@ -2,14 +2,13 @@ package routers
 
 import (
 	"go-rest-skeleton/infrastructure/message/exception"
-	"net/http"
+	"go-rest-skeleton/pkg/response"
 
 	"github.com/gin-gonic/gin"
 )
 
 func noRoutes(e *gin.Engine) {
 	e.NoRoute(func(c *gin.Context) {
-		err := exception.ErrorTextNotFound
-		_ = c.AbortWithError(http.StatusNotFound, err)
+		response.Abort(c, exception.ErrorTextNotFound)
 	})
 }
@@ -0,0 +1,27 @@
+package routers
+
+import (
+	"go-rest-skeleton/application"
+	"go-rest-skeleton/interfaces/middleware"
+
+	notificationV1Point00 "go-rest-skeleton/interfaces/handler/v1.0/notification"
+
+	"github.com/gin-gonic/gin"
+)
+
+func notificationRoutes(e *gin.Engine, r *Router, rg *RouterAuthGateway) {
+	notificationV1 := notificationV1Point00.NewNotifications(
+		application.NewUserNotificationApp(r.dbService.UserNotification),
+		r.notificationService.Broadcaster,
+	)
+
+	guard := middleware.Guard(rg.authGateway)
+
+	v1 := e.Group("/api/v1/external")
+
+	v1.GET("/notifications", guard.Authenticate(), notificationV1.GetNotifications)
+	v1.GET("/notifications/stream", guard.Authenticate(), notificationV1.StreamNotifications)
+	v1.PUT("/notifications/:uuid/read", guard.Authenticate(), notificationV1.MarkNotificationRead)
+	v1.PUT("/notifications/:uuid/unread", guard.Authenticate(), notificationV1.MarkNotificationUnread)
+	v1.POST("/notifications/read-all", guard.Authenticate(), notificationV1.MarkAllNotificationsRead)
+}
@@ -62,29 +62,29 @@ func callbackHandler(c *gin.Context) {
 	r := c.Request
 	errParse := r.ParseForm()
 	if errParse != nil {
-		_ = c.AbortWithError(http.StatusInternalServerError, errParse)
+		response.Abort(c, errParse)
 		return
 	}
 
 	state := r.Form.Get("state")
 	if state != "ok" {
-		_ = c.AbortWithError(http.StatusBadRequest, exception.ErrorTextAnErrorOccurred)
+		response.Abort(c, exception.WithStatus(exception.ErrorTextAnErrorOccurred, http.StatusBadRequest))
 		return
 	}
 	code := r.Form.Get("code")
 	if code == "" {
-		_ = c.AbortWithError(http.StatusBadRequest, exception.ErrorTextAnErrorOccurred)
+		response.Abort(c, exception.WithStatus(exception.ErrorTextAnErrorOccurred, http.StatusBadRequest))
 		return
 	}
 	token, err := oauthConfig.Exchange(context.Background(), code)
 	if err != nil {
-		_ = c.AbortWithError(http.StatusBadRequest, exception.ErrorTextAnErrorOccurred)
+		response.Abort(c, exception.WithStatus(exception.ErrorTextAnErrorOccurred, http.StatusBadRequest))
 		return
 	}
 
 	globalToken = token
 
-	response.NewSuccess(c, token, success.AuthSuccessfullyLogin).JSON()
+	response.NewSuccess(c, token, success.AuthSuccessfullyLogin).Render()
 }
 
 func refreshHandler(c *gin.Context) {
@@ -104,5 +104,5 @@ func refreshHandler(c *gin.Context) {
 
 	globalToken = token
 
-	response.NewSuccess(c, token, success.AuthSuccessfullyRefreshToken).JSON()
+	response.NewSuccess(c, token, success.AuthSuccessfullyRefreshToken).Render()
 }
@@ -1,6 +1,7 @@
 package routers
 
 import (
+	"go-rest-skeleton/application"
 	"go-rest-skeleton/interfaces/middleware"
 
 	roleV1Point00 "go-rest-skeleton/interfaces/handler/v1.0/role"
@@ -9,15 +10,20 @@ import (
 )
 
 func roleRoutes(e *gin.Engine, r *Router, rg *RouterAuthGateway) {
-	roleV1 := roleV1Point00.NewRoles(r.dbService.Role, r.redisService.Auth, rg.authToken)
+	roleV1 := roleV1Point00.NewRoles(application.NewRoleApp(r.dbService.Role, r.dbService), r.redisService.Auth, rg.authToken)
 
 	guard := middleware.Guard(rg.authGateway)
+	idempotent := r.idempotent()
 
 	v1 := e.Group("/api/v1/external")
 
 	v1.GET("/roles", guard.Authenticate(), guard.Authorize("role_read"), roleV1.GetRoles)
-	v1.POST("/roles", guard.Authenticate(), guard.Authorize("role_create"), roleV1.SaveRole)
+	v1.POST("/roles", guard.Authenticate(), guard.Authorize("role_create"), idempotent, roleV1.SaveRole)
+	v1.POST("/roles/bulk", guard.Authenticate(), guard.Authorize("role_bulk_save"), idempotent, roleV1.BulkSaveRoles)
+	v1.DELETE("/roles", guard.Authenticate(), guard.Authorize("role_bulk_delete"), roleV1.BulkDeleteRoles)
 	v1.GET("/roles/:uuid", guard.Authenticate(), guard.Authorize("role_detail"), roleV1.GetRole)
-	v1.PUT("/roles/:uuid", guard.Authenticate(), guard.Authorize("role_update"), roleV1.UpdateRole)
+	v1.PUT("/roles/:uuid", guard.Authenticate(), guard.Authorize("role_update"), idempotent, roleV1.UpdateRole)
 	v1.DELETE("/roles/:uuid", guard.Authenticate(), guard.Authorize("role_delete"), roleV1.DeleteRole)
+	v1.POST("/roles/:uuid/restore", guard.Authenticate(), guard.Authorize("role_restore"), roleV1.RestoreRole)
+	v1.DELETE("/roles/:uuid/force", guard.Authenticate(), guard.Authorize("role_force_delete"), roleV1.ForceDeleteRole)
 }
@@ -3,9 +3,11 @@ package routers
 import (
 	"go-rest-skeleton/config"
 	"go-rest-skeleton/infrastructure/authorization"
+	"go-rest-skeleton/infrastructure/idempotency"
 	"go-rest-skeleton/infrastructure/persistence"
 	"go-rest-skeleton/interfaces/middleware"
 	"os"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -20,6 +22,9 @@ type Router struct {
 	redisService        *persistence.RedisService
 	storageService      *persistence.StorageService
 	notificationService *persistence.NotificationService
+	queueService        *persistence.QueueService
+	webhookService      *persistence.WebhookService
+	healthService       *persistence.HealthService
 }
 
 // RouterAuthGateway is a struct contains needed dependencies to init Routes.
@@ -35,13 +40,19 @@ func NewRouter(
 	dbService *persistence.Repositories,
 	redisService *persistence.RedisService,
 	storageService *persistence.StorageService,
-	notificationService *persistence.NotificationService) *Router {
+	notificationService *persistence.NotificationService,
+	queueService *persistence.QueueService,
+	webhookService *persistence.WebhookService,
+	healthService *persistence.HealthService) *Router {
 	return &Router{
 		conf:                conf,
 		dbService:           dbService,
 		redisService:        redisService,
 		storageService:      storageService,
 		notificationService: notificationService,
+		queueService:        queueService,
+		webhookService:      webhookService,
+		healthService:       healthService,
 	}
 }
 
@@ -74,6 +85,8 @@ func (r *Router) Init() *gin.Engine {
 		DebugMode:       r.conf.DebugMode,
 		DefaultLanguage: r.conf.AppLanguage,
 		DefaultTimezone: r.conf.AppTimezone,
+		ErrorFormat:     r.conf.ErrorFormat,
+		ProblemTypeURL:  r.conf.ProblemTypeURL,
 	}
 
 	// Init authorization
@@ -88,25 +101,48 @@ func (r *Router) Init() *gin.Engine {
 		gin.SetMode(gin.ReleaseMode)
 	}
 	e := gin.Default()
+	e.Use(middleware.Metrics(middleware.MetricsOptions{AllowSetting: r.conf.MetricsEnabled}))
 	e.Use(middleware.NewResponse(optResponse).Handler())
 	e.Use(middleware.SetRequestID(middleware.RequestIDOptions{AllowSetting: r.conf.EnableRequestID}))
+	e.Use(middleware.Tracing(middleware.TracingOptions{
+		AllowSetting: r.conf.TracingExporter != "",
+		ServiceName:  r.conf.TracingServiceName,
+	}))
 	e.Use(middleware.CORS(middleware.CORSOptions{AllowSetting: r.conf.EnableCors}))
 	e.Use(middleware.SetLogger(middleware.LoggerOptions{AllowSetting: r.conf.EnableLogger}))
 	e.Use(middleware.APIVersion())
+	e.Use(middleware.Language(middleware.LanguageOptions{Preference: r.dbService.UserPreference.GetUserLanguage}))
 	e.Use(middleware.Recovery())
 
 	// Init Routes
 	rg := NewRouterAuthGateway(authGateway, authToken, authOauth)
 	authRoutes(e, r, rg)
 	devRoutes(e, r)
+	documentRoutes(e, r, rg)
+	fileRoutes(e, r, rg)
+	healthRoutes(e, r, rg)
+	metricsRoutes(e, r)
 	noRoutes(e)
+	notificationRoutes(e, r, rg)
 	oauthServerRoutes(e, r, rg)
 	oauthClientRoutes(e, r, rg)
 	roleRoutes(e, r, rg)
+	storageRoutes(e, r)
 	tourRoutes(e, r, rg)
+	uploadRoutes(e, r, rg)
 	userRoutes(e, r, rg)
+	webhookRoutes(e, r, rg)
 	welcomeRoutes(e)
 	graphqlRoute(e, r, rg)
 
 	return e
 }
+
+// idempotent returns the middleware replaying responses of requests sent again with the same Idempotency-Key.
+func (r *Router) idempotent() gin.HandlerFunc {
+	return middleware.Idempotency(middleware.IdempotencyOptions{
+		Store:   idempotency.NewRedisStore(r.redisService.Client),
+		TTL:     time.Duration(r.conf.IdempotencyTTL) * time.Second,
+		LockTTL: time.Duration(r.conf.IdempotencyLockTTL) * time.Second,
+	})
+}
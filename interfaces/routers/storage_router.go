@@ -0,0 +1,20 @@
+package routers
+
+import (
+	"go-rest-skeleton/infrastructure/storage"
+	"go-rest-skeleton/interfaces/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+func storageRoutes(e *gin.Engine, r *Router) {
+	// Files of other drivers are served by their own signed URL.
+	if r.storageService.Local == nil {
+		return
+	}
+
+	storageHandler := handler.NewStorageHandler(r.storageService.Local)
+
+	e.GET(storage.LocalDownloadPath+"/*filepath", storageHandler.Download)
+	e.PUT(storage.LocalDownloadPath+"/*filepath", storageHandler.Upload)
+}
@@ -3,7 +3,7 @@ package routers
 import (
 	"go-rest-skeleton/interfaces/middleware"
 
-	TourV1Point00 "go-rest-skeleton/interfaces/handler/v1.0/Tour"
+	TourV1Point00 "go-rest-skeleton/interfaces/handler/v1.0/tour"
 
 	"github.com/gin-gonic/gin"
 )
@@ -12,12 +12,17 @@ func tourRoutes(e *gin.Engine, r *Router, rg *RouterAuthGateway) {
 	TourV1 := TourV1Point00.NewTours(r.dbService.Tour, r.redisService.Auth, rg.authToken)
 
 	guard := middleware.Guard(rg.authGateway)
+	idempotent := r.idempotent()
 
 	v1 := e.Group("/api/v1/external")
 
 	v1.GET("/tours", guard.Authenticate(), guard.Authorize("tour_read"), TourV1.GetTours)
-	v1.POST("/tours", guard.Authenticate(), guard.Authorize("tour_create"), TourV1.SaveTour)
+	v1.POST("/tours", guard.Authenticate(), guard.Authorize("tour_create"), idempotent, TourV1.SaveTour)
+	v1.POST("/tours/bulk", guard.Authenticate(), guard.Authorize("tour_bulk_save"), idempotent, TourV1.BulkSaveTours)
+	v1.DELETE("/tours", guard.Authenticate(), guard.Authorize("tour_bulk_delete"), TourV1.BulkDeleteTours)
 	v1.GET("/tours/:uuid", guard.Authenticate(), guard.Authorize("tour_detail"), TourV1.GetTour)
-	v1.PUT("/tours/:uuid", guard.Authenticate(), guard.Authorize("tour_update"), TourV1.UpdateTour)
+	v1.PUT("/tours/:uuid", guard.Authenticate(), guard.Authorize("tour_update"), idempotent, TourV1.UpdateTour)
 	v1.DELETE("/tours/:uuid", guard.Authenticate(), guard.Authorize("tour_delete"), TourV1.DeleteTour)
+	v1.POST("/tours/:uuid/restore", guard.Authenticate(), guard.Authorize("tour_restore"), TourV1.RestoreTour)
+	v1.DELETE("/tours/:uuid/force", guard.Authenticate(), guard.Authorize("tour_force_delete"), TourV1.ForceDeleteTour)
 }
@@ -0,0 +1,31 @@
+package routers
+
+import (
+	"go-rest-skeleton/application"
+	"go-rest-skeleton/interfaces/middleware"
+
+	uploadV1Point00 "go-rest-skeleton/interfaces/handler/v1.0/upload"
+
+	"github.com/gin-gonic/gin"
+)
+
+func uploadRoutes(e *gin.Engine, r *Router, rg *RouterAuthGateway) {
+	uploadV1 := uploadV1Point00.NewUploads(
+		r.storageService.Storage, r.storageService.Storage, application.NewQueueApp(r.queueService.Queue))
+
+	guard := middleware.Guard(rg.authGateway)
+
+	v1 := e.Group("/api/v1/external")
+
+	v1.POST("/uploads/direct", guard.Authenticate(), uploadV1.CreateDirectUpload)
+	v1.POST("/uploads/direct/:uuid/confirm", guard.Authenticate(), uploadV1.ConfirmDirectUpload)
+
+	// Parts of resumable uploads are kept on local disk, every request of an upload must reach the same instance.
+	if !r.conf.StorageResumableUpload {
+		return
+	}
+	v1.POST("/uploads/resumable", guard.Authenticate(), uploadV1.CreateResumableUpload)
+	v1.HEAD("/uploads/resumable/:uuid", guard.Authenticate(), uploadV1.GetResumableUpload)
+	v1.PATCH("/uploads/resumable/:uuid", guard.Authenticate(), uploadV1.WriteResumableUpload)
+	v1.DELETE("/uploads/resumable/:uuid", guard.Authenticate(), uploadV1.DeleteResumableUpload)
+}
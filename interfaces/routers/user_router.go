@@ -1,6 +1,7 @@
 package routers
 
 import (
+	"go-rest-skeleton/application"
 	"go-rest-skeleton/interfaces/handler"
 	"go-rest-skeleton/interfaces/middleware"
 
@@ -10,19 +11,24 @@ import (
 )
 
 func userRoutes(e *gin.Engine, r *Router, rg *RouterAuthGateway) {
-	userV1 := userV1Point00.NewUsers(r.dbService.User, r.storageService.Storage)
+	userV1 := userV1Point00.NewUsers(application.NewUserApp(r.dbService.User, r.dbService), r.storageService.Storage, application.NewQueueApp(r.queueService.Queue))
 	userPreference := handler.NewPreference(r.dbService.UserPreference, r.redisService.Auth, rg.authToken)
 
 	guard := middleware.Guard(rg.authGateway)
+	idempotent := r.idempotent()
 
 	v1 := e.Group("/api/v1/external")
 
 	v1.GET("/users", guard.Authenticate(), guard.Authorize("user_read"), userV1.GetUsers)
-	v1.POST("/users", guard.Authenticate(), guard.Authorize("user_create"), userV1.SaveUser)
+	v1.POST("/users", guard.Authenticate(), guard.Authorize("user_create"), idempotent, userV1.SaveUser)
+	v1.POST("/users/bulk", guard.Authenticate(), guard.Authorize("user_bulk_save"), idempotent, userV1.BulkSaveUsers)
+	v1.DELETE("/users", guard.Authenticate(), guard.Authorize("user_bulk_delete"), userV1.BulkDeleteUsers)
 	v1.GET("/users/:uuid", guard.Authenticate(), guard.Authorize("user_detail"), userV1.GetUser)
-	v1.PUT("/users/:uuid", guard.Authenticate(), guard.Authorize("user_update"), userV1.UpdateUser)
+	v1.PUT("/users/:uuid", guard.Authenticate(), guard.Authorize("user_update"), idempotent, userV1.UpdateUser)
 	v1.PUT("/users/:uuid/avatar", guard.Authenticate(), guard.Authorize("user_update"), userV1.UpdateAvatar)
 	v1.DELETE("/users/:uuid", guard.Authenticate(), guard.Authorize("user_delete"), userV1.DeleteUser)
+	v1.POST("/users/:uuid/restore", guard.Authenticate(), guard.Authorize("user_restore"), userV1.RestoreUser)
+	v1.DELETE("/users/:uuid/force", guard.Authenticate(), guard.Authorize("user_force_delete"), userV1.ForceDeleteUser)
 
 	v1.GET("/preference", guard.Authenticate(), userPreference.GerPreference)
 	v1.PUT("/preference", guard.Authenticate(), userPreference.UpdatePreference)
@@ -0,0 +1,37 @@
+package routers
+
+import (
+	"go-rest-skeleton/application"
+	"go-rest-skeleton/interfaces/middleware"
+
+	webhookV1Point00 "go-rest-skeleton/interfaces/handler/v1.0/webhook"
+
+	"github.com/gin-gonic/gin"
+)
+
+func webhookRoutes(e *gin.Engine, r *Router, rg *RouterAuthGateway) {
+	webhookV1 := webhookV1Point00.NewWebhooks(application.NewWebhookApp(
+		r.dbService.Application,
+		r.dbService.WebhookSubscription,
+		r.dbService.WebhookDelivery,
+		r.webhookService.Deliverer,
+	))
+
+	guard := middleware.Guard(rg.authGateway)
+
+	v1 := e.Group("/api/v1/external")
+
+	v1.GET("/webhooks", guard.Authenticate(), guard.Authorize("webhook_read"), webhookV1.GetWebhooks)
+	v1.POST("/webhooks", guard.Authenticate(), guard.Authorize("webhook_create"), webhookV1.SaveWebhook)
+	v1.GET("/webhooks/:uuid", guard.Authenticate(), guard.Authorize("webhook_detail"), webhookV1.GetWebhook)
+	v1.PUT("/webhooks/:uuid", guard.Authenticate(), guard.Authorize("webhook_update"), webhookV1.UpdateWebhook)
+	v1.POST("/webhooks/:uuid/enable", guard.Authenticate(), guard.Authorize("webhook_update"), webhookV1.EnableWebhook)
+	v1.DELETE("/webhooks/:uuid", guard.Authenticate(), guard.Authorize("webhook_delete"), webhookV1.DeleteWebhook)
+
+	v1.GET("/webhooks/:uuid/deliveries",
+		guard.Authenticate(), guard.Authorize("webhook_detail"), webhookV1.GetWebhookDeliveries)
+	v1.GET("/webhooks/:uuid/deliveries/:delivery_uuid",
+		guard.Authenticate(), guard.Authorize("webhook_detail"), webhookV1.GetWebhookDelivery)
+	v1.POST("/webhooks/:uuid/deliveries/:delivery_uuid/replay",
+		guard.Authenticate(), guard.Authorize("webhook_replay"), webhookV1.ReplayWebhookDelivery)
+}
@@ -3,20 +3,23 @@ package main
 import (
 	"go-rest-skeleton/config"
 	_ "go-rest-skeleton/docs"
+	"go-rest-skeleton/infrastructure/metrics"
 	"go-rest-skeleton/infrastructure/persistence"
+	"go-rest-skeleton/infrastructure/tracing"
 	"go-rest-skeleton/interfaces/cmd"
 	"go-rest-skeleton/interfaces/routers"
+	"go-rest-skeleton/pkg/translation"
 	"log"
+	"net/http"
 	"os"
 	"time"
 
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/swaggo/gin-swagger/swaggerFiles"
 
+	"github.com/go-redis/redis/extra/redisotel/v8"
 	"github.com/rollbar/rollbar-go"
 
-	"github.com/urfave/cli/v2"
-
 	"github.com/joho/godotenv"
 )
 
@@ -57,11 +60,30 @@ func main() {
 		log.Println("no .env file provided")
 	}
 
-	// Init Config
-	conf := config.New()
+	// Init Config, refusing invalid values and insecure defaults in production
+	configOptions, errConfigFlags := config.ParseFlags(os.Args[1:])
+	if errConfigFlags != nil {
+		log.Fatal(errConfigFlags)
+	}
+	conf, errConfig := config.Load(configOptions)
+	if errConfig != nil {
+		log.Fatal(errConfig)
+	}
 	timeLoc, _ := time.LoadLocation(conf.AppTimezone)
 	time.Local = timeLoc
 
+	// Init translation, messages of every language are loaded once
+	if errTranslation := translation.Init(translation.Options{DefaultLanguage: conf.AppLanguage}); errTranslation != nil {
+		log.Fatal(errTranslation)
+	}
+
+	// Init tracing
+	shutdownTracing, errTracing := tracing.Init(conf.TracingConfig)
+	if errTracing != nil {
+		panic(errTracing)
+	}
+	defer shutdownTracing()
+
 	// Connect to DB
 	dbService, errDBService := persistence.NewDBService(conf.DBConfig)
 	if errDBService != nil {
@@ -80,11 +102,49 @@ func main() {
 		panic(errRedis)
 	}
 
+	// Read repositories enabled in config through the cache
+	dbService.EnableCache(conf.CacheConfig, redisService.Client)
+
 	// Connect to storage services
-	storageService, _ := persistence.NewStorageService(conf.MinioConfig, dbService.DB)
+	storageService, errStorage := persistence.NewStorageService(conf, dbService.DB)
+	if errStorage != nil {
+		panic(errStorage)
+	}
+	storageService.Storage.SetCategories(dbService.StorageCategory)
 
 	// Init notification services
-	notificationService, _ := persistence.NewNotificationService(conf)
+	notificationService, _ := persistence.NewNotificationService(conf, dbService, redisService.Client)
+
+	// Init queue services
+	queueService := persistence.NewQueueService(conf.QueueConfig, redisService.Client)
+
+	// Init outbox services
+	outboxService := persistence.NewOutboxService(conf.OutboxConfig, dbService, redisService.Client, queueService.Queue)
+
+	// Init webhook services
+	webhookService := persistence.NewWebhookService(conf.WebhookConfig, dbService)
+
+	// Init health services
+	healthService := persistence.NewHealthService(conf, dbService, redisService, storageService)
+
+	// Trace queries and commands run within traced requests
+	if conf.TracingExporter != "" {
+		if err := dbService.DB.Use(tracing.NewGormPlugin()); err != nil {
+			panic(err)
+		}
+		redisService.Client.AddHook(redisotel.NewTracingHook())
+	}
+
+	// Collect metrics of services
+	if conf.MetricsEnabled {
+		if err := dbService.DB.Use(metrics.NewGormPlugin()); err != nil {
+			panic(err)
+		}
+		metrics.MustRegister(
+			metrics.NewRedisCollector(redisService.Client),
+			metrics.NewQueueCollector(queueService.Queue),
+		)
+	}
 
 	// Init rollbar services
 	rollbar.SetToken(conf.RollbarConfig.Token)
@@ -92,27 +152,49 @@ func main() {
 
 	// Init App
 	app := cmd.NewCli()
-	app.Action = func(c *cli.Context) error {
-		// Init Router
-		router := routers.NewRouter(conf, dbService, redisService, storageService, notificationService).Init()
-
-		// Inject swagger handler on dev environment
-		if conf.AppEnvironment != "production" {
-			router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-		}
 
-		// Run app at defined port
-		appPort := os.Getenv("APP_PORT")
-		if appPort == "" {
-			appPort = "8888"
-		}
-		log.Println(router.Run(":" + appPort))
-		return nil
-	}
+	// Init serve command, the app serves http requests by default
+	serveCommand := cmd.NewServeCommand(conf, &cmd.ServeServices{
+		Router: func() http.Handler {
+			router := routers.NewRouter(
+				conf,
+				dbService,
+				redisService,
+				storageService,
+				notificationService,
+				queueService,
+				webhookService,
+				healthService,
+			).Init()
+
+			// Inject swagger handler on dev environment
+			if conf.AppEnvironment != "production" {
+				router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+			}
+
+			return router
+		},
+		DB:           dbService,
+		Redis:        redisService,
+		Storage:      storageService,
+		Notification: notificationService,
+		Queue:        queueService,
+		Outbox:       outboxService,
+		Webhook:      webhookService,
+	})
+	app.Action = serveCommand.Action
 
 	// Init Cli
-	cliCommands := cmd.NewCommand(dbService)
-	app.Commands = cliCommands
+	cliCommands := cmd.NewCommand(
+		conf,
+		dbService,
+		storageService,
+		notificationService,
+		queueService,
+		outboxService,
+		webhookService,
+	)
+	app.Commands = append(cliCommands, serveCommand)
 	err := app.Run(os.Args)
 	if err != nil {
 		panic(app)
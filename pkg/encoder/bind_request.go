@@ -0,0 +1,39 @@
+package encoder
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// ErrEmptyRequestBody is returned by BindRequest when the request has no body.
+var ErrEmptyRequestBody = errors.New("invalid request")
+
+// BindRequest decodes body of the request by its Content-Type into obj and validates obj by its binding tags.
+// XML and MessagePack are matched by json tags like JSON, form keeps its form tags.
+// Request without Content-Type is decoded as JSON.
+func BindRequest(c *gin.Context, obj interface{}) error {
+	switch c.ContentType() {
+	case binding.MIMEXML, binding.MIMEXML2:
+		if c.Request.Body == nil {
+			return ErrEmptyRequestBody
+		}
+		if err := UnmarshalXML(c.Request.Body, obj); err != nil {
+			return err
+		}
+		return binding.Validator.ValidateStruct(obj)
+	case binding.MIMEMSGPACK, binding.MIMEMSGPACK2:
+		if c.Request.Body == nil {
+			return ErrEmptyRequestBody
+		}
+		if err := UnmarshalMsgPack(c.Request.Body, obj); err != nil {
+			return err
+		}
+		return binding.Validator.ValidateStruct(obj)
+	case binding.MIMEPOSTForm, binding.MIMEMultipartPOSTForm:
+		return c.ShouldBindWith(obj, binding.Form)
+	default:
+		return c.ShouldBindJSON(obj)
+	}
+}
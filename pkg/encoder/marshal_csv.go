@@ -0,0 +1,115 @@
+package encoder
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// ErrCSVNotSlice is returned by MarshalCSV when the data is not a slice.
+var ErrCSVNotSlice = errors.New("csv data must be a slice")
+
+// IsCSVEncodable returns true when the given interface can be encoded by MarshalCSV.
+func IsCSVEncodable(data interface{}) bool {
+	if data == nil {
+		return false
+	}
+	kind := reflect.TypeOf(data).Kind()
+	return kind == reflect.Slice || kind == reflect.Array
+}
+
+// MarshalCSV encodes the given slice as CSV, one row of each item after a header row.
+// Each item is encoded as JSON object first, its keys become the columns in order of their first appearance.
+// Nested object and array are written as JSON, null is written as empty column.
+func MarshalCSV(data interface{}) ([]byte, error) {
+	if !IsCSVEncodable(data) {
+		return nil, ErrCSVNotSlice
+	}
+
+	var columns []string
+	seen := make(map[string]bool)
+	var rows []map[string]string
+	items := reflect.ValueOf(data)
+	for i := 0; i < items.Len(); i++ {
+		keys, row, err := csvRow(items.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			if !seen[key] {
+				seen[key] = true
+				columns = append(columns, key)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := csv.NewWriter(buffer)
+	if err := writer.Write(columns); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for index, column := range columns {
+			record[index] = row[column]
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+
+	return buffer.Bytes(), writer.Error()
+}
+
+// csvRow encodes the item as JSON object and returns its keys in order along with the columns.
+// Item which is not an object is a single column named value.
+func csvRow(item interface{}) ([]string, map[string]string, error) {
+	encoded, err := json.Marshal(item)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(encoded), []byte("{")) {
+		return []string{"value"}, map[string]string{"value": csvColumn(encoded)}, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	if _, err := decoder.Token(); err != nil {
+		return nil, nil, err
+	}
+	var keys []string
+	row := make(map[string]string)
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key := token.(string)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, key)
+		row[key] = csvColumn(value)
+	}
+
+	return keys, row, nil
+}
+
+func csvColumn(value json.RawMessage) string {
+	text := strings.TrimSpace(string(value))
+	switch {
+	case text == "null":
+		return ""
+	case strings.HasPrefix(text, `"`):
+		var decoded string
+		_ = json.Unmarshal(value, &decoded)
+		return decoded
+	default:
+		return text
+	}
+}
@@ -0,0 +1,39 @@
+package encoder_test
+
+import (
+	"go-rest-skeleton/pkg/encoder"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type CSVRow struct {
+	UUID  string      `json:"uuid"`
+	Name  string      `json:"name"`
+	Size  int64       `json:"size"`
+	Roles []string    `json:"roles"`
+	Extra interface{} `json:"extra,omitempty"`
+}
+
+func TestMarshalCSV(t *testing.T) {
+	rows := []CSVRow{
+		{UUID: "1", Name: "Report, final", Size: 10, Roles: []string{"admin"}},
+		{UUID: "2", Name: `Say "hi"`, Size: 20, Extra: "more"},
+	}
+
+	csv, err := encoder.MarshalCSV(rows)
+
+	assert.NoError(t, err)
+	expectedCSV := "uuid,name,size,roles,extra\n" +
+		"1,\"Report, final\",10,\"[\"\"admin\"\"]\",\n" +
+		"2,\"Say \"\"hi\"\"\",20,,more\n"
+	assert.Equal(t, expectedCSV, string(csv))
+}
+
+func TestMarshalCSV_NotSlice(t *testing.T) {
+	_, err := encoder.MarshalCSV(CSVRow{UUID: "1"})
+
+	assert.Equal(t, encoder.ErrCSVNotSlice, err)
+	assert.False(t, encoder.IsCSVEncodable(nil))
+	assert.True(t, encoder.IsCSVEncodable([]interface{}{}))
+}
@@ -0,0 +1,74 @@
+package encoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+
+	"github.com/ugorji/go/codec"
+)
+
+// MarshalMsgPack encodes the given interface as MessagePack.
+// The data is encoded as JSON first, so keys and values are the same as the JSON response.
+func MarshalMsgPack(data interface{}) ([]byte, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	buffer := new(bytes.Buffer)
+	err = codec.NewEncoder(buffer, msgPackHandle()).Encode(msgPackValue(value))
+	return buffer.Bytes(), err
+}
+
+// UnmarshalMsgPack decodes MessagePack into v, it is converted into JSON so v is decoded the same way as a JSON request.
+func UnmarshalMsgPack(r io.Reader, v interface{}) error {
+	var value interface{}
+	if err := codec.NewDecoder(r, msgPackHandle()).Decode(&value); err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(encoded, v)
+}
+
+func msgPackHandle() *codec.MsgpackHandle {
+	handle := &codec.MsgpackHandle{}
+	handle.RawToString = true
+	handle.WriteExt = true
+	handle.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	return handle
+}
+
+// msgPackValue replaces json.Number by integer or float, so numbers are not encoded as strings.
+func msgPackValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = msgPackValue(item)
+		}
+	case []interface{}:
+		for index, item := range v {
+			v[index] = msgPackValue(item)
+		}
+	case json.Number:
+		if integer, err := v.Int64(); err == nil {
+			return integer
+		}
+		float, _ := v.Float64()
+		return float
+	}
+
+	return value
+}
@@ -0,0 +1,27 @@
+package encoder_test
+
+import (
+	"bytes"
+	"go-rest-skeleton/pkg/encoder"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalMsgPack(t *testing.T) {
+	data := JSONString{
+		Code:    200,
+		Data:    map[string]interface{}{"ratio": 0.5, "roles": []string{"admin"}},
+		Message: "OK",
+	}
+
+	encoded, err := encoder.MarshalMsgPack(data)
+	assert.NoError(t, err)
+
+	var decoded JSONString
+	err = encoder.UnmarshalMsgPack(bytes.NewReader(encoded), &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, decoded.Code)
+	assert.Equal(t, "OK", decoded.Message)
+	assert.Equal(t, map[string]interface{}{"ratio": 0.5, "roles": []interface{}{"admin"}}, decoded.Data)
+}
@@ -1 +1,115 @@
 package encoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"sort"
+	"strings"
+)
+
+const (
+	// Name of root element of response.
+	rootElement = "response"
+
+	// Name of element of each slice item.
+	itemElement = "item"
+)
+
+// PrettyXMLWithoutIndent formats the given interface and return xml string without indentation and tab.
+func PrettyXMLWithoutIndent(data interface{}) string {
+	encoded, _ := MarshalXML(data, rootElement, empty)
+	return string(encoded)
+}
+
+// PrettyXMLWithIndent formats the given interface and return xml string with indentation and tab.
+func PrettyXMLWithIndent(data interface{}) string {
+	encoded, _ := MarshalXML(data, rootElement, tab)
+	return string(encoded)
+}
+
+// MarshalXML encodes the given interface as xml element named root.
+// The data is encoded as JSON first, so element names follow json tags and maps are supported.
+// Object keys become child elements in sorted order, slice items become item elements.
+func MarshalXML(data interface{}, root string, indent string) ([]byte, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	buffer := new(bytes.Buffer)
+	buffer.WriteString(xml.Header)
+	xmlEncoder := xml.NewEncoder(buffer)
+	xmlEncoder.Indent(empty, indent)
+	if err := encodeXMLValue(xmlEncoder, xmlName(root), value); err != nil {
+		return nil, err
+	}
+	if err := xmlEncoder.Flush(); err != nil {
+		return nil, err
+	}
+	buffer.WriteString("\n")
+
+	return buffer.Bytes(), nil
+}
+
+func encodeXMLValue(e *xml.Encoder, name string, value interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if err := encodeXMLValue(e, xmlName(key), v[key]); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if err := encodeXMLValue(e, itemElement, item); err != nil {
+				return err
+			}
+		}
+	case nil:
+	case string:
+		if err := e.EncodeToken(xml.CharData(v)); err != nil {
+			return err
+		}
+	default:
+		encoded, _ := json.Marshal(v)
+		if err := e.EncodeToken(xml.CharData(encoded)); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// xmlName replaces characters not allowed in xml element name, such as brackets of equal[name], by underscore.
+func xmlName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || name[0] == '-' || name[0] == '.' {
+		name = "_" + name
+	}
+
+	return name
+}
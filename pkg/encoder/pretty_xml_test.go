@@ -1 +1,33 @@
 package encoder_test
+
+import (
+	"go-rest-skeleton/pkg/encoder"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrettyXMLWithoutIndent(t *testing.T) {
+	data := JSONString{
+		Code:    200,
+		Data:    map[string]interface{}{"roles": []string{"admin", "user"}, "equal[name]": nil},
+		Message: "OK & Done",
+	}
+	xml := encoder.PrettyXMLWithoutIndent(data)
+	expectedXML := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		"<response><code>200</code><data><equal_name_></equal_name_><roles><item>admin</item><item>user</item></roles></data>" +
+		"<message>OK &amp; Done</message></response>\n"
+	assert.Equal(t, expectedXML, xml)
+}
+
+func TestPrettyXMLWithIndent(t *testing.T) {
+	data := JSONString{
+		Code:    200,
+		Data:    nil,
+		Message: "OK",
+	}
+	xml := encoder.PrettyXMLWithIndent(data)
+	expectedXML := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		"<response>\n\t<code>200</code>\n\t<data></data>\n\t<message>OK</message>\n</response>\n"
+	assert.Equal(t, expectedXML, xml)
+}
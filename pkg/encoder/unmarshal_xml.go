@@ -1 +1,229 @@
 package encoder
+
+import (
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// xmlNode represents an element of decoded xml document.
+type xmlNode struct {
+	name     string
+	text     string
+	children []*xmlNode
+}
+
+// XMLResponseDecoder decodes xml encoded by MarshalXML into a map, values are strings.
+func XMLResponseDecoder(r io.Reader) map[string]interface{} {
+	root, err := decodeXMLNode(r)
+	if err != nil {
+		return nil
+	}
+	decoded, _ := genericXMLValue(root).(map[string]interface{})
+	return decoded
+}
+
+// UnmarshalXML decodes xml document into v, children of the root element are matched by json tags of v.
+// The document is converted into JSON by the type of v, so v is decoded the same way as a JSON request.
+// Slice is written as repeated elements, or as one element wrapping item elements like MarshalXML does.
+func UnmarshalXML(r io.Reader, v interface{}) error {
+	root, err := decodeXMLNode(r)
+	if err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(xmlValueOf(root, reflect.TypeOf(v)))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(encoded, v)
+}
+
+func decodeXMLNode(r io.Reader) (*xmlNode, error) {
+	decoder := xml.NewDecoder(r)
+	var stack []*xmlNode
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: t.Name.Local}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, node)
+			}
+			stack = append(stack, node)
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(t)
+			}
+		case xml.EndElement:
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(node.children) > 0 {
+				node.text = strings.TrimSpace(node.text)
+			}
+			if len(stack) == 0 {
+				return node, nil
+			}
+		}
+	}
+}
+
+// xmlValueOf converts the node into value encoded as JSON of type t.
+func xmlValueOf(node *xmlNode, t reflect.Type) interface{} {
+	if t.Kind() == reflect.Ptr {
+		return xmlValueOf(node, t.Elem())
+	}
+	if isTextual(t) {
+		if node.text == "" {
+			return nil
+		}
+		return node.text
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return genericXMLValue(node)
+	case reflect.String:
+		return node.text
+	case reflect.Bool:
+		if value, err := strconv.ParseBool(strings.TrimSpace(node.text)); err == nil {
+			return value
+		}
+		return node.text
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		text := strings.TrimSpace(node.text)
+		if text == "" {
+			return nil
+		}
+		return json.Number(text)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return node.text
+		}
+		return xmlSliceOf(node.children, t.Elem())
+	case reflect.Map:
+		values := make(map[string]interface{})
+		for _, child := range node.children {
+			values[child.name] = xmlValueOf(child, t.Elem())
+		}
+		return values
+	case reflect.Struct:
+		values := make(map[string]interface{})
+		xmlStructOf(node, t, values)
+		return values
+	default:
+		return node.text
+	}
+}
+
+// xmlStructOf fills values by children of the node matching json name of fields of struct t.
+func xmlStructOf(node *xmlNode, t reflect.Type, values map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			xmlStructOf(node, fieldType, values)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		var matches []*xmlNode
+		for _, child := range node.children {
+			if strings.EqualFold(child.name, name) {
+				matches = append(matches, child)
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+
+		kind := fieldType.Kind()
+		isList := (kind == reflect.Slice || kind == reflect.Array) && fieldType.Elem().Kind() != reflect.Uint8
+		if isList && !isTextual(fieldType) {
+			if len(matches) == 1 && isItemList(matches[0]) {
+				matches = matches[0].children
+			}
+			values[name] = xmlSliceOf(matches, fieldType.Elem())
+			continue
+		}
+		values[name] = xmlValueOf(matches[len(matches)-1], field.Type)
+	}
+}
+
+func xmlSliceOf(nodes []*xmlNode, elem reflect.Type) []interface{} {
+	items := make([]interface{}, len(nodes))
+	for index, node := range nodes {
+		items[index] = xmlValueOf(node, elem)
+	}
+	return items
+}
+
+// genericXMLValue converts the node without knowing its type, element wrapping only item elements is a slice.
+func genericXMLValue(node *xmlNode) interface{} {
+	if len(node.children) == 0 {
+		return node.text
+	}
+	if isItemList(node) {
+		items := make([]interface{}, len(node.children))
+		for index, child := range node.children {
+			items[index] = genericXMLValue(child)
+		}
+		return items
+	}
+
+	values := make(map[string]interface{})
+	for _, child := range node.children {
+		values[child.name] = genericXMLValue(child)
+	}
+	return values
+}
+
+func isItemList(node *xmlNode) bool {
+	if len(node.children) == 0 {
+		return false
+	}
+	for _, child := range node.children {
+		if child.name != itemElement {
+			return false
+		}
+	}
+	return true
+}
+
+// isTextual returns true when t decodes itself from JSON string, such as time.Time.
+func isTextual(t reflect.Type) bool {
+	pointer := reflect.PtrTo(t)
+	return pointer.Implements(textUnmarshalerType) ||
+		(pointer.Implements(jsonUnmarshalerType) && t.Kind() == reflect.Struct)
+}
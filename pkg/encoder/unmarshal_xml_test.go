@@ -1 +1,71 @@
 package encoder_test
+
+import (
+	"go-rest-skeleton/pkg/encoder"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type XMLRequest struct {
+	Name      string            `json:"name"`
+	Age       int               `json:"age"`
+	Active    bool              `json:"active"`
+	Score     *float64          `json:"score"`
+	Roles     []string          `json:"roles"`
+	Tags      []string          `json:"tags"`
+	Labels    map[string]string `json:"labels"`
+	BirthDate time.Time         `json:"birth_date"`
+	Ignored   string            `json:"-"`
+}
+
+func TestUnmarshalXML(t *testing.T) {
+	body := `<request>
+		<name>Alice &amp; Bob</name>
+		<age>30</age>
+		<active>true</active>
+		<score>4.5</score>
+		<roles><item>admin</item><item>user</item></roles>
+		<tags>a</tags>
+		<tags>b</tags>
+		<labels><team>core</team></labels>
+		<birth_date>1990-01-02T00:00:00Z</birth_date>
+		<Ignored>secret</Ignored>
+	</request>`
+
+	var request XMLRequest
+	err := encoder.UnmarshalXML(strings.NewReader(body), &request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Alice & Bob", request.Name)
+	assert.Equal(t, 30, request.Age)
+	assert.True(t, request.Active)
+	assert.Equal(t, 4.5, *request.Score)
+	assert.Equal(t, []string{"admin", "user"}, request.Roles)
+	assert.Equal(t, []string{"a", "b"}, request.Tags)
+	assert.Equal(t, map[string]string{"team": "core"}, request.Labels)
+	assert.Equal(t, time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC), request.BirthDate)
+	assert.Empty(t, request.Ignored)
+}
+
+func TestUnmarshalXML_Invalid(t *testing.T) {
+	var request XMLRequest
+	assert.Error(t, encoder.UnmarshalXML(strings.NewReader(`<request><age>thirty</age></request>`), &request))
+	assert.Error(t, encoder.UnmarshalXML(strings.NewReader(`<request><name>`), &request))
+}
+
+func TestXMLResponseDecoder(t *testing.T) {
+	data := JSONString{
+		Code:    200,
+		Data:    []map[string]interface{}{{"uuid": "1"}, {"uuid": "2"}},
+		Message: "OK",
+	}
+
+	response := encoder.XMLResponseDecoder(strings.NewReader(encoder.PrettyXMLWithIndent(data)))
+
+	assert.Equal(t, "200", response["code"])
+	assert.Equal(t, "OK", response["message"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"uuid": "1"}, map[string]interface{}{"uuid": "2"}}, response["data"])
+}
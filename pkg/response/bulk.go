@@ -0,0 +1,81 @@
+package response
+
+import (
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/pkg/translation"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BulkResult represent result of an item of bulk request.
+type BulkResult struct {
+	Index     int         `json:"index"`
+	UUID      string      `json:"uuid,omitempty"`
+	Status    int         `json:"status"`
+	Message   string      `json:"message,omitempty"`
+	ErrorCode string      `json:"error_code,omitempty"`
+	Errors    interface{} `json:"errors,omitempty"`
+	Data      interface{} `json:"data,omitempty"`
+}
+
+// BulkResults represent results of every item of bulk request, in the order of the items.
+type BulkResults []BulkResult
+
+// BulkMeta represent count of items of bulk request.
+type BulkMeta struct {
+	Total     int `json:"total"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+// Succeed is a function uses to add result of a succeeded item.
+func (r *BulkResults) Succeed(index int, UUID string, status int, data interface{}) {
+	*r = append(*r, BulkResult{
+		Index:  index,
+		UUID:   UUID,
+		Status: status,
+		Data:   data,
+	})
+}
+
+// Fail is a function uses to add result of a failed item, its HTTP status is taken from the exception catalog.
+// Error not registered in the catalog is added as internal server error.
+func (r *BulkResults) Fail(c *gin.Context, index int, UUID string, err error, errors interface{}) {
+	if exception.Code(err) == "" {
+		err = exception.ErrorTextInternalServerError
+	}
+	message, _ := translation.NewTranslation(c, "error", err.Error(), map[string]interface{}{})
+
+	*r = append(*r, BulkResult{
+		Index:     index,
+		UUID:      UUID,
+		Status:    exception.Status(err),
+		Message:   message,
+		ErrorCode: exception.Code(err),
+		Errors:    errors,
+	})
+}
+
+// Meta returns count of total, succeeded and failed items.
+func (r BulkResults) Meta() BulkMeta {
+	meta := BulkMeta{Total: len(r)}
+	for _, result := range r {
+		if result.Status < http.StatusBadRequest {
+			meta.Succeeded++
+		}
+	}
+	meta.Failed = meta.Total - meta.Succeeded
+
+	return meta
+}
+
+// Render is a function uses to respond the results, 200 when every item is succeeded otherwise 207 Multi-Status.
+func (r BulkResults) Render(c *gin.Context, message string) {
+	meta := r.Meta()
+	c.Status(http.StatusOK)
+	if meta.Failed > 0 {
+		c.Status(http.StatusMultiStatus)
+	}
+	NewSuccess(c, r, message).WithMeta(meta).Render()
+}
@@ -0,0 +1,50 @@
+package response_test
+
+import (
+	"errors"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/pkg/encoder"
+	"go-rest-skeleton/pkg/response"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkResults_Render(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	r.DELETE("/test", func(c *gin.Context) {
+		var results response.BulkResults
+		results.Succeed(0, "a6f0e5f5-role", http.StatusOK, nil)
+		results.Fail(c, 1, "b7e1f6a6-role", exception.ErrorTextRoleNotFound, nil)
+		results.Fail(c, 2, "c8d2a7b7-role", errors.New("connection refused"), nil)
+		results.Render(c, "")
+	})
+
+	req, _ := http.NewRequest(http.MethodDelete, "/test", nil)
+	req.Header.Set("Accept-Language", "en")
+	r.ServeHTTP(w, req)
+
+	decoded := encoder.ResponseDecoder(w.Body)
+	data := decoded["data"].([]interface{})
+	assert.Equal(t, http.StatusMultiStatus, w.Code)
+	assert.Equal(t, map[string]interface{}{"total": float64(3), "succeeded": float64(1), "failed": float64(2)}, decoded["meta"])
+	assert.Len(t, data, 3)
+	assert.Equal(t, float64(http.StatusOK), data[0].(map[string]interface{})["status"])
+	assert.Equal(t, float64(http.StatusNotFound), data[1].(map[string]interface{})["status"])
+	assert.Equal(t, "EXROLE001", data[1].(map[string]interface{})["error_code"])
+	assert.Equal(t, float64(http.StatusInternalServerError), data[2].(map[string]interface{})["status"])
+	assert.NotContains(t, data[2].(map[string]interface{})["message"], "connection refused")
+}
+
+func TestBulkResults_Meta(t *testing.T) {
+	var results response.BulkResults
+	results.Succeed(0, "a6f0e5f5-role", http.StatusCreated, nil)
+	results.Succeed(1, "b7e1f6a6-role", http.StatusOK, nil)
+
+	assert.Equal(t, response.BulkMeta{Total: 2, Succeeded: 2, Failed: 0}, results.Meta())
+}
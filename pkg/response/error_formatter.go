@@ -1,6 +1,7 @@
 package response
 
 import (
+	"go-rest-skeleton/infrastructure/message/exception"
 	"go-rest-skeleton/pkg/translation"
 	"strings"
 
@@ -10,6 +11,7 @@ import (
 type errorOutput struct {
 	c                *gin.Context
 	language         string
+	key              string
 	ErrorHTTPCode    int         `json:"code"`
 	Data             interface{} `json:"data"`
 	Message          string      `json:"message"`
@@ -17,6 +19,12 @@ type errorOutput struct {
 	ErrorTracingCode string      `json:"error_code,omitempty"`
 }
 
+// Abort aborts the request with the given error, its HTTP status is taken from the exception catalog.
+// Error not registered in the catalog is responded as internal server error.
+func Abort(c *gin.Context, err error) {
+	_ = c.AbortWithError(exception.Status(err), err)
+}
+
 // NewError will is a constructor will initialize errorOutput.
 func NewError(c *gin.Context, message string) *errorOutput {
 	var errorTracingCode interface{}
@@ -41,6 +49,7 @@ func NewError(c *gin.Context, message string) *errorOutput {
 	errOutput := &errorOutput{
 		c:             c,
 		language:      language,
+		key:           message,
 		ErrorHTTPCode: errorHTTPCode,
 		Data:          errorData,
 		Message:       errMessage,
@@ -58,6 +67,16 @@ func (eo *errorOutput) WithArgs() *errorOutput {
 	return eo
 }
 
+// Render is a function uses to format response then return it in the format accepted by the request.
+// JSON, XML and MessagePack are supported.
+func (eo *errorOutput) Render() {
+	eo.c.Header("Accept-Language", eo.language)
+	if render(eo.c, eo.c.Writer.Status(), eo, nil, nil) {
+		return
+	}
+	eo.JSON()
+}
+
 // JSON is a function uses to format response then return as json.
 func (eo *errorOutput) JSON() {
 	eo.c.Header("Accept-Language", eo.language)
@@ -1 +1,96 @@
-package response
+package response_test
+
+import (
+	"errors"
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/pkg/encoder"
+	"go-rest-skeleton/pkg/response"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorFormatter_Render(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	r.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusNotFound)
+		c.Set("data", map[string]string{"uuid": "required"})
+		response.NewError(c, "api.msg.error.common.not_found").Render()
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Accept", "text/csv, application/xml")
+	r.ServeHTTP(w, req)
+
+	decoded := encoder.XMLResponseDecoder(w.Body)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "application/xml; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "404", decoded["code"])
+	assert.Equal(t, map[string]interface{}{"uuid": "required"}, decoded["data"])
+}
+
+func TestErrorFormatter_RenderProblem(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	r.POST("/test", func(c *gin.Context) {
+		c.Header("X-Request-Id", "3f1c2a4e-request")
+		c.Status(http.StatusUnprocessableEntity)
+		c.Set("data", map[string]string{"name": "Name is required", "email": "Email is invalid"})
+		c.Set("errorTracingCode", "ITMIPO002")
+		response.NewError(c, "api.msg.error.common.unprocessable_entity").RenderProblem("https://example.com/problems/")
+	})
+
+	req, _ := http.NewRequest(http.MethodPost, "/test", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, response.MIMEProblemJSON, w.Header().Get("Content-Type"))
+	expectedResponse := `{"type":"https://example.com/problems/common/unprocessable_entity",` +
+		`"title":"Unprocessable Entity","status":422,"detail":"Unprocessable entity's",` +
+		`"instance":"3f1c2a4e-request","error_code":"ITMIPO002",` +
+		`"errors":[{"field":"email","detail":"Email is invalid"},{"field":"name","detail":"Name is required"}]}` + "\n"
+	assert.Equal(t, expectedResponse, w.Body.String())
+}
+
+func TestErrorFormatter_RenderProblemXML(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	r.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusNotFound)
+		response.NewError(c, "api.msg.error.common.not_found").RenderProblem("/problems")
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Accept", "application/xml")
+	r.ServeHTTP(w, req)
+
+	decoded := encoder.XMLResponseDecoder(w.Body)
+	assert.Equal(t, response.MIMEProblemXML, w.Header().Get("Content-Type"))
+	assert.Equal(t, "/problems/common/not_found", decoded["type"])
+	assert.Equal(t, "404", decoded["status"])
+}
+
+func TestAbort(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	samples := map[error]int{
+		exception.ErrorTextUserNotFound: http.StatusNotFound,
+		exception.WithStatus(exception.ErrorTextBadRequest, http.StatusPreconditionFailed): http.StatusPreconditionFailed,
+		errors.New("dial tcp: connection refused"):                                         http.StatusInternalServerError,
+	}
+	for err, status := range samples {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		response.Abort(c, err)
+
+		assert.True(t, c.IsAborted())
+		assert.Equal(t, status, c.Writer.Status())
+		assert.Equal(t, err, c.Errors.Last().Err)
+	}
+}
@@ -0,0 +1,58 @@
+package response
+
+import (
+	"go-rest-skeleton/infrastructure/message/exception"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ETag returns the strong entity tag of the given version of a resource, such as "3".
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// SetETag sets ETag header of the given version of a resource.
+func SetETag(c *gin.Context, version int64) {
+	c.Header("ETag", ETag(version))
+}
+
+// NotModified sets ETag header of the given version, then writes 304 Not Modified and returns true
+// when the version matches If-None-Match header of the request.
+func NotModified(c *gin.Context, version int64) bool {
+	SetETag(c, version)
+
+	ifNoneMatch := c.GetHeader("If-None-Match")
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		// If-None-Match uses weak comparison, so W/ prefix is ignored.
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == ETag(version) {
+			c.AbortWithStatus(http.StatusNotModified)
+			return true
+		}
+	}
+
+	return false
+}
+
+// IfMatch returns the version required by If-Match header of the request, 0 when any version is accepted.
+// A weak or malformed entity tag can never match, so it returns precondition failed error.
+func IfMatch(c *gin.Context) (int64, error) {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return 0, nil
+	}
+
+	tag := strings.TrimSuffix(strings.TrimPrefix(ifMatch, `"`), `"`)
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version <= 0 || ETag(version) != ifMatch {
+		return 0, exception.ErrorTextPreconditionFailed
+	}
+
+	return version, nil
+}
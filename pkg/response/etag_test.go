@@ -0,0 +1,75 @@
+package response_test
+
+import (
+	"go-rest-skeleton/infrastructure/message/exception"
+	"go-rest-skeleton/pkg/response"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETag(t *testing.T) {
+	assert.Equal(t, `"3"`, response.ETag(3))
+}
+
+func TestNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	_, r := gin.CreateTestContext(httptest.NewRecorder())
+	r.GET("/test", func(c *gin.Context) {
+		if response.NotModified(c, 3) {
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	tests := []struct {
+		ifNoneMatch string
+		statusCode  int
+	}{
+		{"", http.StatusOK},
+		{`"2"`, http.StatusOK},
+		{`"3"`, http.StatusNotModified},
+		{`W/"3"`, http.StatusNotModified},
+		{`"1", "3"`, http.StatusNotModified},
+		{"*", http.StatusNotModified},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		if test.ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", test.ifNoneMatch)
+		}
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, test.statusCode, w.Code, test.ifNoneMatch)
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+	}
+}
+
+func TestIfMatch(t *testing.T) {
+	tests := []struct {
+		ifMatch string
+		version int64
+		err     error
+	}{
+		{"", 0, nil},
+		{"*", 0, nil},
+		{`"3"`, 3, nil},
+		{`W/"3"`, 0, exception.ErrorTextPreconditionFailed},
+		{`"1", "3"`, 0, exception.ErrorTextPreconditionFailed},
+		{`"0"`, 0, exception.ErrorTextPreconditionFailed},
+		{"3", 0, exception.ErrorTextPreconditionFailed},
+	}
+	for _, test := range tests {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request, _ = http.NewRequest(http.MethodPut, "/test", nil)
+		c.Request.Header.Set("If-Match", test.ifMatch)
+
+		version, err := response.IfMatch(c)
+		assert.Equal(t, test.version, version, test.ifMatch)
+		assert.Equal(t, test.err, err, test.ifMatch)
+	}
+}
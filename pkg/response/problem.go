@@ -0,0 +1,100 @@
+package response
+
+import (
+	"go-rest-skeleton/pkg/encoder"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Collections of MIME type of problem details.
+const (
+	MIMEProblemJSON = "application/problem+json"
+	MIMEProblemXML  = "application/problem+xml"
+
+	// Prefix of error message keys, it is left out of problem type.
+	errorKeyPrefix = "api.msg.error."
+)
+
+// ProblemError represents an invalid field of request, listed in errors of Problem.
+type ProblemError struct {
+	Field  string `json:"field"`
+	Detail string `json:"detail"`
+}
+
+// Problem represents problem details of RFC 7807.
+// ErrorCode and Errors are extension members, Data keeps any other data of the error.
+type Problem struct {
+	Type      string         `json:"type"`
+	Title     string         `json:"title"`
+	Status    int            `json:"status"`
+	Detail    string         `json:"detail,omitempty"`
+	Instance  string         `json:"instance,omitempty"`
+	ErrorCode string         `json:"error_code,omitempty"`
+	Errors    []ProblemError `json:"errors,omitempty"`
+	Data      interface{}    `json:"data,omitempty"`
+}
+
+// ProblemType returns stable type URI of the error message key under typeURL,
+// such as api.msg.error.storage.file.not_found under /problems is /problems/storage/file/not_found.
+func ProblemType(typeURL string, key string) string {
+	name := strings.ReplaceAll(strings.TrimPrefix(key, errorKeyPrefix), ".", "/")
+	return strings.TrimSuffix(typeURL, "/") + "/" + name
+}
+
+// Problem is a function uses to convert errorOutput into problem details with type under typeURL.
+// Request ID becomes the instance, translated errors of form become the errors.
+func (eo *errorOutput) Problem(typeURL string) *Problem {
+	problem := &Problem{
+		Type:      ProblemType(typeURL, eo.key),
+		Title:     http.StatusText(eo.ErrorHTTPCode),
+		Status:    eo.ErrorHTTPCode,
+		Detail:    eo.Message,
+		Instance:  eo.c.Writer.Header().Get("X-Request-Id"),
+		ErrorCode: eo.ErrorTracingCode,
+	}
+
+	switch data := eo.Data.(type) {
+	case nil:
+	case map[string]string:
+		for field, detail := range data {
+			problem.Errors = append(problem.Errors, ProblemError{Field: field, Detail: detail})
+		}
+		sort.Slice(problem.Errors, func(i, j int) bool {
+			return problem.Errors[i].Field < problem.Errors[j].Field
+		})
+	default:
+		problem.Data = data
+	}
+
+	return problem
+}
+
+// RenderProblem is a function uses to format response as problem details with type under typeURL.
+// It is written as application/problem+json, or application/problem+xml and MessagePack when accepted.
+func (eo *errorOutput) RenderProblem(typeURL string) {
+	eo.c.Header("Accept-Language", eo.language)
+	eo.c.Header("Vary", "Accept")
+	problem := eo.Problem(typeURL)
+
+	var body []byte
+	var err error
+	format := MIMEProblemJSON
+	switch NegotiateFormat(eo.c, false) {
+	case MIMEXML, MIMEXML2:
+		format = MIMEProblemXML
+		body, err = encoder.MarshalXML(problem, "problem", "")
+	case MIMEMsgPack, MIMEMsgPack2:
+		format = MIMEMsgPack
+		body, err = encoder.MarshalMsgPack(problem)
+	default:
+		body = []byte(encoder.PrettyJSONWithoutIndent(problem))
+	}
+	if err != nil {
+		_ = eo.c.Error(err)
+		eo.c.Data(eo.ErrorHTTPCode, MIMEProblemJSON, []byte(encoder.PrettyJSONWithoutIndent(problem)))
+		return
+	}
+
+	eo.c.Data(eo.ErrorHTTPCode, format, body)
+}
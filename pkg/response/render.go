@@ -0,0 +1,109 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"go-rest-skeleton/pkg/encoder"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// Collections of MIME type of response.
+const (
+	MIMEJSON     = binding.MIMEJSON
+	MIMEXML      = binding.MIMEXML
+	MIMEXML2     = binding.MIMEXML2
+	MIMEMsgPack  = binding.MIMEMSGPACK
+	MIMEMsgPack2 = binding.MIMEMSGPACK2
+	MIMECSV      = "text/csv"
+)
+
+// NegotiateFormat returns MIME type of response preferred by Accept header of the request.
+// CSV is only offered when withCSV is true, JSON is returned when none of the offered types is acceptable.
+func NegotiateFormat(c *gin.Context, withCSV bool) string {
+	offered := []string{MIMEJSON, MIMEXML, MIMEXML2, MIMEMsgPack, MIMEMsgPack2}
+	if withCSV {
+		offered = append(offered, MIMECSV)
+	}
+
+	format := c.NegotiateFormat(offered...)
+	if format == "" {
+		return MIMEJSON
+	}
+	return format
+}
+
+// render writes output in the negotiated format, rows are written instead of output when the format is CSV.
+// It returns false when the format is JSON, so the caller writes JSON the way it always did.
+func render(c *gin.Context, code int, output interface{}, rows interface{}, meta interface{}) bool {
+	c.Header("Vary", "Accept")
+
+	var body []byte
+	var err error
+	format := NegotiateFormat(c, rows != nil && encoder.IsCSVEncodable(rows))
+	switch format {
+	case MIMEXML, MIMEXML2:
+		body, err = encoder.MarshalXML(output, "response", "")
+	case MIMEMsgPack, MIMEMsgPack2:
+		body, err = encoder.MarshalMsgPack(output)
+	case MIMECSV:
+		setMetaHeaders(c, meta)
+		body, err = encoder.MarshalCSV(rows)
+	default:
+		return false
+	}
+	if err != nil {
+		_ = c.Error(err)
+		return false
+	}
+
+	if format != MIMEMsgPack && format != MIMEMsgPack2 {
+		format += "; charset=utf-8"
+	}
+	c.Data(code, format, body)
+	return true
+}
+
+// setMetaHeaders writes each value of meta as header, such as per_page as X-Per-Page, CSV has no room for meta.
+func setMetaHeaders(c *gin.Context, meta interface{}) {
+	if meta == nil {
+		return
+	}
+	encoded, err := json.Marshal(meta)
+	if err != nil {
+		return
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var values map[string]interface{}
+	if err := decoder.Decode(&values); err != nil {
+		return
+	}
+
+	for key, value := range values {
+		switch v := value.(type) {
+		case string:
+			c.Header(metaHeader(key), v)
+		case json.Number:
+			c.Header(metaHeader(key), v.String())
+		case bool:
+			if v {
+				c.Header(metaHeader(key), "true")
+			} else {
+				c.Header(metaHeader(key), "false")
+			}
+		}
+	}
+}
+
+func metaHeader(key string) string {
+	words := strings.Split(key, "_")
+	for index, word := range words {
+		if word != "" {
+			words[index] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return "X-" + strings.Join(words, "-")
+}
@@ -1,6 +1,7 @@
 package response
 
 import (
+	"go-rest-skeleton/pkg/encoder"
 	"go-rest-skeleton/pkg/translation"
 
 	"github.com/gin-gonic/gin"
@@ -20,7 +21,7 @@ func NewSuccess(c *gin.Context, data interface{}, message string) *successOutput
 	successHTTPCode := c.Writer.Status()
 	successData := data
 	if message == "" {
-		message = "OK"
+		message = "api.msg.success.common.ok"
 	}
 	successMessage, language := translation.NewTranslation(c, "success", message, map[string]interface{}{})
 
@@ -41,6 +42,16 @@ func (so *successOutput) WithMeta(meta interface{}) *successOutput {
 	return so
 }
 
+// Render is a function uses to format response then return it in the format accepted by the request.
+// JSON, XML and MessagePack are supported, data of list is also available as CSV.
+func (so *successOutput) Render() {
+	so.c.Header("Accept-Language", so.language)
+	if render(so.c, so.SuccessHTTPCode, so, so.Data, so.Meta) {
+		return
+	}
+	so.JSON()
+}
+
 // JSON is a function uses to format response then return as json.
 func (so *successOutput) JSON() {
 	so.c.Header("Accept-Language", so.language)
@@ -50,5 +61,10 @@ func (so *successOutput) JSON() {
 // XML is a function uses to format response then return as xml.
 func (so *successOutput) XML() {
 	so.c.Header("Accept-Language", so.language)
-	so.c.XML(so.SuccessHTTPCode, so)
+	body, err := encoder.MarshalXML(so, "response", "")
+	if err != nil {
+		_ = so.c.Error(err)
+		return
+	}
+	so.c.Data(so.SuccessHTTPCode, MIMEXML+"; charset=utf-8", body)
 }
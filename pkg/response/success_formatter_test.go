@@ -31,3 +31,55 @@ func TestFormatter(t *testing.T) {
 
 	assert.Equal(t, expectedResponse, actualResponse)
 }
+
+func TestFormatter_Render(t *testing.T) {
+	rows := []map[string]interface{}{{"uuid": "1", "name": "Alice"}}
+	meta := map[string]interface{}{"page": 1, "per_page": 10, "total": 1}
+	samples := []struct {
+		accept      string
+		contentType string
+		body        string
+	}{
+		{accept: "", contentType: "application/json; charset=utf-8", body: `{"code":200,"data":[{"name":"Alice","uuid":"1"}]`},
+		{accept: "text/html", contentType: "application/json; charset=utf-8", body: `{"code":200`},
+		{accept: "application/xml", contentType: "application/xml; charset=utf-8", body: "<response><code>200</code>"},
+		{accept: "text/csv", contentType: "text/csv; charset=utf-8", body: "name,uuid\nAlice,1\n"},
+		{accept: "application/x-msgpack", contentType: "application/x-msgpack", body: "\xa7message\xa2Ok"},
+	}
+
+	for _, sample := range samples {
+		gin.SetMode(gin.TestMode)
+		w := httptest.NewRecorder()
+		_, r := gin.CreateTestContext(w)
+		r.GET("/test", func(c *gin.Context) {
+			response.NewSuccess(c, rows, "api.msg.success.common.ok").WithMeta(meta).Render()
+		})
+
+		req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Accept", sample.accept)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, sample.contentType, w.Header().Get("Content-Type"), sample.accept)
+		assert.Contains(t, w.Body.String(), sample.body, sample.accept)
+		if sample.accept == "text/csv" {
+			assert.Equal(t, "10", w.Header().Get("X-Per-Page"))
+			assert.Equal(t, "1", w.Header().Get("X-Total"))
+		}
+	}
+}
+
+func TestFormatter_RenderCSVOfDetail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	r.GET("/test", func(c *gin.Context) {
+		response.NewSuccess(c, map[string]interface{}{"uuid": "1"}, "api.msg.success.common.ok").Render()
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Accept", "text/csv")
+	r.ServeHTTP(w, req)
+
+	// Detail is not a list, CSV is not offered.
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+}
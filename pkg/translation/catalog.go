@@ -0,0 +1,179 @@
+package translation
+
+import (
+	"fmt"
+	"go-rest-skeleton/pkg/util"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+// FallbackLanguage is the language messages fall back to when they are not translated in any other language.
+const FallbackLanguage = "en"
+
+// messageFilePattern matches message files, one file per language, e.g. global.en.yaml.
+const messageFilePattern = "global.*.yaml"
+
+// Catalog keeps messages of every available language, it is loaded once and shared by every request.
+type Catalog struct {
+	bundle          *i18n.Bundle
+	defaultLanguage string
+	languages       []string
+	tags            []language.Tag
+	matcher         language.Matcher
+	messages        map[string]map[string]*i18n.Message
+}
+
+// NewCatalog creates an empty Catalog, defaultLanguage is used when no language of a request is available.
+func NewCatalog(defaultLanguage string) *Catalog {
+	if defaultLanguage == "" {
+		defaultLanguage = FallbackLanguage
+	}
+	bundle := i18n.NewBundle(language.MustParse(FallbackLanguage))
+	bundle.RegisterUnmarshalFunc("yaml", yaml.Unmarshal)
+
+	return &Catalog{
+		bundle:          bundle,
+		defaultLanguage: defaultLanguage,
+		messages:        make(map[string]map[string]*i18n.Message),
+	}
+}
+
+// LoadDir loads every message file of dir, the language is part of the file name, e.g. global.id.yaml.
+func (ct *Catalog) LoadDir(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, messageFilePattern))
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("translation: no message file in %s", dir)
+	}
+
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := ct.AddMessageFile(filepath.Base(path), content); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddMessageFile adds messages of a YAML message file named like global.<language>.yaml,
+// its content may come from disk or be embedded in the binary.
+func (ct *Catalog) AddMessageFile(name string, content []byte) error {
+	file, err := ct.bundle.ParseMessageFileBytes(content, name)
+	if err != nil {
+		return fmt.Errorf("translation: %s: %w", name, err)
+	}
+	if file.Tag == language.Und {
+		return fmt.Errorf("translation: %s: language is missing from the file name", name)
+	}
+
+	lang := file.Tag.String()
+	if _, ok := ct.messages[lang]; !ok {
+		ct.messages[lang] = make(map[string]*i18n.Message)
+		ct.languages = append(ct.languages, lang)
+		sort.Strings(ct.languages)
+	}
+	for _, message := range file.Messages {
+		ct.messages[lang][message.ID] = message
+	}
+
+	ct.tags = ct.tags[:0]
+	for _, lang := range ct.languages {
+		ct.tags = append(ct.tags, language.Make(lang))
+	}
+	ct.matcher = language.NewMatcher(ct.tags)
+
+	return nil
+}
+
+// Languages returns every available language, sorted.
+func (ct *Catalog) Languages() []string {
+	return append([]string(nil), ct.languages...)
+}
+
+// DefaultLanguage returns the language used when no language of a request is available.
+func (ct *Catalog) DefaultLanguage() string {
+	return ct.defaultLanguage
+}
+
+// IsAvailable reports whether messages of lang are available, lang must be exactly one of Languages.
+func (ct *Catalog) IsAvailable(lang string) bool {
+	_, ok := ct.messages[lang]
+	return ok
+}
+
+// Has reports whether the message is translated in lang, without falling back to another language.
+func (ct *Catalog) Has(lang string, messageID string) bool {
+	_, ok := ct.messages[lang][messageID]
+	return ok
+}
+
+// Messages returns messages of lang by ID.
+func (ct *Catalog) Messages(lang string) map[string]*i18n.Message {
+	messages := make(map[string]*i18n.Message, len(ct.messages[lang]))
+	for id, message := range ct.messages[lang] {
+		messages[id] = message
+	}
+
+	return messages
+}
+
+// Negotiate returns the available language best matching the Accept-Language header, honouring q-values,
+// e.g. "fr;q=0.9, id-ID;q=0.8, en;q=0.5" is "id". The second value is false when none of them is available.
+func (ct *Catalog) Negotiate(accept string) (string, bool) {
+	if accept == "" || ct.matcher == nil {
+		return "", false
+	}
+	tags, _, err := language.ParseAcceptLanguage(accept)
+	if err != nil || len(tags) == 0 {
+		return "", false
+	}
+
+	_, index, confidence := ct.matcher.Match(tags...)
+	if confidence == language.No {
+		return "", false
+	}
+
+	return ct.languages[index], true
+}
+
+// Localize translates the message into lang, falling back to the default language, then to FallbackLanguage,
+// then to defaultMessage. Count of templateData map selects the plural form, e.g. one or other.
+func (ct *Catalog) Localize(
+	lang string,
+	messageID string,
+	defaultMessage string,
+	templateData interface{}) (string, error) {
+	localizer := i18n.NewLocalizer(ct.bundle, ct.fallbacks(lang)...)
+	config := &i18n.LocalizeConfig{MessageID: messageID, TemplateData: templateData}
+	if defaultMessage != "" {
+		config.DefaultMessage = &i18n.Message{ID: messageID, Other: defaultMessage}
+	}
+	if data, ok := templateData.(map[string]interface{}); ok && data["Count"] != nil {
+		config.PluralCount = data["Count"]
+	}
+
+	return localizer.Localize(config)
+}
+
+// fallbacks returns the chain of languages tried by Localize.
+func (ct *Catalog) fallbacks(lang string) []string {
+	langs := []string{lang}
+	for _, fallback := range []string{ct.defaultLanguage, FallbackLanguage} {
+		if !util.SliceContains(langs, fallback) {
+			langs = append(langs, fallback)
+		}
+	}
+
+	return langs
+}
@@ -0,0 +1,291 @@
+package translation
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// keyPattern matches a message key, e.g. api.msg.error.common.not_found.
+	keyPattern = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)+$`)
+
+	// dynamicKeyPattern matches a format of message keys built at runtime, e.g. notification.%s.title.
+	dynamicKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_]+(\.([A-Za-z0-9_]+|%[sdv]))+$`)
+
+	// verbPattern matches verbs of dynamicKeyPattern.
+	verbPattern = regexp.MustCompile(`%[sdv]`)
+
+	// variablePattern matches template variables of a message, e.g. {{.Field}}.
+	variablePattern = regexp.MustCompile(`{{-?\s*\.([A-Za-z0-9_]+)`)
+)
+
+// Usage keeps message keys referenced by the code.
+type Usage struct {
+	// Keys are positions where each key is referenced, by key.
+	Keys map[string][]string
+
+	// Patterns match keys built at runtime, such as by fmt.Sprintf("notification.%s.title", template).
+	Patterns []*regexp.Regexp
+}
+
+// NewUsage creates an empty Usage.
+func NewUsage() *Usage {
+	return &Usage{Keys: make(map[string][]string)}
+}
+
+// Add adds key referenced at position, e.g. a file and line or the name of a registry.
+func (u *Usage) Add(key string, position string) {
+	u.Keys[key] = append(u.Keys[key], position)
+}
+
+// Uses reports whether key is referenced, either directly or by a pattern.
+func (u *Usage) Uses(key string) bool {
+	if _, ok := u.Keys[key]; ok {
+		return true
+	}
+	for _, pattern := range u.Patterns {
+		if pattern.MatchString(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Extract scans string literals of Go files in dir, tests excluded, for message keys whose first segment is
+// one of roots, e.g. api or attributes. A literal holding a verb, e.g. attributes.%s, is kept as a pattern.
+func Extract(dir string, roots []string) (*Usage, error) {
+	usage := NewUsage()
+	patterns := make(map[string]bool)
+	fset := token.NewFileSet()
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			name := info.Name()
+			if path != dir && (strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			literal, ok := node.(*ast.BasicLit)
+			if !ok || literal.Kind != token.STRING {
+				return true
+			}
+			value, err := strconv.Unquote(literal.Value)
+			if err != nil || !hasRoot(value, roots) {
+				return true
+			}
+
+			switch {
+			case keyPattern.MatchString(value):
+				position := fset.Position(literal.Pos())
+				relative, _ := filepath.Rel(dir, position.Filename)
+				usage.Add(value, fmt.Sprintf("%s:%d", filepath.ToSlash(relative), position.Line))
+			case dynamicKeyPattern.MatchString(value) && !patterns[value]:
+				patterns[value] = true
+				parts := verbPattern.Split(value, -1)
+				for i := range parts {
+					parts[i] = regexp.QuoteMeta(parts[i])
+				}
+				usage.Patterns = append(usage.Patterns, regexp.MustCompile("^"+strings.Join(parts, `[^.]+`)+"$"))
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
+
+func hasRoot(key string, roots []string) bool {
+	for _, root := range roots {
+		if strings.HasPrefix(key, root+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Roots returns first segments of message keys of lang, e.g. api, attributes and notification.
+func (ct *Catalog) Roots(lang string) []string {
+	seen := make(map[string]bool)
+	var roots []string
+	for id := range ct.messages[lang] {
+		root := strings.SplitN(id, ".", 2)[0]
+		if !seen[root] {
+			seen[root] = true
+			roots = append(roots, root)
+		}
+	}
+	sort.Strings(roots)
+
+	return roots
+}
+
+// Mismatch is a message whose template variables differ from the message of the reference language.
+type Mismatch struct {
+	Key      string   `json:"key"`
+	Language string   `json:"language"`
+	Expected []string `json:"expected"`
+	Actual   []string `json:"actual"`
+}
+
+// CheckReport is the result of Check.
+type CheckReport struct {
+	Reference string `json:"reference"`
+
+	// Missing are keys referenced by the code or translated in the reference language,
+	// but not translated in a language, by language.
+	Missing map[string][]string `json:"missing"`
+
+	// Unused are keys of the reference language which are not referenced by the code.
+	Unused []string `json:"unused"`
+
+	Mismatched []Mismatch `json:"mismatched"`
+}
+
+// OK reports whether every language translates every key with the same template variables, and no key is unused.
+func (r *CheckReport) OK() bool {
+	for _, keys := range r.Missing {
+		if len(keys) > 0 {
+			return false
+		}
+	}
+
+	return len(r.Unused) == 0 && len(r.Mismatched) == 0
+}
+
+// Check compares messages of every language with the reference language and with keys referenced by the code.
+func (ct *Catalog) Check(reference string, usage *Usage) *CheckReport {
+	report := &CheckReport{Reference: reference, Missing: make(map[string][]string)}
+	references := ct.messages[reference]
+
+	keys := make(map[string]bool)
+	for key := range usage.Keys {
+		keys[key] = true
+	}
+	for key := range references {
+		keys[key] = true
+	}
+
+	for _, lang := range ct.languages {
+		missing := make([]string, 0)
+		for key := range keys {
+			translated, ok := ct.messages[lang][key]
+			if !ok {
+				missing = append(missing, key)
+				continue
+			}
+			message, ok := references[key]
+			if !ok || lang == reference {
+				continue
+			}
+
+			expected, actual := variables(message), variables(translated)
+			if strings.Join(expected, ",") != strings.Join(actual, ",") {
+				report.Mismatched = append(report.Mismatched, Mismatch{
+					Key: key, Language: lang, Expected: expected, Actual: actual})
+			}
+		}
+		sort.Strings(missing)
+		report.Missing[lang] = missing
+	}
+
+	report.Unused = make([]string, 0)
+	for key := range references {
+		if !usage.Uses(key) {
+			report.Unused = append(report.Unused, key)
+		}
+	}
+	sort.Strings(report.Unused)
+	sort.Slice(report.Mismatched, func(i, j int) bool {
+		if report.Mismatched[i].Key == report.Mismatched[j].Key {
+			return report.Mismatched[i].Language < report.Mismatched[j].Language
+		}
+		return report.Mismatched[i].Key < report.Mismatched[j].Key
+	})
+
+	return report
+}
+
+// variables returns sorted template variables of every plural form of the message.
+func variables(message *i18n.Message) []string {
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	for _, form := range []string{message.Zero, message.One, message.Two, message.Few, message.Many, message.Other} {
+		for _, match := range variablePattern.FindAllStringSubmatch(form, -1) {
+			if !seen[match[1]] {
+				seen[match[1]] = true
+				names = append(names, match[1])
+			}
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Scaffold returns a message file holding every message of the reference language, to be translated
+// into a new language. Messages having plural forms keep them.
+func (ct *Catalog) Scaffold(reference string) ([]byte, error) {
+	if !ct.IsAvailable(reference) {
+		return nil, fmt.Errorf("translation: language %s is not available", reference)
+	}
+
+	tree := make(map[string]interface{})
+	for id, message := range ct.messages[reference] {
+		var value interface{} = message.Other
+		if message.One != "" || message.Few != "" || message.Many != "" || message.Two != "" || message.Zero != "" {
+			forms := make(map[string]string)
+			for name, form := range map[string]string{
+				"zero": message.Zero, "one": message.One, "two": message.Two,
+				"few": message.Few, "many": message.Many, "other": message.Other} {
+				if form != "" {
+					forms[name] = form
+				}
+			}
+			value = forms
+		}
+
+		node := tree
+		segments := strings.Split(id, ".")
+		for _, segment := range segments[:len(segments)-1] {
+			child, ok := node[segment].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				node[segment] = child
+			}
+			node = child
+		}
+		node[segments[len(segments)-1]] = value
+	}
+
+	return yaml.Marshal(tree)
+}
@@ -0,0 +1,107 @@
+package translation_test
+
+import (
+	"go-rest-skeleton/pkg/translation"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func newCheckCatalog(t *testing.T) *translation.Catalog {
+	catalog := translation.NewCatalog("en")
+	assert.NoError(t, catalog.AddMessageFile("global.en.yaml", []byte(`
+api:
+  used: "Field {{.Field}} Is Used"
+  unused: "Unused"
+  files:
+    one: "{{.Count}} File"
+    other: "{{.Count}} Files"
+notification:
+  welcome:
+    title: "Welcome {{.Name}}"
+`)))
+	assert.NoError(t, catalog.AddMessageFile("global.id.yaml", []byte(`
+api:
+  used: "Isian Digunakan"
+  files: "{{.Count}} Berkas"
+`)))
+
+	return catalog
+}
+
+func TestExtract(t *testing.T) {
+	dir, err := ioutil.TempDir("", "extract")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	source := `package app
+
+import "fmt"
+
+const keyUsed = "api.used"
+
+func title(template string) string {
+	return fmt.Sprintf("notification.%s.title", template) + "api.missing" + "application.json"
+}
+`
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "app.go"), []byte(source), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "app_test.go"), []byte(`package app
+
+const keyTest = "api.unused"
+`), 0644))
+
+	usage, err := translation.Extract(dir, []string{"api", "notification"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"app.go:5"}, usage.Keys["api.used"])
+	assert.Contains(t, usage.Keys, "api.missing")
+	assert.NotContains(t, usage.Keys, "api.unused")
+	assert.NotContains(t, usage.Keys, "application.json")
+	assert.True(t, usage.Uses("notification.welcome.title"))
+	assert.False(t, usage.Uses("notification.welcome.body"))
+}
+
+func TestCatalog_Check(t *testing.T) {
+	catalog := newCheckCatalog(t)
+	assert.Equal(t, []string{"api", "notification"}, catalog.Roots("en"))
+
+	usage := translation.NewUsage()
+	usage.Add("api.used", "app.go:5")
+	usage.Add("api.files", "app.go:6")
+	usage.Add("api.missing", "app.go:7")
+	usage.Add("notification.welcome.title", "app.go:8")
+
+	report := catalog.Check("en", usage)
+	assert.False(t, report.OK())
+	assert.Equal(t, []string{"api.missing"}, report.Missing["en"])
+	assert.Equal(t, []string{"api.missing", "api.unused", "notification.welcome.title"}, report.Missing["id"])
+	assert.Equal(t, []string{"api.unused"}, report.Unused)
+	assert.Equal(t, []translation.Mismatch{
+		{Key: "api.used", Language: "id", Expected: []string{"Field"}, Actual: []string{}},
+	}, report.Mismatched)
+}
+
+func TestCatalog_Scaffold(t *testing.T) {
+	catalog := newCheckCatalog(t)
+
+	content, err := catalog.Scaffold("en")
+	assert.NoError(t, err)
+
+	var tree map[string]interface{}
+	assert.NoError(t, yaml.Unmarshal(content, &tree))
+	assert.Equal(t, map[string]interface{}{
+		"used":   "Field {{.Field}} Is Used",
+		"unused": "Unused",
+		"files":  map[string]interface{}{"one": "{{.Count}} File", "other": "{{.Count}} Files"},
+	}, tree["api"])
+
+	scaffolded := translation.NewCatalog("en")
+	assert.NoError(t, scaffolded.AddMessageFile("global.ms.yaml", content))
+	assert.True(t, scaffolded.Has("ms", "notification.welcome.title"))
+
+	_, err = catalog.Scaffold("fr")
+	assert.Error(t, err)
+}
@@ -3,83 +3,156 @@ package translation
 import (
 	"fmt"
 	"go-rest-skeleton/pkg/util"
+	"path/filepath"
+	"sync"
 
 	"github.com/gin-gonic/gin"
-	"github.com/nicksnyder/go-i18n/v2/i18n"
-	"golang.org/x/text/language"
-	"gopkg.in/yaml.v3"
 )
 
-type translationLanguage struct {
-	Available []string
+// PreferenceKey is the key of gin context holding a func() string which returns the language
+// preferred by the authenticated user, it takes precedence over Accept-Language.
+const PreferenceKey = "languagePreference"
+
+type Language struct {
+	Language string `json:"language" form:"language"`
 }
 
-type translationMessage struct {
-	DefaultMessage string
-	Message        string
-	Language       string
-	Type           string
+// Options is a struct to store options of the catalog loaded by Init.
+type Options struct {
+	// Dir contains message files, languages directory of the app is used when both Dir and Files are empty.
+	Dir string
+
+	// Files are contents of message files by name, e.g. global.en.yaml embedded in the binary.
+	Files map[string][]byte
+
+	// DefaultLanguage is used when no language of a request is available, defaults to FallbackLanguage.
+	DefaultLanguage string
 }
 
-type Language struct {
-	Language string `json:"language" form:"language"`
+var (
+	catalogMu sync.RWMutex
+	catalog   *Catalog
+)
+
+// Init loads messages of every language once, on startup, and shares them with every request.
+// Adding a language only requires a message file named global.<language>.yaml.
+func Init(opts Options) error {
+	ct := NewCatalog(opts.DefaultLanguage)
+
+	dir := opts.Dir
+	if dir == "" && len(opts.Files) == 0 {
+		dir = filepath.Join(util.RootDir(), "languages")
+	}
+	if dir != "" {
+		if err := ct.LoadDir(dir); err != nil {
+			return err
+		}
+	}
+	for name, content := range opts.Files {
+		if err := ct.AddMessageFile(name, content); err != nil {
+			return err
+		}
+	}
+	if !ct.IsAvailable(ct.DefaultLanguage()) {
+		return fmt.Errorf("translation: default language %s is not available, available are %v",
+			ct.DefaultLanguage(), ct.Languages())
+	}
+
+	catalogMu.Lock()
+	catalog = ct
+	catalogMu.Unlock()
+
+	return nil
+}
+
+// Default returns the catalog loaded by Init, messages of languages directory are loaded when Init is not called.
+func Default() *Catalog {
+	catalogMu.RLock()
+	ct := catalog
+	catalogMu.RUnlock()
+	if ct != nil {
+		return ct
+	}
+
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	if catalog == nil {
+		catalog = NewCatalog(FallbackLanguage)
+		_ = catalog.LoadDir(filepath.Join(util.RootDir(), "languages"))
+	}
+
+	return catalog
 }
 
-// NewTranslation creates a new translationMessage.
+// NewTranslation translates the message into the language of the request, see GetLanguage.
+// It returns the translated message and its language.
 func NewTranslation(
 	c *gin.Context,
 	messageType string,
 	messageString string,
 	messageData map[string]interface{}) (string, string) {
-	bundle := i18n.NewBundle(language.Indonesian)
-	bundle.RegisterUnmarshalFunc("yaml", yaml.Unmarshal)
-	accept := c.GetHeader("Accept-Language")
-	translation := translationMessage{Message: messageString, Language: "en", Type: messageType}
-
-	if accept != "" {
-		if IsValidAcceptLanguage(accept) {
-			translation.Language = accept
-		}
-	}
+	lang := GetLanguage(c)
+
+	defaultMessage := messageString
 	if messageType == "success" {
-		translation.DefaultMessage = "OK"
+		defaultMessage = "OK"
 	}
-	if messageType == "error" {
-		translation.DefaultMessage = messageString
+
+	translatedMessage, err := Default().Localize(lang, messageString, defaultMessage, messageData)
+	if err != nil && translatedMessage == "" {
+		translatedMessage = defaultMessage
 	}
 
-	languageFile := fmt.Sprintf("%s/languages/global.%s.yaml", util.RootDir(), translation.Language)
-	bundle.MustLoadMessageFile(languageFile)
-	translator := i18n.NewLocalizer(bundle, translation.Language, translation.Language)
+	return util.SentenceCase(translatedMessage), lang
+}
 
-	translatedMessage := translator.MustLocalize(&i18n.LocalizeConfig{
-		DefaultMessage: &i18n.Message{
-			ID:    translation.Message,
-			Other: translation.DefaultMessage,
-		},
-		TemplateData: messageData,
-	})
+// Translate translates the message into the given language without request context, e.g. from a queue worker.
+// The message ID is returned when the message is not translated.
+func Translate(lang string, messageID string, templateData interface{}) string {
+	ct := Default()
+	lang, ok := ct.Negotiate(lang)
+	if !ok {
+		lang = ct.DefaultLanguage()
+	}
+
+	translatedMessage, err := ct.Localize(lang, messageID, "", templateData)
+	if err != nil {
+		return messageID
+	}
 
-	return util.SentenceCase(translatedMessage), translation.Language
+	return translatedMessage
 }
 
-// GetLanguage gets language from Accept-Language on request header. Default language is "en".
+// GetLanguage gets language of the request. The language preferred by the authenticated user is used first,
+// then the available language best matching Accept-Language, then the default language.
 func GetLanguage(c *gin.Context) string {
-	accept := c.GetHeader("Accept-Language")
-	if accept != "" {
-		if IsValidAcceptLanguage(accept) {
-			return accept
+	ct := Default()
+	if preference, exists := c.Get(PreferenceKey); exists {
+		if preferred, ok := preference.(func() string); ok {
+			if lang := preferred(); ct.IsAvailable(lang) {
+				return lang
+			}
 		}
 	}
 
-	return "en"
+	if lang, ok := ct.Negotiate(c.GetHeader("Accept-Language")); ok {
+		return lang
+	}
+
+	return ct.DefaultLanguage()
 }
 
 // IsValidAcceptLanguage validates the given string is valid language or not.
 func IsValidAcceptLanguage(x string) bool {
-	a := translationLanguage{
-		Available: []string{"en", "id"},
-	}
+	return Default().IsAvailable(x)
+}
+
+// Languages returns every available language.
+func Languages() []string {
+	return Default().Languages()
+}
 
-	return util.SliceContains(a.Available, x)
+// Has reports whether the message is translated in lang, without falling back to another language.
+func Has(lang string, messageID string) bool {
+	return Default().Has(lang, messageID)
 }
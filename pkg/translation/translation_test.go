@@ -109,3 +109,113 @@ func TestIsValidAcceptLanguage(t *testing.T) {
 	invalidLang := translation.IsValidAcceptLanguage(selectedLang)
 	assert.Equal(t, false, invalidLang)
 }
+
+func TestTranslate(t *testing.T) {
+	data := struct{ Name string }{Name: "Jane"}
+
+	assert.Contains(t, translation.Translate("en", "notification.forgot_password.body", data), "Hi Jane")
+	assert.Contains(t, translation.Translate("id", "notification.forgot_password.body", data), "Hai Jane")
+	assert.Contains(t, translation.Translate("xx", "notification.forgot_password.body", data), "Hi Jane")
+	assert.Equal(t, "unknown.message", translation.Translate("en", "unknown.message", nil))
+}
+
+func TestGetLanguage_Negotiation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cases := map[string]string{
+		"id":                              "id",
+		"id-ID":                           "id",
+		"en-US,en;q=0.9":                  "en",
+		"fr;q=0.9, id-ID;q=0.8, en;q=0.5": "id",
+		"en;q=0.2, id;q=0.7":              "id",
+		"fr, de":                          "en",
+		"invalid;;q=x":                    "en",
+	}
+
+	for accept, expected := range cases {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+		c.Request.Header.Set("Accept-Language", accept)
+		assert.Equal(t, expected, translation.GetLanguage(c), accept)
+	}
+}
+
+func TestGetLanguage_WithPreference(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+	c.Request.Header.Set("Accept-Language", "en")
+
+	c.Set(translation.PreferenceKey, func() string { return "id" })
+	assert.Equal(t, "id", translation.GetLanguage(c))
+
+	c.Set(translation.PreferenceKey, func() string { return "xx" })
+	assert.Equal(t, "en", translation.GetLanguage(c))
+}
+
+func TestLanguages(t *testing.T) {
+	assert.Equal(t, []string{"en", "id"}, translation.Languages())
+	assert.True(t, translation.Has("id", "api.msg.success.common.ok"))
+	assert.False(t, translation.Has("id", "unknown.message"))
+}
+
+func TestCatalog_Localize(t *testing.T) {
+	catalog := translation.NewCatalog("id")
+	assert.NoError(t, catalog.AddMessageFile("global.en.yaml", []byte(`
+file:
+  deleted:
+    one: "{{.Count}} file deleted"
+    other: "{{.Count}} files deleted"
+  only_en: "Only in English"
+`)))
+	assert.NoError(t, catalog.AddMessageFile("global.id.yaml", []byte(`
+file:
+  deleted: "{{.Count}} berkas dihapus"
+`)))
+	assert.Error(t, catalog.AddMessageFile("global.yaml", []byte(`file: "x"`)))
+	assert.Equal(t, []string{"en", "id"}, catalog.Languages())
+
+	message, err := catalog.Localize("en", "file.deleted", "", map[string]interface{}{"Count": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "1 file deleted", message)
+
+	message, err = catalog.Localize("en", "file.deleted", "", map[string]interface{}{"Count": 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "3 files deleted", message)
+
+	message, err = catalog.Localize("id", "file.deleted", "", map[string]interface{}{"Count": 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "3 berkas dihapus", message)
+
+	// Falls back to FallbackLanguage, then to the default message
+	message, err = catalog.Localize("id", "file.only_en", "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Only in English", message)
+
+	message, err = catalog.Localize("id", "file.unknown", "Unknown", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Unknown", message)
+
+	_, err = catalog.Localize("id", "file.unknown", "", nil)
+	assert.Error(t, err)
+}
+
+func TestInit(t *testing.T) {
+	defer func() {
+		assert.NoError(t, translation.Init(translation.Options{}))
+	}()
+
+	err := translation.Init(translation.Options{
+		Files: map[string][]byte{
+			"global.en.yaml": []byte(`greeting: "Hello"`),
+			"global.ms.yaml": []byte(`greeting: "Helo"`),
+		},
+		DefaultLanguage: "ms",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"en", "ms"}, translation.Languages())
+	assert.Equal(t, "Helo", translation.Translate("ms-MY", "greeting", nil))
+	assert.Equal(t, "Helo", translation.Translate("fr", "greeting", nil))
+
+	err = translation.Init(translation.Options{DefaultLanguage: "fr"})
+	assert.Error(t, err)
+}
@@ -136,7 +136,7 @@ func (vr *ValidationRules) MinValue(length interface{}) *ValidationRules {
 // MinValue is a function to set the rule that current field value must be no less than the length.
 func (vr *ValidationRules) MinLength(length interface{}) *ValidationRules {
 	vr.Rules = append(vr.Rules, ValidationRule{
-		Rule: validation.Min(length).Error("api.msg.error.validation.must_be_no_less_than_length"),
+		Rule: validation.RuneLength(lengthValue(length), 0).Error("api.msg.error.validation.must_be_no_less_than_length"),
 		RuleOpt: []RuleOpt{
 			{
 				Key:   "Length",
@@ -164,7 +164,7 @@ func (vr *ValidationRules) MaxValue(length interface{}) *ValidationRules {
 // MaxValue is a function to set the rule that current field value must be no more than the length.
 func (vr *ValidationRules) MaxLength(length interface{}) *ValidationRules {
 	vr.Rules = append(vr.Rules, ValidationRule{
-		Rule: validation.Max(length).Error("api.msg.error.validation.must_be_no_more_than_length"),
+		Rule: validation.RuneLength(0, lengthValue(length)).Error("api.msg.error.validation.must_be_no_more_than_length"),
 		RuleOpt: []RuleOpt{
 			{
 				Key:   "Length",
@@ -385,6 +385,19 @@ func (vr *ValidationRules) IsExists(tx *gorm.DB, tableName string, fieldName str
 }
 
 // joinSlice is a function uses to join slice of interfaces.
+// lengthValue converts length of MinLength and MaxLength, which are compared with number of characters.
+func lengthValue(length interface{}) int {
+	switch v := length.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case uint:
+		return int(v)
+	}
+	return 0
+}
+
 func joinSlice(sliceOfInterface []interface{}) string {
 	var sliceOfString []string
 	for _, slice := range sliceOfInterface {
@@ -128,6 +128,17 @@ func TestValidationRules_Max(t *testing.T) {
 	}
 }
 
+func TestValidationRules_MaxLength(t *testing.T) {
+	validation := validator.New()
+	rules := validation.AddRule().MaxLength(5).Apply()
+
+	for _, r := range rules {
+		assert.IsType(t, r.Rule, ozzoValidation.LengthRule{})
+		assert.NoError(t, r.Rule.Validate("abcde"))
+		assert.Error(t, r.Rule.Validate("abcdef"))
+	}
+}
+
 func TestValidationRules_Length(t *testing.T) {
 	validation := validator.New()
 	rules := validation.AddRule().Length(10, 255).Apply()
@@ -0,0 +1,21 @@
+//go:build cgo
+// +build cgo
+
+// Package webp encodes images in lossy WebP format by libwebp.
+// libwebp is linked by cgo, a build without cgo can not encode WebP, see Available.
+package webp
+
+import (
+	"image"
+	"io"
+
+	"github.com/chai2010/webp"
+)
+
+// Available reports whether this build can encode WebP.
+const Available = true
+
+// Encode writes the image to w in lossy WebP format, quality ranges from 1 to 100.
+func Encode(w io.Writer, img image.Image, quality int) error {
+	return webp.Encode(w, img, &webp.Options{Quality: float32(quality)})
+}
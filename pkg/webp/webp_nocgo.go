@@ -0,0 +1,21 @@
+//go:build !cgo
+// +build !cgo
+
+package webp
+
+import (
+	"errors"
+	"image"
+	"io"
+)
+
+// Available reports whether this build can encode WebP.
+const Available = false
+
+// ErrNotAvailable is returned by Encode of a build without cgo.
+var ErrNotAvailable = errors.New("webp: encoding needs a build with cgo")
+
+// Encode returns ErrNotAvailable, libwebp is not linked without cgo.
+func Encode(w io.Writer, img image.Image, quality int) error {
+	return ErrNotAvailable
+}
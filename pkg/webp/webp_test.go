@@ -0,0 +1,40 @@
+package webp_test
+
+import (
+	"bytes"
+	"go-rest-skeleton/pkg/webp"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	xwebp "golang.org/x/image/webp"
+)
+
+func TestEncode(t *testing.T) {
+	if !webp.Available {
+		t.Skip("webp needs a build with cgo")
+	}
+
+	gradient := image.NewNRGBA(image.Rect(0, 0, 128, 96))
+	for y := 0; y < 96; y++ {
+		for x := 0; x < 128; x++ {
+			gradient.SetNRGBA(x, y, color.NRGBA{R: uint8(x * 2), G: uint8(y * 2), B: uint8(x + y), A: 0xff})
+		}
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, webp.Encode(&buf, gradient, 90))
+	size := buf.Len()
+
+	decoded, err := xwebp.Decode(&buf)
+	if assert.NoError(t, err) {
+		assert.Equal(t, gradient.Bounds(), decoded.Bounds())
+	}
+
+	// Lossy WebP is smaller than JPEG of the same quality.
+	var jpegBuf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&jpegBuf, gradient, &jpeg.Options{Quality: 90}))
+	assert.Less(t, size, jpegBuf.Len())
+}
@@ -7,13 +7,13 @@ import (
 
 // TokenInterface is a mock of authorization.TokenInterface.
 type TokenInterface struct {
-	CreateTokenFn          func(UUID string) (*authorization.TokenDetails, error)
+	CreateTokenFn          func(UUID string, AUD string) (*authorization.TokenDetails, error)
 	ExtractTokenMetadataFn func(*http.Request) (*authorization.AccessDetails, error)
 }
 
 // CreateToken calls the CreateTokenFn.
-func (f *TokenInterface) CreateToken(UUID string) (*authorization.TokenDetails, error) {
-	return f.CreateTokenFn(UUID)
+func (f *TokenInterface) CreateToken(UUID string, AUD string) (*authorization.TokenDetails, error) {
+	return f.CreateTokenFn(UUID, AUD)
 }
 
 // ExtractTokenMetadata calls the ExtractTokenMetadataFn.
@@ -0,0 +1,48 @@
+package mock
+
+import (
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+)
+
+// DocumentAppInterface is a mock of application.DocumentAppInterface.
+type DocumentAppInterface struct {
+	SaveDocumentFn    func(*entity.Document) (*entity.Document, map[string]string, error)
+	UpdateDocumentFn  func(string, *entity.Document) (*entity.Document, map[string]string, error)
+	ApproveDocumentFn func(UUID string) (*entity.Document, map[string]string, error)
+	DeleteDocumentFn  func(UUID string) error
+	GetDocumentsFn    func(p *repository.Parameters) ([]entity.Document, interface{}, error)
+	GetDocumentFn     func(UUID string) (*entity.Document, error)
+}
+
+// SaveDocument calls the SaveDocumentFn.
+func (d *DocumentAppInterface) SaveDocument(document *entity.Document) (*entity.Document, map[string]string, error) {
+	return d.SaveDocumentFn(document)
+}
+
+// UpdateDocument calls the UpdateDocumentFn.
+func (d *DocumentAppInterface) UpdateDocument(
+	uuid string,
+	document *entity.Document) (*entity.Document, map[string]string, error) {
+	return d.UpdateDocumentFn(uuid, document)
+}
+
+// ApproveDocument calls the ApproveDocumentFn.
+func (d *DocumentAppInterface) ApproveDocument(uuid string) (*entity.Document, map[string]string, error) {
+	return d.ApproveDocumentFn(uuid)
+}
+
+// DeleteDocument calls the DeleteDocumentFn.
+func (d *DocumentAppInterface) DeleteDocument(uuid string) error {
+	return d.DeleteDocumentFn(uuid)
+}
+
+// GetDocuments calls the GetDocumentsFn.
+func (d *DocumentAppInterface) GetDocuments(p *repository.Parameters) ([]entity.Document, interface{}, error) {
+	return d.GetDocumentsFn(p)
+}
+
+// GetDocument calls the GetDocumentFn.
+func (d *DocumentAppInterface) GetDocument(uuid string) (*entity.Document, error) {
+	return d.GetDocumentFn(uuid)
+}
@@ -0,0 +1,15 @@
+package mock
+
+import (
+	"go-rest-skeleton/infrastructure/queue"
+)
+
+// QueueAppInterface is a mock of application.QueueAppInterface.
+type QueueAppInterface struct {
+	DispatchFn func(job *queue.Job) error
+}
+
+// Dispatch calls the DispatchFn.
+func (q *QueueAppInterface) Dispatch(job *queue.Job) error {
+	return q.DispatchFn(job)
+}
@@ -6,16 +6,31 @@ import (
 
 // StorageAppInterface is a mock of application.StorageAppInterface.
 type StorageAppInterface struct {
-	UploadFileFn func(file *multipart.FileHeader, category string) (string, map[string]string, error, interface{})
-	GetFileFn    func(UUID string) (interface{}, error)
+	UploadFileFn      func(file *multipart.FileHeader, category string, userUUID string) (string, map[string]string, error, interface{})
+	GetFileFn         func(UUID string, variant string) (interface{}, error)
+	GetFileVariantsFn func(UUID string) (map[string]interface{}, error)
+	DeleteFileFn      func(UUID string) error
 }
 
 // UploadFile calls the UploadFileFn.
-func (s *StorageAppInterface) UploadFile(file *multipart.FileHeader, c string) (string, map[string]string, error, interface{}) {
-	return s.UploadFileFn(file, c)
+func (s *StorageAppInterface) UploadFile(
+	file *multipart.FileHeader,
+	c string,
+	userUUID string) (string, map[string]string, error, interface{}) {
+	return s.UploadFileFn(file, c, userUUID)
 }
 
 // GetFile calls the GetFileFn.
-func (s *StorageAppInterface) GetFile(UUID string) (interface{}, error) {
-	return s.GetFileFn(UUID)
+func (s *StorageAppInterface) GetFile(UUID string, variant string) (interface{}, error) {
+	return s.GetFileFn(UUID, variant)
+}
+
+// GetFileVariants calls the GetFileVariantsFn.
+func (s *StorageAppInterface) GetFileVariants(UUID string) (map[string]interface{}, error) {
+	return s.GetFileVariantsFn(UUID)
+}
+
+// DeleteFile calls the DeleteFileFn.
+func (s *StorageAppInterface) DeleteFile(UUID string) error {
+	return s.DeleteFileFn(UUID)
 }
@@ -0,0 +1,25 @@
+package mock
+
+import (
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/domain/repository"
+)
+
+// StorageFileAppInterface is a mock of application.StorageFileAppInterface.
+type StorageFileAppInterface struct {
+	GetUserFilesFn func(userUUID string, category string, p *repository.Parameters) ([]entity.StorageFile, interface{}, error)
+	GetUserFileFn  func(userUUID string, UUID string) (*entity.StorageFile, error)
+}
+
+// GetUserFiles calls the GetUserFilesFn.
+func (f *StorageFileAppInterface) GetUserFiles(
+	userUUID string,
+	category string,
+	p *repository.Parameters) ([]entity.StorageFile, interface{}, error) {
+	return f.GetUserFilesFn(userUUID, category, p)
+}
+
+// GetUserFile calls the GetUserFileFn.
+func (f *StorageFileAppInterface) GetUserFile(userUUID string, UUID string) (*entity.StorageFile, error) {
+	return f.GetUserFileFn(userUUID, UUID)
+}
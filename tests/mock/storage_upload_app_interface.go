@@ -0,0 +1,57 @@
+package mock
+
+import (
+	"go-rest-skeleton/domain/entity"
+	"go-rest-skeleton/infrastructure/storage"
+	"io"
+)
+
+// StorageUploadAppInterface is a mock of application.StorageUploadAppInterface.
+type StorageUploadAppInterface struct {
+	CreateDirectUploadFn    func(upload *storage.DirectUploadRequest) (*storage.DirectUpload, error, interface{})
+	ConfirmDirectUploadFn   func(UUID string, userUUID string) (string, error, interface{})
+	CreateResumableUploadFn func(upload *storage.ResumableUploadRequest) (*entity.StorageUpload, error, interface{})
+	GetResumableUploadFn    func(UUID string, userUUID string) (*entity.StorageUpload, error)
+	WriteResumableUploadFn  func(
+		UUID string,
+		userUUID string,
+		offset int64,
+		reader io.Reader) (*entity.StorageUpload, error, interface{})
+	DeleteResumableUploadFn func(UUID string, userUUID string) error
+}
+
+// CreateDirectUpload calls the CreateDirectUploadFn.
+func (s *StorageUploadAppInterface) CreateDirectUpload(
+	upload *storage.DirectUploadRequest) (*storage.DirectUpload, error, interface{}) {
+	return s.CreateDirectUploadFn(upload)
+}
+
+// ConfirmDirectUpload calls the ConfirmDirectUploadFn.
+func (s *StorageUploadAppInterface) ConfirmDirectUpload(UUID string, userUUID string) (string, error, interface{}) {
+	return s.ConfirmDirectUploadFn(UUID, userUUID)
+}
+
+// CreateResumableUpload calls the CreateResumableUploadFn.
+func (s *StorageUploadAppInterface) CreateResumableUpload(
+	upload *storage.ResumableUploadRequest) (*entity.StorageUpload, error, interface{}) {
+	return s.CreateResumableUploadFn(upload)
+}
+
+// GetResumableUpload calls the GetResumableUploadFn.
+func (s *StorageUploadAppInterface) GetResumableUpload(UUID string, userUUID string) (*entity.StorageUpload, error) {
+	return s.GetResumableUploadFn(UUID, userUUID)
+}
+
+// WriteResumableUpload calls the WriteResumableUploadFn.
+func (s *StorageUploadAppInterface) WriteResumableUpload(
+	UUID string,
+	userUUID string,
+	offset int64,
+	reader io.Reader) (*entity.StorageUpload, error, interface{}) {
+	return s.WriteResumableUploadFn(UUID, userUUID, offset, reader)
+}
+
+// DeleteResumableUpload calls the DeleteResumableUploadFn.
+func (s *StorageUploadAppInterface) DeleteResumableUpload(UUID string, userUUID string) error {
+	return s.DeleteResumableUploadFn(UUID, userUUID)
+}
@@ -3,6 +3,7 @@ package mock
 import (
 	"go-rest-skeleton/domain/entity"
 	"go-rest-skeleton/domain/repository"
+	"time"
 )
 
 // UserAppInterface is a mock of application.UserAppInterface.
@@ -10,11 +11,15 @@ type UserAppInterface struct {
 	SaveUserFn                  func(*entity.User) (*entity.User, map[string]string, error)
 	UpdateUserFn                func(string, *entity.User) (*entity.User, map[string]string, error)
 	DeleteUserFn                func(UUID string) error
-	GetUsersFn                  func(params *repository.Parameters) ([]entity.User, interface{}, error)
+	RestoreUserFn               func(UUID string) (*entity.User, error)
+	ForceDeleteUserFn           func(UUID string) error
+	PurgeUsersFn                func(before time.Time) (int64, error)
+	GetUsersFn                  func(params *repository.Parameters) ([]*entity.User, *repository.Meta, error)
 	GetUserFn                   func(UUID string) (*entity.User, error)
 	GetUserRolesFn              func(UUID string) ([]entity.UserRole, error)
 	GetUserWithRolesFn          func(UUID string) (*entity.User, error)
 	GetUserByEmailFn            func(*entity.User) (*entity.User, map[string]string, error)
+	GetUserByPhoneFn            func(*entity.User) (*entity.User, map[string]string, error)
 	GetUserByEmailAndPasswordFn func(*entity.User) (*entity.User, map[string]string, error)
 	UpdateUserAvatarFn          func(string, *entity.User) (*entity.User, map[string]string, error)
 }
@@ -34,8 +39,23 @@ func (u *UserAppInterface) DeleteUser(uuid string) error {
 	return u.DeleteUserFn(uuid)
 }
 
+// RestoreUser calls the RestoreUserFn.
+func (u *UserAppInterface) RestoreUser(uuid string) (*entity.User, error) {
+	return u.RestoreUserFn(uuid)
+}
+
+// ForceDeleteUser calls the ForceDeleteUserFn.
+func (u *UserAppInterface) ForceDeleteUser(uuid string) error {
+	return u.ForceDeleteUserFn(uuid)
+}
+
+// PurgeUsers calls the PurgeUsersFn.
+func (u *UserAppInterface) PurgeUsers(before time.Time) (int64, error) {
+	return u.PurgeUsersFn(before)
+}
+
 // GetUsers calls the GetUsersFn.
-func (u *UserAppInterface) GetUsers(params *repository.Parameters) ([]entity.User, interface{}, error) {
+func (u *UserAppInterface) GetUsers(params *repository.Parameters) ([]*entity.User, *repository.Meta, error) {
 	return u.GetUsersFn(params)
 }
 
@@ -59,6 +79,11 @@ func (u *UserAppInterface) GetUserByEmail(user *entity.User) (*entity.User, map[
 	return u.GetUserByEmailFn(user)
 }
 
+// GetUserByPhone calls the GetUserByPhoneFn.
+func (u *UserAppInterface) GetUserByPhone(user *entity.User) (*entity.User, map[string]string, error) {
+	return u.GetUserByPhoneFn(user)
+}
+
 // GetUserByEmailAndPassword calls the GetUserByEmailAndPasswordFn.
 func (u *UserAppInterface) GetUserByEmailAndPassword(user *entity.User) (*entity.User, map[string]string, error) {
 	return u.GetUserByEmailAndPasswordFn(user)